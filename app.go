@@ -2,18 +2,23 @@ package main
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
+	"github.com/run-bigpig/jcp/internal/adk"
 	"github.com/run-bigpig/jcp/internal/adk/mcp"
 	"github.com/run-bigpig/jcp/internal/adk/tools"
 	"github.com/run-bigpig/jcp/internal/agent"
+	"github.com/run-bigpig/jcp/internal/indicators"
 	"github.com/run-bigpig/jcp/internal/logger"
 	"github.com/run-bigpig/jcp/internal/meeting"
 	"github.com/run-bigpig/jcp/internal/memory"
 	"github.com/run-bigpig/jcp/internal/models"
 	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+	"github.com/run-bigpig/jcp/internal/pkg/store"
 	"github.com/run-bigpig/jcp/internal/services"
 	"github.com/run-bigpig/jcp/internal/services/hottrend"
 
@@ -30,19 +35,27 @@ type App struct {
 	newsService        *services.NewsService
 	hotTrendService    *hottrend.HotTrendService
 	longHuBangService  *services.LongHuBangService
+	researchService    *services.ResearchReportService
+	marketBreadth      *services.MarketBreadthService
 	marketPusher       *services.MarketDataPusher
 	meetingService     *meeting.Service
 	sessionService     *services.SessionService
 	agentConfigService *services.AgentConfigService
+	profileService     *services.ProfileService
+	analysisArchive    *services.MeetingAnalysisService
+	decisionLog        *meeting.DecisionLog
 	agentContainer     *agent.Container
 	toolRegistry       *tools.Registry
 	mcpManager         *mcp.Manager
 	memoryManager      *memory.Manager
+	stockInfoService   *services.StockInfoService
 	updateService      *services.UpdateService
 
 	// 会议取消管理
 	meetingCancels   map[string]context.CancelFunc
 	meetingCancelsMu sync.RWMutex
+	// 会议事件缓冲（前端刷新后回放）
+	meetingEvents *meeting.EventBuffer
 }
 
 // NewApp creates a new App application struct
@@ -75,6 +88,7 @@ func NewApp() *App {
 
 	marketService := services.NewMarketService()
 	newsService := services.NewNewsService()
+	newsService.SetEnabledSources(configService.GetConfig().NewsSources)
 
 	// 初始化龙虎榜服务
 	longHuBangService := services.NewLongHuBangService()
@@ -83,9 +97,27 @@ func NewApp() *App {
 	stockInfoSvc := services.NewStockInfoService()
 	marketBreadthSvc := services.NewMarketBreadthService()
 	sectorSvc := services.NewSectorService()
+	northboundSvc := services.NewNorthboundService()
+	shareholderSvc := services.NewShareholderService()
+	indexValuationSvc := services.NewIndexValuationService()
+	marginSvc := services.NewMarginService()
+	gubaSvc := services.NewGubaService()
+	moneyFlowSvc := services.NewMoneyFlowService()
+
+	// 按配置设置各数据源请求超时
+	applyTimeouts(configService.GetConfig().Timeouts, marketService, longHuBangService, researchReportService, hotTrendSvc)
+
+	// 各数据类型的数据源顺序
+	applySourceChains(configService.GetConfig().SourceChains, marketService, stockInfoSvc, marketBreadthSvc)
+
+	// 休市守卫：开启后休市期间复用缓存，减少无效请求
+	marketService.Guard().SetEnabled(configService.GetConfig().MarketHoursOnly)
+	marketBreadthSvc.SetGuard(marketService.Guard())
+	marketBreadthSvc.EnableHistory(dataDir, marketService.GetMarketStatus)
 
 	// 初始化工具注册中心
-	toolRegistry := tools.NewRegistry(marketService, newsService, configService, researchReportService, hotTrendSvc, longHuBangService, stockInfoSvc, sectorSvc, marketBreadthSvc)
+	toolRegistry := tools.NewRegistry(marketService, newsService, configService, researchReportService, hotTrendSvc, longHuBangService, stockInfoSvc, sectorSvc, marketBreadthSvc, northboundSvc, shareholderSvc, indexValuationSvc, marginSvc, gubaSvc, moneyFlowSvc)
+	toolRegistry.EnableToolSnapshots(dataDir)
 
 	// 初始化 MCP 管理器
 	mcpManager := mcp.NewManager()
@@ -95,18 +127,43 @@ func NewApp() *App {
 
 	// 初始化会议室服务
 	meetingService := meeting.NewServiceFull(toolRegistry, mcpManager)
+	meetingService.SetModeratorPrompt(configService.GetConfig().ModeratorPrompt)
+	meetingService.SetAIConfigs(configService.GetConfig().AIConfigs)
+	meetingService.SetAgentDelay(time.Duration(configService.GetConfig().AgentDelayMs) * time.Millisecond)
+	meetingService.SetIncludeToolTrace(configService.GetConfig().IncludeToolTrace)
+	meetingService.SetSummaryParams(configService.GetConfig().SummaryParams)
+	meetingService.SetGlobalTools(configService.GetConfig().GlobalTools)
+	meetingService.SetMaxContextMessages(configService.GetConfig().MaxContextMessages)
+	meetingService.SetFallbackAgentCount(configService.GetConfig().FallbackAgentCount)
+	meetingService.SetPreflightGather(configService.GetConfig().PreflightGather)
+	meetingService.SetAnalysisDepth(configService.GetConfig().AnalysisDepth)
+	meetingService.SetUserProfile(configService.GetConfig().UserName, configService.GetConfig().UserPersona)
+	meetingService.SetCircuitBreaker(configService.GetConfig().CircuitBreaker)
+	decisionLog := meeting.NewDecisionLog(filepath.Join(dataDir, "meeting_decisions.jsonl"))
+	decisionLog.SetEnabled(configService.GetConfig().MeetingDecisionLog)
+	meetingService.SetDecisionLog(decisionLog)
+
+	// 初始化会话与记忆的存储后端（nil 表示使用默认文件存储）
+	sessionStore, memoryStore := openStores(dataDir, configService.GetConfig().StorageBackend)
 
 	// 初始化记忆管理器
 	var memoryManager *memory.Manager
 	memConfig := configService.GetConfig().Memory
 	if memConfig.Enabled {
-		memoryManager = memory.NewManagerWithConfig(dataDir, memory.Config{
+		memCfg := memory.Config{
 			MaxRecentRounds:   memConfig.MaxRecentRounds,
 			MaxKeyFacts:       memConfig.MaxKeyFacts,
 			MaxSummaryLength:  memConfig.MaxSummaryLength,
 			CompressThreshold: memConfig.CompressThreshold,
-		})
+			MaxContextLength:  memConfig.MaxContextLength,
+		}
+		if memoryStore != nil {
+			memoryManager = memory.NewManagerWithStore(memoryStore, memCfg)
+		} else {
+			memoryManager = memory.NewManagerWithConfig(dataDir, memCfg)
+		}
 		meetingService.SetMemoryManager(memoryManager)
+		meetingService.SetMemoryCheckpoint(memConfig.Checkpoint)
 
 		if memConfig.AIConfigID != "" {
 			for i := range configService.GetConfig().AIConfigs {
@@ -121,13 +178,24 @@ func NewApp() *App {
 	}
 
 	// 初始化Session服务
-	sessionService := services.NewSessionService(dataDir)
+	var sessionService *services.SessionService
+	if sessionStore != nil {
+		sessionService = services.NewSessionServiceWithStore(sessionStore)
+	} else {
+		sessionService = services.NewSessionService(dataDir)
+	}
 
 	// 初始化Agent配置服务和容器
 	agentConfigService := services.NewAgentConfigService(dataDir)
 	agentContainer := agent.NewContainer()
 	agentContainer.LoadAgents(agentConfigService.GetAllAgents())
 
+	// 初始化分析方案服务
+	profileService := services.NewProfileService(dataDir)
+
+	// 初始化会议技术分析存档服务
+	analysisArchive := services.NewMeetingAnalysisService(dataDir)
+
 	// 初始化更新服务
 	updateService := services.NewUpdateService("run-bigpig", "jcp", Version)
 
@@ -139,15 +207,22 @@ func NewApp() *App {
 		newsService:        newsService,
 		hotTrendService:    hotTrendSvc,
 		longHuBangService:  longHuBangService,
+		researchService:    researchReportService,
+		marketBreadth:      marketBreadthSvc,
 		meetingService:     meetingService,
 		sessionService:     sessionService,
 		agentConfigService: agentConfigService,
+		profileService:     profileService,
+		analysisArchive:    analysisArchive,
+		decisionLog:        decisionLog,
 		agentContainer:     agentContainer,
 		toolRegistry:       toolRegistry,
 		mcpManager:         mcpManager,
 		memoryManager:      memoryManager,
+		stockInfoService:   stockInfoSvc,
 		updateService:      updateService,
 		meetingCancels:     make(map[string]context.CancelFunc),
+		meetingEvents:      meeting.NewEventBuffer(meeting.DefaultEventBufferSize),
 	}
 }
 
@@ -159,6 +234,52 @@ func getDataDir() string {
 	return filepath.Join(userConfigDir, "jcp")
 }
 
+// applyTimeouts 将配置的请求超时应用到各数据服务，未配置(0)的项保持原值
+func applyTimeouts(t models.TimeoutConfig, market *services.MarketService, lhb *services.LongHuBangService, research *services.ResearchReportService, hot *hottrend.HotTrendService) {
+	seconds := func(n int) time.Duration { return time.Duration(n) * time.Second }
+	market.SetTimeouts(seconds(t.Quote), seconds(t.KLine))
+	lhb.SetTimeout(seconds(t.LongHuBang))
+	research.SetTimeout(seconds(t.Research))
+	if hot != nil {
+		// 批量获取总预算随单平台超时放大，与默认 8s/12s 保持同样比例
+		hot.SetTimeouts(seconds(t.HotTrend), seconds(t.HotTrend)*3/2)
+	}
+}
+
+// applySourceChains 将配置的数据源顺序应用到各数据服务，未配置的数据类型使用默认顺序
+func applySourceChains(c models.SourceChainConfig, market *services.MarketService, stockInfo *services.StockInfoService, breadth *services.MarketBreadthService) {
+	market.SetSourceChains(c.Quote, c.KLine, c.Retries)
+	stockInfo.SetSourceChain(c.ExtendedInfo, c.Retries)
+	breadth.SetSourceChain(c.Breadth, c.Retries)
+}
+
+// openStores 按配置打开会话与记忆的存储后端
+// 默认使用文件存储（返回 nil）；sqlite 打开失败时回退到文件存储
+func openStores(dataDir, backend string) (sessions, memories store.Store) {
+	if backend != "sqlite" {
+		return nil, nil
+	}
+	db, err := store.OpenSQLite(filepath.Join(dataDir, "jcp.db"))
+	if err != nil {
+		log.Warn("打开SQLite存储失败，回退到文件存储: %v", err)
+		return nil, nil
+	}
+	sessionStore, err := store.NewSQLiteStore(db, "sessions")
+	if err != nil {
+		log.Warn("初始化SQLite会话存储失败，回退到文件存储: %v", err)
+		db.Close()
+		return nil, nil
+	}
+	memoryStore, err := store.NewSQLiteStore(db, "memories")
+	if err != nil {
+		log.Warn("初始化SQLite记忆存储失败，回退到文件存储: %v", err)
+		db.Close()
+		return nil, nil
+	}
+	log.Info("会话与记忆使用SQLite存储")
+	return sessionStore, memoryStore
+}
+
 // migrateDataDir 迁移旧数据目录到新位置
 // 仅在新目录为空且旧目录存在时执行迁移
 func migrateDataDir(newDataDir string) {
@@ -278,6 +399,10 @@ func (a *App) startup(ctx context.Context) {
 	a.marketPusher = services.NewMarketDataPusher(a.marketService, a.configService, a.newsService)
 	a.marketPusher.Start(ctx)
 	log.Info("市场数据推送服务已启动")
+
+	// 每日收盘后积累涨跌家数，供宽度趋势分析
+	a.marketBreadth.StartHistoryRecorder(ctx)
+	a.toolRegistry.StartSnapshotFlusher(ctx)
 }
 
 // shutdown 应用关闭时调用
@@ -286,6 +411,7 @@ func (a *App) shutdown(ctx context.Context) {
 	if a.marketPusher != nil {
 		a.marketPusher.Stop()
 	}
+	a.toolRegistry.FlushToolSnapshots()
 	logger.Close()
 }
 
@@ -312,6 +438,31 @@ func (a *App) UpdateConfig(config *models.AppConfig) string {
 	}
 	// 更新代理配置
 	proxy.GetManager().SetConfig(&config.Proxy)
+	// 更新休市守卫开关
+	a.marketService.Guard().SetEnabled(config.MarketHoursOnly)
+	// 更新快讯来源
+	a.newsService.SetEnabledSources(config.NewsSources)
+	// 更新各数据源请求超时
+	applyTimeouts(config.Timeouts, a.marketService, a.longHuBangService, a.researchService, a.hotTrendService)
+	// 更新各数据类型的数据源顺序
+	applySourceChains(config.SourceChains, a.marketService, a.stockInfoService, a.marketBreadth)
+	// 更新小韭菜 Prompt 模板及总结参数、专家可选的 AI 配置、发言间隔、工具调用记录开关、全局工具、会话历史条数上限、兜底专家数量及会前数据预取
+	if a.meetingService != nil {
+		a.meetingService.SetModeratorPrompt(config.ModeratorPrompt)
+		a.meetingService.SetAIConfigs(config.AIConfigs)
+		a.meetingService.SetAgentDelay(time.Duration(config.AgentDelayMs) * time.Millisecond)
+		a.meetingService.SetIncludeToolTrace(config.IncludeToolTrace)
+		a.meetingService.SetSummaryParams(config.SummaryParams)
+		a.meetingService.SetGlobalTools(config.GlobalTools)
+		a.meetingService.SetMaxContextMessages(config.MaxContextMessages)
+		a.meetingService.SetFallbackAgentCount(config.FallbackAgentCount)
+		a.meetingService.SetPreflightGather(config.PreflightGather)
+		a.meetingService.SetAnalysisDepth(config.AnalysisDepth)
+		a.meetingService.SetUserProfile(config.UserName, config.UserPersona)
+		a.meetingService.SetCircuitBreaker(config.CircuitBreaker)
+		a.decisionLog.SetEnabled(config.MeetingDecisionLog)
+		a.meetingService.SetMemoryCheckpoint(config.Memory.Checkpoint)
+	}
 	// 更新记忆管理器的 LLM 配置
 	if a.meetingService != nil && config.Memory.AIConfigID != "" {
 		for i := range config.AIConfigs {
@@ -324,6 +475,13 @@ func (a *App) UpdateConfig(config *models.AppConfig) string {
 	return "success"
 }
 
+// ListModels 查询 AI 配置对应提供商的可用模型列表，供设置页下拉选择
+func (a *App) ListModels(config models.AIConfig) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	return adk.NewModelFactory().ListModels(ctx, &config)
+}
+
 // GetWatchlist 获取自选股列表
 func (a *App) GetWatchlist() []models.Stock {
 	return a.configService.GetWatchlist()
@@ -357,6 +515,34 @@ func (a *App) RemoveFromWatchlist(symbol string) string {
 	return "success"
 }
 
+// SubscribeOrderBook 订阅当前查看股票的盘口推送，传空字符串暂停推送
+func (a *App) SubscribeOrderBook(code string) string {
+	if a.marketPusher == nil {
+		return "推送服务未启动"
+	}
+	a.marketPusher.SetOrderBookSubscription(code)
+	return "success"
+}
+
+// ClearOrderBookSubscription 清除盘口订阅，停止盘口推送
+func (a *App) ClearOrderBookSubscription() string {
+	if a.marketPusher == nil {
+		return "推送服务未启动"
+	}
+	a.marketPusher.ClearOrderBookSubscription()
+	return "success"
+}
+
+// RefreshMarketData 手动刷新行情（休市期间也会请求最新数据），同时清空工具结果缓存
+func (a *App) RefreshMarketData() string {
+	if a.marketPusher == nil {
+		return "推送服务未启动"
+	}
+	a.toolRegistry.ClearToolCache()
+	a.marketPusher.Refresh()
+	return "success"
+}
+
 // GetStockRealTimeData 获取股票实时数据
 func (a *App) GetStockRealTimeData(codes []string) []models.Stock {
 	stocks, _ := a.marketService.GetStockRealTimeData(codes...)
@@ -375,11 +561,26 @@ func (a *App) GetOrderBook(code string) models.OrderBook {
 	return orderBook
 }
 
+// GetTechnicalAnalysis 获取结构化技术分析数据（供前端原生渲染指标）
+func (a *App) GetTechnicalAnalysis(code string) *indicators.FullAnalysis {
+	analysis, err := a.toolRegistry.ComputeTechnicalAnalysis(code)
+	if err != nil {
+		log.Error("获取技术分析失败: %v", err)
+		return nil
+	}
+	return analysis
+}
+
 // SearchStocks 搜索股票
 func (a *App) SearchStocks(keyword string) []services.StockSearchResult {
 	return a.configService.SearchStocks(keyword, 20)
 }
 
+// SearchStocksByIndustry 按行业搜索股票，limit <= 0 时返回全部
+func (a *App) SearchStocksByIndustry(industry string, limit int) []services.StockSearchResult {
+	return a.configService.SearchStocksByIndustry(industry, limit)
+}
+
 // getDefaultAIConfig 获取默认AI配置
 func (a *App) getDefaultAIConfig(config *models.AppConfig) *models.AIConfig {
 	for i := range config.AIConfigs {
@@ -443,6 +644,14 @@ func (a *App) UpdateStockPosition(stockCode string, shares int64, costPrice floa
 	return "success"
 }
 
+// GetConsensusHistory 获取股票的共识分历史（专家观点随时间变化）
+func (a *App) GetConsensusHistory(stockCode string) []memory.ConsensusPoint {
+	if a.memoryManager == nil {
+		return []memory.ConsensusPoint{}
+	}
+	return a.memoryManager.GetConsensusHistory(stockCode)
+}
+
 // ========== Agent Config API ==========
 
 // GetAgentConfigs 获取所有Agent配置
@@ -478,6 +687,43 @@ func (a *App) DeleteAgentConfig(id string) string {
 	return "success"
 }
 
+// ========== Profile API ==========
+
+// SaveProfile 将当前专家配置及会议设置保存为分析方案，同名方案覆盖
+func (a *App) SaveProfile(name string) string {
+	profile := models.Profile{
+		Name:     name,
+		Agents:   a.agentConfigService.GetAllAgents(),
+		Settings: services.ProfileSettingsFromConfig(a.configService.GetConfig()),
+	}
+	if err := a.profileService.Save(profile); err != nil {
+		return err.Error()
+	}
+	return "success"
+}
+
+// ListProfiles 获取已保存的分析方案列表
+func (a *App) ListProfiles() []models.ProfileInfo {
+	return a.profileService.List()
+}
+
+// SwitchProfile 切换到指定分析方案：替换专家配置并应用方案的会议设置
+func (a *App) SwitchProfile(name string) string {
+	profile, err := a.profileService.Load(name)
+	if err != nil {
+		return err.Error()
+	}
+	if err := a.agentConfigService.ReplaceAgents(profile.Agents); err != nil {
+		return err.Error()
+	}
+	a.agentContainer.LoadAgents(a.agentConfigService.GetAllAgents())
+
+	config := *a.configService.GetConfig()
+	services.ApplyProfileSettings(&config, profile.Settings)
+	log.Info("切换分析方案: %s", profile.Name)
+	return a.UpdateConfig(&config)
+}
+
 // ========== Meeting Room API ==========
 
 // MeetingMessageRequest 会议室消息请求
@@ -487,6 +733,11 @@ type MeetingMessageRequest struct {
 	MentionIds   []string `json:"mentionIds"`
 	ReplyToId    string   `json:"replyToId"`
 	ReplyContent string   `json:"replyContent"`
+	// 本次会议的工具白名单/黑名单（如"仅技术分析"模式），在专家已配置工具范围内收窄，黑名单优先
+	AllowedTools []string `json:"allowedTools"`
+	DeniedTools  []string `json:"deniedTools"`
+	// 随机种子，非 0 时覆盖 AI 配置中的种子，用于复现某次会议的输出
+	Seed int `json:"seed,omitempty"`
 }
 
 // cancelMeetingInternal 内部取消会议方法
@@ -524,17 +775,29 @@ func (a *App) SendMeetingMessage(req MeetingMessageRequest) []models.ChatMessage
 	a.meetingCancels[req.StockCode] = cancel
 	a.meetingCancelsMu.Unlock()
 
+	// 开始记录会议事件
+	generation := a.meetingEvents.Begin(req.StockCode)
+
 	// 会议结束后清理
 	defer func() {
 		a.meetingCancelsMu.Lock()
 		delete(a.meetingCancels, req.StockCode)
 		a.meetingCancelsMu.Unlock()
+		a.meetingEvents.End(req.StockCode, generation)
+		runtime.EventsEmit(a.ctx, "meeting:end:"+req.StockCode)
 	}()
 
+	// 取出此前的会话消息作为追问上下文（条数上限由会议室服务截取）
+	sessionHistory := a.sessionService.GetMessages(req.StockCode)
+
 	// 先保存用户消息
+	userName := a.configService.GetConfig().UserName
+	if userName == "" {
+		userName = models.DefaultUserName
+	}
 	userMsg := models.ChatMessage{
 		AgentID:   "user",
-		AgentName: "老韭菜",
+		AgentName: userName,
 		Content:   req.Content,
 		ReplyTo:   req.ReplyToId,
 		Mentions:  req.MentionIds,
@@ -542,11 +805,7 @@ func (a *App) SendMeetingMessage(req MeetingMessageRequest) []models.ChatMessage
 	a.sessionService.AddMessage(req.StockCode, userMsg)
 
 	// 获取股票数据
-	stocks, _ := a.marketService.GetStockRealTimeData(req.StockCode)
-	var stock models.Stock
-	if len(stocks) > 0 {
-		stock = stocks[0]
-	}
+	stock := a.meetingStock(req.StockCode)
 
 	// 获取默认AI配置
 	config := a.configService.GetConfig()
@@ -559,23 +818,110 @@ func (a *App) SendMeetingMessage(req MeetingMessageRequest) []models.ChatMessage
 	// 获取持仓信息
 	position := a.sessionService.GetPosition(req.StockCode)
 
+	// 按配置存档喂给专家的技术分析原文
+	var onAnalysis meeting.AnalysisCallback
+	if config.PersistMeetingAnalysis {
+		recorder := a.analysisArchive.Begin(req.StockCode, req.Content)
+		onAnalysis = recorder.Add
+		defer func() {
+			if id, err := recorder.Save(); err != nil {
+				log.Warn("保存会议技术分析失败: %v", err)
+			} else if id != "" {
+				log.Info("会议技术分析已存档: %s", id)
+			}
+		}()
+	}
+
 	// 判断是否为智能模式（无 @ 任何人）
 	if len(req.MentionIds) == 0 {
-		return a.runSmartMeeting(meetingCtx, req.StockCode, stock, req.Content, aiConfig, position)
+		return a.runSmartMeeting(meetingCtx, req, stock, aiConfig, position, sessionHistory, onAnalysis)
 	}
 
 	// 原有逻辑：@ 指定专家
-	return a.runDirectMeeting(meetingCtx, req, stock, aiConfig, position)
+	return a.runDirectMeeting(meetingCtx, req, stock, aiConfig, position, sessionHistory, onAnalysis)
+}
+
+// meetingStock 获取会议使用的股票行情，获取失败时至少保留代码
+// 离线模式下不请求网络，使用本次运行中最后一次行情；没有行情时从自选股或会话中补全名称
+func (a *App) meetingStock(code string) models.Stock {
+	if config := a.configService.GetConfig(); config == nil || !config.OfflineMode {
+		if stocks, _ := a.marketService.GetStockRealTimeData(code); len(stocks) > 0 {
+			return stocks[0]
+		}
+	} else if stock, ok := a.marketService.LastQuote(code); ok {
+		return stock
+	}
+
+	stock := models.Stock{Symbol: code}
+	for _, s := range a.configService.GetWatchlist() {
+		if s.Symbol == code {
+			stock.Name = s.Name
+			return stock
+		}
+	}
+	if session := a.sessionService.GetSession(code); session != nil {
+		stock.Name = session.StockName
+	}
+	return stock
+}
+
+// RunMeetingStructured 以智能模式运行会议并返回结构化结果（小韭菜决策、专家观点及倾向、总结、投票、用量）
+// 供外部集成使用：忽略 MentionIds，不写入会话消息、不推送会议事件；同一股票的进行中会议会被取消
+func (a *App) RunMeetingStructured(req MeetingMessageRequest) (*meeting.MeetingResult, error) {
+	if req.StockCode == "" || req.Content == "" {
+		return nil, errors.New("股票代码和问题不能为空")
+	}
+
+	a.cancelMeetingInternal(req.StockCode)
+	meetingCtx, cancel := context.WithCancel(a.ctx)
+	a.meetingCancelsMu.Lock()
+	a.meetingCancels[req.StockCode] = cancel
+	a.meetingCancelsMu.Unlock()
+	defer func() {
+		a.meetingCancelsMu.Lock()
+		delete(a.meetingCancels, req.StockCode)
+		a.meetingCancelsMu.Unlock()
+		cancel()
+	}()
+
+	stock := a.meetingStock(req.StockCode)
+
+	aiConfig := a.getDefaultAIConfig(a.configService.GetConfig())
+	if aiConfig == nil {
+		return nil, meeting.ErrNoAIConfig
+	}
+
+	chatReq := meeting.ChatRequest{
+		Stock:          stock,
+		Query:          req.Content,
+		AllAgents:      a.agentConfigService.GetAllAgents(),
+		Position:       a.sessionService.GetPosition(req.StockCode),
+		SessionHistory: a.sessionService.GetMessages(req.StockCode),
+		AllowedTools:   req.AllowedTools,
+		DeniedTools:    req.DeniedTools,
+		Seed:           req.Seed,
+	}
+	result, err := a.meetingService.RunSmartMeetingStructured(meetingCtx, aiConfig, chatReq)
+	if err != nil {
+		log.Error("RunMeetingStructured error: %v", err)
+		return nil, err
+	}
+	return result, nil
 }
 
 // runSmartMeeting 智能会议模式
-func (a *App) runSmartMeeting(ctx context.Context, stockCode string, stock models.Stock, query string, aiConfig *models.AIConfig, position *models.StockPosition) []models.ChatMessage {
+func (a *App) runSmartMeeting(ctx context.Context, req MeetingMessageRequest, stock models.Stock, aiConfig *models.AIConfig, position *models.StockPosition, sessionHistory []models.ChatMessage, onAnalysis meeting.AnalysisCallback) []models.ChatMessage {
 	allAgents := a.agentConfigService.GetAllAgents()
 	chatReq := meeting.ChatRequest{
-		Stock:     stock,
-		Query:     query,
-		AllAgents: allAgents,
-		Position:  position,
+		Stock:          stock,
+		Query:          req.Content,
+		AllAgents:      allAgents,
+		Position:       position,
+		SessionHistory: sessionHistory,
+		OnAnalysis:     onAnalysis,
+		AllowedTools:   req.AllowedTools,
+		DeniedTools:    req.DeniedTools,
+		Seed:           req.Seed,
 	}
 
 	// 响应回调：每次发言完成后推送
@@ -587,18 +933,28 @@ func (a *App) runSmartMeeting(ctx context.Context, stockCode string, stock model
 			Content:   resp.Content,
 			Round:     resp.Round,
 			MsgType:   resp.MsgType,
+			ToolTrace: resp.ToolTrace,
 		}
-		a.sessionService.AddMessage(stockCode, msg)
-		runtime.EventsEmit(a.ctx, "meeting:message:"+stockCode, msg)
+		a.sessionService.AddMessage(req.StockCode, msg)
+		a.emitMeetingMessage(req.StockCode, msg)
 	}
 
 	// 进度回调：工具调用、流式输出等细粒度事件
 	progressCallback := func(event meeting.ProgressEvent) {
-		runtime.EventsEmit(a.ctx, "meeting:progress:"+stockCode, event)
+		a.meetingEvents.AppendProgress(req.StockCode, event)
+		runtime.EventsEmit(a.ctx, "meeting:progress:"+req.StockCode, event)
 	}
 
 	responses, err := a.meetingService.RunSmartMeetingWithCallback(ctx, aiConfig, chatReq, respCallback, progressCallback)
-	if err != nil {
+	a.emitMeetingComplete(req.StockCode, meeting.MeetingModeSmart, responses, err)
+	if errors.Is(err, meeting.ErrMeetingTimeout) {
+		// 超时截断：已完成的发言照常返回
+		log.Warn("runSmartMeeting timeout, got %d responses", len(responses))
+	} else if errors.Is(err, meeting.ErrAIUnavailable) {
+		// 熔断中：直接提示不可用，避免用户空等
+		log.Warn("runSmartMeeting rejected: %v", err)
+		return a.convertSaveAndEmitResponses(req.StockCode, []meeting.ChatResponse{unavailableResponse(err)}, "")
+	} else if err != nil {
 		log.Error("runSmartMeeting error: %v", err)
 		return []models.ChatMessage{}
 	}
@@ -613,34 +969,48 @@ func (a *App) runSmartMeeting(ctx context.Context, stockCode string, stock model
 			Content:   resp.Content,
 			Round:     resp.Round,
 			MsgType:   resp.MsgType,
+			ToolTrace: resp.ToolTrace,
 		})
 	}
 	return messages
 }
 
 // runDirectMeeting 直接 @ 指定专家模式（带事件推送）
-func (a *App) runDirectMeeting(ctx context.Context, req MeetingMessageRequest, stock models.Stock, aiConfig *models.AIConfig, position *models.StockPosition) []models.ChatMessage {
+func (a *App) runDirectMeeting(ctx context.Context, req MeetingMessageRequest, stock models.Stock, aiConfig *models.AIConfig, position *models.StockPosition, sessionHistory []models.ChatMessage, onAnalysis meeting.AnalysisCallback) []models.ChatMessage {
 	agentConfigs := a.agentConfigService.GetAgentsByIDs(req.MentionIds)
 	if len(agentConfigs) == 0 {
 		return []models.ChatMessage{}
 	}
 
 	chatReq := meeting.ChatRequest{
-		Stock:        stock,
-		Agents:       agentConfigs,
-		Query:        req.Content,
-		ReplyContent: req.ReplyContent,
-		Position:     position,
+		Stock:          stock,
+		Agents:         agentConfigs,
+		Query:          req.Content,
+		ReplyContent:   req.ReplyContent,
+		Position:       position,
+		SessionHistory: sessionHistory,
+		OnAnalysis:     onAnalysis,
+		AllowedTools:   req.AllowedTools,
+		DeniedTools:    req.DeniedTools,
+		Seed:           req.Seed,
 	}
 
 	responses, err := a.meetingService.SendMessage(ctx, aiConfig, chatReq)
-	if err != nil {
+	if errors.Is(err, meeting.ErrAIUnavailable) {
+		log.Warn("runDirectMeeting rejected: %v", err)
+		a.emitMeetingComplete(req.StockCode, meeting.MeetingModeDirect, nil, err)
+		return a.convertSaveAndEmitResponses(req.StockCode, []meeting.ChatResponse{unavailableResponse(err)}, req.ReplyToId)
+	}
+	if err != nil && !errors.Is(err, meeting.ErrMeetingTimeout) {
 		log.Error("runDirectMeeting error: %v", err)
+		a.emitMeetingComplete(req.StockCode, meeting.MeetingModeDirect, nil, err)
 		return []models.ChatMessage{}
 	}
 
 	// 转换并保存响应，同时推送事件
-	return a.convertSaveAndEmitResponses(req.StockCode, responses, req.ReplyToId)
+	messages := a.convertSaveAndEmitResponses(req.StockCode, responses, req.ReplyToId)
+	a.emitMeetingComplete(req.StockCode, meeting.MeetingModeDirect, responses, err)
+	return messages
 }
 
 // convertSaveAndEmitResponses 转换响应、保存并推送事件（统一体验）
@@ -655,16 +1025,126 @@ func (a *App) convertSaveAndEmitResponses(stockCode string, responses []meeting.
 			ReplyTo:   replyTo,
 			Round:     resp.Round,
 			MsgType:   resp.MsgType,
+			ToolTrace: resp.ToolTrace,
 		}
 		// 保存单条消息
 		a.sessionService.AddMessage(stockCode, msg)
 		// 推送事件（与智能模式一致）
-		runtime.EventsEmit(a.ctx, "meeting:message:"+stockCode, msg)
+		a.emitMeetingMessage(stockCode, msg)
 		messages = append(messages, msg)
 	}
 	return messages
 }
 
+// ResummarizeMeeting 仅用选中的专家发言重新生成小韭菜总结（不重新运行专家），新总结追加到会话并推送
+// includeMessageIDs 为会话中专家发言的消息ID，非专家观点的消息会被忽略
+func (a *App) ResummarizeMeeting(stockCode string, includeMessageIDs []string) (models.ChatMessage, error) {
+	query, history := meeting.SummaryHistoryFromMessages(a.sessionService.GetMessages(stockCode), includeMessageIDs)
+	if len(history) == 0 {
+		return models.ChatMessage{}, meeting.ErrNoOpinions
+	}
+
+	aiConfig := a.getDefaultAIConfig(a.configService.GetConfig())
+	if aiConfig == nil {
+		return models.ChatMessage{}, meeting.ErrNoAIConfig
+	}
+
+	stock := a.meetingStock(stockCode)
+
+	summary, err := a.meetingService.Resummarize(a.ctx, aiConfig, &stock, query, history)
+	if err != nil {
+		log.Error("resummarize %s error: %v", stockCode, err)
+		return models.ChatMessage{}, err
+	}
+
+	msg := models.ChatMessage{
+		AgentID:   "moderator",
+		AgentName: "小韭菜",
+		Role:      "会议主持",
+		Content:   summary,
+		Round:     2,
+		MsgType:   "summary",
+	}
+	if err := a.sessionService.AddMessage(stockCode, msg); err != nil {
+		return models.ChatMessage{}, err
+	}
+	// 取回保存后的消息，带上会话分配的ID和时间戳
+	if msgs := a.sessionService.GetMessages(stockCode); len(msgs) > 0 {
+		msg = msgs[len(msgs)-1]
+	}
+	a.emitMeetingMessage(stockCode, msg)
+	return msg, nil
+}
+
+// unavailableResponse AI 服务熔断时以小韭菜名义返回的错误提示
+func unavailableResponse(err error) meeting.ChatResponse {
+	return meeting.ChatResponse{
+		AgentID:   "moderator",
+		AgentName: "小韭菜",
+		Content:   err.Error(),
+		MsgType:   "error",
+	}
+}
+
+// emitMeetingMessage 记录并推送会议发言事件
+func (a *App) emitMeetingMessage(stockCode string, msg models.ChatMessage) {
+	a.meetingEvents.AppendMessage(stockCode, msg)
+	runtime.EventsEmit(a.ctx, "meeting:message:"+stockCode, msg)
+}
+
+// emitMeetingComplete 会议结束时推送完整快照（需在配置中开启）
+func (a *App) emitMeetingComplete(stockCode, mode string, responses []meeting.ChatResponse, err error) {
+	if !a.configService.GetConfig().EmitMeetingComplete {
+		return
+	}
+	runtime.EventsEmit(a.ctx, "meeting:complete:"+stockCode, meeting.NewMeetingComplete(stockCode, mode, responses, err))
+}
+
+// GetMeetingEvents 获取会议已推送的事件，供前端刷新后回放（会议ID即股票代码）
+func (a *App) GetMeetingEvents(meetingID string) meeting.MeetingEvents {
+	return a.meetingEvents.Events(meetingID)
+}
+
+// GetAIBreakerStatus 获取各 AI 配置的熔断状态（仅包含近期有失败记录的配置）
+func (a *App) GetAIBreakerStatus() []meeting.BreakerStatus {
+	if a.meetingService == nil {
+		return nil
+	}
+	return a.meetingService.GetBreakerStatus()
+}
+
+// GetMeetingAnalysis 获取会议存档的技术分析原文（会议ID为 股票代码_会议开始时间）
+func (a *App) GetMeetingAnalysis(meetingID string) *models.MeetingAnalysis {
+	record, err := a.analysisArchive.Get(meetingID)
+	if err != nil {
+		log.Warn("获取会议技术分析失败: %v", err)
+		return nil
+	}
+	return record
+}
+
+// ListMeetingAnalyses 列出股票已存档技术分析的会议ID（按时间倒序）
+func (a *App) ListMeetingAnalyses(stockCode string) []string {
+	ids, err := a.analysisArchive.List(stockCode)
+	if err != nil {
+		return []string{}
+	}
+	return ids
+}
+
+// GetMeetingDecisionLog 读取智能会议决策日志（按时间升序），用于分析小韭菜的专家选择是否合理
+func (a *App) GetMeetingDecisionLog() []meeting.DecisionRecord {
+	records, err := a.decisionLog.Read()
+	if err != nil {
+		log.Warn("读取会议决策日志失败: %v", err)
+		return []meeting.DecisionRecord{}
+	}
+	if records == nil {
+		return []meeting.DecisionRecord{}
+	}
+	return records
+}
+
 // ========== News API ==========
 
 // GetTelegraphList 获取快讯列表
@@ -676,6 +1156,16 @@ func (a *App) GetTelegraphList() []services.Telegraph {
 	return telegraphs
 }
 
+// GetDefaultModeratorPrompt 获取内置的小韭菜意图分析 Prompt 模板
+func (a *App) GetDefaultModeratorPrompt() string {
+	return meeting.DefaultModeratorPrompt
+}
+
+// GetNewsSources 获取快讯来源列表及启用状态
+func (a *App) GetNewsSources() []services.NewsSourceInfo {
+	return a.newsService.GetSources()
+}
+
 // OpenURL 在浏览器中打开URL
 func (a *App) OpenURL(url string) {
 	runtime.BrowserOpenURL(a.ctx, url)
@@ -769,6 +1259,51 @@ func (a *App) GetMCPServerTools(serverID string) []mcp.ToolInfo {
 	return tools
 }
 
+// InvokeMCPTool 直接调用 MCP 工具并返回原始结果（用于调试）
+func (a *App) InvokeMCPTool(serverID, toolName string, args map[string]any) (string, error) {
+	return a.mcpManager.InvokeMCPTool(serverID, toolName, args)
+}
+
+// ========== Diagnostics API ==========
+
+// HealthCheck 检测所有外部依赖（行情/资讯数据源、默认 LLM、已启用的 MCP 服务器）的可用性
+func (a *App) HealthCheck() map[string]services.HealthStatus {
+	probes := services.DataSourceProbes()
+
+	config := a.configService.GetConfig()
+	if aiConfig := a.getDefaultAIConfig(config); aiConfig != nil {
+		factory := adk.NewModelFactory()
+		probes = append(probes, services.HealthProbe{
+			ID:   "llm",
+			Name: "大模型: " + aiConfig.Name,
+			Check: func(ctx context.Context) error {
+				return factory.PingModel(ctx, aiConfig)
+			},
+		})
+	}
+
+	if a.mcpManager != nil {
+		for _, server := range config.MCPServers {
+			if !server.Enabled {
+				continue
+			}
+			serverID := server.ID
+			probes = append(probes, services.HealthProbe{
+				ID:   "mcp:" + serverID,
+				Name: "MCP: " + server.Name,
+				Check: func(ctx context.Context) error {
+					if status := a.mcpManager.TestConnection(serverID); !status.Connected {
+						return errors.New(status.Error)
+					}
+					return nil
+				},
+			})
+		}
+	}
+
+	return services.RunHealthChecks(context.Background(), probes, 8*time.Second)
+}
+
 // ========== Window Control API ==========
 
 // WindowMinimize 最小化窗口
@@ -1,7 +1,8 @@
 import React, { useState, useEffect, useRef } from 'react';
 import { Stock, KLineData } from '../types';
 import { getAgentConfigs, AgentConfig } from '../services/agentConfigService';
-import { StockSession, ChatMessage, sendMeetingMessage, MeetingMessageRequest, getSessionMessages } from '../services/sessionService';
+import { getConfig, getAvailableTools } from '../services/configService';
+import { StockSession, ChatMessage, sendMeetingMessage, MeetingMessageRequest, getSessionMessages, getMeetingEvents } from '../services/sessionService';
 import { MessageSquare, Loader2, Send, User, Users, X, Reply, Trash2, Wrench, CheckCircle2, AlertCircle, Copy, Check, RotateCcw, Pencil, Square } from 'lucide-react';
 import { clearSessionMessages } from '../services/sessionService';
 import { NodeRenderer } from 'markstream-react';
@@ -28,6 +29,58 @@ interface ProgressState {
   streamingText: string;
 }
 
+const emptyProgress: ProgressState = {
+  currentAgent: null,
+  currentAgentName: null,
+  steps: [],
+  streamingText: '',
+};
+
+// 根据进度事件计算新的进度状态
+const applyProgressEvent = (prev: ProgressState, event: ProgressEvent): ProgressState => {
+  switch (event.type) {
+    case 'agent_start':
+      return {
+        currentAgent: event.agentId,
+        currentAgentName: event.agentName,
+        steps: [],
+        streamingText: '',
+      };
+    case 'agent_done':
+      return { ...prev, currentAgent: null, currentAgentName: null, steps: [], streamingText: '' };
+    case 'tool_call':
+      return {
+        ...prev,
+        steps: [...prev.steps, { type: 'tool_call', detail: event.detail || '', done: false }],
+      };
+    case 'tool_result':
+      const updatedSteps = prev.steps.map(s =>
+        s.type === 'tool_call' && s.detail === event.detail ? { ...s, done: true } : s
+      );
+      return { ...prev, steps: updatedSteps };
+    case 'streaming':
+      return { ...prev, streamingText: prev.streamingText + (event.content || '') };
+    default:
+      return prev;
+  }
+};
+
+// 会议工具模式：在专家已配置的工具范围内收窄，不修改专家配置
+type ToolMode = 'all' | 'technical' | 'fundamental';
+
+const TOOL_MODE_LABELS: Record<ToolMode, string> = {
+  all: '全部工具',
+  technical: '仅技术分析',
+  fundamental: '仅基本面',
+};
+
+// 各模式允许的工具，按后端工具分类生成；all 返回空数组表示不限制
+const toolModeAllowed = async (mode: ToolMode): Promise<string[]> => {
+  if (mode === 'all') return [];
+  const tools = await getAvailableTools();
+  return (tools || []).filter(t => t.categories?.includes(mode)).map(t => t.name);
+};
+
 interface AgentRoomProps {
   stock: Stock;
   kLineData: KLineData[];
@@ -40,6 +93,8 @@ export const AgentRoom: React.FC<AgentRoomProps> = ({ session, onSessionUpdate }
   const [messages, setMessages] = useState<ChatMessage[]>([]);
   const [simulatingMap, setSimulatingMap] = useState<Record<string, boolean>>({});
   const [userQuery, setUserQuery] = useState('');
+  const [userName, setUserName] = useState('老韭菜');
+  const [toolMode, setToolMode] = useState<ToolMode>('all');
   const scrollRef = useRef<HTMLDivElement>(null);
   const inputRef = useRef<HTMLInputElement>(null);
 
@@ -112,6 +167,13 @@ export const AgentRoom: React.FC<AgentRoomProps> = ({ session, onSessionUpdate }
       });
   }, []);
 
+  // 加载用户称呼（切换会话时刷新，以便设置修改后生效）
+  useEffect(() => {
+    getConfig()
+      .then(config => setUserName(config.userName || '老韭菜'))
+      .catch(err => console.error('[AgentRoom] 加载用户称呼失败:', err));
+  }, [session?.stockCode]);
+
   // 当Session变化时，从后端加载最新消息
   useEffect(() => {
     // 记录之前的 stockCode 用于取消
@@ -125,9 +187,24 @@ export const AgentRoom: React.FC<AgentRoomProps> = ({ session, onSessionUpdate }
       }
 
       // 从后端获取最新消息（包括切换期间产生的新消息）
-      getSessionMessages(session.stockCode).then(msgs => {
+      const stockCode = session.stockCode;
+      getSessionMessages(stockCode).then(msgs => {
         setMessages(msgs || []);
       });
+
+      // 会议仍在进行（如前端刷新），回放进度事件恢复讨论状态
+      getMeetingEvents(stockCode).then(result => {
+        if (!result?.active || currentStockCodeRef.current !== stockCode) return;
+        meetingCancelledRef.current[stockCode] = false;
+        setSimulatingMap(prev => ({ ...prev, [stockCode]: true }));
+        setProgress(
+          result.events
+            .filter(e => e.type === 'progress' && e.progress)
+            .reduce((prev, e) => applyProgressEvent(prev, e.progress as ProgressEvent), emptyProgress)
+        );
+      }).catch(err => {
+        console.error('[AgentRoom] 获取会议事件失败:', err);
+      });
     } else {
       setMessages([]);
     }
@@ -165,33 +242,26 @@ export const AgentRoom: React.FC<AgentRoomProps> = ({ session, onSessionUpdate }
       if (meetingCancelledRef.current[stockCode]) return;
       if (currentStockCodeRef.current !== stockCode) return;
 
-      setProgress(prev => {
-        switch (event.type) {
-          case 'agent_start':
-            return {
-              currentAgent: event.agentId,
-              currentAgentName: event.agentName,
-              steps: [],
-              streamingText: '',
-            };
-          case 'agent_done':
-            return { ...prev, currentAgent: null, currentAgentName: null, steps: [], streamingText: '' };
-          case 'tool_call':
-            return {
-              ...prev,
-              steps: [...prev.steps, { type: 'tool_call', detail: event.detail || '', done: false }],
-            };
-          case 'tool_result':
-            const updatedSteps = prev.steps.map(s =>
-              s.type === 'tool_call' && s.detail === event.detail ? { ...s, done: true } : s
-            );
-            return { ...prev, steps: updatedSteps };
-          case 'streaming':
-            return { ...prev, streamingText: prev.streamingText + (event.content || '') };
-          default:
-            return prev;
-        }
-      });
+      setProgress(prev => applyProgressEvent(prev, event));
+    });
+
+    return () => {
+      EventsOff(eventName);
+      if (cleanup) cleanup();
+    };
+  }, [session?.stockCode]);
+
+  // 订阅会议结束事件（刷新后恢复的会议由此重置状态）
+  useEffect(() => {
+    if (!session?.stockCode) return;
+
+    const stockCode = session.stockCode;
+    const eventName = `meeting:end:${stockCode}`;
+    const cleanup = EventsOn(eventName, () => {
+      setSimulatingMap(prev => ({ ...prev, [stockCode]: false }));
+      if (currentStockCodeRef.current === stockCode) {
+        setProgress(emptyProgress);
+      }
     });
 
     return () => {
@@ -223,7 +293,7 @@ export const AgentRoom: React.FC<AgentRoomProps> = ({ session, onSessionUpdate }
     const userMsg: ChatMessage = {
       id: `user-${Date.now()}`,
       agentId: 'user',
-      agentName: '老韭菜',
+      agentName: userName,
       role: '',
       content: query,
       timestamp: Date.now(),
@@ -240,7 +310,9 @@ export const AgentRoom: React.FC<AgentRoomProps> = ({ session, onSessionUpdate }
         content: query,
         mentionIds: mentions,
         replyToId: replyTo?.id || '',
-        replyContent: replyTo?.content || ''
+        replyContent: replyTo?.content || '',
+        allowedTools: await toolModeAllowed(toolMode),
+        deniedTools: []
       };
 
       // 统一模式：无论智能模式还是直接@模式，消息都通过事件实时推送
@@ -426,7 +498,7 @@ export const AgentRoom: React.FC<AgentRoomProps> = ({ session, onSessionUpdate }
               .filter(Boolean);
             // 获取引用的消息
             const quotedMsg = msg.replyTo ? messages.find(m => m.id === msg.replyTo) : null;
-            const displayName = msg.agentName || '老韭菜';
+            const displayName = msg.agentName || userName;
 
             return (
                <div key={msg.id} className="flex gap-3 justify-end animate-in fade-in slide-in-from-bottom-2 duration-300">
@@ -529,9 +601,35 @@ export const AgentRoom: React.FC<AgentRoomProps> = ({ session, onSessionUpdate }
                   <span className="text-[9px] text-slate-500 uppercase border fin-divider px-1 rounded fin-chip">{msg.role || agent?.role}</span>
                 </div>
                 <div className="relative">
-                  <div className="text-sm text-slate-200 bg-slate-800/70 p-3 rounded-2xl rounded-tl-none border border-slate-700/40 leading-relaxed shadow-sm agent-message-content">
+                  <div className={`text-sm p-3 rounded-2xl rounded-tl-none border leading-relaxed shadow-sm agent-message-content ${
+                    msg.msgType === 'error'
+                      ? 'bg-red-950/40 border-red-500/40 text-red-300'
+                      : 'bg-slate-800/70 border-slate-700/40 text-slate-200'
+                  }`}>
                     <NodeRenderer content={msg.content} />
                   </div>
+                  {/* 工具调用记录 */}
+                  {msg.toolTrace && msg.toolTrace.length > 0 && (
+                    <details className="mt-1 text-xs text-slate-400">
+                      <summary className="cursor-pointer select-none flex items-center gap-1 hover:text-slate-200">
+                        <Wrench size={12} />
+                        工具调用 ({msg.toolTrace.length})
+                      </summary>
+                      <div className="mt-1 space-y-2">
+                        {msg.toolTrace.map((trace, i) => (
+                          <div key={i} className="p-2 rounded-lg bg-slate-900/60 border border-slate-700/40">
+                            <div className="font-mono text-accent-2">{trace.name}</div>
+                            {trace.args && trace.args !== 'null' && (
+                              <div className="font-mono text-slate-500 break-all mt-0.5">{trace.args}</div>
+                            )}
+                            {trace.result && (
+                              <pre className="mt-1 whitespace-pre-wrap break-all text-slate-400 max-h-40 overflow-y-auto">{trace.result}</pre>
+                            )}
+                          </div>
+                        ))}
+                      </div>
+                    </details>
+                  )}
                   {/* 操作按钮组 */}
                   <div className="absolute -right-2 top-1 flex flex-col gap-1 opacity-0 group-hover:opacity-100 transition-opacity">
                     <button
@@ -704,8 +802,19 @@ export const AgentRoom: React.FC<AgentRoomProps> = ({ session, onSessionUpdate }
             )}
           </form>
         </div>
-        <div className="mt-1 text-center">
+        <div className="mt-1 flex items-center justify-center gap-2">
           <span className="text-[10px] text-slate-600">直接提问由小韭菜安排韭菜专家，@ 可指定韭菜专家</span>
+          <select
+            value={toolMode}
+            onChange={(e) => setToolMode(e.target.value as ToolMode)}
+            disabled={isSimulating}
+            title="限定本次讨论专家可用的工具，不修改专家配置"
+            className="text-[10px] text-slate-500 bg-transparent border fin-divider rounded px-1 py-0.5"
+          >
+            {(Object.keys(TOOL_MODE_LABELS) as ToolMode[]).map(mode => (
+              <option key={mode} value={mode}>{TOOL_MODE_LABELS[mode]}</option>
+            ))}
+          </select>
         </div>
       </div>
 
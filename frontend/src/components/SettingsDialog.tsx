@@ -1,6 +1,6 @@
 import React, { useState, useEffect } from 'react';
 import { X, Cpu, Bot, ChevronLeft, Plug, Plus, Trash2, Wrench, Sliders, Check, Loader2, Brain, RefreshCw, Download, RotateCcw, Globe } from 'lucide-react';
-import { getConfig, updateConfig, getAvailableTools, ToolInfo } from '../services/configService';
+import { getConfig, updateConfig, getAvailableTools, getNewsSources, getDefaultModeratorPrompt, listModels, ToolInfo, NewsSourceInfo } from '../services/configService';
 import { getAgentConfigs, updateAgentConfig, AgentConfig } from '../services/agentConfigService';
 import { getMCPServers, MCPServerConfig, MCPServerStatus, testMCPConnection, getMCPServerTools, MCPToolInfo } from '../services/mcpService';
 import { checkForUpdate, doUpdate, restartApp, getCurrentVersion, onUpdateProgress, UpdateInfo, UpdateProgress } from '../services/updateService';
@@ -18,6 +18,8 @@ interface AIConfig {
   isDefault: boolean;
   // OpenAI Responses API 开关
   useResponses: boolean;
+  // 随机种子，0 表示不指定
+  seed?: number;
   // Vertex AI 专用字段
   project: string;
   location: string;
@@ -31,6 +33,8 @@ interface MemoryConfig {
   maxKeyFacts: number;
   maxSummaryLength: number;
   compressThreshold: number;
+  maxContextLength: number;
+  checkpoint: boolean;
 }
 
 // 代理模式类型
@@ -42,6 +46,71 @@ interface ProxyConfig {
   customUrl: string;
 }
 
+// 保存时需保留的其他 AppConfig 字段
+interface FullConfigFields {
+  theme: string;
+  orderBookPush: string;
+  marketHoursOnly: boolean;
+  newsSources: string[];
+  moderatorPrompt: string;
+  agentDelayMs: number;
+  includeToolTrace: boolean;
+  summaryParams: SummaryParams;
+  globalTools: string[];
+  maxContextMessages: number;
+  fallbackAgentCount: number;
+  preflightGather: boolean;
+  analysisDepth: string;
+  userName: string;
+  userPersona: string;
+  circuitBreaker: CircuitBreakerConfig;
+  storageBackend: string;
+  timeouts: TimeoutConfig;
+  sourceChains: SourceChainConfig;
+  telegraphDedupMinutes: number;
+  emitMeetingComplete: boolean;
+  persistMeetingAnalysis: boolean;
+  meetingDecisionLog: boolean;
+  offlineMode: boolean;
+  coalesceMarketPush: boolean;
+  toolCacheTTL: Record<string, number>;
+}
+
+// 各数据类型的数据源顺序（仅在配置文件中编辑，保存时原样保留）
+interface SourceChainConfig {
+  quote?: string[];
+  kline?: string[];
+  extendedInfo?: string[];
+  breadth?: string[];
+  retries?: number;
+}
+
+// 各数据源请求超时(秒)
+interface TimeoutConfig {
+  quote: number;
+  kline: number;
+  longHuBang: number;
+  research: number;
+  hotTrend: number;
+}
+
+const DEFAULT_TIMEOUTS: TimeoutConfig = { quote: 10, kline: 10, longHuBang: 15, research: 15, hotTrend: 8 };
+
+interface CircuitBreakerConfig {
+  threshold: number;
+  windowSec: number;
+  cooldownSec: number;
+}
+
+const DEFAULT_CIRCUIT_BREAKER: CircuitBreakerConfig = { threshold: 0, windowSec: 300, cooldownSec: 60 };
+
+// 小韭菜总结生成参数，temperature 未设置时沿用模型默认值
+interface SummaryParams {
+  temperature?: number;
+  maxTokens: number;
+  weighted?: boolean;
+}
+
 type TabType = 'provider' | 'agent' | 'mcp' | 'memory' | 'proxy' | 'update';
 
 interface SettingsDialogProps {
@@ -69,7 +138,11 @@ export const SettingsDialog: React.FC<SettingsDialogProps> = ({ isOpen, onClose
     maxKeyFacts: 20,
     maxSummaryLength: 300,
     compressThreshold: 5,
+    maxContextLength: 2000,
+    checkpoint: false,
   });
+  const [newsSources, setNewsSources] = useState<NewsSourceInfo[]>([]);
+  const [defaultModeratorPrompt, setDefaultModeratorPrompt] = useState('');
   const [proxyConfig, setProxyConfig] = useState<ProxyConfig>({
     mode: 'none',
     customUrl: '',
@@ -82,9 +155,7 @@ export const SettingsDialog: React.FC<SettingsDialogProps> = ({ isOpen, onClose
     mcpServers: MCPServerConfig[];
   } | null>(null);
   // 完整的原始 AppConfig（用于保存时保留其他字段）
-  const [fullConfig, setFullConfig] = useState<{
-    theme: string;
-  } | null>(null);
+  const [fullConfig, setFullConfig] = useState<FullConfigFields | null>(null);
 
   useEffect(() => {
     if (isOpen) {
@@ -116,7 +187,40 @@ export const SettingsDialog: React.FC<SettingsDialogProps> = ({ isOpen, onClose
     // 保存完整配置的其他字段
     setFullConfig({
       theme: config.theme || 'military',
+      orderBookPush: config.orderBookPush || 'auto',
+      marketHoursOnly: !!config.marketHoursOnly,
+      newsSources: config.newsSources || [],
+      moderatorPrompt: config.moderatorPrompt || '',
+      agentDelayMs: config.agentDelayMs || 0,
+      includeToolTrace: !!config.includeToolTrace,
+      summaryParams: {
+        temperature: config.summaryParams?.temperature,
+        maxTokens: config.summaryParams?.maxTokens || 0,
+        weighted: !!config.summaryParams?.weighted,
+      },
+      globalTools: config.globalTools || [],
+      maxContextMessages: config.maxContextMessages ?? 10,
+      fallbackAgentCount: config.fallbackAgentCount ?? 2,
+      preflightGather: !!config.preflightGather,
+      analysisDepth: config.analysisDepth || 'standard',
+      userName: config.userName || '',
+      userPersona: config.userPersona || '',
+      circuitBreaker: { ...DEFAULT_CIRCUIT_BREAKER, ...config.circuitBreaker },
+      storageBackend: config.storageBackend || '',
+      timeouts: { ...DEFAULT_TIMEOUTS, ...config.timeouts },
+      sourceChains: config.sourceChains || {},
+      telegraphDedupMinutes: config.telegraphDedupMinutes || 60,
+      emitMeetingComplete: !!config.emitMeetingComplete,
+      persistMeetingAnalysis: !!config.persistMeetingAnalysis,
+      meetingDecisionLog: !!config.meetingDecisionLog,
+      offlineMode: !!config.offlineMode,
+      coalesceMarketPush: !!config.coalesceMarketPush,
+      toolCacheTTL: config.toolCacheTTL || {},
     });
+    setDefaultModeratorPrompt(await getDefaultModeratorPrompt());
+    // 加载快讯来源
+    const sources = await getNewsSources();
+    setNewsSources(sources || []);
     // 加载可用的内置工具列表
     const tools = await getAvailableTools();
     setAvailableTools(tools || []);
@@ -227,6 +331,75 @@ export const SettingsDialog: React.FC<SettingsDialogProps> = ({ isOpen, onClose
                 onUpdateAgent={(updated) => {
                   setAgentConfigs(prev => prev.map(a => a.id === updated.id ? updated : a));
                 }}
+                moderatorPrompt={fullConfig?.moderatorPrompt || ''}
+                defaultModeratorPrompt={defaultModeratorPrompt}
+                onModeratorPromptChange={(prompt) =>
+                  setFullConfig(prev => prev ? { ...prev, moderatorPrompt: prompt } : prev)
+                }
+                agentDelayMs={fullConfig?.agentDelayMs || 0}
+                onAgentDelayChange={(delay) =>
+                  setFullConfig(prev => prev ? { ...prev, agentDelayMs: delay } : prev)
+                }
+                includeToolTrace={fullConfig?.includeToolTrace ?? false}
+                onIncludeToolTraceChange={(enabled) =>
+                  setFullConfig(prev => prev ? { ...prev, includeToolTrace: enabled } : prev)
+                }
+                emitMeetingComplete={fullConfig?.emitMeetingComplete ?? false}
+                onEmitMeetingCompleteChange={(enabled) =>
+                  setFullConfig(prev => prev ? { ...prev, emitMeetingComplete: enabled } : prev)
+                }
+                persistMeetingAnalysis={fullConfig?.persistMeetingAnalysis ?? false}
+                onPersistMeetingAnalysisChange={(enabled) =>
+                  setFullConfig(prev => prev ? { ...prev, persistMeetingAnalysis: enabled } : prev)
+                }
+                meetingDecisionLog={fullConfig?.meetingDecisionLog ?? false}
+                onMeetingDecisionLogChange={(enabled) =>
+                  setFullConfig(prev => prev ? { ...prev, meetingDecisionLog: enabled } : prev)
+                }
+                offlineMode={fullConfig?.offlineMode ?? false}
+                onOfflineModeChange={(enabled) =>
+                  setFullConfig(prev => prev ? { ...prev, offlineMode: enabled } : prev)
+                }
+                toolCacheTTL={fullConfig?.toolCacheTTL || {}}
+                onToolCacheTTLChange={(ttl) =>
+                  setFullConfig(prev => prev ? { ...prev, toolCacheTTL: ttl } : prev)
+                }
+                summaryParams={fullConfig?.summaryParams || { maxTokens: 0 }}
+                onSummaryParamsChange={(params) =>
+                  setFullConfig(prev => prev ? { ...prev, summaryParams: params } : prev)
+                }
+                globalTools={fullConfig?.globalTools || []}
+                onGlobalToolsChange={(tools) =>
+                  setFullConfig(prev => prev ? { ...prev, globalTools: tools } : prev)
+                }
+                maxContextMessages={fullConfig?.maxContextMessages ?? 10}
+                onMaxContextMessagesChange={(max) =>
+                  setFullConfig(prev => prev ? { ...prev, maxContextMessages: max } : prev)
+                }
+                fallbackAgentCount={fullConfig?.fallbackAgentCount ?? 2}
+                onFallbackAgentCountChange={(count) =>
+                  setFullConfig(prev => prev ? { ...prev, fallbackAgentCount: count } : prev)
+                }
+                preflightGather={fullConfig?.preflightGather ?? false}
+                onPreflightGatherChange={(enabled) =>
+                  setFullConfig(prev => prev ? { ...prev, preflightGather: enabled } : prev)
+                }
+                analysisDepth={fullConfig?.analysisDepth || 'standard'}
+                onAnalysisDepthChange={(depth) =>
+                  setFullConfig(prev => prev ? { ...prev, analysisDepth: depth } : prev)
+                }
+                userName={fullConfig?.userName || ''}
+                onUserNameChange={(name) =>
+                  setFullConfig(prev => prev ? { ...prev, userName: name } : prev)
+                }
+                userPersona={fullConfig?.userPersona || ''}
+                onUserPersonaChange={(persona) =>
+                  setFullConfig(prev => prev ? { ...prev, userPersona: persona } : prev)
+                }
+                circuitBreaker={fullConfig?.circuitBreaker || DEFAULT_CIRCUIT_BREAKER}
+                onCircuitBreakerChange={(cb) =>
+                  setFullConfig(prev => prev ? { ...prev, circuitBreaker: cb } : prev)
+                }
               />
             )}
             {activeTab === 'mcp' && (
@@ -259,6 +432,27 @@ export const SettingsDialog: React.FC<SettingsDialogProps> = ({ isOpen, onClose
               <ProxySettings
                 config={proxyConfig}
                 onChange={setProxyConfig}
+                marketHoursOnly={fullConfig?.marketHoursOnly ?? false}
+                onMarketHoursOnlyChange={(enabled) =>
+                  setFullConfig(prev => prev ? { ...prev, marketHoursOnly: enabled } : prev)
+                }
+                newsSources={newsSources}
+                onNewsSourcesChange={(sources) => {
+                  setNewsSources(sources);
+                  setFullConfig(prev => prev ? { ...prev, newsSources: sources.filter(s => s.enabled).map(s => s.id) } : prev);
+                }}
+                timeouts={fullConfig?.timeouts || DEFAULT_TIMEOUTS}
+                onTimeoutsChange={(timeouts) =>
+                  setFullConfig(prev => prev ? { ...prev, timeouts } : prev)
+                }
+                telegraphDedupMinutes={fullConfig?.telegraphDedupMinutes || 60}
+                onTelegraphDedupMinutesChange={(minutes) =>
+                  setFullConfig(prev => prev ? { ...prev, telegraphDedupMinutes: minutes } : prev)
+                }
+                coalesceMarketPush={fullConfig?.coalesceMarketPush ?? false}
+                onCoalesceMarketPushChange={(enabled) =>
+                  setFullConfig(prev => prev ? { ...prev, coalesceMarketPush: enabled } : prev)
+                }
               />
             )}
             {activeTab === 'update' && (
@@ -412,6 +606,31 @@ interface ProviderConfigFormProps {
 
 const ProviderConfigForm: React.FC<ProviderConfigFormProps> = ({ config, onChange }) => {
   const isVertexAI = config.provider === 'vertexai';
+  const [modelOptions, setModelOptions] = useState<string[]>([]);
+  const [loadingModels, setLoadingModels] = useState(false);
+  const [modelError, setModelError] = useState('');
+
+  // 切换提供商后清空上一次的模型列表
+  useEffect(() => {
+    setModelOptions([]);
+    setModelError('');
+  }, [config.id, config.provider]);
+
+  const handleListModels = async () => {
+    setLoadingModels(true);
+    setModelError('');
+    try {
+      const names = await listModels(config as any);
+      setModelOptions(names);
+      if (names.length === 0) {
+        setModelError('未获取到可用模型，请手动填写');
+      }
+    } catch (e) {
+      setModelError(String(e));
+    } finally {
+      setLoadingModels(false);
+    }
+  };
 
   return (
     <div className="space-y-4 fin-panel rounded-lg p-4 border fin-divider">
@@ -460,7 +679,45 @@ const ProviderConfigForm: React.FC<ProviderConfigFormProps> = ({ config, onChang
       )}
 
       {/* 通用字段 */}
-      <FormField label="模型名称" value={config.modelName} onChange={v => onChange({ ...config, modelName: v })} />
+      <div>
+        <div className="flex items-center justify-between mb-1.5">
+          <label className="text-sm text-slate-400">模型名称</label>
+          <button
+            type="button"
+            onClick={handleListModels}
+            disabled={loadingModels}
+            className="flex items-center gap-1 text-xs text-[var(--accent)] hover:opacity-80 disabled:opacity-50"
+          >
+            {loadingModels ? <Loader2 className="h-3 w-3 animate-spin" /> : <RefreshCw className="h-3 w-3" />}
+            获取模型列表
+          </button>
+        </div>
+        <input
+          type="text"
+          list={`models-${config.id}`}
+          value={config.modelName}
+          onChange={e => onChange({ ...config, modelName: e.target.value })}
+          placeholder={`留空使用默认模型 ${getDefaultModel(config.provider)}`}
+          className="w-full fin-input rounded-lg px-3 py-2 text-white text-sm transition-colors"
+        />
+        <datalist id={`models-${config.id}`}>
+          {modelOptions.map(name => <option key={name} value={name} />)}
+        </datalist>
+        {modelError && <p className="text-xs text-red-400 mt-1">{modelError}</p>}
+        {!modelError && modelOptions.length > 0 && (
+          <p className="text-xs text-slate-500 mt-1">已获取 {modelOptions.length} 个模型，点击输入框可下拉选择</p>
+        )}
+      </div>
+      {(config.provider === 'gemini' || config.provider === 'vertexai' || (config.provider === 'openai' && !config.useResponses)) && (
+        <div>
+          <FormField
+            label="随机种子 (可选)"
+            value={config.seed ? String(config.seed) : ''}
+            onChange={v => onChange({ ...config, seed: parseInt(v) || 0 })}
+          />
+          <p className="text-xs text-slate-500 mt-1">配合温度 0 使用可尽量复现相同输出，便于调试专家 Prompt；留空表示不指定</p>
+        </div>
+      )}
       <div className="flex items-center pt-2">
         <label className="flex items-center gap-2 text-sm text-slate-400 cursor-pointer">
           <input
@@ -527,10 +784,57 @@ interface AgentSettingsProps {
   selectedAgent: AgentConfig | null;
   onSelectAgent: (agent: AgentConfig | null) => void;
   onUpdateAgent: (agent: AgentConfig) => void;
+  moderatorPrompt: string;
+  defaultModeratorPrompt: string;
+  onModeratorPromptChange: (prompt: string) => void;
+  agentDelayMs: number;
+  onAgentDelayChange: (delay: number) => void;
+  includeToolTrace: boolean;
+  onIncludeToolTraceChange: (enabled: boolean) => void;
+  emitMeetingComplete: boolean;
+  onEmitMeetingCompleteChange: (enabled: boolean) => void;
+  persistMeetingAnalysis: boolean;
+  onPersistMeetingAnalysisChange: (enabled: boolean) => void;
+  meetingDecisionLog: boolean;
+  onMeetingDecisionLogChange: (enabled: boolean) => void;
+  offlineMode: boolean;
+  onOfflineModeChange: (enabled: boolean) => void;
+  toolCacheTTL: Record<string, number>;
+  onToolCacheTTLChange: (ttl: Record<string, number>) => void;
+  summaryParams: SummaryParams;
+  onSummaryParamsChange: (params: SummaryParams) => void;
+  globalTools: string[];
+  onGlobalToolsChange: (tools: string[]) => void;
+  maxContextMessages: number;
+  onMaxContextMessagesChange: (max: number) => void;
+  fallbackAgentCount: number;
+  onFallbackAgentCountChange: (count: number) => void;
+  preflightGather: boolean;
+  onPreflightGatherChange: (enabled: boolean) => void;
+  analysisDepth: string;
+  onAnalysisDepthChange: (depth: string) => void;
+  userName: string;
+  onUserNameChange: (name: string) => void;
+  userPersona: string;
+  onUserPersonaChange: (persona: string) => void;
+  circuitBreaker: CircuitBreakerConfig;
+  onCircuitBreakerChange: (cb: CircuitBreakerConfig) => void;
 }
 
 const AgentSettings: React.FC<AgentSettingsProps> = ({
-  agents, providers, availableTools, mcpServers, selectedAgent, onSelectAgent, onUpdateAgent
+  agents, providers, availableTools, mcpServers, selectedAgent, onSelectAgent, onUpdateAgent,
+  moderatorPrompt, defaultModeratorPrompt, onModeratorPromptChange,
+  agentDelayMs, onAgentDelayChange, includeToolTrace, onIncludeToolTraceChange,
+  emitMeetingComplete, onEmitMeetingCompleteChange,
+  persistMeetingAnalysis, onPersistMeetingAnalysisChange,
+  meetingDecisionLog, onMeetingDecisionLogChange,
+  offlineMode, onOfflineModeChange,
+  toolCacheTTL, onToolCacheTTLChange,
+  summaryParams, onSummaryParamsChange, globalTools, onGlobalToolsChange,
+  maxContextMessages, onMaxContextMessagesChange, fallbackAgentCount, onFallbackAgentCountChange,
+  preflightGather, onPreflightGatherChange, analysisDepth, onAnalysisDepthChange,
+  userName, onUserNameChange, userPersona, onUserPersonaChange,
+  circuitBreaker, onCircuitBreakerChange,
 }) => {
   // 从 agents 数组中获取最新的 selectedAgent（确保数据同步）
   const currentAgent = selectedAgent ? agents.find(a => a.id === selectedAgent.id) || selectedAgent : null;
@@ -565,6 +869,392 @@ const AgentSettings: React.FC<AgentSettingsProps> = ({
           />
         ))
       )}
+
+      {/* 小韭菜编排 Prompt */}
+      <div className="pt-4 mt-4 border-t border-slate-700">
+        <div className="flex items-center justify-between mb-2">
+          <h3 className="text-sm font-medium text-white">小韭菜编排 Prompt</h3>
+          <button
+            onClick={() => onModeratorPromptChange(moderatorPrompt ? '' : defaultModeratorPrompt)}
+            className="text-xs text-slate-400 hover:text-white transition-colors"
+          >
+            {moderatorPrompt ? '恢复默认' : '基于默认编辑'}
+          </button>
+        </div>
+        <p className="text-xs text-slate-500 mb-2">
+          智能模式下小韭菜选择专家的提示词，留空使用内置模板。可用占位符：{'{{stock}}'} 股票信息、{'{{query}}'} 用户问题、{'{{agents}}'} 专家列表、{'{{user}}'} 对用户的称呼，JSON 输出格式会自动追加
+        </p>
+        <textarea
+          value={moderatorPrompt}
+          onChange={e => onModeratorPromptChange(e.target.value)}
+          rows={10}
+          placeholder={defaultModeratorPrompt}
+          className="w-full fin-input rounded-lg px-3 py-2 text-white text-sm resize-none font-mono"
+        />
+      </div>
+
+      {/* 全局工具 */}
+      <div className="pt-4 mt-4 border-t border-slate-700">
+        <h3 className="text-sm font-medium text-white mb-2">全局工具</h3>
+        <p className="text-xs text-slate-500 mb-2">
+          勾选的工具对所有专家始终可用，即使专家配置中移除了该工具，避免专家缺少基础数据
+        </p>
+        <div className="flex flex-wrap gap-2">
+          {availableTools.map(tool => {
+            const isSelected = globalTools.includes(tool.name);
+            return (
+              <button
+                key={tool.name}
+                onClick={() => onGlobalToolsChange(
+                  isSelected ? globalTools.filter(n => n !== tool.name) : [...globalTools, tool.name]
+                )}
+                title={tool.description}
+                className={`text-xs px-2 py-1 rounded border transition-colors ${
+                  isSelected
+                    ? 'border-accent/50 bg-accent/10 text-white'
+                    : 'border-slate-700 text-slate-400 hover:border-slate-600'
+                }`}
+              >
+                {tool.name}
+              </button>
+            );
+          })}
+        </div>
+      </div>
+
+      {/* 小韭菜总结参数 */}
+      <div className="pt-4 mt-4 border-t border-slate-700">
+        <h3 className="text-sm font-medium text-white mb-2">小韭菜总结参数</h3>
+        <p className="text-xs text-slate-500 mb-2">
+          仅作用于会议总结，与专家的模型参数相互独立。调低温度可让总结更贴近专家原意。温度留空、Tokens 为 0 时使用模型默认值
+        </p>
+        <div className="flex gap-4">
+          <div>
+            <label className="block text-xs text-slate-400 mb-1">温度</label>
+            <input
+              type="number"
+              min={0}
+              max={2}
+              step={0.1}
+              value={summaryParams.temperature ?? ''}
+              onChange={e => onSummaryParamsChange({
+                ...summaryParams,
+                temperature: e.target.value === '' ? undefined : Math.min(2, Math.max(0, parseFloat(e.target.value) || 0)),
+              })}
+              placeholder="默认"
+              className="w-32 fin-input rounded-lg px-3 py-2 text-white text-sm"
+            />
+          </div>
+          <div>
+            <label className="block text-xs text-slate-400 mb-1">最大输出 Tokens</label>
+            <input
+              type="number"
+              min={0}
+              step={256}
+              value={summaryParams.maxTokens}
+              onChange={e => onSummaryParamsChange({ ...summaryParams, maxTokens: Math.max(0, parseInt(e.target.value) || 0) })}
+              className="w-32 fin-input rounded-lg px-3 py-2 text-white text-sm"
+            />
+          </div>
+        </div>
+        <label className="flex items-center gap-2 mt-3 text-xs text-slate-400 cursor-pointer">
+          <input
+            type="checkbox"
+            checked={!!summaryParams.weighted}
+            onChange={e => onSummaryParamsChange({ ...summaryParams, weighted: e.target.checked })}
+            className="accent-[var(--accent)]"
+          />
+          按专家权重总结（小韭菜按问题相关度给专家加权，结论以相关度高的专家为主）
+        </label>
+      </div>
+
+      {/* 专家发言间隔 */}
+      <div className="pt-4 mt-4 border-t border-slate-700">
+        <h3 className="text-sm font-medium text-white mb-2">专家发言间隔 (毫秒)</h3>
+        <p className="text-xs text-slate-500 mb-2">
+          智能模式下专家依次发言的等待时间，并行模式下专家在该时长内随机错开启动。API 限流严格时可适当调大，0 表示不等待
+        </p>
+        <input
+          type="number"
+          min={0}
+          step={500}
+          value={agentDelayMs}
+          onChange={e => onAgentDelayChange(Math.max(0, parseInt(e.target.value) || 0))}
+          className="w-40 fin-input rounded-lg px-3 py-2 text-white text-sm"
+        />
+      </div>
+
+      {/* 用户称呼与画像 */}
+      <div className="pt-4 mt-4 border-t border-slate-700">
+        <h3 className="text-sm font-medium text-white mb-2">我的称呼与画像</h3>
+        <p className="text-xs text-slate-500 mb-2">
+          会议中专家对你的称呼（留空为“老韭菜”），以及投资画像如“稳健型投资者”，专家和小韭菜会据此调整语气和建议风格
+        </p>
+        <div className="flex gap-2">
+          <input
+            type="text"
+            value={userName}
+            onChange={e => onUserNameChange(e.target.value)}
+            placeholder="老韭菜"
+            maxLength={20}
+            className="w-40 fin-input rounded-lg px-3 py-2 text-white text-sm"
+          />
+          <input
+            type="text"
+            value={userPersona}
+            onChange={e => onUserPersonaChange(e.target.value)}
+            placeholder="如：稳健型投资者，偏好低回撤"
+            maxLength={100}
+            className="flex-1 fin-input rounded-lg px-3 py-2 text-white text-sm"
+          />
+        </div>
+      </div>
+
+      {/* 分析深度 */}
+      <div className="pt-4 mt-4 border-t border-slate-700">
+        <h3 className="text-sm font-medium text-white mb-2">分析深度</h3>
+        <p className="text-xs text-slate-500 mb-2">
+          智能模式的一键预设：快速只请一位专家发言且不总结；标准由小韭菜选择专家并总结；深度邀请全部专家发言并在会前预取行情、技术分析和快讯
+        </p>
+        <select
+          value={analysisDepth}
+          onChange={e => onAnalysisDepthChange(e.target.value)}
+          className="w-40 fin-input rounded-lg px-3 py-2 text-white text-sm"
+        >
+          <option value="quick">快速</option>
+          <option value="standard">标准</option>
+          <option value="deep">深度</option>
+        </select>
+      </div>
+
+      {/* AI 服务熔断 */}
+      <div className="pt-4 mt-4 border-t border-slate-700">
+        <h3 className="text-sm font-medium text-white mb-2">AI 服务熔断</h3>
+        <p className="text-xs text-slate-500 mb-2">
+          同一模型配置在统计窗口内连续失败达到次数后，冷却期内新会议直接提示“AI服务暂时不可用”，冷却结束后放行一次试探。失败次数为 0 表示关闭
+        </p>
+        <div className="flex items-center gap-2 text-xs text-slate-400">
+          <span>连续失败</span>
+          <input
+            type="number"
+            min={0}
+            max={20}
+            value={circuitBreaker.threshold}
+            onChange={e => onCircuitBreakerChange({ ...circuitBreaker, threshold: Math.min(20, Math.max(0, parseInt(e.target.value) || 0)) })}
+            className="w-16 fin-input rounded-lg px-2 py-2 text-white text-sm"
+          />
+          <span>次 / 窗口</span>
+          <input
+            type="number"
+            min={10}
+            step={10}
+            value={circuitBreaker.windowSec}
+            onChange={e => onCircuitBreakerChange({ ...circuitBreaker, windowSec: Math.max(10, parseInt(e.target.value) || 300) })}
+            className="w-20 fin-input rounded-lg px-2 py-2 text-white text-sm"
+          />
+          <span>秒，冷却</span>
+          <input
+            type="number"
+            min={10}
+            step={10}
+            value={circuitBreaker.cooldownSec}
+            onChange={e => onCircuitBreakerChange({ ...circuitBreaker, cooldownSec: Math.max(10, parseInt(e.target.value) || 60) })}
+            className="w-20 fin-input rounded-lg px-2 py-2 text-white text-sm"
+          />
+          <span>秒</span>
+        </div>
+      </div>
+
+      {/* 兜底专家数量 */}
+      <div className="pt-4 mt-4 border-t border-slate-700">
+        <h3 className="text-sm font-medium text-white mb-2">兜底专家数量</h3>
+        <p className="text-xs text-slate-500 mb-2">
+          小韭菜没有选中任何专家时，按优先级邀请前几位已启用的专家发言，避免只有开场白。0 表示不兜底
+        </p>
+        <input
+          type="number"
+          min={0}
+          max={6}
+          value={fallbackAgentCount}
+          onChange={e => onFallbackAgentCountChange(Math.min(6, Math.max(0, parseInt(e.target.value) || 0)))}
+          className="w-40 fin-input rounded-lg px-3 py-2 text-white text-sm"
+        />
+      </div>
+
+      {/* 会前数据预取 */}
+      <div className="flex items-center justify-between pt-4 mt-4 border-t border-slate-700">
+        <div>
+          <div className="text-white text-sm font-medium">会前数据预取</div>
+          <div className="text-slate-400 text-xs mt-0.5">
+            智能模式下专家发言前统一获取实时行情、技术分析和快讯并共享给所有专家，减少重复调用工具，降低耗时和 token 消耗
+          </div>
+        </div>
+        <label className="relative inline-flex items-center cursor-pointer">
+          <input
+            type="checkbox"
+            checked={preflightGather}
+            onChange={(e) => onPreflightGatherChange(e.target.checked)}
+            className="sr-only peer"
+          />
+          <div className="w-11 h-6 bg-slate-700 peer-focus:outline-none rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-accent"></div>
+        </label>
+      </div>
+
+      {/* 会话历史条数 */}
+      <div className="pt-4 mt-4 border-t border-slate-700">
+        <h3 className="text-sm font-medium text-white mb-2">会话历史条数</h3>
+        <p className="text-xs text-slate-500 mb-2">
+          追问时注入专家上下文的最近会话消息条数，长会话只保留最近部分以免超出模型上下文，0 表示不注入
+        </p>
+        <input
+          type="number"
+          min={0}
+          max={50}
+          value={maxContextMessages}
+          onChange={e => onMaxContextMessagesChange(Math.min(50, Math.max(0, parseInt(e.target.value) || 0)))}
+          className="w-40 fin-input rounded-lg px-3 py-2 text-white text-sm"
+        />
+      </div>
+
+      {/* 记录工具调用 */}
+      <div className="flex items-center justify-between pt-4 mt-4 border-t border-slate-700">
+        <div>
+          <div className="text-white text-sm font-medium">记录工具调用</div>
+          <div className="text-slate-400 text-xs mt-0.5">
+            在专家发言中附带调用的工具、参数及返回结果（超长截断），便于核对数据来源
+          </div>
+        </div>
+        <label className="relative inline-flex items-center cursor-pointer">
+          <input
+            type="checkbox"
+            checked={includeToolTrace}
+            onChange={(e) => onIncludeToolTraceChange(e.target.checked)}
+            className="sr-only peer"
+          />
+          <div className="w-11 h-6 bg-slate-700 peer-focus:outline-none rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-accent"></div>
+        </label>
+      </div>
+
+      {/* 会议结束快照事件 */}
+      <div className="flex items-center justify-between pt-4 mt-4 border-t border-slate-700">
+        <div>
+          <div className="text-white text-sm font-medium">推送会议结束快照</div>
+          <div className="text-slate-400 text-xs mt-0.5">
+            会议结束时额外推送一次完整结果（全部发言、token 用量、是否超时截断），供外部前端判断会议已结束
+          </div>
+        </div>
+        <label className="relative inline-flex items-center cursor-pointer">
+          <input
+            type="checkbox"
+            checked={emitMeetingComplete}
+            onChange={(e) => onEmitMeetingCompleteChange(e.target.checked)}
+            className="sr-only peer"
+          />
+          <div className="w-11 h-6 bg-slate-700 peer-focus:outline-none rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-accent"></div>
+        </label>
+      </div>
+
+      {/* 存档会议技术分析 */}
+      <div className="flex items-center justify-between pt-4 mt-4 border-t border-slate-700">
+        <div>
+          <div className="text-white text-sm font-medium">存档会议技术分析</div>
+          <div className="text-slate-400 text-xs mt-0.5">
+            保存每场会议中专家看到的技术分析原文（按股票和会议时间存档），便于事后核对指标、复现结论，会占用一定磁盘空间
+          </div>
+        </div>
+        <label className="relative inline-flex items-center cursor-pointer">
+          <input
+            type="checkbox"
+            checked={persistMeetingAnalysis}
+            onChange={(e) => onPersistMeetingAnalysisChange(e.target.checked)}
+            className="sr-only peer"
+          />
+          <div className="w-11 h-6 bg-slate-700 peer-focus:outline-none rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-accent"></div>
+        </label>
+      </div>
+
+      {/* 记录会议决策日志 */}
+      <div className="flex items-center justify-between pt-4 mt-4 border-t border-slate-700">
+        <div>
+          <div className="text-white text-sm font-medium">记录会议决策日志</div>
+          <div className="text-slate-400 text-xs mt-0.5">
+            记录每场智能会议的问题、小韭菜选择的专家及结果摘要（meeting_decisions.jsonl，上限 5MB 自动轮转），用于评估专家选择是否合理
+          </div>
+        </div>
+        <label className="relative inline-flex items-center cursor-pointer">
+          <input
+            type="checkbox"
+            checked={meetingDecisionLog}
+            onChange={(e) => onMeetingDecisionLogChange(e.target.checked)}
+            className="sr-only peer"
+          />
+          <div className="w-11 h-6 bg-slate-700 peer-focus:outline-none rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-accent"></div>
+        </label>
+      </div>
+
+      {/* 离线模式 */}
+      <div className="flex items-center justify-between pt-4 mt-4 border-t border-slate-700">
+        <div>
+          <div className="text-white text-sm font-medium">离线模式</div>
+          <div className="text-slate-400 text-xs mt-0.5">
+            数据源无法访问时开启：专家工具不再请求行情/资讯，改用最近一次联网调用的结果并标注为离线数据，会前预取跳过；大模型仍需联网
+          </div>
+        </div>
+        <label className="relative inline-flex items-center cursor-pointer">
+          <input
+            type="checkbox"
+            checked={offlineMode}
+            onChange={(e) => onOfflineModeChange(e.target.checked)}
+            className="sr-only peer"
+          />
+          <div className="w-11 h-6 bg-slate-700 peer-focus:outline-none rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-accent"></div>
+        </label>
+      </div>
+
+      {/* 工具结果缓存 */}
+      <ToolCacheTTLEditor value={toolCacheTTL} onChange={onToolCacheTTLChange} />
+    </div>
+  );
+};
+
+// 工具结果缓存配置：每行 "工具名=秒"，失焦时解析保存
+const formatToolCacheTTL = (ttl: Record<string, number>) =>
+  Object.entries(ttl).map(([name, seconds]) => `${name}=${seconds}`).join('\n');
+
+const parseToolCacheTTL = (text: string) => {
+  const ttl: Record<string, number> = {};
+  for (const line of text.split('\n')) {
+    const [name, seconds] = line.split('=').map(s => s.trim());
+    const value = parseInt(seconds);
+    if (name && value > 0) {
+      ttl[name] = value;
+    }
+  }
+  return ttl;
+};
+
+const ToolCacheTTLEditor: React.FC<{ value: Record<string, number>; onChange: (ttl: Record<string, number>) => void }> = ({ value, onChange }) => {
+  const formatted = formatToolCacheTTL(value);
+  const [text, setText] = useState(formatted);
+
+  useEffect(() => {
+    setText(formatted);
+  }, [formatted]);
+
+  return (
+    <div className="pt-4 mt-4 border-t border-slate-700">
+      <div className="text-white text-sm font-medium">工具结果缓存</div>
+      <div className="text-slate-400 text-xs mt-0.5 mb-3">
+        每行一个"工具名=秒"，相同参数的调用在有效期内直接复用结果，专家之间、会议之间共享；未配置的工具不缓存，手动刷新行情时清空缓存
+      </div>
+      <textarea
+        value={text}
+        onChange={e => setText(e.target.value)}
+        onBlur={() => onChange(parseToolCacheTTL(text))}
+        rows={4}
+        placeholder={'get_company_profile=86400\nget_stock_realtime=2'}
+        className="w-full fin-input rounded-lg px-3 py-2 text-white text-sm resize-none font-mono"
+      />
     </div>
   );
 };
@@ -876,11 +1566,12 @@ const getDefaultBaseUrl = (provider: string): string => {
   }
 };
 
+// 与后端 adk.DefaultModelName 保持一致，模型名称留空时后端使用同一默认值
 const getDefaultModel = (provider: string): string => {
   switch (provider) {
-    case 'openai': return 'gpt-4';
-    case 'gemini': return 'gemini-pro';
-    case 'vertexai': return 'gemini-1.5-pro';
+    case 'openai': return 'gpt-4o';
+    case 'gemini': return 'gemini-2.5-flash';
+    case 'vertexai': return 'gemini-2.5-flash';
     case 'anthropic': return 'claude-sonnet-4-5-20250929';
     default: return '';
   }
@@ -892,7 +1583,7 @@ const handleSave = async (
   mcpServers: MCPServerConfig[],
   memoryConfig: MemoryConfig,
   proxyConfig: ProxyConfig,
-  fullConfig: { theme: string } | null,
+  fullConfig: FullConfigFields | null,
   setSaving: React.Dispatch<React.SetStateAction<boolean>>,
   onClose: () => void
 ) => {
@@ -906,6 +1597,31 @@ const handleSave = async (
       mcpServers: mcpServers,
       memory: memoryConfig,
       proxy: proxyConfig,
+      orderBookPush: fullConfig?.orderBookPush || 'auto',
+      marketHoursOnly: fullConfig?.marketHoursOnly ?? false,
+      newsSources: fullConfig?.newsSources || [],
+      moderatorPrompt: fullConfig?.moderatorPrompt || '',
+      agentDelayMs: fullConfig?.agentDelayMs || 0,
+      includeToolTrace: fullConfig?.includeToolTrace ?? false,
+      summaryParams: fullConfig?.summaryParams || { maxTokens: 0 },
+      globalTools: fullConfig?.globalTools || [],
+      maxContextMessages: fullConfig?.maxContextMessages ?? 10,
+      fallbackAgentCount: fullConfig?.fallbackAgentCount ?? 2,
+      preflightGather: fullConfig?.preflightGather ?? false,
+      analysisDepth: fullConfig?.analysisDepth || 'standard',
+      userName: fullConfig?.userName || '',
+      userPersona: fullConfig?.userPersona || '',
+      circuitBreaker: fullConfig?.circuitBreaker || DEFAULT_CIRCUIT_BREAKER,
+      storageBackend: fullConfig?.storageBackend || '',
+      timeouts: fullConfig?.timeouts || DEFAULT_TIMEOUTS,
+      sourceChains: fullConfig?.sourceChains || {},
+      telegraphDedupMinutes: fullConfig?.telegraphDedupMinutes || 60,
+      emitMeetingComplete: fullConfig?.emitMeetingComplete ?? false,
+      persistMeetingAnalysis: fullConfig?.persistMeetingAnalysis ?? false,
+      meetingDecisionLog: fullConfig?.meetingDecisionLog ?? false,
+      offlineMode: fullConfig?.offlineMode ?? false,
+      coalesceMarketPush: fullConfig?.coalesceMarketPush ?? false,
+      toolCacheTTL: fullConfig?.toolCacheTTL || {},
     } as any);
 
     // 保存所有 Agent 配置（会触发后端重载）
@@ -1040,6 +1756,43 @@ const MemorySettings: React.FC<MemorySettingsProps> = ({ config, aiConfigs, onCh
               className="w-full h-2 bg-slate-700 rounded-lg appearance-none cursor-pointer accent-[var(--accent)]"
             />
           </div>
+
+          <div>
+            <label className="block text-sm text-slate-300 mb-2">
+              记忆上下文最大长度
+              <span className="text-slate-500 ml-2">({config.maxContextLength || 2000}字)</span>
+            </label>
+            <input
+              type="range"
+              min="500"
+              max="5000"
+              step="250"
+              value={config.maxContextLength || 2000}
+              onChange={(e) => onChange({ ...config, maxContextLength: parseInt(e.target.value) })}
+              className="w-full h-2 bg-slate-700 rounded-lg appearance-none cursor-pointer accent-[var(--accent)]"
+            />
+            <p className="text-xs text-slate-500 mt-1">
+              超出时优先保留最近讨论和高相关事实，避免挤占分析数据
+            </p>
+          </div>
+
+          <div className="flex items-center justify-between pt-4 border-t border-slate-700">
+            <div>
+              <div className="text-white text-sm font-medium">逐位保存记忆草稿</div>
+              <div className="text-slate-400 text-xs mt-0.5">
+                每位专家发言后更新记忆草稿，会议中途取消也能保留已发言专家的观点；会议正常结束时由正式总结取代
+              </div>
+            </div>
+            <label className="relative inline-flex items-center cursor-pointer">
+              <input
+                type="checkbox"
+                checked={!!config.checkpoint}
+                onChange={(e) => onChange({ ...config, checkpoint: e.target.checked })}
+                className="sr-only peer"
+              />
+              <div className="w-11 h-6 bg-slate-700 peer-focus:outline-none rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-accent"></div>
+            </label>
+          </div>
         </div>
       )}
     </div>
@@ -1335,15 +2088,41 @@ const MCPEditForm: React.FC<MCPEditFormProps> = ({ server, status, tools, onBack
 interface ProxySettingsProps {
   config: ProxyConfig;
   onChange: (config: ProxyConfig) => void;
+  marketHoursOnly: boolean;
+  onMarketHoursOnlyChange: (enabled: boolean) => void;
+  newsSources: NewsSourceInfo[];
+  onNewsSourcesChange: (sources: NewsSourceInfo[]) => void;
+  timeouts: TimeoutConfig;
+  onTimeoutsChange: (timeouts: TimeoutConfig) => void;
+  telegraphDedupMinutes: number;
+  onTelegraphDedupMinutesChange: (minutes: number) => void;
+  coalesceMarketPush: boolean;
+  onCoalesceMarketPushChange: (enabled: boolean) => void;
 }
 
-const ProxySettings: React.FC<ProxySettingsProps> = ({ config, onChange }) => {
+const ProxySettings: React.FC<ProxySettingsProps> = ({ config, onChange, marketHoursOnly, onMarketHoursOnlyChange, newsSources, onNewsSourcesChange, timeouts, onTimeoutsChange, telegraphDedupMinutes, onTelegraphDedupMinutesChange, coalesceMarketPush, onCoalesceMarketPushChange }) => {
+  // 至少保留一个启用的来源
+  const toggleNewsSource = (id: string, enabled: boolean) => {
+    const next = newsSources.map(s => s.id === id ? { ...s, enabled } : s);
+    if (next.some(s => s.enabled)) {
+      onNewsSourcesChange(next);
+    }
+  };
+
   const proxyModes: { value: ProxyMode; label: string; desc: string }[] = [
     { value: 'none', label: '无代理', desc: '直接连接，不使用任何代理' },
     { value: 'system', label: '系统代理', desc: '使用操作系统的代理设置' },
     { value: 'custom', label: '自定义代理', desc: '手动指定代理服务器地址' },
   ];
 
+  const timeoutFields: { key: keyof TimeoutConfig; label: string }[] = [
+    { key: 'quote', label: '实时行情' },
+    { key: 'kline', label: 'K线' },
+    { key: 'longHuBang', label: '龙虎榜' },
+    { key: 'research', label: '研报' },
+    { key: 'hotTrend', label: '舆情热点' },
+  ];
+
   return (
     <div className="space-y-6">
       <div>
@@ -1398,6 +2177,102 @@ const ProxySettings: React.FC<ProxySettingsProps> = ({ config, onChange }) => {
           </p>
         </div>
       )}
+
+      {/* 休市期间减少请求 */}
+      <div className="flex items-center justify-between pt-4 border-t border-slate-700">
+        <div>
+          <div className="text-white text-sm font-medium">仅交易时段拉取行情</div>
+          <div className="text-slate-400 text-xs mt-0.5">
+            休市期间行情、盘口推送复用最后一次数据，减少夜间挂机时的无效请求
+          </div>
+        </div>
+        <label className="relative inline-flex items-center cursor-pointer">
+          <input
+            type="checkbox"
+            checked={marketHoursOnly}
+            onChange={(e) => onMarketHoursOnlyChange(e.target.checked)}
+            className="sr-only peer"
+          />
+          <div className="w-11 h-6 bg-slate-700 peer-focus:outline-none rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-accent"></div>
+        </label>
+      </div>
+
+      {/* 行情推送合并 */}
+      <div className="flex items-center justify-between pt-4 border-t border-slate-700">
+        <div>
+          <div className="text-white text-sm font-medium">合并行情推送</div>
+          <div className="text-slate-400 text-xs mt-0.5">
+            自选股和大盘指数价格无变化时不推送，每 30 秒强制同步一次，减少界面无效刷新
+          </div>
+        </div>
+        <label className="relative inline-flex items-center cursor-pointer">
+          <input
+            type="checkbox"
+            checked={coalesceMarketPush}
+            onChange={(e) => onCoalesceMarketPushChange(e.target.checked)}
+            className="sr-only peer"
+          />
+          <div className="w-11 h-6 bg-slate-700 peer-focus:outline-none rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-accent"></div>
+        </label>
+      </div>
+
+      {/* 请求超时 */}
+      <div className="pt-4 border-t border-slate-700">
+        <div className="text-white text-sm font-medium">请求超时（秒）</div>
+        <div className="text-slate-400 text-xs mt-0.5 mb-3">
+          网络或代理较慢时可适当调大，网络稳定时调小可更快失败重试
+        </div>
+        <div className="grid grid-cols-3 gap-3">
+          {timeoutFields.map(field => (
+            <label key={field.key} className="text-xs text-slate-300">
+              {field.label}
+              <input
+                type="number"
+                min={1}
+                max={120}
+                value={timeouts[field.key]}
+                onChange={e => onTimeoutsChange({ ...timeouts, [field.key]: Math.min(120, Math.max(1, parseInt(e.target.value) || DEFAULT_TIMEOUTS[field.key])) })}
+                className="mt-1 w-full fin-input rounded-lg px-3 py-2 text-white text-sm"
+              />
+            </label>
+          ))}
+        </div>
+      </div>
+
+      {/* 快讯来源 */}
+      {newsSources.length > 0 && (
+        <div className="pt-4 border-t border-slate-700">
+          <div className="text-white text-sm font-medium">快讯来源</div>
+          <div className="text-slate-400 text-xs mt-0.5 mb-3">
+            多个来源合并去重，某个来源不可用时自动使用其他来源
+          </div>
+          <div className="flex flex-wrap gap-4">
+            {newsSources.map(source => (
+              <label key={source.id} className="flex items-center gap-2 text-sm text-slate-300 cursor-pointer">
+                <input
+                  type="checkbox"
+                  checked={source.enabled}
+                  onChange={(e) => toggleNewsSource(source.id, e.target.checked)}
+                  className="accent-[var(--accent)]"
+                />
+                {source.name}
+              </label>
+            ))}
+          </div>
+          <div className="flex items-center gap-2 mt-3 text-xs text-slate-400">
+            <span>推送去重窗口</span>
+            <input
+              type="number"
+              min={1}
+              max={1440}
+              value={telegraphDedupMinutes}
+              onChange={e => onTelegraphDedupMinutesChange(Math.min(1440, Math.max(1, parseInt(e.target.value) || 60)))}
+              className="w-20 fin-input rounded-lg px-2 py-1 text-white text-sm"
+            />
+            <span>分钟内推送过的快讯不再重复提醒</span>
+          </div>
+        </div>
+      )}
     </div>
   );
 };
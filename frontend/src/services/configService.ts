@@ -1,5 +1,5 @@
 // 配置服务 - 调用后端API
-import { GetConfig, UpdateConfig, GetAvailableTools } from '@wailsjs/go/main/App';
+import { GetConfig, UpdateConfig, GetAvailableTools, GetNewsSources, GetDefaultModeratorPrompt, ListModels } from '@wailsjs/go/main/App';
 import type { models } from '@wailsjs/go/models';
 
 export type AppConfig = models.AppConfig;
@@ -8,6 +8,7 @@ export type AppConfig = models.AppConfig;
 export interface ToolInfo {
   name: string;
   description: string;
+  categories?: string[]; // 所属分类（technical / fundamental）
 }
 
 export const getConfig = async (): Promise<AppConfig> => {
@@ -22,3 +23,25 @@ export const updateConfig = async (config: AppConfig): Promise<string> => {
 export const getAvailableTools = async (): Promise<ToolInfo[]> => {
   return await GetAvailableTools();
 };
+
+// 查询 AI 配置对应提供商的可用模型列表
+export const listModels = async (config: models.AIConfig): Promise<string[]> => {
+  return (await ListModels(config)) || [];
+};
+
+// 获取内置的小韭菜意图分析 Prompt 模板
+export const getDefaultModeratorPrompt = async (): Promise<string> => {
+  return await GetDefaultModeratorPrompt();
+};
+
+// 快讯来源信息
+export interface NewsSourceInfo {
+  id: string;
+  name: string;
+  enabled: boolean;
+}
+
+// 获取快讯来源列表
+export const getNewsSources = async (): Promise<NewsSourceInfo[]> => {
+  return await GetNewsSources();
+};
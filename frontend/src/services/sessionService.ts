@@ -1,4 +1,4 @@
-import { GetOrCreateSession, GetSessionMessages, ClearSessionMessages, SendMeetingMessage, UpdateStockPosition } from '../../wailsjs/go/main/App';
+import { GetOrCreateSession, GetSessionMessages, ClearSessionMessages, SendMeetingMessage, UpdateStockPosition, GetMeetingEvents, ResummarizeMeeting } from '../../wailsjs/go/main/App';
 import type { StockPosition } from '../types';
 
 export interface StockSession {
@@ -22,6 +22,14 @@ export interface ChatMessage {
   mentions?: string[];
   round?: number;
   msgType?: string;
+  toolTrace?: ToolTrace[];
+}
+
+// 专家发言中的一次工具调用
+export interface ToolTrace {
+  name: string;
+  args: string;
+  result: string;
 }
 
 // 会议室消息请求
@@ -31,6 +39,30 @@ export interface MeetingMessageRequest {
   mentionIds: string[];
   replyToId: string;
   replyContent: string;
+  allowedTools: string[]; // 本次会议工具白名单，为空不限制
+  deniedTools: string[];  // 本次会议工具黑名单，优先于白名单
+  seed?: number;          // 随机种子，非 0 时覆盖 AI 配置中的种子
+}
+
+// 会议事件（用于刷新后回放）
+export interface MeetingEvent {
+  seq: number;
+  type: 'message' | 'progress';
+  message?: ChatMessage;
+  progress?: {
+    type: string;
+    agentId: string;
+    agentName: string;
+    detail?: string;
+    content?: string;
+  };
+  timestamp: number;
+}
+
+export interface MeetingEvents {
+  meetingId: string;
+  active: boolean;
+  events: MeetingEvent[];
 }
 
 // 获取或创建Session
@@ -53,6 +85,16 @@ export const sendMeetingMessage = async (req: MeetingMessageRequest): Promise<Ch
   return await SendMeetingMessage(req);
 };
 
+// 仅用选中的专家发言重新生成总结（不重新运行专家），新总结会追加到会话并通过事件推送
+export const resummarizeMeeting = async (stockCode: string, includeMessageIds: string[]): Promise<ChatMessage> => {
+  return await ResummarizeMeeting(stockCode, includeMessageIds) as ChatMessage;
+};
+
+// 获取会议已推送的事件（前端刷新后恢复进行中的讨论）
+export const getMeetingEvents = async (stockCode: string): Promise<MeetingEvents> => {
+  return await GetMeetingEvents(stockCode) as MeetingEvents;
+};
+
 // 更新股票持仓信息
 export const updateStockPosition = async (stockCode: string, shares: number, costPrice: number): Promise<string> => {
   return await UpdateStockPosition(stockCode, shares, costPrice);
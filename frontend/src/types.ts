@@ -12,6 +12,7 @@ export interface Stock {
   high: number;
   low: number;
   preClose: number;
+  suspended?: boolean; // 是否停牌
 }
 
 // 股票持仓信息
@@ -76,7 +77,7 @@ export interface ChatMessage {
 }
 
 // 消息类型
-export type MsgType = 'opening' | 'opinion' | 'summary';
+export type MsgType = 'opening' | 'opinion' | 'summary' | 'error';
 
 export type TimePeriod = '1m' | '1d' | '1w' | '1mo';
 
@@ -85,6 +86,7 @@ export interface Telegraph {
   time: string;
   content: string;
   url: string;
+  source?: string;  // 来源名称
 }
 
 // MCP 传输类型
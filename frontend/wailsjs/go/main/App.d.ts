@@ -2,9 +2,12 @@
 // This file is automatically generated. DO NOT EDIT
 import {models} from '../models';
 import {services} from '../models';
+import {meeting} from '../models';
 import {hottrend} from '../models';
 import {tools} from '../models';
+import {memory} from '../models';
 import {mcp} from '../models';
+import {indicators} from '../models';
 import {main} from '../models';
 
 export function AddAgentConfig(arg1:models.AgentConfig):Promise<string>;
@@ -17,6 +20,8 @@ export function CancelMeeting(arg1:string):Promise<boolean>;
 
 export function CheckForUpdate():Promise<services.UpdateInfo>;
 
+export function ClearOrderBookSubscription():Promise<string>;
+
 export function ClearSessionMessages(arg1:string):Promise<string>;
 
 export function DeleteAgentConfig(arg1:string):Promise<string>;
@@ -25,6 +30,8 @@ export function DeleteMCPServer(arg1:string):Promise<string>;
 
 export function DoUpdate():Promise<string>;
 
+export function GetAIBreakerStatus():Promise<Array<meeting.BreakerStatus>>;
+
 export function GetAgentConfigs():Promise<Array<models.AgentConfig>>;
 
 export function GetAllHotTrends():Promise<Array<hottrend.HotTrendResult>>;
@@ -33,8 +40,12 @@ export function GetAvailableTools():Promise<Array<tools.ToolInfo>>;
 
 export function GetConfig():Promise<models.AppConfig>;
 
+export function GetConsensusHistory(arg1:string):Promise<Array<memory.ConsensusPoint>>;
+
 export function GetCurrentVersion():Promise<string>;
 
+export function GetDefaultModeratorPrompt():Promise<string>;
+
 export function GetHotTrend(arg1:string):Promise<hottrend.HotTrendResult>;
 
 export function GetHotTrendPlatforms():Promise<Array<hottrend.PlatformInfo>>;
@@ -51,6 +62,14 @@ export function GetMCPServers():Promise<Array<models.MCPServerConfig>>;
 
 export function GetMCPStatus():Promise<Array<mcp.ServerStatus>>;
 
+export function GetMeetingAnalysis(arg1:string):Promise<models.MeetingAnalysis>;
+
+export function GetMeetingDecisionLog():Promise<Array<meeting.DecisionRecord>>;
+
+export function GetMeetingEvents(arg1:string):Promise<meeting.MeetingEvents>;
+
+export function GetNewsSources():Promise<Array<services.NewsSourceInfo>>;
+
 export function GetOrCreateSession(arg1:string,arg2:string):Promise<models.StockSession>;
 
 export function GetOrderBook(arg1:string):Promise<models.OrderBook>;
@@ -59,22 +78,48 @@ export function GetSessionMessages(arg1:string):Promise<Array<models.ChatMessage
 
 export function GetStockRealTimeData(arg1:Array<string>):Promise<Array<models.Stock>>;
 
+export function GetTechnicalAnalysis(arg1:string):Promise<indicators.FullAnalysis>;
+
 export function GetTelegraphList():Promise<Array<services.Telegraph>>;
 
 export function GetWatchlist():Promise<Array<models.Stock>>;
 
 export function Greet(arg1:string):Promise<string>;
 
+export function HealthCheck():Promise<Record<string, services.HealthStatus>>;
+
+export function InvokeMCPTool(arg1:string,arg2:string,arg3:Record<string, any>):Promise<string>;
+
+export function ListMeetingAnalyses(arg1:string):Promise<Array<string>>;
+
+export function ListModels(arg1:models.AIConfig):Promise<Array<string>>;
+
+export function ListProfiles():Promise<Array<models.ProfileInfo>>;
+
 export function OpenURL(arg1:string):Promise<void>;
 
+export function RefreshMarketData():Promise<string>;
+
 export function RemoveFromWatchlist(arg1:string):Promise<string>;
 
 export function RestartApp():Promise<string>;
 
+export function ResummarizeMeeting(arg1:string,arg2:Array<string>):Promise<models.ChatMessage>;
+
+export function RunMeetingStructured(arg1:main.MeetingMessageRequest):Promise<meeting.MeetingResult>;
+
+export function SaveProfile(arg1:string):Promise<string>;
+
 export function SearchStocks(arg1:string):Promise<Array<services.StockSearchResult>>;
 
+export function SearchStocksByIndustry(arg1:string,arg2:number):Promise<Array<services.StockSearchResult>>;
+
 export function SendMeetingMessage(arg1:main.MeetingMessageRequest):Promise<Array<models.ChatMessage>>;
 
+export function SubscribeOrderBook(arg1:string):Promise<string>;
+
+export function SwitchProfile(arg1:string):Promise<string>;
+
 export function TestMCPConnection(arg1:string):Promise<mcp.ServerStatus>;
 
 export function UpdateAgentConfig(arg1:models.AgentConfig):Promise<string>;
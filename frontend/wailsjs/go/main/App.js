@@ -22,6 +22,10 @@ export function CheckForUpdate() {
   return window['go']['main']['App']['CheckForUpdate']();
 }
 
+export function ClearOrderBookSubscription() {
+  return window['go']['main']['App']['ClearOrderBookSubscription']();
+}
+
 export function ClearSessionMessages(arg1) {
   return window['go']['main']['App']['ClearSessionMessages'](arg1);
 }
@@ -38,6 +42,10 @@ export function DoUpdate() {
   return window['go']['main']['App']['DoUpdate']();
 }
 
+export function GetAIBreakerStatus() {
+  return window['go']['main']['App']['GetAIBreakerStatus']();
+}
+
 export function GetAgentConfigs() {
   return window['go']['main']['App']['GetAgentConfigs']();
 }
@@ -54,10 +62,18 @@ export function GetConfig() {
   return window['go']['main']['App']['GetConfig']();
 }
 
+export function GetConsensusHistory(arg1) {
+  return window['go']['main']['App']['GetConsensusHistory'](arg1);
+}
+
 export function GetCurrentVersion() {
   return window['go']['main']['App']['GetCurrentVersion']();
 }
 
+export function GetDefaultModeratorPrompt() {
+  return window['go']['main']['App']['GetDefaultModeratorPrompt']();
+}
+
 export function GetHotTrend(arg1) {
   return window['go']['main']['App']['GetHotTrend'](arg1);
 }
@@ -90,6 +106,22 @@ export function GetMCPStatus() {
   return window['go']['main']['App']['GetMCPStatus']();
 }
 
+export function GetMeetingAnalysis(arg1) {
+  return window['go']['main']['App']['GetMeetingAnalysis'](arg1);
+}
+
+export function GetMeetingDecisionLog() {
+  return window['go']['main']['App']['GetMeetingDecisionLog']();
+}
+
+export function GetMeetingEvents(arg1) {
+  return window['go']['main']['App']['GetMeetingEvents'](arg1);
+}
+
+export function GetNewsSources() {
+  return window['go']['main']['App']['GetNewsSources']();
+}
+
 export function GetOrCreateSession(arg1, arg2) {
   return window['go']['main']['App']['GetOrCreateSession'](arg1, arg2);
 }
@@ -106,6 +138,10 @@ export function GetStockRealTimeData(arg1) {
   return window['go']['main']['App']['GetStockRealTimeData'](arg1);
 }
 
+export function GetTechnicalAnalysis(arg1) {
+  return window['go']['main']['App']['GetTechnicalAnalysis'](arg1);
+}
+
 export function GetTelegraphList() {
   return window['go']['main']['App']['GetTelegraphList']();
 }
@@ -118,10 +154,34 @@ export function Greet(arg1) {
   return window['go']['main']['App']['Greet'](arg1);
 }
 
+export function HealthCheck() {
+  return window['go']['main']['App']['HealthCheck']();
+}
+
+export function InvokeMCPTool(arg1, arg2, arg3) {
+  return window['go']['main']['App']['InvokeMCPTool'](arg1, arg2, arg3);
+}
+
+export function ListMeetingAnalyses(arg1) {
+  return window['go']['main']['App']['ListMeetingAnalyses'](arg1);
+}
+
+export function ListModels(arg1) {
+  return window['go']['main']['App']['ListModels'](arg1);
+}
+
+export function ListProfiles() {
+  return window['go']['main']['App']['ListProfiles']();
+}
+
 export function OpenURL(arg1) {
   return window['go']['main']['App']['OpenURL'](arg1);
 }
 
+export function RefreshMarketData() {
+  return window['go']['main']['App']['RefreshMarketData']();
+}
+
 export function RemoveFromWatchlist(arg1) {
   return window['go']['main']['App']['RemoveFromWatchlist'](arg1);
 }
@@ -130,14 +190,38 @@ export function RestartApp() {
   return window['go']['main']['App']['RestartApp']();
 }
 
+export function ResummarizeMeeting(arg1, arg2) {
+  return window['go']['main']['App']['ResummarizeMeeting'](arg1, arg2);
+}
+
+export function RunMeetingStructured(arg1) {
+  return window['go']['main']['App']['RunMeetingStructured'](arg1);
+}
+
+export function SaveProfile(arg1) {
+  return window['go']['main']['App']['SaveProfile'](arg1);
+}
+
 export function SearchStocks(arg1) {
   return window['go']['main']['App']['SearchStocks'](arg1);
 }
 
+export function SearchStocksByIndustry(arg1, arg2) {
+  return window['go']['main']['App']['SearchStocksByIndustry'](arg1, arg2);
+}
+
 export function SendMeetingMessage(arg1) {
   return window['go']['main']['App']['SendMeetingMessage'](arg1);
 }
 
+export function SubscribeOrderBook(arg1) {
+  return window['go']['main']['App']['SubscribeOrderBook'](arg1);
+}
+
+export function SwitchProfile(arg1) {
+  return window['go']['main']['App']['SwitchProfile'](arg1);
+}
+
 export function TestMCPConnection(arg1) {
   return window['go']['main']['App']['TestMCPConnection'](arg1);
 }
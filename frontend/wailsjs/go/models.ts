@@ -82,6 +82,229 @@ export namespace hottrend {
 	    }
 	}
 
+}
+
+export namespace indicators {
+	
+	export class DayRow {
+	    date: string;
+	    open: number;
+	    high: number;
+	    low: number;
+	    close: number;
+	    change_pct: number;
+	    volume: number;
+	    amount: number;
+	    ma5: number;
+	    ma10: number;
+	    ma20: number;
+	    dif: number;
+	    dea: number;
+	    macd_hist: number;
+	    macd_signal: string;
+	    k: number;
+	    d: number;
+	    j: number;
+	    kdj_signal: string;
+	    boll_upper: number;
+	    boll_mid: number;
+	    boll_lower: number;
+	    boll_width: number;
+	    adx: number;
+	    vol_ma5: number;
+	    turnover_rate: number;
+	    turnover_level: string;
+	    obv: number;
+	    atr: number;
+	    atr_pct: number;
+	    bias: number;
+	    br: number;
+	    ar: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DayRow(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.open = source["open"];
+	        this.high = source["high"];
+	        this.low = source["low"];
+	        this.close = source["close"];
+	        this.change_pct = source["change_pct"];
+	        this.volume = source["volume"];
+	        this.amount = source["amount"];
+	        this.ma5 = source["ma5"];
+	        this.ma10 = source["ma10"];
+	        this.ma20 = source["ma20"];
+	        this.dif = source["dif"];
+	        this.dea = source["dea"];
+	        this.macd_hist = source["macd_hist"];
+	        this.macd_signal = source["macd_signal"];
+	        this.k = source["k"];
+	        this.d = source["d"];
+	        this.j = source["j"];
+	        this.kdj_signal = source["kdj_signal"];
+	        this.boll_upper = source["boll_upper"];
+	        this.boll_mid = source["boll_mid"];
+	        this.boll_lower = source["boll_lower"];
+	        this.boll_width = source["boll_width"];
+	        this.adx = source["adx"];
+	        this.vol_ma5 = source["vol_ma5"];
+	        this.turnover_rate = source["turnover_rate"];
+	        this.turnover_level = source["turnover_level"];
+	        this.obv = source["obv"];
+	        this.atr = source["atr"];
+	        this.atr_pct = source["atr_pct"];
+	        this.bias = source["bias"];
+	        this.br = source["br"];
+	        this.ar = source["ar"];
+	    }
+	}
+	export class StatusSummary {
+	    ma_trend: string;
+	    macd_cross?: string;
+	    macd_status?: string;
+	    kdj_status?: string;
+	    boll_squeeze?: boolean;
+	    trend_mode?: string;
+	    obv_slope?: string;
+	    vol_ratio: number;
+	    band_width: number;
+	    atr_pct_level?: string;
+	    bias_signal?: string;
+	    bias12: number;
+	    bias24: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new StatusSummary(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ma_trend = source["ma_trend"];
+	        this.macd_cross = source["macd_cross"];
+	        this.macd_status = source["macd_status"];
+	        this.kdj_status = source["kdj_status"];
+	        this.boll_squeeze = source["boll_squeeze"];
+	        this.trend_mode = source["trend_mode"];
+	        this.obv_slope = source["obv_slope"];
+	        this.vol_ratio = source["vol_ratio"];
+	        this.band_width = source["band_width"];
+	        this.atr_pct_level = source["atr_pct_level"];
+	        this.bias_signal = source["bias_signal"];
+	        this.bias12 = source["bias12"];
+	        this.bias24 = source["bias24"];
+	    }
+	}
+	export class MarketBreadthData {
+	    advance: number;
+	    decline: number;
+	    flat: number;
+	    limit_up: number;
+	    limit_down: number;
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MarketBreadthData(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.advance = source["advance"];
+	        this.decline = source["decline"];
+	        this.flat = source["flat"];
+	        this.limit_up = source["limit_up"];
+	        this.limit_down = source["limit_down"];
+	        this.total = source["total"];
+	    }
+	}
+	export class TechnicalSnapshot {
+	    ma60: number;
+	    ma120: number;
+	    high60: number;
+	    low60: number;
+	    pos60: number;
+	    float_cap?: string;
+	    float_shares?: string;
+	    sector?: string;
+	    concepts?: string[];
+	    market?: MarketBreadthData;
+	
+	    static createFrom(source: any = {}) {
+	        return new TechnicalSnapshot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ma60 = source["ma60"];
+	        this.ma120 = source["ma120"];
+	        this.high60 = source["high60"];
+	        this.low60 = source["low60"];
+	        this.pos60 = source["pos60"];
+	        this.float_cap = source["float_cap"];
+	        this.float_shares = source["float_shares"];
+	        this.sector = source["sector"];
+	        this.concepts = source["concepts"];
+	        this.market = this.convertValues(source["market"], MarketBreadthData);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FullAnalysis {
+	    snapshot: TechnicalSnapshot;
+	    status: StatusSummary;
+	    series: DayRow[];
+	
+	    static createFrom(source: any = {}) {
+	        return new FullAnalysis(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.snapshot = this.convertValues(source["snapshot"], TechnicalSnapshot);
+	        this.status = this.convertValues(source["status"], StatusSummary);
+	        this.series = this.convertValues(source["series"], DayRow);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+
 }
 
 export namespace main {
@@ -92,6 +315,9 @@ export namespace main {
 	    mentionIds: string[];
 	    replyToId: string;
 	    replyContent: string;
+	    allowedTools: string[];
+	    deniedTools: string[];
+	    seed?: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new MeetingMessageRequest(source);
@@ -104,6 +330,9 @@ export namespace main {
 	        this.mentionIds = source["mentionIds"];
 	        this.replyToId = source["replyToId"];
 	        this.replyContent = source["replyContent"];
+	        this.allowedTools = source["allowedTools"];
+	        this.deniedTools = source["deniedTools"];
+	        this.seed = source["seed"];
 	    }
 	}
 
@@ -132,6 +361,7 @@ export namespace mcp {
 	    description: string;
 	    serverId: string;
 	    serverName: string;
+	    inputSchema?: Record<string, any>;
 	
 	    static createFrom(source: any = {}) {
 	        return new ToolInfo(source);
@@ -143,6 +373,315 @@ export namespace mcp {
 	        this.description = source["description"];
 	        this.serverId = source["serverId"];
 	        this.serverName = source["serverName"];
+	        this.inputSchema = source["inputSchema"];
+	    }
+	}
+
+}
+
+export namespace meeting {
+	
+	export class BreakerStatus {
+	    configId: string;
+	    state: string;
+	    failures: number;
+	    openUntil: number;
+	    lastError: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BreakerStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.configId = source["configId"];
+	        this.state = source["state"];
+	        this.failures = source["failures"];
+	        this.openUntil = source["openUntil"];
+	        this.lastError = source["lastError"];
+	    }
+	}
+	export class DecisionRecord {
+	    time: number;
+	    stockCode: string;
+	    query: string;
+	    intent: string;
+	    topic: string;
+	    selected: string[];
+	    speakers: string[];
+	    summarized: boolean;
+	    outcomeHash: string;
+	    durationMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DecisionRecord(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.stockCode = source["stockCode"];
+	        this.query = source["query"];
+	        this.intent = source["intent"];
+	        this.topic = source["topic"];
+	        this.selected = source["selected"];
+	        this.speakers = source["speakers"];
+	        this.summarized = source["summarized"];
+	        this.outcomeHash = source["outcomeHash"];
+	        this.durationMs = source["durationMs"];
+	    }
+	}
+	export class ExpertAnalysis {
+	    agentId: string;
+	    agentName: string;
+	    role: string;
+	    content: string;
+	    stance: string;
+	    toolTrace?: models.ToolTrace[];
+	    usage?: models.TokenUsage;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExpertAnalysis(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.agentId = source["agentId"];
+	        this.agentName = source["agentName"];
+	        this.role = source["role"];
+	        this.content = source["content"];
+	        this.stance = source["stance"];
+	        this.toolTrace = this.convertValues(source["toolTrace"], models.ToolTrace);
+	        this.usage = this.convertValues(source["usage"], models.TokenUsage);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ProgressEvent {
+	    type: string;
+	    agentId: string;
+	    agentName: string;
+	    detail: string;
+	    content: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProgressEvent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.agentId = source["agentId"];
+	        this.agentName = source["agentName"];
+	        this.detail = source["detail"];
+	        this.content = source["content"];
+	    }
+	}
+	export class MeetingEvent {
+	    seq: number;
+	    type: string;
+	    message?: models.ChatMessage;
+	    progress?: ProgressEvent;
+	    timestamp: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new MeetingEvent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.seq = source["seq"];
+	        this.type = source["type"];
+	        this.message = this.convertValues(source["message"], models.ChatMessage);
+	        this.progress = this.convertValues(source["progress"], ProgressEvent);
+	        this.timestamp = source["timestamp"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class MeetingEvents {
+	    meetingId: string;
+	    active: boolean;
+	    events: MeetingEvent[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MeetingEvents(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.meetingId = source["meetingId"];
+	        this.active = source["active"];
+	        this.events = this.convertValues(source["events"], MeetingEvent);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class VoteTally {
+	    bullish: number;
+	    neutral: number;
+	    bearish: number;
+	    score: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new VoteTally(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.bullish = source["bullish"];
+	        this.neutral = source["neutral"];
+	        this.bearish = source["bearish"];
+	        this.score = source["score"];
+	    }
+	}
+	export class ModeratorDecision {
+	    intent: string;
+	    selected: string[];
+	    weights?: Record<string, number>;
+	    topic: string;
+	    opening: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ModeratorDecision(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.intent = source["intent"];
+	        this.selected = source["selected"];
+	        this.weights = source["weights"];
+	        this.topic = source["topic"];
+	        this.opening = source["opening"];
+	    }
+	}
+	export class MeetingResult {
+	    stockCode: string;
+	    stockName: string;
+	    query: string;
+	    decision?: ModeratorDecision;
+	    opening: string;
+	    experts: ExpertAnalysis[];
+	    summary: string;
+	    votes: VoteTally;
+	    usage: models.TokenUsage;
+	    completed: boolean;
+	    truncated: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MeetingResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.stockCode = source["stockCode"];
+	        this.stockName = source["stockName"];
+	        this.query = source["query"];
+	        this.decision = this.convertValues(source["decision"], ModeratorDecision);
+	        this.opening = source["opening"];
+	        this.experts = this.convertValues(source["experts"], ExpertAnalysis);
+	        this.summary = source["summary"];
+	        this.votes = this.convertValues(source["votes"], VoteTally);
+	        this.usage = this.convertValues(source["usage"], models.TokenUsage);
+	        this.completed = source["completed"];
+	        this.truncated = source["truncated"];
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+
+}
+
+export namespace memory {
+	
+	export class ConsensusPoint {
+	    round: number;
+	    bullish: number;
+	    neutral: number;
+	    bearish: number;
+	    score: number;
+	    timestamp: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ConsensusPoint(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.round = source["round"];
+	        this.bullish = source["bullish"];
+	        this.neutral = source["neutral"];
+	        this.bearish = source["bearish"];
+	        this.score = source["score"];
+	        this.timestamp = source["timestamp"];
 	    }
 	}
 
@@ -162,6 +701,7 @@ export namespace models {
 	    timeout: number;
 	    isDefault: boolean;
 	    useResponses: boolean;
+	    seed: number;
 	    project: string;
 	    location: string;
 	    credentialsJson: string;
@@ -183,6 +723,7 @@ export namespace models {
 	        this.timeout = source["timeout"];
 	        this.isDefault = source["isDefault"];
 	        this.useResponses = source["useResponses"];
+	        this.seed = source["seed"];
 	        this.project = source["project"];
 	        this.location = source["location"];
 	        this.credentialsJson = source["credentialsJson"];
@@ -222,6 +763,78 @@ export namespace models {
 	        this.providerId = source["providerId"];
 	    }
 	}
+	export class CircuitBreakerConfig {
+	    threshold: number;
+	    windowSec: number;
+	    cooldownSec: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CircuitBreakerConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.threshold = source["threshold"];
+	        this.windowSec = source["windowSec"];
+	        this.cooldownSec = source["cooldownSec"];
+	    }
+	}
+	export class SourceChainConfig {
+	    quote: string[];
+	    kline: string[];
+	    extendedInfo: string[];
+	    breadth: string[];
+	    retries: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SourceChainConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.quote = source["quote"];
+	        this.kline = source["kline"];
+	        this.extendedInfo = source["extendedInfo"];
+	        this.breadth = source["breadth"];
+	        this.retries = source["retries"];
+	    }
+	}
+	export class TimeoutConfig {
+	    quote: number;
+	    kline: number;
+	    longHuBang: number;
+	    research: number;
+	    hotTrend: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimeoutConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.quote = source["quote"];
+	        this.kline = source["kline"];
+	        this.longHuBang = source["longHuBang"];
+	        this.research = source["research"];
+	        this.hotTrend = source["hotTrend"];
+	    }
+	}
+	export class SummaryParams {
+	    temperature?: number;
+	    maxTokens: number;
+	    weighted: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SummaryParams(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.temperature = source["temperature"];
+	        this.maxTokens = source["maxTokens"];
+	        this.weighted = source["weighted"];
+	    }
+	}
 	export class ProxyConfig {
 	    mode: string;
 	    customUrl: string;
@@ -243,6 +856,8 @@ export namespace models {
 	    maxKeyFacts: number;
 	    maxSummaryLength: number;
 	    compressThreshold: number;
+	    maxContextLength: number;
+	    checkpoint: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new MemoryConfig(source);
@@ -256,6 +871,8 @@ export namespace models {
 	        this.maxKeyFacts = source["maxKeyFacts"];
 	        this.maxSummaryLength = source["maxSummaryLength"];
 	        this.compressThreshold = source["compressThreshold"];
+	        this.maxContextLength = source["maxContextLength"];
+	        this.checkpoint = source["checkpoint"];
 	    }
 	}
 	export class MCPServerConfig {
@@ -291,6 +908,32 @@ export namespace models {
 	    mcpServers: MCPServerConfig[];
 	    memory: MemoryConfig;
 	    proxy: ProxyConfig;
+	    orderBookPush: string;
+	    marketHoursOnly: boolean;
+	    newsSources: string[];
+	    moderatorPrompt: string;
+	    agentDelayMs: number;
+	    includeToolTrace: boolean;
+	    summaryParams: SummaryParams;
+	    globalTools: string[];
+	    maxContextMessages: number;
+	    fallbackAgentCount: number;
+	    preflightGather: boolean;
+	    storageBackend: string;
+	    timeouts: TimeoutConfig;
+	    sourceChains: SourceChainConfig;
+	    telegraphDedupMinutes: number;
+	    emitMeetingComplete: boolean;
+	    persistMeetingAnalysis: boolean;
+	    coalesceMarketPush: boolean;
+	    toolCacheTTL: Record<string, number>;
+	    analysisDepth: string;
+	    userName: string;
+	    userPersona: string;
+	    circuitBreaker: CircuitBreakerConfig;
+	    meetingDecisionLog: boolean;
+	    offlineMode: boolean;
+	    configVersion: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new AppConfig(source);
@@ -304,6 +947,32 @@ export namespace models {
 	        this.mcpServers = this.convertValues(source["mcpServers"], MCPServerConfig);
 	        this.memory = this.convertValues(source["memory"], MemoryConfig);
 	        this.proxy = this.convertValues(source["proxy"], ProxyConfig);
+	        this.orderBookPush = source["orderBookPush"];
+	        this.marketHoursOnly = source["marketHoursOnly"];
+	        this.newsSources = source["newsSources"];
+	        this.moderatorPrompt = source["moderatorPrompt"];
+	        this.agentDelayMs = source["agentDelayMs"];
+	        this.includeToolTrace = source["includeToolTrace"];
+	        this.summaryParams = this.convertValues(source["summaryParams"], SummaryParams);
+	        this.globalTools = source["globalTools"];
+	        this.maxContextMessages = source["maxContextMessages"];
+	        this.fallbackAgentCount = source["fallbackAgentCount"];
+	        this.preflightGather = source["preflightGather"];
+	        this.storageBackend = source["storageBackend"];
+	        this.timeouts = this.convertValues(source["timeouts"], TimeoutConfig);
+	        this.sourceChains = this.convertValues(source["sourceChains"], SourceChainConfig);
+	        this.telegraphDedupMinutes = source["telegraphDedupMinutes"];
+	        this.emitMeetingComplete = source["emitMeetingComplete"];
+	        this.persistMeetingAnalysis = source["persistMeetingAnalysis"];
+	        this.coalesceMarketPush = source["coalesceMarketPush"];
+	        this.toolCacheTTL = source["toolCacheTTL"];
+	        this.analysisDepth = source["analysisDepth"];
+	        this.userName = source["userName"];
+	        this.userPersona = source["userPersona"];
+	        this.circuitBreaker = this.convertValues(source["circuitBreaker"], CircuitBreakerConfig);
+	        this.meetingDecisionLog = source["meetingDecisionLog"];
+	        this.offlineMode = source["offlineMode"];
+	        this.configVersion = source["configVersion"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -324,6 +993,22 @@ export namespace models {
 		    return a;
 		}
 	}
+	export class ToolTrace {
+	    name: string;
+	    args: string;
+	    result: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ToolTrace(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.args = source["args"];
+	        this.result = source["result"];
+	    }
+	}
 	export class ChatMessage {
 	    id: string;
 	    agentId: string;
@@ -335,6 +1020,7 @@ export namespace models {
 	    mentions?: string[];
 	    round?: number;
 	    msgType?: string;
+	    toolTrace?: ToolTrace[];
 	
 	    static createFrom(source: any = {}) {
 	        return new ChatMessage(source);
@@ -352,8 +1038,28 @@ export namespace models {
 	        this.mentions = source["mentions"];
 	        this.round = source["round"];
 	        this.msgType = source["msgType"];
+	        this.toolTrace = this.convertValues(source["toolTrace"], ToolTrace);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
+	
 	export class KLineData {
 	    time: string;
 	    open: number;
@@ -467,6 +1173,59 @@ export namespace models {
 	    }
 	}
 	
+	export class MeetingAnalysisEntry {
+	    agents: string[];
+	    content: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MeetingAnalysisEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.agents = source["agents"];
+	        this.content = source["content"];
+	    }
+	}
+	export class MeetingAnalysis {
+	    meetingId: string;
+	    stockCode: string;
+	    query: string;
+	    createdAt: number;
+	    entries: MeetingAnalysisEntry[];
+	
+	    static createFrom(source: any = {}) {
+	        return new MeetingAnalysis(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.meetingId = source["meetingId"];
+	        this.stockCode = source["stockCode"];
+	        this.query = source["query"];
+	        this.createdAt = source["createdAt"];
+	        this.entries = this.convertValues(source["entries"], MeetingAnalysisEntry);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
 	
 	export class OrderBookItem {
 	    price: number;
@@ -519,6 +1278,23 @@ export namespace models {
 		}
 	}
 	
+	export class ProfileInfo {
+	    name: string;
+	    agentCount: number;
+	    updatedAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ProfileInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.agentCount = source["agentCount"];
+	        this.updatedAt = source["updatedAt"];
+	    }
+	}
+	
 	
 	export class Stock {
 	    symbol: string;
@@ -534,6 +1310,7 @@ export namespace models {
 	    high: number;
 	    low: number;
 	    preClose: number;
+	    suspended: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new Stock(source);
@@ -554,6 +1331,7 @@ export namespace models {
 	        this.high = source["high"];
 	        this.low = source["low"];
 	        this.preClose = source["preClose"];
+	        this.suspended = source["suspended"];
 	    }
 	}
 	export class StockPosition {
@@ -612,6 +1390,24 @@ export namespace models {
 		    return a;
 		}
 	}
+	
+	
+	export class TokenUsage {
+	    promptTokens: number;
+	    completionTokens: number;
+	    totalTokens: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new TokenUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.promptTokens = source["promptTokens"];
+	        this.completionTokens = source["completionTokens"];
+	        this.totalTokens = source["totalTokens"];
+	    }
+	}
 
 }
 
@@ -649,6 +1445,22 @@ export namespace services {
 		    return a;
 		}
 	}
+	export class NewsSourceInfo {
+	    id: string;
+	    name: string;
+	    enabled: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new NewsSourceInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.enabled = source["enabled"];
+	    }
+	}
 	export class StockSearchResult {
 	    symbol: string;
 	    name: string;
@@ -671,6 +1483,7 @@ export namespace services {
 	    time: string;
 	    content: string;
 	    url: string;
+	    source: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new Telegraph(source);
@@ -681,6 +1494,7 @@ export namespace services {
 	        this.time = source["time"];
 	        this.content = source["content"];
 	        this.url = source["url"];
+	        this.source = source["source"];
 	    }
 	}
 	export class UpdateInfo {
@@ -713,6 +1527,7 @@ export namespace tools {
 	export class ToolInfo {
 	    name: string;
 	    description: string;
+	    categories?: string[];
 	
 	    static createFrom(source: any = {}) {
 	        return new ToolInfo(source);
@@ -722,6 +1537,7 @@ export namespace tools {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
 	        this.description = source["description"];
+	        this.categories = source["categories"];
 	    }
 	}
 
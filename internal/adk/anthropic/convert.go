@@ -46,6 +46,7 @@ func toMessagesRequest(req *model.LLMRequest, modelName string, maxTokens int) (
 			p := float64(*req.Config.TopP)
 			apiReq.TopP = &p
 		}
+		// Anthropic 不支持 seed，忽略 req.Config.Seed
 		if len(req.Config.StopSequences) > 0 {
 			apiReq.StopSequences = req.Config.StopSequences
 		}
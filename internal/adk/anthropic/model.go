@@ -49,7 +49,7 @@ func NewAnthropicModel(modelName, apiKey, baseURL string, maxTokens int, httpCli
 		maxTokens = DefaultMaxTokens
 	}
 	return &AnthropicModel{
-		httpClient: httpClient,
+		httpClient: withRateLimit(httpClient),
 		baseURL:    strings.TrimRight(baseURL, "/"),
 		apiKey:     apiKey,
 		modelName:  modelName,
@@ -165,7 +165,11 @@ func (m *AnthropicModel) generateStream(ctx context.Context, req *model.LLMReque
 			return
 		}
 
-		m.processStream(resp.Body, yield)
+		// 上下文取消时立即关闭响应体，中断阻塞中的读取并释放连接
+		stop := context.AfterFunc(ctx, func() { resp.Body.Close() })
+		defer stop()
+
+		m.processStream(ctx, resp.Body, yield)
 	}
 }
 
@@ -177,7 +181,8 @@ type toolCallBuilder struct {
 }
 
 // processStream 处理 Anthropic Messages API 的 SSE 流
-func (m *AnthropicModel) processStream(body io.Reader, yield func(*model.LLMResponse, error) bool) {
+// ctx 取消或调用方停止接收后立即返回，不再产出聚合响应
+func (m *AnthropicModel) processStream(ctx context.Context, body io.Reader, yield func(*model.LLMResponse, error) bool) {
 	scanner := bufio.NewScanner(body)
 	// 增大 scanner 缓冲区以处理大型 SSE 事件
 	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
@@ -193,6 +198,9 @@ func (m *AnthropicModel) processStream(body io.Reader, yield func(*model.LLMResp
 	var currentEventType string
 
 	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return
+		}
 		line := scanner.Text()
 
 		// SSE 格式解析
@@ -211,7 +219,9 @@ func (m *AnthropicModel) processStream(body io.Reader, yield func(*model.LLMResp
 		case "content_block_start":
 			m.handleContentBlockStart(data, blockTypes, toolCallsMap)
 		case "content_block_delta":
-			m.handleContentBlockDelta(data, blockTypes, &textContent, &thinkingContent, toolCallsMap, yield)
+			if !m.handleContentBlockDelta(data, blockTypes, &textContent, &thinkingContent, toolCallsMap, yield) {
+				return
+			}
 		case "message_delta":
 			m.handleMessageDelta(data, &finishReason, &usageMetadata)
 		case "error":
@@ -223,6 +233,11 @@ func (m *AnthropicModel) processStream(body io.Reader, yield func(*model.LLMResp
 		currentEventType = ""
 	}
 
+	// 取消导致的读取中断不产出不完整的聚合响应
+	if ctx.Err() != nil {
+		return
+	}
+
 	// 组装最终聚合响应
 	if thinkingContent != "" {
 		aggregatedContent.Parts = append(aggregatedContent.Parts, &genai.Part{
@@ -288,6 +303,7 @@ func (m *AnthropicModel) handleContentBlockStart(data string, blockTypes map[int
 }
 
 // handleContentBlockDelta 处理 content_block_delta 事件
+// 返回 false 表示调用方已停止接收
 func (m *AnthropicModel) handleContentBlockDelta(
 	data string,
 	blockTypes map[int]string,
@@ -295,17 +311,17 @@ func (m *AnthropicModel) handleContentBlockDelta(
 	thinkingContent *string,
 	toolCallsMap map[int]*toolCallBuilder,
 	yield func(*model.LLMResponse, error) bool,
-) {
+) bool {
 	var event ContentBlockDeltaEvent
 	if json.Unmarshal([]byte(data), &event) != nil {
-		return
+		return true
 	}
 
 	switch event.Delta.Type {
 	case "text_delta":
 		*textContent += event.Delta.Text
 		// 发送部分响应用于实时 UI 更新
-		yield(&model.LLMResponse{
+		return yield(&model.LLMResponse{
 			Content: &genai.Content{
 				Role:  "model",
 				Parts: []*genai.Part{{Text: event.Delta.Text}},
@@ -322,6 +338,7 @@ func (m *AnthropicModel) handleContentBlockDelta(
 			builder.args += event.Delta.PartialJSON
 		}
 	}
+	return true
 }
 
 // handleMessageDelta 处理 message_delta 事件
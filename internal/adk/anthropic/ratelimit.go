@@ -0,0 +1,33 @@
+package anthropic
+
+import (
+	"net/http"
+
+	"github.com/run-bigpig/jcp/internal/adk/ratelimit"
+	"github.com/run-bigpig/jcp/internal/logger"
+)
+
+// statusOverloaded Anthropic 服务过载状态码
+const statusOverloaded = 529
+
+// rateLimitPolicy Anthropic 限流约定：429/529 重试，anthropic-ratelimit-*-reset 为 RFC 3339 时间
+var rateLimitPolicy = &ratelimit.Policy{
+	Log:           logger.New("anthropic"),
+	RetryStatuses: []int{http.StatusTooManyRequests, statusOverloaded},
+	ResetHeaders: []string{
+		"anthropic-ratelimit-requests-reset",
+		"anthropic-ratelimit-tokens-reset",
+		"anthropic-ratelimit-input-tokens-reset",
+		"anthropic-ratelimit-output-tokens-reset",
+	},
+	ParseReset:        ratelimit.ParseResetTime,
+	RequestsRemaining: "anthropic-ratelimit-requests-remaining",
+	RequestsLimit:     "anthropic-ratelimit-requests-limit",
+	TokensRemaining:   "anthropic-ratelimit-tokens-remaining",
+	TokensLimit:       "anthropic-ratelimit-tokens-limit",
+}
+
+// withRateLimit 为 HTTP 客户端增加限流处理，已包装时原样返回
+func withRateLimit(doer HTTPDoer) HTTPDoer {
+	return ratelimit.Wrap(doer, rateLimitPolicy)
+}
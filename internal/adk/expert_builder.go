@@ -20,6 +20,10 @@ type ExpertAgentBuilder struct {
 	llm          model.LLM
 	toolRegistry *tools.Registry
 	mcpManager   *mcp.Manager
+	globalTools  []string    // 所有专家始终可用的工具
+	userName     string      // 对用户的称呼
+	userPersona  string      // 用户投资画像
+	toolFilter   *ToolFilter // 本次会议的工具过滤，nil 表示不过滤
 }
 
 // NewExpertAgentBuilder 创建专家 Agent 构建器
@@ -37,6 +41,40 @@ func NewExpertAgentBuilderFull(llm model.LLM, registry *tools.Registry, mcpMgr *
 	return &ExpertAgentBuilder{llm: llm, toolRegistry: registry, mcpManager: mcpMgr}
 }
 
+// SetGlobalTools 设置所有专家始终可用的工具，与专家自身配置的工具合并
+func (b *ExpertAgentBuilder) SetGlobalTools(names []string) {
+	b.globalTools = names
+}
+
+// SetUserProfile 设置对用户的称呼和投资画像，专家据此称呼用户并调整建议风格
+func (b *ExpertAgentBuilder) SetUserProfile(name, persona string) {
+	b.userName = name
+	b.userPersona = persona
+}
+
+// SetToolFilter 设置本次会议的工具过滤，在专家工具与全局工具合并后生效
+func (b *ExpertAgentBuilder) SetToolFilter(filter *ToolFilter) {
+	b.toolFilter = filter
+}
+
+// toolNames 合并专家配置的工具与全局工具（去重，保持专家配置顺序），再应用会议工具过滤
+func (b *ExpertAgentBuilder) toolNames(config *models.AgentConfig) []string {
+	if len(b.globalTools) == 0 {
+		return b.toolFilter.apply(config.Tools)
+	}
+	names := make([]string, 0, len(config.Tools)+len(b.globalTools))
+	seen := make(map[string]bool, cap(names))
+	for _, list := range [][]string{config.Tools, b.globalTools} {
+		for _, name := range list {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return b.toolFilter.apply(names)
+}
+
 // BuildAgent 根据配置构建 LLM Agent
 func (b *ExpertAgentBuilder) BuildAgent(config *models.AgentConfig, stock *models.Stock, query string, position *models.StockPosition) (agent.Agent, error) {
 	return b.BuildAgentWithContext(config, stock, query, "", position)
@@ -48,14 +86,19 @@ func (b *ExpertAgentBuilder) BuildAgentWithContext(config *models.AgentConfig, s
 
 	// 获取 Agent 配置的工具
 	var agentTools []tool.Tool
-	if b.toolRegistry != nil && len(config.Tools) > 0 {
-		agentTools = b.toolRegistry.GetTools(config.Tools)
+	if names := b.toolNames(config); b.toolRegistry != nil && len(names) > 0 {
+		agentTools = b.toolRegistry.GetTools(names)
 	}
 
 	// 获取 MCP toolsets
 	var toolsets []tool.Toolset
 	if b.mcpManager != nil && len(config.MCPServers) > 0 {
 		toolsets = b.mcpManager.GetToolsetsByIDs(config.MCPServers)
+		if b.toolFilter != nil {
+			for i, ts := range toolsets {
+				toolsets[i] = &filteredToolset{Toolset: ts, filter: b.toolFilter}
+			}
+		}
 	}
 
 	return llmagent.New(llmagent.Config{
@@ -131,6 +174,14 @@ func (b *ExpertAgentBuilder) buildInstructionWithContext(config *models.AgentCon
 `, position.Shares, position.CostPrice, marketValue, profitLoss, profitPercent)
 	}
 
+	// 用户称呼和投资画像
+	if b.userName != "" {
+		prompt += fmt.Sprintf("\n用户称呼: %s（回复时以此称呼用户）\n", b.userName)
+	}
+	if b.userPersona != "" {
+		prompt += fmt.Sprintf("用户画像: %s（请据此调整语气、风险提示和仓位建议）\n", b.userPersona)
+	}
+
 	// 如果有引用内容，加入上下文
 	if replyContent != "" {
 		prompt += fmt.Sprintf(`--- 引用的观点 ---
@@ -159,8 +210,8 @@ func (b *ExpertAgentBuilder) buildToolsDescription(config *models.AgentConfig) s
 	searchKeywords := []string{"search", "搜索", "web", "网页", "tavily", "google", "bing"}
 
 	// 获取内置工具信息并分类
-	if b.toolRegistry != nil && len(config.Tools) > 0 {
-		toolInfos := b.toolRegistry.GetToolInfosByNames(config.Tools)
+	if names := b.toolNames(config); b.toolRegistry != nil && len(names) > 0 {
+		toolInfos := b.toolRegistry.GetToolInfosByNames(names)
 		for _, info := range toolInfos {
 			desc := fmt.Sprintf("- %s: %s", info.Name, info.Description)
 			if b.isSearchTool(info.Name, info.Description, searchKeywords) {
@@ -177,6 +228,9 @@ func (b *ExpertAgentBuilder) buildToolsDescription(config *models.AgentConfig) s
 	if b.mcpManager != nil && len(config.MCPServers) > 0 {
 		mcpTools := b.mcpManager.GetToolInfosByServerIDs(config.MCPServers)
 		for _, info := range mcpTools {
+			if !b.toolFilter.Allows(info.Name) {
+				continue
+			}
 			desc := fmt.Sprintf("- %s: %s (来自 %s)", info.Name, info.Description, info.ServerName)
 			if b.isSearchTool(info.Name, info.Description, searchKeywords) {
 				searchTools = append(searchTools, desc)
@@ -189,10 +243,17 @@ func (b *ExpertAgentBuilder) buildToolsDescription(config *models.AgentConfig) s
 	}
 
 	if len(searchTools) == 0 && len(dataTools) == 0 && len(otherTools) == 0 {
+		if b.toolFilter != nil {
+			return "\n本次会议未开放任何工具，请基于已有信息分析，不要提及调用工具。\n"
+		}
 		return ""
 	}
 
-	return b.formatToolsInstruction(searchTools, dataTools, otherTools)
+	desc := b.formatToolsInstruction(searchTools, dataTools, otherTools)
+	if b.toolFilter != nil {
+		desc += "5. 本次会议限定了可用工具，角色设定中提到但未列出的工具不可调用\n"
+	}
+	return desc
 }
 
 // isSearchTool 判断是否为搜索类工具
@@ -258,6 +319,7 @@ func (b *ExpertAgentBuilder) formatToolsInstruction(searchTools, dataTools, othe
 	result.WriteString("1. 需要实时数据时，必须调用工具，不要编造数据\n")
 	result.WriteString("2. 搜索类工具优先用于获取最新信息\n")
 	result.WriteString("3. 工具返回结果后再组织回答\n")
+	result.WriteString("4. 工具结果以" + tools.NoDataPrefix + "开头表示数据源没有该项数据，不代表数值为零，不得据此推断或补全数字\n")
 
 	return result.String()
 }
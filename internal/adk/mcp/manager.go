@@ -3,7 +3,10 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"os/exec"
+	"strings"
 	"sync"
 	"time"
 
@@ -26,10 +29,11 @@ type ServerStatus struct {
 
 // ToolInfo MCP 工具信息
 type ToolInfo struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	ServerID    string `json:"serverId"`
-	ServerName  string `json:"serverName"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	ServerID    string         `json:"serverId"`
+	ServerName  string         `json:"serverName"`
+	InputSchema map[string]any `json:"inputSchema,omitempty"` // 输入参数 JSON Schema，供前端渲染参数表单
 }
 
 // Manager MCP 服务管理器
@@ -237,11 +241,90 @@ func (m *Manager) GetServerTools(serverID string) ([]ToolInfo, error) {
 			Description: t.Description,
 			ServerID:    serverID,
 			ServerName:  cfg.Name,
+			InputSchema: schemaToMap(t.InputSchema),
 		})
 	}
 	return tools, nil
 }
 
+// schemaToMap 将工具输入 Schema 转为通用 map，便于前端使用
+func schemaToMap(schema any) map[string]any {
+	if schema == nil {
+		return nil
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil
+	}
+	var result map[string]any
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil
+	}
+	return result
+}
+
+// InvokeMCPTool 直接调用指定 MCP 服务器的工具并返回原始结果（用于调试）
+func (m *Manager) InvokeMCPTool(serverID, toolName string, args map[string]any) (string, error) {
+	m.mu.RLock()
+	cfg, ok := m.configs[serverID]
+	m.mu.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("服务器未配置: %s", serverID)
+	}
+	if toolName == "" {
+		return "", fmt.Errorf("工具名称不能为空")
+	}
+
+	log.Info("调试调用 MCP 工具 [%s]: %s, 参数: %v", cfg.Name, toolName, args)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	impl := &mcp.Implementation{Name: cfg.Name, Version: "1.0.0"}
+	client := mcp.NewClient(impl, nil)
+	session, err := client.Connect(ctx, createTransport(cfg), nil)
+	if err != nil {
+		return "", fmt.Errorf("连接服务器失败: %w", err)
+	}
+	defer session.Close()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      toolName,
+		Arguments: args,
+	})
+	if err != nil {
+		log.Error("调用 MCP 工具失败 [%s/%s]: %v", cfg.Name, toolName, err)
+		return "", fmt.Errorf("调用工具失败: %w", err)
+	}
+
+	output := formatCallToolResult(result)
+	if result.IsError {
+		return "", fmt.Errorf("工具返回错误: %s", output)
+	}
+	return output, nil
+}
+
+// formatCallToolResult 将工具调用结果格式化为文本
+func formatCallToolResult(result *mcp.CallToolResult) string {
+	var parts []string
+	for _, c := range result.Content {
+		if text, ok := c.(*mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+			continue
+		}
+		if data, err := json.Marshal(c); err == nil {
+			parts = append(parts, string(data))
+		}
+	}
+	if result.StructuredContent != nil {
+		if data, err := json.MarshalIndent(result.StructuredContent, "", "  "); err == nil {
+			parts = append(parts, string(data))
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
 // GetToolInfosByServerIDs 根据服务器 ID 列表获取工具信息
 func (m *Manager) GetToolInfosByServerIDs(serverIDs []string) []ToolInfo {
 	log.Info("获取工具信息, 服务器IDs: %v", serverIDs)
@@ -31,8 +31,19 @@ func NewModelFactory() *ModelFactory {
 	return &ModelFactory{}
 }
 
-// CreateModel 根据 AI 配置创建对应的模型
+// CreateModel 根据 AI 配置创建对应的模型，配置了随机种子时自动注入
+// 未填写模型名称时使用提供商的默认模型
 func (f *ModelFactory) CreateModel(ctx context.Context, config *models.AIConfig) (model.LLM, error) {
+	config = withDefaultModel(config)
+	llm, err := f.createModel(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return WithSeed(llm, config.Seed), nil
+}
+
+// createModel 按提供商创建模型
+func (f *ModelFactory) createModel(ctx context.Context, config *models.AIConfig) (model.LLM, error) {
 	switch config.Provider {
 	case models.AIProviderGemini:
 		return f.createGeminiModel(ctx, config)
@@ -182,3 +193,33 @@ func (f *ModelFactory) createAnthropicModel(config *models.AIConfig) (model.LLM,
 		httpClient,
 	), nil
 }
+
+// PingModel 检测模型服务是否可达及鉴权是否有效（仅请求模型列表，不消耗 token）
+// Vertex AI 通过创建模型校验凭据
+func (f *ModelFactory) PingModel(ctx context.Context, config *models.AIConfig) error {
+	if config.Provider == models.AIProviderVertexAI {
+		_, err := f.createVertexAIModel(ctx, config)
+		return err
+	}
+
+	req, err := newModelsRequest(ctx, config, 1)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{Transport: proxy.GetManager().GetTransport()}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("鉴权失败(HTTP %d)，请检查 API Key", resp.StatusCode)
+	case resp.StatusCode >= 500:
+		return fmt.Errorf("服务异常(HTTP %d)", resp.StatusCode)
+	}
+	// 部分兼容服务未实现模型列表接口（404等），能连通即视为可用
+	return nil
+}
@@ -0,0 +1,164 @@
+package adk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/adk/anthropic"
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+)
+
+// defaultModelNames 各提供商未填写模型名称时使用的默认模型
+var defaultModelNames = map[models.AIProvider]string{
+	models.AIProviderOpenAI:    "gpt-4o",
+	models.AIProviderGemini:    "gemini-2.5-flash",
+	models.AIProviderVertexAI:  "gemini-2.5-flash",
+	models.AIProviderAnthropic: "claude-sonnet-4-5-20250929",
+}
+
+// knownAnthropicModels Anthropic 常用模型，兼容服务未实现模型列表接口时使用
+var knownAnthropicModels = []string{
+	"claude-opus-4-1-20250805",
+	"claude-opus-4-20250514",
+	"claude-sonnet-4-5-20250929",
+	"claude-sonnet-4-20250514",
+	"claude-3-7-sonnet-20250219",
+	"claude-3-5-haiku-20241022",
+}
+
+// knownVertexAIModels Vertex AI 常用 Gemini 模型（发布方模型无需逐项目列举）
+var knownVertexAIModels = []string{
+	"gemini-2.5-pro",
+	"gemini-2.5-flash",
+	"gemini-2.5-flash-lite",
+	"gemini-2.0-flash",
+	"gemini-2.0-flash-lite",
+}
+
+// DefaultModelName 返回提供商的默认模型名称，未知提供商返回空
+func DefaultModelName(provider models.AIProvider) string {
+	return defaultModelNames[provider]
+}
+
+// withDefaultModel 模型名称为空时返回填充了默认模型的配置副本
+func withDefaultModel(config *models.AIConfig) *models.AIConfig {
+	if strings.TrimSpace(config.ModelName) != "" {
+		return config
+	}
+	name := DefaultModelName(config.Provider)
+	if name == "" {
+		return config
+	}
+	cfg := *config
+	cfg.ModelName = name
+	log.Info("%s 未配置模型名称，使用默认模型 %s", config.Name, name)
+	return &cfg
+}
+
+// newModelsRequest 构造提供商模型列表接口的请求，pageSize > 0 时限制 Gemini 单页条数
+func newModelsRequest(ctx context.Context, config *models.AIConfig, pageSize int) (*http.Request, error) {
+	var (
+		url     string
+		headers = map[string]string{}
+	)
+	switch config.Provider {
+	case models.AIProviderOpenAI:
+		url = normalizeOpenAIBaseURL(config.BaseURL) + "/models"
+		headers["Authorization"] = "Bearer " + config.APIKey
+	case models.AIProviderAnthropic:
+		baseURL := config.BaseURL
+		if baseURL == "" {
+			baseURL = anthropic.DefaultBaseURL
+		}
+		baseURL = strings.TrimSuffix(strings.TrimRight(baseURL, "/"), "/v1")
+		url = baseURL + "/v1/models?limit=1000"
+		headers["x-api-key"] = config.APIKey
+		headers["anthropic-version"] = "2023-06-01"
+	case models.AIProviderGemini:
+		baseURL := strings.TrimRight(config.BaseURL, "/")
+		if baseURL == "" {
+			baseURL = "https://generativelanguage.googleapis.com"
+		}
+		if pageSize <= 0 {
+			pageSize = 1000
+		}
+		url = fmt.Sprintf("%s/v1beta/models?pageSize=%d", baseURL, pageSize)
+		headers["x-goog-api-key"] = config.APIKey
+	default:
+		return nil, fmt.Errorf("unsupported provider: %s", config.Provider)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return req, nil
+}
+
+// modelsResponse 模型列表响应，兼容 OpenAI/Anthropic(data) 与 Gemini(models) 两种格式
+type modelsResponse struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+	Models []struct {
+		Name                       string   `json:"name"`
+		SupportedGenerationMethods []string `json:"supportedGenerationMethods"`
+	} `json:"models"`
+}
+
+// ListModels 查询提供商可用的模型名称（按名称排序）
+// Vertex AI 返回常用模型列表；Anthropic 兼容服务未实现模型列表接口时同样返回常用模型
+func (f *ModelFactory) ListModels(ctx context.Context, config *models.AIConfig) ([]string, error) {
+	if config.Provider == models.AIProviderVertexAI {
+		return append([]string(nil), knownVertexAIModels...), nil
+	}
+
+	req, err := newModelsRequest(ctx, config, 0)
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{Transport: proxy.GetManager().GetTransport()}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, fmt.Errorf("鉴权失败(HTTP %d)，请检查 API Key", resp.StatusCode)
+	case resp.StatusCode != http.StatusOK && config.Provider == models.AIProviderAnthropic:
+		return append([]string(nil), knownAnthropicModels...), nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("获取模型列表失败(HTTP %d)，该服务可能不支持模型列表接口，请手动填写模型名称", resp.StatusCode)
+	}
+
+	var result modelsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("解析模型列表失败: %w", err)
+	}
+
+	var names []string
+	for _, m := range result.Data {
+		if m.ID != "" {
+			names = append(names, m.ID)
+		}
+	}
+	for _, m := range result.Models {
+		// Gemini 列表包含嵌入等模型，仅保留支持 generateContent 的对话模型
+		if m.Name != "" && slices.Contains(m.SupportedGenerationMethods, "generateContent") {
+			names = append(names, strings.TrimPrefix(m.Name, "models/"))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,132 @@
+package adk
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// newModelsServer 启动返回固定状态码和响应体的模型列表服务，记录收到的请求
+func newModelsServer(t *testing.T, status int, body string) (*httptest.Server, *http.Request) {
+	t.Helper()
+	received := &http.Request{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*received = *r.Clone(context.Background())
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, received
+}
+
+// TestListModelsOpenAI 测试解析 OpenAI data 格式并按名称排序
+func TestListModelsOpenAI(t *testing.T) {
+	srv, req := newModelsServer(t, http.StatusOK, `{"data":[{"id":"gpt-4o"},{"id":""},{"id":"gpt-4.1-mini"}]}`)
+	config := &models.AIConfig{Provider: models.AIProviderOpenAI, BaseURL: srv.URL, APIKey: "sk-test"}
+
+	names, err := NewModelFactory().ListModels(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(names, []string{"gpt-4.1-mini", "gpt-4o"}) {
+		t.Errorf("模型列表错误: %v", names)
+	}
+	if req.URL.Path != "/v1/models" || req.Header.Get("Authorization") != "Bearer sk-test" {
+		t.Errorf("请求错误: %s %q", req.URL.Path, req.Header.Get("Authorization"))
+	}
+}
+
+// TestListModelsGemini 测试解析 Gemini models 格式，仅保留支持 generateContent 的模型并去掉 models/ 前缀
+func TestListModelsGemini(t *testing.T) {
+	srv, req := newModelsServer(t, http.StatusOK, `{"models":[
+		{"name":"models/gemini-2.5-flash","supportedGenerationMethods":["generateContent","countTokens"]},
+		{"name":"models/text-embedding-004","supportedGenerationMethods":["embedContent"]},
+		{"name":"models/gemini-2.0-flash","supportedGenerationMethods":["generateContent"]}
+	]}`)
+	config := &models.AIConfig{Provider: models.AIProviderGemini, BaseURL: srv.URL + "/", APIKey: "g-test"}
+
+	names, err := NewModelFactory().ListModels(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(names, []string{"gemini-2.0-flash", "gemini-2.5-flash"}) {
+		t.Errorf("模型列表错误: %v", names)
+	}
+	if req.URL.Path != "/v1beta/models" || req.Header.Get("x-goog-api-key") != "g-test" {
+		t.Errorf("请求错误: %s %q", req.URL.Path, req.Header.Get("x-goog-api-key"))
+	}
+}
+
+// TestListModelsAnthropicFallback 测试 Anthropic 兼容服务不支持模型列表接口时返回常用模型
+func TestListModelsAnthropicFallback(t *testing.T) {
+	srv, req := newModelsServer(t, http.StatusNotFound, `not found`)
+	config := &models.AIConfig{Provider: models.AIProviderAnthropic, BaseURL: srv.URL + "/v1", APIKey: "a-test"}
+
+	names, err := NewModelFactory().ListModels(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(names, knownAnthropicModels) {
+		t.Errorf("应返回常用模型: %v", names)
+	}
+	if req.URL.Path != "/v1/models" || req.Header.Get("x-api-key") != "a-test" {
+		t.Errorf("请求错误: %s %q", req.URL.Path, req.Header.Get("x-api-key"))
+	}
+
+	// 返回的是副本，修改不影响常用模型列表
+	names[0] = "changed"
+	if knownAnthropicModels[0] == "changed" {
+		t.Error("不应返回常用模型列表本身")
+	}
+}
+
+// TestListModelsErrors 测试鉴权失败及其他非 200 状态码的错误
+func TestListModelsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider models.AIProvider
+		status   int
+		want     string
+	}{
+		{"OpenAI 401", models.AIProviderOpenAI, http.StatusUnauthorized, "鉴权失败(HTTP 401)"},
+		{"Anthropic 403 不回退", models.AIProviderAnthropic, http.StatusForbidden, "鉴权失败(HTTP 403)"},
+		{"Gemini 403", models.AIProviderGemini, http.StatusForbidden, "鉴权失败(HTTP 403)"},
+		{"OpenAI 404", models.AIProviderOpenAI, http.StatusNotFound, "获取模型列表失败(HTTP 404)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, _ := newModelsServer(t, tt.status, `{}`)
+			config := &models.AIConfig{Provider: tt.provider, BaseURL: srv.URL}
+			names, err := NewModelFactory().ListModels(context.Background(), config)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("错误信息应包含 %q: %v %v", tt.want, names, err)
+			}
+		})
+	}
+}
+
+// TestWithDefaultModel 测试模型名称为空时填充默认模型，且不修改调用方的配置
+func TestWithDefaultModel(t *testing.T) {
+	config := &models.AIConfig{Provider: models.AIProviderAnthropic, ModelName: "  "}
+	got := withDefaultModel(config)
+	if got == config || got.ModelName != DefaultModelName(models.AIProviderAnthropic) {
+		t.Errorf("应返回填充默认模型的副本: %+v", got)
+	}
+	if config.ModelName != "  " {
+		t.Errorf("不应修改调用方的配置: %q", config.ModelName)
+	}
+
+	named := &models.AIConfig{Provider: models.AIProviderOpenAI, ModelName: "gpt-4.1"}
+	if withDefaultModel(named) != named {
+		t.Error("已填写模型名称时应原样返回")
+	}
+	unknown := &models.AIConfig{Provider: "unknown"}
+	if withDefaultModel(unknown) != unknown || unknown.ModelName != "" {
+		t.Error("未知提供商应原样返回")
+	}
+}
@@ -57,6 +57,10 @@ func toOpenAIChatCompletionRequest(req *model.LLMRequest, modelName string) (ope
 		if req.Config.TopP != nil {
 			openaiReq.TopP = *req.Config.TopP
 		}
+		if req.Config.Seed != nil {
+			seed := int(*req.Config.Seed)
+			openaiReq.Seed = &seed
+		}
 		if len(req.Config.StopSequences) > 0 {
 			openaiReq.Stop = req.Config.StopSequences
 		}
@@ -23,7 +23,9 @@ type OpenAIModel struct {
 }
 
 // NewOpenAIModel 创建 OpenAI 模型
+// HTTP 客户端包装限流处理，429 时按 Retry-After 等响应头重试
 func NewOpenAIModel(modelName string, cfg openai.ClientConfig) *OpenAIModel {
+	cfg.HTTPClient = withRateLimit(cfg.HTTPClient)
 	client := openai.NewClientWithConfig(cfg)
 	return &OpenAIModel{
 		Client:    client,
@@ -86,12 +88,17 @@ func (o *OpenAIModel) generateStream(ctx context.Context, req *model.LLMRequest)
 		}
 		defer stream.Close()
 
-		o.processStream(stream, yield)
+		// 上下文取消时立即关闭流，中断阻塞中的读取并释放连接
+		stop := context.AfterFunc(ctx, func() { stream.Close() })
+		defer stop()
+
+		o.processStream(ctx, stream, yield)
 	}
 }
 
 // processStream 处理流式响应
-func (o *OpenAIModel) processStream(stream *openai.ChatCompletionStream, yield func(*model.LLMResponse, error) bool) {
+// ctx 取消或调用方停止接收后立即返回，不再产出聚合响应
+func (o *OpenAIModel) processStream(ctx context.Context, stream *openai.ChatCompletionStream, yield func(*model.LLMResponse, error) bool) {
 	aggregatedContent := &genai.Content{
 		Role:  "model",
 		Parts: []*genai.Part{},
@@ -104,7 +111,7 @@ func (o *OpenAIModel) processStream(stream *openai.ChatCompletionStream, yield f
 
 	for {
 		chunk, err := stream.Recv()
-		if errors.Is(err, context.Canceled) {
+		if errors.Is(err, context.Canceled) || ctx.Err() != nil {
 			return
 		}
 		if err != nil {
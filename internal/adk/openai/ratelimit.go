@@ -0,0 +1,25 @@
+package openai
+
+import (
+	"net/http"
+
+	"github.com/run-bigpig/jcp/internal/adk/ratelimit"
+	"github.com/run-bigpig/jcp/internal/logger"
+)
+
+// rateLimitPolicy OpenAI 限流约定：429 重试，x-ratelimit-reset-requests/tokens 为时长格式（如 "1s"、"6m0s"）
+var rateLimitPolicy = &ratelimit.Policy{
+	Log:               logger.New("openai"),
+	RetryStatuses:     []int{http.StatusTooManyRequests},
+	ResetHeaders:      []string{"x-ratelimit-reset-requests", "x-ratelimit-reset-tokens"},
+	ParseReset:        ratelimit.ParseResetDuration,
+	RequestsRemaining: "x-ratelimit-remaining-requests",
+	RequestsLimit:     "x-ratelimit-limit-requests",
+	TokensRemaining:   "x-ratelimit-remaining-tokens",
+	TokensLimit:       "x-ratelimit-limit-tokens",
+}
+
+// withRateLimit 为 HTTP 客户端增加限流处理，已包装时原样返回
+func withRateLimit(doer HTTPDoer) HTTPDoer {
+	return ratelimit.Wrap(doer, rateLimitPolicy)
+}
@@ -61,6 +61,7 @@ func toResponsesRequest(req *model.LLMRequest, modelName string) (CreateResponse
 		p := float32(*req.Config.TopP)
 		apiReq.TopP = &p
 	}
+	// Responses API 不支持 seed，忽略 req.Config.Seed
 	if len(req.Config.StopSequences) > 0 {
 		apiReq.Stop = req.Config.StopSequences
 	}
@@ -33,11 +33,8 @@ type ResponsesModel struct {
 // NewResponsesModel 创建 Responses API 模型
 // apiKey 从工厂单独传入，因 go-openai ClientConfig.authToken 不可导出
 func NewResponsesModel(modelName, apiKey, baseURL string, httpClient HTTPDoer) *ResponsesModel {
-	if httpClient == nil {
-		httpClient = http.DefaultClient
-	}
 	return &ResponsesModel{
-		httpClient: httpClient,
+		httpClient: withRateLimit(httpClient),
 		baseURL:    strings.TrimRight(baseURL, "/"),
 		apiKey:     apiKey,
 		modelName:  modelName,
@@ -152,12 +149,17 @@ func (r *ResponsesModel) generateStream(ctx context.Context, req *model.LLMReque
 			return
 		}
 
-		r.processResponsesStream(resp.Body, yield)
+		// 上下文取消时立即关闭响应体，中断阻塞中的读取并释放连接
+		stop := context.AfterFunc(ctx, func() { resp.Body.Close() })
+		defer stop()
+
+		r.processResponsesStream(ctx, resp.Body, yield)
 	}
 }
 
 // processResponsesStream 处理 Responses API 的 SSE 流
-func (r *ResponsesModel) processResponsesStream(body io.Reader, yield func(*model.LLMResponse, error) bool) {
+// ctx 取消或调用方停止接收后立即返回，不再产出聚合响应
+func (r *ResponsesModel) processResponsesStream(ctx context.Context, body io.Reader, yield func(*model.LLMResponse, error) bool) {
 	scanner := bufio.NewScanner(body)
 	// 聚合状态
 	aggregatedContent := &genai.Content{Role: "model", Parts: []*genai.Part{}}
@@ -167,6 +169,9 @@ func (r *ResponsesModel) processResponsesStream(body io.Reader, yield func(*mode
 	var currentEventType string
 
 	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return
+		}
 		line := scanner.Text()
 
 		// SSE 格式解析: "event: <type>" 和 "data: <json>"
@@ -181,7 +186,9 @@ func (r *ResponsesModel) processResponsesStream(body io.Reader, yield func(*mode
 
 		switch currentEventType {
 		case "response.output_text.delta":
-			r.handleTextDelta(data, &textContent, yield)
+			if !r.handleTextDelta(data, &textContent, yield) {
+				return
+			}
 
 		case "response.function_call_arguments.delta":
 			r.handleFuncArgsDelta(data, toolCallsMap)
@@ -199,6 +206,11 @@ func (r *ResponsesModel) processResponsesStream(body io.Reader, yield func(*mode
 		currentEventType = ""
 	}
 
+	// 取消导致的读取中断不产出不完整的聚合响应
+	if ctx.Err() != nil {
+		return
+	}
+
 	// 组装最终聚合响应
 	if textContent != "" {
 		aggregatedContent.Parts = append(aggregatedContent.Parts, &genai.Part{Text: textContent})
@@ -232,10 +244,11 @@ type responsesToolCallBuilder struct {
 }
 
 // handleTextDelta 处理文本增量事件
-func (r *ResponsesModel) handleTextDelta(data string, textContent *string, yield func(*model.LLMResponse, error) bool) {
+// 返回 false 表示调用方已停止接收
+func (r *ResponsesModel) handleTextDelta(data string, textContent *string, yield func(*model.LLMResponse, error) bool) bool {
 	var delta ResponsesTextDelta
 	if json.Unmarshal([]byte(data), &delta) != nil {
-		return
+		return true
 	}
 	*textContent += delta.Delta
 	part := &genai.Part{Text: delta.Delta}
@@ -244,7 +257,7 @@ func (r *ResponsesModel) handleTextDelta(data string, textContent *string, yield
 		Partial:      true,
 		TurnComplete: false,
 	}
-	yield(llmResp, nil)
+	return yield(llmResp, nil)
 }
 
 // handleFuncArgsDelta 处理函数调用参数增量事件
@@ -0,0 +1,151 @@
+// Package ratelimit 提供模型 API 的限流处理：记录剩余配额，限流或过载时按服务端建议的等待时间重试
+package ratelimit
+
+import (
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/logger"
+)
+
+// 限流重试参数
+const (
+	maxRetries = 2                // 最多重试次数
+	maxDelay   = 30 * time.Second // 建议等待超过该值时不再重试，直接返回错误
+	backoff    = 2 * time.Second  // 响应未给出等待时间时的固定退避
+)
+
+// Doer 发送 HTTP 请求的客户端
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Policy 服务商的限流约定：哪些状态码可重试、从哪些响应头读取重置时间和剩余配额
+type Policy struct {
+	Log           *logger.Logger
+	RetryStatuses []int // 可重试的状态码
+	// ResetHeaders 重置时间头，取最晚者；ParseReset 将单个头的取值解析为等待时间
+	ResetHeaders []string
+	ParseReset   func(value string, now time.Time) (time.Duration, bool)
+	// 剩余配额与上限头，仅用于日志
+	RequestsRemaining, RequestsLimit string
+	TokensRemaining, TokensLimit     string
+}
+
+// ParseResetDuration 解析时长格式的重置头（如 "1s"、"6m0s"）
+func ParseResetDuration(value string, _ time.Time) (time.Duration, bool) {
+	d, err := time.ParseDuration(value)
+	return d, err == nil
+}
+
+// ParseResetTime 解析 RFC 3339 时间格式的重置头，已过去的时间视为无需等待
+func ParseResetTime(value string, now time.Time) (time.Duration, bool) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, false
+	}
+	return max(t.Sub(now), 0), true
+}
+
+// rateLimitDoer 包装 Doer，按 Policy 处理限流
+type rateLimitDoer struct {
+	doer   Doer
+	policy *Policy
+}
+
+// Wrap 为 HTTP 客户端增加限流处理，nil 时使用默认客户端，已包装时原样返回
+func Wrap(doer Doer, policy *Policy) Doer {
+	if doer == nil {
+		doer = http.DefaultClient
+	}
+	if _, ok := doer.(*rateLimitDoer); ok {
+		return doer
+	}
+	return &rateLimitDoer{doer: doer, policy: policy}
+}
+
+// Do 发送请求，遇到可重试状态码时等待后重放请求体
+func (d *rateLimitDoer) Do(req *http.Request) (*http.Response, error) {
+	p := d.policy
+	for attempt := 0; ; attempt++ {
+		resp, err := d.doer.Do(req)
+		if err != nil {
+			return resp, err
+		}
+		p.logQuota(resp.Header)
+		if !slices.Contains(p.RetryStatuses, resp.StatusCode) || attempt >= maxRetries || req.GetBody == nil {
+			return resp, nil
+		}
+
+		delay, ok := p.retryDelay(resp.Header, time.Now())
+		if !ok {
+			delay = backoff
+		}
+		if delay > maxDelay {
+			return resp, nil
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		p.Log.Warn("触发限流 (HTTP %d)，%v 后第 %d 次重试", resp.StatusCode, delay, attempt+1)
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			body.Close()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+		req.Body = body
+	}
+}
+
+// retryDelay 解析建议等待时间
+// 优先 retry-after-ms / Retry-After（秒数或 HTTP 日期），其次服务商的重置时间头取最晚者
+func (p *Policy) retryDelay(h http.Header, now time.Time) (time.Duration, bool) {
+	if v := h.Get("retry-after-ms"); v != "" {
+		if ms, err := strconv.ParseFloat(v, 64); err == nil && ms >= 0 {
+			return time.Duration(ms * float64(time.Millisecond)), true
+		}
+	}
+	if v := h.Get("Retry-After"); v != "" {
+		if sec, err := strconv.ParseFloat(v, 64); err == nil && sec >= 0 {
+			return time.Duration(sec * float64(time.Second)), true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return max(t.Sub(now), 0), true
+		}
+	}
+
+	var delay time.Duration
+	found := false
+	for _, key := range p.ResetHeaders {
+		v := h.Get(key)
+		if v == "" {
+			continue
+		}
+		if d, ok := p.ParseReset(v, now); ok {
+			delay = max(delay, d)
+			found = true
+		}
+	}
+	return delay, found
+}
+
+// logQuota 响应携带限流头时记录剩余配额
+func (p *Policy) logQuota(h http.Header) {
+	requests := h.Get(p.RequestsRemaining)
+	tokens := h.Get(p.TokensRemaining)
+	if requests == "" && tokens == "" {
+		return
+	}
+	p.Log.Debug("剩余配额: requests=%s/%s tokens=%s/%s",
+		requests, h.Get(p.RequestsLimit), tokens, h.Get(p.TokensLimit))
+}
@@ -0,0 +1,122 @@
+package ratelimit
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/logger"
+)
+
+// 测试策略：durationPolicy 对应 openai 的时长重置头，timePolicy 对应 anthropic 的 RFC 3339 重置头
+var (
+	durationPolicy = &Policy{
+		Log:           logger.New("test"),
+		RetryStatuses: []int{http.StatusTooManyRequests},
+		ResetHeaders:  []string{"x-ratelimit-reset-requests", "x-ratelimit-reset-tokens"},
+		ParseReset:    ParseResetDuration,
+	}
+	timePolicy = &Policy{
+		Log:           logger.New("test"),
+		RetryStatuses: []int{http.StatusTooManyRequests, 529},
+		ResetHeaders:  []string{"anthropic-ratelimit-requests-reset", "anthropic-ratelimit-tokens-reset"},
+		ParseReset:    ParseResetTime,
+	}
+)
+
+// TestRetryDelay 测试各类响应头的等待时间解析
+func TestRetryDelay(t *testing.T) {
+	now := time.Date(2024, 1, 5, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		policy  *Policy
+		headers map[string]string
+		want    time.Duration
+		wantOK  bool
+	}{
+		{"Retry-After 秒数", durationPolicy, map[string]string{"Retry-After": "3"}, 3 * time.Second, true},
+		{"Retry-After 小数秒", durationPolicy, map[string]string{"Retry-After": "1.5"}, 1500 * time.Millisecond, true},
+		{"Retry-After HTTP 日期", durationPolicy,
+			map[string]string{"Retry-After": now.Add(5 * time.Second).Format(http.TimeFormat)}, 5 * time.Second, true},
+		{"Retry-After 已过去的日期", durationPolicy,
+			map[string]string{"Retry-After": now.Add(-time.Minute).Format(http.TimeFormat)}, 0, true},
+		{"retry-after-ms 优先", durationPolicy,
+			map[string]string{"retry-after-ms": "250", "Retry-After": "3"}, 250 * time.Millisecond, true},
+		{"时长重置头取较大值", durationPolicy,
+			map[string]string{"x-ratelimit-reset-requests": "1s", "x-ratelimit-reset-tokens": "6m0s"}, 6 * time.Minute, true},
+		{"RFC 3339 重置头取最晚者", timePolicy, map[string]string{
+			"anthropic-ratelimit-requests-reset": now.Add(2 * time.Second).Format(time.RFC3339),
+			"anthropic-ratelimit-tokens-reset":   now.Add(10 * time.Second).Format(time.RFC3339),
+		}, 10 * time.Second, true},
+		{"Retry-After 优先于重置头", timePolicy, map[string]string{
+			"Retry-After":                        "1",
+			"anthropic-ratelimit-requests-reset": now.Add(10 * time.Second).Format(time.RFC3339),
+		}, time.Second, true},
+		{"无限流头", durationPolicy, map[string]string{}, 0, false},
+		{"无法解析的头", durationPolicy, map[string]string{
+			"retry-after-ms":             "abc",
+			"Retry-After":                "soon",
+			"x-ratelimit-reset-requests": "later",
+		}, 0, false},
+		{"负数等待", durationPolicy, map[string]string{"Retry-After": "-1"}, 0, false},
+		{"重置头格式不符", timePolicy, map[string]string{"anthropic-ratelimit-requests-reset": "1s"}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			for k, v := range tt.headers {
+				h.Set(k, v)
+			}
+			got, ok := tt.policy.retryDelay(h, now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("retryDelay = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// doerFunc 以函数实现 Doer
+type doerFunc func(req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+// TestDoRetry 测试可重试状态码时重放请求体，超过重试次数后返回最后的响应
+func TestDoRetry(t *testing.T) {
+	var bodies []string
+	statuses := []int{529, http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK}
+	doer := Wrap(doerFunc(func(req *http.Request) (*http.Response, error) {
+		data, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(data))
+		status := statuses[len(bodies)-1]
+		h := http.Header{}
+		h.Set("Retry-After", "0")
+		return &http.Response{StatusCode: status, Header: h, Body: io.NopCloser(strings.NewReader(""))}, nil
+	}), timePolicy)
+	if Wrap(doer, timePolicy) != doer {
+		t.Error("已包装的客户端应原样返回")
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, "http://example.com", strings.NewReader("payload"))
+	resp, err := doer.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusTooManyRequests || len(bodies) != maxRetries+1 {
+		t.Errorf("应重试 %d 次后返回最后的响应: status=%d calls=%d", maxRetries, resp.StatusCode, len(bodies))
+	}
+	for i, b := range bodies {
+		if b != "payload" {
+			t.Errorf("第 %d 次请求体未重放: %q", i+1, b)
+		}
+	}
+
+	// 不可重试的状态码直接返回
+	bodies = nil
+	statuses = []int{http.StatusInternalServerError}
+	req, _ = http.NewRequest(http.MethodPost, "http://example.com", strings.NewReader("payload"))
+	if resp, _ := doer.Do(req); resp.StatusCode != http.StatusInternalServerError || len(bodies) != 1 {
+		t.Errorf("不可重试的状态码不应重试: status=%d calls=%d", resp.StatusCode, len(bodies))
+	}
+}
@@ -0,0 +1,36 @@
+package adk
+
+import (
+	"context"
+	"iter"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/genai"
+)
+
+// seededLLM 为每次请求注入固定随机种子的模型包装
+// 配合 temperature=0 可获得可复现的输出；OpenAI Chat 与 Gemini 支持 seed，
+// Responses API 和 Anthropic 不支持该参数，仅为尽力而为
+type seededLLM struct {
+	model.LLM
+	seed int32
+}
+
+// WithSeed 包装模型，在请求未指定 seed 时使用给定种子；seed 为 0 时原样返回
+func WithSeed(llm model.LLM, seed int) model.LLM {
+	if seed == 0 || llm == nil {
+		return llm
+	}
+	return &seededLLM{LLM: llm, seed: int32(seed)}
+}
+
+// GenerateContent 注入种子后调用底层模型
+func (m *seededLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	if req.Config == nil {
+		req.Config = &genai.GenerateContentConfig{}
+	}
+	if req.Config.Seed == nil {
+		req.Config.Seed = genai.Ptr(m.seed)
+	}
+	return m.LLM.GenerateContent(ctx, req, stream)
+}
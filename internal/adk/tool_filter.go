@@ -0,0 +1,78 @@
+package adk
+
+import (
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/tool"
+)
+
+// ToolFilter 单次会议的工具白名单/黑名单，作用于专家已有工具（含全局工具和 MCP 工具）
+// 只能收窄不能扩展：白名单中专家未配置的工具不会被加入；
+// 黑名单优先于白名单，同时出现在两者中的工具被禁用；白名单为空表示不限制
+type ToolFilter struct {
+	allowed map[string]bool
+	denied  map[string]bool
+}
+
+// NewToolFilter 创建工具过滤器，两个列表都为空时返回 nil（不过滤）
+func NewToolFilter(allowed, denied []string) *ToolFilter {
+	if len(allowed) == 0 && len(denied) == 0 {
+		return nil
+	}
+	f := &ToolFilter{denied: make(map[string]bool, len(denied))}
+	if len(allowed) > 0 {
+		f.allowed = make(map[string]bool, len(allowed))
+		for _, name := range allowed {
+			f.allowed[name] = true
+		}
+	}
+	for _, name := range denied {
+		f.denied[name] = true
+	}
+	return f
+}
+
+// Allows 判断工具是否可用，nil 过滤器放行所有工具
+func (f *ToolFilter) Allows(name string) bool {
+	if f == nil {
+		return true
+	}
+	if f.denied[name] {
+		return false
+	}
+	return f.allowed == nil || f.allowed[name]
+}
+
+// apply 过滤工具名列表，保持原有顺序
+func (f *ToolFilter) apply(names []string) []string {
+	if f == nil {
+		return names
+	}
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		if f.Allows(name) {
+			result = append(result, name)
+		}
+	}
+	return result
+}
+
+// filteredToolset 按过滤器裁剪 MCP toolset 暴露的工具
+type filteredToolset struct {
+	tool.Toolset
+	filter *ToolFilter
+}
+
+// Tools 返回通过过滤的工具
+func (t *filteredToolset) Tools(ctx agent.ReadonlyContext) ([]tool.Tool, error) {
+	tools, err := t.Toolset.Tools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]tool.Tool, 0, len(tools))
+	for _, tl := range tools {
+		if t.filter.Allows(tl.Name()) {
+			result = append(result, tl)
+		}
+	}
+	return result, nil
+}
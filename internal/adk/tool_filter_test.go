@@ -0,0 +1,102 @@
+package adk
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"google.golang.org/adk/agent"
+	"google.golang.org/adk/tool"
+)
+
+// TestNewToolFilterNil 测试两个列表都为空时不过滤
+func TestNewToolFilterNil(t *testing.T) {
+	f := NewToolFilter(nil, []string{})
+	if f != nil {
+		t.Fatalf("两个列表都为空时应返回 nil: %+v", f)
+	}
+	if !f.Allows("get_kline_data") {
+		t.Error("nil 过滤器应放行所有工具")
+	}
+	names := []string{"a", "b"}
+	if got := f.apply(names); !slices.Equal(got, names) {
+		t.Errorf("nil 过滤器应原样返回: %v", got)
+	}
+}
+
+// TestToolFilterAllows 测试白名单、黑名单及黑名单优先
+func TestToolFilterAllows(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		denied  []string
+		tool    string
+		want    bool
+	}{
+		{"白名单内", []string{"a", "b"}, nil, "a", true},
+		{"白名单外", []string{"a", "b"}, nil, "c", false},
+		{"仅黑名单命中", nil, []string{"a"}, "a", false},
+		{"仅黑名单未命中", nil, []string{"a"}, "b", true},
+		{"黑名单优先于白名单", []string{"a", "b"}, []string{"a"}, "a", false},
+		{"白名单与黑名单均未命中", []string{"a"}, []string{"b"}, "c", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewToolFilter(tt.allowed, tt.denied).Allows(tt.tool); got != tt.want {
+				t.Errorf("Allows(%q) = %v, want %v", tt.tool, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestToolFilterApply 测试过滤保持原顺序，白名单中专家未配置的工具不会被加入
+func TestToolFilterApply(t *testing.T) {
+	f := NewToolFilter([]string{"c", "a", "extra"}, []string{"c"})
+	got := f.apply([]string{"a", "b", "c", "d"})
+	if !slices.Equal(got, []string{"a"}) {
+		t.Errorf("过滤结果错误: %v", got)
+	}
+	if got := f.apply(nil); len(got) != 0 {
+		t.Errorf("空列表不应加入白名单中的工具: %v", got)
+	}
+}
+
+// stubFilterTool 只有名称的测试工具
+type stubFilterTool string
+
+func (t stubFilterTool) Name() string        { return string(t) }
+func (t stubFilterTool) Description() string { return "" }
+func (t stubFilterTool) IsLongRunning() bool { return false }
+
+// stubToolset 返回固定工具列表的测试 toolset
+type stubToolset struct {
+	tools []tool.Tool
+	err   error
+}
+
+func (s *stubToolset) Name() string { return "stub" }
+
+func (s *stubToolset) Tools(agent.ReadonlyContext) ([]tool.Tool, error) {
+	return s.tools, s.err
+}
+
+// TestFilteredToolset 测试 MCP toolset 按过滤器裁剪，出错时透传错误
+func TestFilteredToolset(t *testing.T) {
+	ts := &filteredToolset{
+		Toolset: &stubToolset{tools: []tool.Tool{stubFilterTool("a"), stubFilterTool("b"), stubFilterTool("c")}},
+		filter:  NewToolFilter([]string{"a", "c", "z"}, []string{"c"}),
+	}
+	got, err := ts.Tools(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Name() != "a" {
+		t.Errorf("裁剪结果错误: %v", got)
+	}
+
+	errList := errors.New("list tools failed")
+	ts.Toolset = &stubToolset{err: errList}
+	if _, err := ts.Tools(nil); !errors.Is(err, errList) {
+		t.Errorf("应透传 toolset 错误: %v", err)
+	}
+}
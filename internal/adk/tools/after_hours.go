@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// afterHoursETFs 盘后情绪参考的宽基ETF
+var afterHoursETFs = []string{"sh510050", "sh510300", "sh510500", "sh512100", "sh588000", "sz159915"}
+
+// GetAfterHoursInput 盘后情绪输入参数
+type GetAfterHoursInput struct{}
+
+// GetAfterHoursOutput 盘后情绪输出
+type GetAfterHoursOutput struct {
+	Data string `json:"data" jsonschema:"股指期货、富时A50期货及宽基ETF收盘折溢价，附数据可得性说明"`
+}
+
+// createAfterHoursTool 创建盘后情绪工具
+func (r *Registry) createAfterHoursTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetAfterHoursInput) (GetAfterHoursOutput, error) {
+		if r.stockInfoService == nil {
+			return GetAfterHoursOutput{Data: "盘后行情服务不可用"}, nil
+		}
+
+		codes := make([]string, 0, len(services.DefaultIndexFutures)+1)
+		for _, f := range services.DefaultIndexFutures {
+			codes = append(codes, f.Code)
+		}
+		codes = append(codes, services.A50FutureCode)
+
+		futures, futErr := r.stockInfoService.GetFuturesQuotes(codes)
+		if futErr != nil {
+			fmt.Printf("[Tool:get_after_hours] 错误: %v\n", futErr)
+		}
+		etfs, etfErr := r.stockInfoService.GetETFQuotes(afterHoursETFs)
+		if etfErr != nil {
+			fmt.Printf("[Tool:get_after_hours] 错误: %v\n", etfErr)
+		}
+		if len(futures) == 0 && len(etfs) == 0 {
+			if futErr != nil {
+				return GetAfterHoursOutput{}, futErr
+			}
+			if etfErr != nil {
+				return GetAfterHoursOutput{}, etfErr
+			}
+			return GetAfterHoursOutput{Data: noData("暂无股指期货及跨境ETF行情")}, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== 盘后情绪参考（%s） ===\n", time.Now().Format("2006-01-02 15:04")))
+		sb.WriteString("数据说明:\n")
+		sb.WriteString("- 中金所股指期货交易时间为9:30-11:30、13:00-15:00，无夜盘，盘后数据即当日收盘\n")
+		sb.WriteString("- A股ETF无盘后交易，仅能参考收盘价与IOPV折溢价\n")
+		sb.WriteString("- 富时A50期货在新加坡交易所含夜盘，是A股休市期间唯一连续交易的参考\n")
+
+		sb.WriteString("\n【股指期货】\n")
+		for _, f := range services.DefaultIndexFutures {
+			q, ok := futures[f.Code]
+			if !ok {
+				sb.WriteString(fmt.Sprintf("%s(%s): 暂无数据\n", f.Name, f.Index))
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("%s(%s) %s: %.1f 较昨结算%+.2f%% 持仓%.0f手 [%s]\n",
+				f.Name, f.Index, q.Name, q.Price, q.ChangePercent, q.OpenInterest, q.UpdateTime))
+		}
+
+		sb.WriteString("\n【富时A50期货（含夜盘）】\n")
+		if q, ok := futures[services.A50FutureCode]; ok {
+			sb.WriteString(fmt.Sprintf("%s: %.2f 较昨结算%+.2f%% [%s]\n", q.Name, q.Price, q.ChangePercent, q.UpdateTime))
+		} else {
+			sb.WriteString("暂无数据\n")
+		}
+
+		sb.WriteString("\n【宽基ETF收盘】\n")
+		for _, code := range afterHoursETFs {
+			q, ok := etfs[code]
+			if !ok {
+				continue
+			}
+			line := fmt.Sprintf("%s(%s): %.3f %+.2f%%", q.Name, q.Code, q.Price, q.ChangePercent)
+			if q.IOPV > 0 {
+				line += fmt.Sprintf(" IOPV %.4f 溢价率%+.2f%%", q.IOPV, q.Premium)
+			}
+			sb.WriteString(line + "\n")
+		}
+
+		return GetAfterHoursOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_after_hours",
+		Description: "获取盘后/休市期间的情绪参考：沪深300/上证50/中证500/中证1000股指期货、富时A50期货夜盘及宽基ETF收盘折溢价，用于次日开盘预判",
+	}, handler)
+}
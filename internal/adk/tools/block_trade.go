@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+const (
+	// blockTradeDefaultDays 默认回溯的自然日数
+	blockTradeDefaultDays = 90
+	// blockTradeMaxDays 最大回溯的自然日数
+	blockTradeMaxDays = 365
+	// blockTradeMaxRows 输出的最大明细笔数
+	blockTradeMaxRows = 30
+)
+
+// GetBlockTradesInput 大宗交易游资动向输入参数
+type GetBlockTradesInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh600519 或 600519"`
+	Days int    `json:"days,omitempty" jsonschema:"回溯最近N个自然日，默认90，最大365"`
+}
+
+// GetBlockTradesOutput 大宗交易游资动向输出
+type GetBlockTradesOutput struct {
+	Data string `json:"data" jsonschema:"大宗交易明细（含折溢价及买卖方游资标注）与游资动向判断"`
+}
+
+// createBlockTradesTool 创建大宗交易游资动向工具
+func (r *Registry) createBlockTradesTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetBlockTradesInput) (GetBlockTradesOutput, error) {
+		fmt.Printf("[Tool:get_block_trades] 调用开始, code=%s, days=%d\n", input.Code, input.Days)
+
+		if input.Code == "" {
+			return GetBlockTradesOutput{Data: "请提供股票代码"}, nil
+		}
+		if r.longHuBangService == nil {
+			return GetBlockTradesOutput{Data: "大宗交易数据服务不可用"}, nil
+		}
+
+		days := input.Days
+		if days <= 0 {
+			days = blockTradeDefaultDays
+		}
+		if days > blockTradeMaxDays {
+			days = blockTradeMaxDays
+		}
+
+		trades, err := r.longHuBangService.GetBlockTrades(input.Code, days)
+		if err != nil {
+			fmt.Printf("[Tool:get_block_trades] 错误: %v\n", err)
+			return GetBlockTradesOutput{}, err
+		}
+		if len(trades) == 0 {
+			return GetBlockTradesOutput{Data: noData("%s 最近%d天无大宗交易记录", input.Code, days)}, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 大宗交易（最近%d天，共%d笔）===\n", input.Code, days, len(trades)))
+		sb.WriteString("日期,成交价,收盘价,折溢价,成交额(万元),买方,卖方\n")
+		for i, t := range trades {
+			if i >= blockTradeMaxRows {
+				sb.WriteString(fmt.Sprintf("……其余%d笔略\n", len(trades)-blockTradeMaxRows))
+				break
+			}
+			sb.WriteString(fmt.Sprintf("%s,%.2f,%.2f,%+.2f%%,%.0f,%s,%s\n",
+				t.Date, t.Price, t.Close, t.Premium, t.Amount/10000,
+				blockTradeSeat(t.Buyer, t.BuyerTrader), blockTradeSeat(t.Seller, t.SellerTrader)))
+		}
+
+		summary := services.SummarizeBlockTrades(trades)
+		sb.WriteString("\n【游资动向】\n")
+		sb.WriteString(fmt.Sprintf("大宗成交总额: %.0f万元\n", summary.TotalAmount/10000))
+		sb.WriteString(fmt.Sprintf("游资买入: %.0f万元，游资卖出: %.0f万元", summary.HotMoneyBuy/10000, summary.HotMoneySell/10000))
+		if summary.HotMoneyBuy > 0 {
+			sb.WriteString(fmt.Sprintf("，游资买入加权折溢价: %+.2f%%", summary.HotMoneyPremium))
+		}
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("机构买入: %.0f万元，机构卖出: %.0f万元\n", summary.InstBuy/10000, summary.InstSell/10000))
+		if len(summary.HotMoneySeats) > 0 {
+			sb.WriteString("参与游资: " + strings.Join(summary.HotMoneySeats, "、") + "\n")
+		}
+		sb.WriteString("判断: " + summary.Verdict + "\n")
+		sb.WriteString("说明: 折溢价=成交价相对当日收盘价；游资折价大额承接多为锁仓吸筹信号，但须结合解禁、减持公告判断是否为股东折价甩卖的对手盘")
+
+		return GetBlockTradesOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_block_trades",
+		Description: "获取个股近期大宗交易明细，标注买卖方中的知名游资与机构席位及成交折溢价，汇总判断游资是否在通过大宗交易折价吸筹或出货",
+	}, handler)
+}
+
+// blockTradeSeat 营业部名称附加游资标注
+func blockTradeSeat(name string, trader *services.HotMoneyTrader) string {
+	if trader == nil {
+		return name
+	}
+	return fmt.Sprintf("%s[%s·%s]", name, trader.Label, trader.Style)
+}
@@ -0,0 +1,123 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+const (
+	// breadthTrendDefaultDays 默认返回的交易日数
+	breadthTrendDefaultDays = 20
+	// breadthTrendMaxDays 最大返回的交易日数
+	breadthTrendMaxDays = 250
+	// breadthTrendIndex 用于判断背离的基准指数
+	breadthTrendIndex = "sh000001"
+)
+
+// GetBreadthTrendInput 市场宽度趋势输入参数
+type GetBreadthTrendInput struct {
+	Days int `json:"days,omitempty" jsonschema:"返回最近N个交易日，默认20，最大250"`
+}
+
+// GetBreadthTrendOutput 市场宽度趋势输出
+type GetBreadthTrendOutput struct {
+	Data string `json:"data" jsonschema:"逐日涨跌家数、腾落线及与上证指数的背离判断"`
+}
+
+// createBreadthTrendTool 创建市场宽度趋势工具
+func (r *Registry) createBreadthTrendTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetBreadthTrendInput) (GetBreadthTrendOutput, error) {
+		fmt.Printf("[Tool:get_breadth_trend] 调用开始, days=%d\n", input.Days)
+
+		if r.marketBreadthService == nil {
+			return GetBreadthTrendOutput{Data: "市场广度服务不可用"}, nil
+		}
+		days := input.Days
+		if days <= 0 {
+			days = breadthTrendDefaultDays
+		}
+		if days > breadthTrendMaxDays {
+			days = breadthTrendMaxDays
+		}
+
+		history := r.marketBreadthService.GetBreadthHistory(days)
+		if len(history) == 0 {
+			return GetBreadthTrendOutput{Data: noData("暂无涨跌家数历史：数据源仅提供当日统计，历史需从软件运行之日起按交易日积累，可先使用 get_market_breadth 查看今日数据")}, nil
+		}
+		adl := services.AdvanceDeclineLine(history)
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== 全市场涨跌家数趋势（最近%d个交易日）===\n", len(history)))
+		sb.WriteString("日期,上涨,下跌,平盘,净上涨,腾落线\n")
+		for i, d := range history {
+			mark := ""
+			if !d.Final {
+				mark = "(盘中)"
+			}
+			sb.WriteString(fmt.Sprintf("%s%s,%d,%d,%d,%d,%d\n", d.Date, mark, d.Advance, d.Decline, d.Flat, d.Advance-d.Decline, adl[i]))
+		}
+
+		if len(history) < days {
+			sb.WriteString(fmt.Sprintf("\n注: 历史按交易日逐日积累，当前仅有%d天记录，少于请求的%d天\n", len(history), days))
+		}
+		if len(history) >= 2 {
+			sb.WriteString("\n" + r.breadthDivergence(history, adl))
+		}
+
+		return GetBreadthTrendOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_breadth_trend",
+		Description: "获取最近N个交易日全市场上涨/下跌家数及腾落线（ADL），对比上证指数判断指数与个股普涨普跌是否背离",
+	}, handler)
+}
+
+// breadthDivergence 比较区间内腾落线与上证指数的方向，判断是否背离
+func (r *Registry) breadthDivergence(history []services.BreadthDay, adl []int) string {
+	first, last := history[0], history[len(history)-1]
+	adlChange := adl[len(adl)-1]
+	adlDir := "上行"
+	if adlChange < 0 {
+		adlDir = "下行"
+	}
+	result := fmt.Sprintf("腾落线: 区间净上涨累计 %d，%s", adlChange, adlDir)
+
+	klines, err := r.marketService.GetKLineData(breadthTrendIndex, "1d", breadthTrendMaxDays+1)
+	if err != nil {
+		return result + "\n"
+	}
+	dates, closes := performanceSeries(klines)
+	var base, end float64
+	for i, d := range dates {
+		// 以首日前一交易日收盘为基准，与腾落线首日净上涨口径一致
+		if d < first.Date {
+			base = closes[i]
+		}
+		if d <= last.Date {
+			end = closes[i]
+		}
+	}
+	if base == 0 || end == 0 {
+		return result + "\n"
+	}
+	change := (end - base) / base * 100
+	result += fmt.Sprintf("；上证指数同期 %+.2f%%\n", change)
+
+	switch {
+	case change > 0 && adlChange < 0:
+		result += "判断: 指数上涨而腾落线下行，多数个股走弱，上涨由少数权重股支撑，属顶背离，警惕指数回落\n"
+	case change < 0 && adlChange > 0:
+		result += "判断: 指数下跌而腾落线上行，多数个股走强，属底背离，市场内部结构好于指数表现\n"
+	case change > 0:
+		result += "判断: 指数与腾落线同步上行，上涨有广泛个股参与，趋势较健康\n"
+	default:
+		result += "判断: 指数与腾落线同步下行，下跌具有普遍性，市场整体偏弱\n"
+	}
+	return result
+}
@@ -0,0 +1,143 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/adk/tool"
+	"google.golang.org/genai"
+)
+
+// functionTool ADK 函数工具的方法集（functiontool.New 返回值满足该接口）
+type functionTool interface {
+	tool.Tool
+	Declaration() *genai.FunctionDeclaration
+	Run(ctx tool.Context, args any) (map[string]any, error)
+	ProcessRequest(ctx tool.Context, req *model.LLMRequest) error
+}
+
+// toolCacheEntry 工具结果缓存条目
+type toolCacheEntry struct {
+	result  map[string]any
+	expires time.Time
+}
+
+// toolResultCache 工具结果缓存，按工具名+参数缓存，跨专家、跨会议共享
+type toolResultCache struct {
+	mu      sync.Mutex
+	entries map[string]toolCacheEntry
+}
+
+// newToolResultCache 创建工具结果缓存
+func newToolResultCache() *toolResultCache {
+	return &toolResultCache{entries: make(map[string]toolCacheEntry)}
+}
+
+// get 获取未过期的缓存结果
+func (c *toolResultCache) get(key string, now time.Time) (map[string]any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if now.After(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return entry.result, true
+}
+
+// set 写入缓存，同时清理已过期条目
+func (c *toolResultCache) set(key string, result map[string]any, ttl time.Duration, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, entry := range c.entries {
+		if now.After(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = toolCacheEntry{result: result, expires: now.Add(ttl)}
+}
+
+// clear 清空缓存
+func (c *toolResultCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]toolCacheEntry)
+}
+
+// toolCacheKey 缓存键：工具名 + 参数 JSON（map 序列化时键有序，参数相同则键相同）
+func toolCacheKey(name string, args any) (string, bool) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", false
+	}
+	return name + "\x00" + string(data), true
+}
+
+// cachedTool 带结果缓存的工具包装，TTL 每次调用时从配置读取，未配置的工具直接透传
+type cachedTool struct {
+	functionTool
+	registry *Registry
+}
+
+// ProcessRequest 注册工具声明，并将请求中的工具实例替换为包装后的工具，保证调用经过缓存
+func (t *cachedTool) ProcessRequest(ctx tool.Context, req *model.LLMRequest) error {
+	if err := t.functionTool.ProcessRequest(ctx, req); err != nil {
+		return err
+	}
+	req.Tools[t.Name()] = t
+	return nil
+}
+
+// Run 命中缓存时直接返回，否则执行工具并缓存成功结果
+// 离线模式下不执行工具，直接返回最近一次成功结果的快照
+func (t *cachedTool) Run(ctx tool.Context, args any) (map[string]any, error) {
+	key, ok := toolCacheKey(t.Name(), args)
+	// 离线判断先于缓存键检查，参数无法序列化时同样不请求网络（键为空，按无快照处理）
+	if t.registry.IsOfflineMode() && !offlineLiveTools[t.Name()] {
+		fmt.Printf("[Tool:%s] 离线模式，使用快照\n", t.Name())
+		return t.registry.offlineResult(t.Name(), key), nil
+	}
+	if !ok {
+		return t.functionTool.Run(ctx, args)
+	}
+
+	ttl := t.registry.toolCacheTTL(t.Name())
+	if ttl > 0 {
+		if result, ok := t.registry.toolCache.get(key, time.Now()); ok {
+			fmt.Printf("[Tool:%s] 命中缓存\n", t.Name())
+			return result, nil
+		}
+	}
+	result, err := t.functionTool.Run(ctx, args)
+	if err != nil {
+		return result, err
+	}
+	if ttl > 0 {
+		t.registry.toolCache.set(key, result, ttl, time.Now())
+	}
+	t.registry.snapshots.record(key, result, time.Now())
+	return result, nil
+}
+
+// toolCacheTTL 读取工具的缓存时长配置
+func (r *Registry) toolCacheTTL(name string) time.Duration {
+	if r.configService == nil {
+		return 0
+	}
+	config := r.configService.GetConfig()
+	if config == nil {
+		return 0
+	}
+	return time.Duration(config.ToolCacheTTL[name]) * time.Second
+}
+
+// ClearToolCache 清空工具结果缓存（用户主动刷新时调用，后续调用重新拉取数据）
+func (r *Registry) ClearToolCache() {
+	r.toolCache.clear()
+}
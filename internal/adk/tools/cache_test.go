@@ -0,0 +1,177 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// stubToolInput 测试工具输入
+type stubToolInput struct {
+	Code string `json:"code"`
+}
+
+// stubToolOutput 测试工具输出
+type stubToolOutput struct {
+	Data string `json:"data"`
+}
+
+// stubTool 记录调用次数的测试工具，fail 为 true 时返回错误
+type stubTool struct {
+	*cachedTool
+	calls int
+	fail  bool
+}
+
+// newTestRegistry 创建只含配置服务和缓存的注册中心，update 用于修改测试配置
+func newTestRegistry(t *testing.T, update func(*models.AppConfig)) *Registry {
+	t.Helper()
+	cs, err := services.NewConfigService(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := *cs.GetConfig()
+	if update != nil {
+		update(&config)
+	}
+	if err := cs.UpdateConfig(&config); err != nil {
+		t.Fatal(err)
+	}
+	return &Registry{
+		configService: cs,
+		toolCache:     newToolResultCache(),
+		snapshots:     newToolSnapshotStore(),
+	}
+}
+
+// newStubTool 创建经过缓存包装的测试工具
+func newStubTool(t *testing.T, r *Registry, name string) *stubTool {
+	t.Helper()
+	s := &stubTool{}
+	ft, err := functiontool.New(functiontool.Config{Name: name, Description: "测试工具"},
+		func(ctx tool.Context, input stubToolInput) (stubToolOutput, error) {
+			s.calls++
+			if s.fail {
+				return stubToolOutput{}, errors.New("network error")
+			}
+			return stubToolOutput{Data: fmt.Sprintf("%s#%d", input.Code, s.calls)}, nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.cachedTool = &cachedTool{functionTool: ft.(functionTool), registry: r}
+	return s
+}
+
+// run 以指定代码调用工具，返回 data 字段
+func (s *stubTool) run(t *testing.T, code string) (string, error) {
+	t.Helper()
+	result, err := s.Run(nil, map[string]any{"code": code})
+	if err != nil {
+		return "", err
+	}
+	data, _ := result["data"].(string)
+	return data, nil
+}
+
+// TestCachedToolHit 测试 TTL 内相同参数命中缓存，不同参数各自缓存
+func TestCachedToolHit(t *testing.T) {
+	r := newTestRegistry(t, func(c *models.AppConfig) {
+		c.ToolCacheTTL = map[string]int{"stub": 60}
+	})
+	s := newStubTool(t, r, "stub")
+
+	first, err := s.run(t, "sh600519")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := s.run(t, "sh600519")
+	if first != second || s.calls != 1 {
+		t.Errorf("TTL 内应命中缓存: %q %q calls=%d", first, second, s.calls)
+	}
+
+	if other, _ := s.run(t, "sz000001"); other != "sz000001#2" || s.calls != 2 {
+		t.Errorf("不同参数不应共用缓存: %q calls=%d", other, s.calls)
+	}
+}
+
+// TestCachedToolBypass 测试未配置 TTL 或 TTL<=0 时不缓存
+func TestCachedToolBypass(t *testing.T) {
+	r := newTestRegistry(t, func(c *models.AppConfig) {
+		c.ToolCacheTTL = map[string]int{"negative": -1}
+	})
+	for _, name := range []string{"negative", "unconfigured"} {
+		s := newStubTool(t, r, name)
+		s.run(t, "sh600519")
+		s.run(t, "sh600519")
+		if s.calls != 2 {
+			t.Errorf("%s: TTL<=0 时每次都应执行工具, calls=%d", name, s.calls)
+		}
+	}
+}
+
+// TestCachedToolErrorNotCached 测试失败结果不写入缓存
+func TestCachedToolErrorNotCached(t *testing.T) {
+	r := newTestRegistry(t, func(c *models.AppConfig) {
+		c.ToolCacheTTL = map[string]int{"stub": 60}
+	})
+	s := newStubTool(t, r, "stub")
+
+	s.fail = true
+	if _, err := s.run(t, "sh600519"); err == nil {
+		t.Fatal("工具失败应返回错误")
+	}
+	s.fail = false
+	data, err := s.run(t, "sh600519")
+	if err != nil || data != "sh600519#2" || s.calls != 2 {
+		t.Errorf("失败后应重新执行工具: %q %v calls=%d", data, err, s.calls)
+	}
+}
+
+// TestToolResultCacheExpiry 测试缓存条目超过 TTL 后失效并被清理
+func TestToolResultCacheExpiry(t *testing.T) {
+	c := newToolResultCache()
+	now := time.Now()
+	c.set("a", map[string]any{"data": "a"}, time.Minute, now)
+
+	if _, ok := c.get("a", now.Add(59*time.Second)); !ok {
+		t.Error("TTL 内应命中")
+	}
+	if _, ok := c.get("a", now.Add(time.Minute+time.Second)); ok {
+		t.Error("超过 TTL 应失效")
+	}
+	if len(c.entries) != 0 {
+		t.Errorf("过期条目应被删除: %d", len(c.entries))
+	}
+
+	// 写入新条目时顺带清理其他过期条目
+	c.set("b", map[string]any{"data": "b"}, time.Second, now)
+	c.set("c", map[string]any{"data": "c"}, time.Minute, now.Add(2*time.Second))
+	if _, ok := c.entries["b"]; ok {
+		t.Error("写入时应清理已过期条目")
+	}
+}
+
+// TestClearToolCache 测试清空缓存后重新执行工具
+func TestClearToolCache(t *testing.T) {
+	r := newTestRegistry(t, func(c *models.AppConfig) {
+		c.ToolCacheTTL = map[string]int{"stub": 60}
+	})
+	s := newStubTool(t, r, "stub")
+
+	s.run(t, "sh600519")
+	r.ClearToolCache()
+	if len(r.toolCache.entries) != 0 {
+		t.Errorf("清空后缓存应为空: %d", len(r.toolCache.entries))
+	}
+	if data, _ := s.run(t, "sh600519"); data != "sh600519#2" || s.calls != 2 {
+		t.Errorf("清空后应重新执行工具: %q calls=%d", data, s.calls)
+	}
+}
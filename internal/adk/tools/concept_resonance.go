@@ -0,0 +1,143 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+const (
+	// conceptHotChange 板块涨幅不低于该值(%)视为当日热点题材
+	conceptHotChange = 2
+	// conceptResonanceChange 个股与板块同向涨跌均不低于该值(%)视为资金共振
+	conceptResonanceChange = 1
+	// conceptReasonMaxRunes 入选理由最多展示的字数
+	conceptReasonMaxRunes = 60
+)
+
+// GetConceptResonanceInput 概念题材共振输入参数
+type GetConceptResonanceInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh600519"`
+}
+
+// GetConceptResonanceOutput 概念题材共振输出
+type GetConceptResonanceOutput struct {
+	Data string `json:"data" jsonschema:"个股所属概念题材板块及各板块当日涨跌幅、主力净流入、涨跌家数和领涨股，按涨幅排序并标注热点与共振"`
+}
+
+// createConceptResonanceTool 创建概念题材共振工具
+func (r *Registry) createConceptResonanceTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetConceptResonanceInput) (GetConceptResonanceOutput, error) {
+		fmt.Printf("[Tool:get_concept_resonance] 调用开始, code=%s\n", input.Code)
+
+		if input.Code == "" {
+			return GetConceptResonanceOutput{Data: "请提供股票代码"}, nil
+		}
+		if r.sectorService == nil {
+			return GetConceptResonanceOutput{Data: "板块服务不可用"}, nil
+		}
+		if services.IsETF(input.Code) || services.IsIndex(input.Code) {
+			return GetConceptResonanceOutput{Data: noData("%s 为ETF或指数，无概念题材归属", input.Code)}, nil
+		}
+
+		boards, err := r.sectorService.GetStockConcepts(input.Code)
+		if err != nil {
+			fmt.Printf("[Tool:get_concept_resonance] 错误: %v\n", err)
+			return GetConceptResonanceOutput{}, err
+		}
+		if len(boards) == 0 {
+			return GetConceptResonanceOutput{Data: noData("%s 暂无概念题材归属数据", input.Code)}, nil
+		}
+
+		var stockChange float64
+		var hasStockQuote bool
+		stockName := input.Code
+		if stocks, err := r.marketService.GetStockRealTimeData(input.Code); err == nil && len(stocks) > 0 {
+			stockChange, hasStockQuote = stocks[0].ChangePercent, stocks[0].Price > 0
+			stockName = fmt.Sprintf("%s(%s)", stocks[0].Name, input.Code)
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 概念题材共振 ===\n", stockName))
+		if hasStockQuote {
+			sb.WriteString(fmt.Sprintf("个股今日涨跌幅: %+.2f%%\n", stockChange))
+		}
+		sb.WriteString("\n板块,代码,涨跌幅,主力净流入,涨/跌家数,领涨股,标记\n")
+
+		var hot, resonant []string
+		for _, b := range boards {
+			if !b.HasQuote {
+				sb.WriteString(fmt.Sprintf("%s,%s,-,-,-,-,行情缺失\n", b.Name, b.Code))
+				continue
+			}
+			var tags []string
+			if b.ChangePercent >= conceptHotChange {
+				tags = append(tags, "热点")
+				hot = append(hot, b.Name)
+			}
+			if hasStockQuote && conceptResonates(stockChange, b.ChangePercent) {
+				tags = append(tags, "共振")
+				resonant = append(resonant, b.Name)
+			}
+			if b.Precise {
+				tags = append(tags, "精准题材")
+			}
+			leader := "-"
+			if b.Leader != "" && b.Leader != "-" {
+				leader = fmt.Sprintf("%s(%+.2f%%)", b.Leader, b.LeaderChange)
+			}
+			sb.WriteString(fmt.Sprintf("%s,%s,%+.2f%%,%.2f亿,%d/%d,%s,%s\n",
+				b.Name, b.Code, b.ChangePercent, b.MainNetInflow/1e8, b.UpCount, b.DownCount, leader, strings.Join(tags, "/")))
+		}
+
+		sb.WriteString("\n【结论】\n")
+		if len(hot) > 0 {
+			sb.WriteString(fmt.Sprintf("今日热点题材（板块涨幅≥%d%%）: %s\n", conceptHotChange, strings.Join(hot, "、")))
+		} else {
+			sb.WriteString(fmt.Sprintf("所属题材今日均无明显热度（无板块涨幅≥%d%%）\n", conceptHotChange))
+		}
+		if len(resonant) > 0 {
+			sb.WriteString(fmt.Sprintf("与个股同向共振（个股与板块同向涨跌均≥%d%%）: %s\n", conceptResonanceChange, strings.Join(resonant, "、")))
+		} else if hasStockQuote {
+			sb.WriteString("个股与所属题材未形成同向共振，走势更多受个股自身因素驱动\n")
+		}
+
+		if reasons := conceptReasons(boards); reasons != "" {
+			sb.WriteString("\n【题材入选理由】\n")
+			sb.WriteString(reasons)
+		}
+		sb.WriteString("\n说明: 题材归属来自东方财富F10核心题材，含行业与地域板块；板块涨幅和主力净流入为当日实时数据，盘中会变化")
+		return GetConceptResonanceOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_concept_resonance",
+		Description: "列出个股所属的概念题材板块及各板块今日涨跌幅、主力净流入、涨跌家数和领涨股，按涨幅排序并标注当日热点题材及与个股同向共振的题材，用于量化判断个股是否处于当日热点主线",
+	}, handler)
+}
+
+// conceptResonates 个股与板块同向涨跌且幅度均达到共振阈值
+func conceptResonates(stockChange, boardChange float64) bool {
+	return (stockChange >= conceptResonanceChange && boardChange >= conceptResonanceChange) ||
+		(stockChange <= -conceptResonanceChange && boardChange <= -conceptResonanceChange)
+}
+
+// conceptReasons 列出有入选理由的题材（按涨幅顺序），理由过长时截断
+func conceptReasons(boards []services.ConceptBoard) string {
+	var sb strings.Builder
+	for _, b := range boards {
+		if b.Reason == "" {
+			continue
+		}
+		reason := []rune(b.Reason)
+		if len(reason) > conceptReasonMaxRunes {
+			reason = append(reason[:conceptReasonMaxRunes], '…')
+		}
+		sb.WriteString(fmt.Sprintf("- %s: %s\n", b.Name, string(reason)))
+	}
+	return sb.String()
+}
@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// GetCrossMarketInput 外盘关联行情输入参数
+type GetCrossMarketInput struct {
+	Code string `json:"code" jsonschema:"A股代码，如 sh601318"`
+}
+
+// GetCrossMarketOutput 外盘关联行情输出
+type GetCrossMarketOutput struct {
+	Data string `json:"data" jsonschema:"关联的港股/美股标的及隔夜涨跌，外盘大盘参考"`
+}
+
+// createCrossMarketTool 创建外盘关联行情工具
+func (r *Registry) createCrossMarketTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetCrossMarketInput) (GetCrossMarketOutput, error) {
+		fmt.Printf("[Tool:get_cross_market] 调用开始, code=%s\n", input.Code)
+
+		if input.Code == "" {
+			return GetCrossMarketOutput{Data: "请提供股票代码"}, nil
+		}
+		if r.stockInfoService == nil {
+			return GetCrossMarketOutput{Data: "境外行情服务不可用"}, nil
+		}
+
+		// 统一为带前缀代码，并查询所属行业
+		code := strings.ToLower(input.Code)
+		var industry string
+		if r.configService != nil {
+			symbol := code
+			if len(symbol) == 8 {
+				symbol = symbol[2:]
+			}
+			if info := r.configService.GetStockBasicInfo(symbol); info != nil {
+				code = info.Symbol
+				industry = info.Industry
+			}
+		}
+
+		targets := services.CrossMarketTargets(code, industry)
+		codes := make([]string, 0, len(targets)+len(services.CrossMarketBenchmarks))
+		for _, t := range targets {
+			codes = append(codes, t.Code)
+		}
+		for _, t := range services.CrossMarketBenchmarks {
+			codes = append(codes, t.Code)
+		}
+
+		quotes, err := r.stockInfoService.GetOverseasQuotes(codes)
+		if err != nil {
+			fmt.Printf("[Tool:get_cross_market] 错误: %v\n", err)
+			return GetCrossMarketOutput{}, err
+		}
+
+		writeQuote := func(sb *strings.Builder, t services.OverseasTarget) {
+			q := quotes[t.Code]
+			if q == nil {
+				sb.WriteString(fmt.Sprintf("- %s(%s): 暂无行情\n", t.Name, t.Relation))
+				return
+			}
+			sb.WriteString(fmt.Sprintf("- %s(%s): %.3f %+.2f%% [%s]\n", t.Name, t.Relation, q.Price, q.ChangePercent, q.UpdateTime))
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 外盘关联 ===\n", code))
+		if quotes, err := r.marketService.GetStockRealTimeData(code); err == nil && len(quotes) > 0 {
+			sb.WriteString(fmt.Sprintf("A股: %s %.2f %+.2f%%\n", quotes[0].Name, quotes[0].Price, quotes[0].ChangePercent))
+		}
+		if industry != "" {
+			sb.WriteString(fmt.Sprintf("所属行业: %s\n", industry))
+		}
+
+		sb.WriteString("\n【关联标的】\n")
+		if len(targets) == 0 {
+			sb.WriteString("无境外上市或明确的行业映射标的，仅参考外盘大盘\n")
+		}
+		for _, t := range targets {
+			writeQuote(&sb, t)
+		}
+
+		sb.WriteString("\n【外盘大盘】\n")
+		for _, t := range services.CrossMarketBenchmarks {
+			writeQuote(&sb, t)
+		}
+		sb.WriteString("\n说明: 美股行情为最近一个交易日收盘（北京时间凌晨），对A股次日开盘有参考意义；港股与A股同时段交易，两地上市股注意AH溢价")
+		return GetCrossMarketOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_cross_market",
+		Description: "获取A股关联的境外标的行情：A+H两地上市的H股、美股同时上市股，或行业映射标的（如半导体对应费城半导体指数），附美股、中概股和港股大盘隔夜涨跌，用于判断次日高开低开",
+	}, handler)
+}
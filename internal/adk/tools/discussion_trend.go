@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// GetDiscussionTrendInput 股吧讨论热度输入参数
+type GetDiscussionTrendInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh600519 或 600519"`
+	Days int    `json:"days,omitzero" jsonschema:"返回最近N天，默认10，最大30"`
+}
+
+// GetDiscussionTrendOutput 股吧讨论热度输出
+type GetDiscussionTrendOutput struct {
+	Data string `json:"data" jsonschema:"近期股吧人气排名走势、热度趋势及异动标记"`
+}
+
+// createDiscussionTrendTool 创建股吧讨论热度趋势工具
+func (r *Registry) createDiscussionTrendTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetDiscussionTrendInput) (GetDiscussionTrendOutput, error) {
+		if r.gubaService == nil {
+			return GetDiscussionTrendOutput{Data: "股吧热度服务不可用"}, nil
+		}
+		if input.Code == "" {
+			return GetDiscussionTrendOutput{Data: "请提供股票代码"}, nil
+		}
+
+		days := input.Days
+		if days <= 0 {
+			days = 10
+		}
+		if days > 30 {
+			days = 30
+		}
+
+		// 统一为带前缀代码
+		code := strings.ToLower(input.Code)
+		if len(code) == 6 && r.configService != nil {
+			if info := r.configService.GetStockBasicInfo(code); info != nil {
+				code = info.Symbol
+			}
+		}
+
+		trend, err := r.gubaService.GetDiscussionTrend(code)
+		if err != nil {
+			// 数据源不可用时降级为提示，不中断专家分析
+			fmt.Printf("[Tool:get_discussion_trend] 错误: %v\n", err)
+			return GetDiscussionTrendOutput{Data: fmt.Sprintf("%s 股吧热度数据暂不可用，请结合其他舆情信息判断", input.Code)}, nil
+		}
+		if len(trend.Days) == 0 {
+			return GetDiscussionTrendOutput{Data: noData("%s 暂无股吧人气排名数据", input.Code)}, nil
+		}
+
+		list := trend.Days
+		if len(list) > days {
+			list = list[len(list)-days:]
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 股吧讨论热度（东方财富股吧人气排名，越小越热）===\n", input.Code))
+		sb.WriteString("日期,人气排名,较前日\n")
+		for i, d := range list {
+			delta := "-"
+			if i > 0 {
+				delta = fmt.Sprintf("%+d", list[i-1].Rank-d.Rank)
+			}
+			sb.WriteString(fmt.Sprintf("%s,%d,%s\n", d.Date, d.Rank, delta))
+		}
+
+		latest := trend.Days[len(trend.Days)-1]
+		sb.WriteString(fmt.Sprintf("\n最新排名: %d（前期平均 %.0f），热度趋势: %s\n", latest.Rank, trend.AvgRank, trend.Trend))
+		if trend.Spike {
+			sb.WriteString("【关注度异动】散户关注度突然放大，警惕情绪过热或消息驱动\n")
+		}
+
+		return GetDiscussionTrendOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_discussion_trend",
+		Description: "获取个股近期东方财富股吧人气排名走势，判断散户关注度是在升温还是降温，并标记关注度突然放大的异动",
+	}, handler)
+}
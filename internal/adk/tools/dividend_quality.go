@@ -0,0 +1,94 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// dividendQualityRecentRecords 输出的最近分红方案条数
+const dividendQualityRecentRecords = 6
+
+// GetDividendQualityInput 分红质量输入参数
+type GetDividendQualityInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如600519或sh600519"`
+}
+
+// GetDividendQualityOutput 分红质量输出
+type GetDividendQualityOutput struct {
+	Data string `json:"data" jsonschema:"股息率TTM、连续分红年数、平均分红率、分红质量评级及近期分红方案"`
+}
+
+// createDividendQualityTool 创建分红质量工具
+func (r *Registry) createDividendQualityTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetDividendQualityInput) (GetDividendQualityOutput, error) {
+		fmt.Printf("[Tool:get_dividend_quality] 调用开始, code=%s\n", input.Code)
+
+		if r.shareholderService == nil {
+			return GetDividendQualityOutput{Data: "股东数据服务不可用"}, nil
+		}
+		if input.Code == "" {
+			return GetDividendQualityOutput{}, fmt.Errorf("股票代码不能为空")
+		}
+
+		history, err := r.shareholderService.GetDividendHistory(input.Code)
+		if err != nil {
+			fmt.Printf("[Tool:get_dividend_quality] 错误: %v\n", err)
+			return GetDividendQualityOutput{}, err
+		}
+		if len(history.Records) == 0 {
+			return GetDividendQualityOutput{Data: noData("%s 暂无分红送配记录", input.Code)}, nil
+		}
+
+		// 股息率按最新价计算，行情获取失败时仅输出分红年数和分红率
+		var price float64
+		if stocks, err := r.marketService.GetStockRealTimeData(input.Code); err == nil && len(stocks) > 0 {
+			price = stocks[0].Price
+		}
+		q := services.AssessDividendQuality(history, price, time.Now())
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s(%s) 分红质量 ===\n", history.Name, history.Code))
+		if price > 0 {
+			sb.WriteString(fmt.Sprintf("股息率TTM: %.2f%%（近12个月每股派现%.3f元 / 最新价%.2f）\n", q.Yield, q.TTMDividend, price))
+		} else {
+			sb.WriteString(fmt.Sprintf("股息率TTM: 行情不可用（近12个月每股派现%.3f元）\n", q.TTMDividend))
+		}
+		sb.WriteString(fmt.Sprintf("连续现金分红: %d年\n", q.ConsecutiveYears))
+		if q.PayoutYears > 0 {
+			sb.WriteString(fmt.Sprintf("平均分红率: %.1f%%（近%d个年度）\n", q.AvgPayout, q.PayoutYears))
+		}
+		sb.WriteString(fmt.Sprintf("\n【分红质量】%s（%d分）\n", q.Level, q.Score))
+		for _, reason := range q.Reasons {
+			sb.WriteString("- " + reason + "\n")
+		}
+
+		sb.WriteString("\n【近期分红方案】\n")
+		for i, rec := range history.Records {
+			if i >= dividendQualityRecentRecords {
+				break
+			}
+			sb.WriteString(fmt.Sprintf("[%s] %s", rec.ReportDate, rec.Progress))
+			if rec.Plan != "" {
+				sb.WriteString(" " + rec.Plan)
+			}
+			if rec.ExDate != "" {
+				sb.WriteString(" 除息日:" + rec.ExDate)
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("说明: 评分综合股息率(40分)、连续分红年数(40分)和分红率(20分)；分红率超过100%说明分红超过当年盈利")
+
+		return GetDividendQualityOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_dividend_quality",
+		Description: "获取个股分红质量：基于历史分红方案计算股息率TTM、连续现金分红年数和近5年平均分红率，给出分红质量评级（优/良/中/差）",
+	}, handler)
+}
@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/indicators"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// drawdownDefaultLookbacks 默认统计的交易日窗口
+var drawdownDefaultLookbacks = []int{60, 120, 250}
+
+// drawdownMaxLookback 单个统计窗口的最大交易日数
+const drawdownMaxLookback = 500
+
+// GetDrawdownStatsInput 回撤统计输入参数
+type GetDrawdownStatsInput struct {
+	Code      string `json:"code" jsonschema:"股票代码，如 sh600519"`
+	Lookbacks []int  `json:"lookbacks,omitempty" jsonschema:"统计的交易日窗口列表，默认[60,120,250]，单个窗口最大500"`
+}
+
+// GetDrawdownStatsOutput 回撤统计输出
+type GetDrawdownStatsOutput struct {
+	Data string `json:"data" jsonschema:"各窗口最大回撤、当前回撤及历史回撤平均修复天数"`
+}
+
+// createDrawdownTool 创建最大回撤统计工具
+func (r *Registry) createDrawdownTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetDrawdownStatsInput) (GetDrawdownStatsOutput, error) {
+		fmt.Printf("[Tool:get_drawdown_stats] 调用开始, code=%s, lookbacks=%v\n", input.Code, input.Lookbacks)
+
+		if input.Code == "" {
+			return GetDrawdownStatsOutput{Data: "请提供股票代码"}, nil
+		}
+
+		lookbacks := make([]int, 0, len(input.Lookbacks))
+		for _, lb := range input.Lookbacks {
+			if lb < 2 {
+				continue
+			}
+			if lb > drawdownMaxLookback {
+				lb = drawdownMaxLookback
+			}
+			lookbacks = append(lookbacks, lb)
+		}
+		if len(lookbacks) == 0 {
+			lookbacks = drawdownDefaultLookbacks
+		}
+		longest := 0
+		for _, lb := range lookbacks {
+			if lb > longest {
+				longest = lb
+			}
+		}
+
+		klines, err := r.marketService.GetKLineData(input.Code, "1d", longest)
+		if err != nil {
+			fmt.Printf("[Tool:get_drawdown_stats] 错误: %v\n", err)
+			return GetDrawdownStatsOutput{}, err
+		}
+		if len(klines) < 2 {
+			return GetDrawdownStatsOutput{Data: noData("%s 日K数据不足，无法计算回撤", input.Code)}, nil
+		}
+		closes := make([]float64, len(klines))
+		for i, k := range klines {
+			closes[i] = k.Close
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 回撤统计（最新收盘 %.2f） ===\n", input.Code, closes[len(closes)-1]))
+		sb.WriteString("窗口,最大回撤,前高日期,谷底日期,当前回撤,窗口最高日期,修复次数,平均修复天数\n")
+		for _, lb := range lookbacks {
+			window := lb
+			if window > len(closes) {
+				window = len(closes)
+			}
+			st := indicators.ComputeDrawdown(closes, window)
+			recovery := "-"
+			if st.Recoveries > 0 {
+				recovery = fmt.Sprintf("%.1f", st.AvgRecoveryDays)
+			}
+			label := fmt.Sprintf("%d日", lb)
+			if window < lb {
+				label = fmt.Sprintf("%d日(仅%d根)", lb, window)
+			}
+			sb.WriteString(fmt.Sprintf("%s,%.2f%%,%s,%s,%.2f%%,%s,%d,%s\n",
+				label, st.MaxDrawdown, klines[st.PeakIndex].Time, klines[st.TroughIndex].Time,
+				st.CurrentDrawdown, klines[st.PeakCloseIndex].Time, st.Recoveries, recovery))
+		}
+		sb.WriteString("说明: 基于日收盘价计算；修复指从前高回撤5%以上后重新站上前高，未修复的回撤不计入平均修复天数")
+		return GetDrawdownStatsOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_drawdown_stats",
+		Description: "计算个股在60/120/250日等窗口内的最大回撤、当前距前高回撤及历史回撤平均修复天数，量化下行风险",
+	}, handler)
+}
@@ -0,0 +1,116 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+const (
+	// etfFlowDefaultDays 默认统计的交易日数
+	etfFlowDefaultDays = 10
+	// etfFlowMaxDays 最大统计的交易日数（每个交易日需单独查询份额）
+	etfFlowMaxDays = 20
+	// etfFlowMinShareRatio 累计份额变化低于期初份额该比例视为申赎平衡
+	etfFlowMinShareRatio = 0.005
+)
+
+// GetETFFlowInput ETF资金流背离输入参数
+type GetETFFlowInput struct {
+	Code string `json:"code" jsonschema:"沪市ETF代码，如 sh512480 或 512480"`
+	Days int    `json:"days,omitempty" jsonschema:"统计最近N个交易日，默认10，最大20"`
+}
+
+// GetETFFlowOutput ETF资金流背离输出
+type GetETFFlowOutput struct {
+	Data string `json:"data" jsonschema:"ETF逐日份额变化、净申赎估算及与价格走势的背离判断"`
+}
+
+// createETFFlowTool 创建ETF申赎资金流背离工具
+func (r *Registry) createETFFlowTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetETFFlowInput) (GetETFFlowOutput, error) {
+		fmt.Printf("[Tool:get_etf_flow] 调用开始, code=%s, days=%d\n", input.Code, input.Days)
+
+		if input.Code == "" {
+			return GetETFFlowOutput{Data: "请提供ETF代码"}, nil
+		}
+		if r.stockInfoService == nil {
+			return GetETFFlowOutput{Data: "ETF数据服务不可用"}, nil
+		}
+		if !services.IsSHETF(input.Code) {
+			return GetETFFlowOutput{Data: fmt.Sprintf("%s 不是沪市ETF：目前仅上交所公布逐日基金份额，深市ETF暂不支持申赎资金流分析", input.Code)}, nil
+		}
+		code := strings.ToLower(input.Code)
+		if !strings.HasPrefix(code, "sh") {
+			code = "sh" + code
+		}
+
+		days := input.Days
+		if days <= 0 {
+			days = etfFlowDefaultDays
+		}
+		if days > etfFlowMaxDays {
+			days = etfFlowMaxDays
+		}
+
+		// 多取一天作为份额变化基准
+		klines, err := r.marketService.GetKLineData(code, "1d", days+1)
+		if err != nil {
+			fmt.Printf("[Tool:get_etf_flow] 错误: %v\n", err)
+			return GetETFFlowOutput{}, err
+		}
+		dates, closes := performanceSeries(klines)
+		shares, err := r.stockInfoService.GetETFShares(code, dates)
+		if err != nil {
+			fmt.Printf("[Tool:get_etf_flow] 错误: %v\n", err)
+			return GetETFFlowOutput{}, err
+		}
+		flows := services.ComputeETFFlows(dates, closes, shares)
+		if len(flows) < 2 {
+			return GetETFFlowOutput{Data: noData("%s 最近交易日份额数据不足，无法计算申赎资金流（上交所份额通常于次日公布）", code)}, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 份额变化与净申赎（最近%d个交易日）===\n", code, len(flows)-1))
+		sb.WriteString("日期,收盘,份额(万份),份额变化(万份),净申赎(万元)\n")
+		var netFlow float64
+		for _, f := range flows[1:] {
+			netFlow += f.NetFlow
+			sb.WriteString(fmt.Sprintf("%s,%.3f,%.2f,%+.2f,%+.2f\n", f.Date, f.Close, f.Shares, f.ShareChange, f.NetFlow))
+		}
+
+		first, last := flows[0], flows[len(flows)-1]
+		priceChange := (last.Close - first.Close) / first.Close * 100
+		shareChange := (last.Shares - first.Shares) / first.Shares
+		sb.WriteString(fmt.Sprintf("\n区间涨跌幅: %+.2f%%，份额变化: %+.2f%%，累计净申赎: %+.2f亿元\n", priceChange, shareChange*100, netFlow/10000))
+		sb.WriteString("判断: " + etfFlowVerdict(priceChange, shareChange) + "\n")
+		sb.WriteString("说明: 净申赎按份额变化×当日收盘价估算，份额数据来自上交所，通常滞后一个交易日")
+
+		return GetETFFlowOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_etf_flow",
+		Description: "获取沪市ETF最近N个交易日的份额变化和净申购/赎回金额估算，对比价格走势识别背离（价涨份额减=资金借涨离场需谨慎，价跌份额增=资金逆势布局），用于行业/主题配置判断",
+	}, handler)
+}
+
+// etfFlowVerdict 根据区间价格涨跌与份额变化判断量价关系
+func etfFlowVerdict(priceChange, shareChange float64) string {
+	switch {
+	case shareChange > -etfFlowMinShareRatio && shareChange < etfFlowMinShareRatio:
+		return "申赎基本平衡，价格主要由二级市场交易驱动"
+	case priceChange > 0 && shareChange < 0:
+		return "背离：价格上涨但份额减少，资金借涨赎回离场，上涨持续性存疑，需谨慎"
+	case priceChange < 0 && shareChange > 0:
+		return "背离：价格下跌但份额增加，资金逆势申购布局，关注底部企稳信号"
+	case priceChange > 0:
+		return "同向：价格上涨且持续净申购，增量资金入场推动，趋势较健康"
+	default:
+		return "同向：价格下跌且持续净赎回，资金撤离该主题，短期偏弱"
+	}
+}
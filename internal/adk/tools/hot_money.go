@@ -0,0 +1,98 @@
+package tools
+
+import (
+	"fmt"
+
+	"github.com/run-bigpig/jcp/internal/models"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// IdentifyHotMoneyInput 游资识别输入参数
+type IdentifyHotMoneyInput struct {
+	Code      string `json:"code" jsonschema:"股票代码，如600477"`
+	TradeDate string `json:"trade_date" jsonschema:"交易日期，格式YYYY-MM-DD，如2026-02-09"`
+}
+
+// IdentifyHotMoneyOutput 游资识别输出
+type IdentifyHotMoneyOutput struct {
+	Data string `json:"data" jsonschema:"营业部明细及游资识别结果"`
+}
+
+// createKnownHotMoneyTool 创建知名游资识别工具
+func (r *Registry) createKnownHotMoneyTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input IdentifyHotMoneyInput) (IdentifyHotMoneyOutput, error) {
+		lhbLog.Debug("游资识别开始, code=%s, date=%s", input.Code, input.TradeDate)
+
+		if input.Code == "" || input.TradeDate == "" {
+			return IdentifyHotMoneyOutput{}, fmt.Errorf("股票代码和交易日期不能为空")
+		}
+
+		details, err := r.longHuBangService.GetStockDetail(input.Code, input.TradeDate)
+		if err != nil {
+			lhbLog.Error("获取营业部明细失败: %v", err)
+			return IdentifyHotMoneyOutput{}, err
+		}
+
+		if len(details) == 0 {
+			return IdentifyHotMoneyOutput{Data: noData("未找到该股票的龙虎榜营业部数据")}, nil
+		}
+
+		var result string
+		result += fmt.Sprintf("=== %s 龙虎榜游资识别 (%s) ===\n\n", input.Code, input.TradeDate)
+
+		styleCount := make(map[string]int)
+		result += "【买入前五营业部】\n"
+		result += r.formatHotMoneySeats(details, "buy", styleCount)
+		result += "\n【卖出前五营业部】\n"
+		result += r.formatHotMoneySeats(details, "sell", styleCount)
+
+		result += "\n【识别汇总】\n"
+		if len(styleCount) == 0 {
+			result += "未识别到知名游资或机构席位，以普通营业部为主\n"
+		} else {
+			for _, style := range []string{"打板", "接力", "机构"} {
+				if n := styleCount[style]; n > 0 {
+					result += fmt.Sprintf("%s席位: %d个\n", style, n)
+				}
+			}
+		}
+
+		lhbLog.Debug("游资识别完成, 识别风格数=%d", len(styleCount))
+		return IdentifyHotMoneyOutput{Data: result}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "identify_hot_money",
+		Description: "识别个股龙虎榜营业部中的知名游资席位，标注游资名称及操作风格(接力/打板/机构)，需要提供股票代码和交易日期",
+	}, handler)
+}
+
+// formatHotMoneySeats 格式化指定方向的前五营业部并标注游资
+func (r *Registry) formatHotMoneySeats(details []models.LongHuBangDetail, direction string, styleCount map[string]int) string {
+	var result string
+	count := 0
+	for _, d := range details {
+		if d.Direction != direction || count >= 5 {
+			continue
+		}
+		count++
+
+		tag := ""
+		if trader := r.longHuBangService.IdentifyHotMoney(d.OperName); trader != nil {
+			tag = fmt.Sprintf(" [%s·%s]", trader.Label, trader.Style)
+			styleCount[trader.Style]++
+		}
+		result += fmt.Sprintf("%d. %s%s\n", count, d.OperName, tag)
+		if direction == "buy" {
+			result += fmt.Sprintf("   买入:%.0f万 占比:%.2f%% 净额:%.0f万\n", d.BuyAmt/10000, d.BuyPercent, d.NetAmt/10000)
+		} else {
+			result += fmt.Sprintf("   卖出:%.0f万 占比:%.2f%% 净额:%.0f万\n", d.SellAmt/10000, d.SellPercent, d.NetAmt/10000)
+		}
+	}
+	if count == 0 {
+		result += "无\n"
+	}
+	return result
+}
@@ -71,6 +71,10 @@ func formatTrendResult(sb *strings.Builder, tr hottrend.HotTrendResult, limit in
 	}
 
 	sb.WriteString(fmt.Sprintf("【%s】热搜榜:\n", tr.PlatformCN))
+	if len(tr.Items) == 0 {
+		sb.WriteString("  " + noData("该平台暂无热搜条目") + "\n")
+		return
+	}
 	count := 0
 	for _, item := range tr.Items {
 		if count >= limit {
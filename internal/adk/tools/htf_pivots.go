@@ -0,0 +1,151 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/indicators"
+	"github.com/run-bigpig/jcp/internal/models"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// htfPivotKLines 计算高周期枢轴点所取的周K/月K根数（当前周期 + 上一完整周期，多取几根容错）
+const htfPivotKLines = 5
+
+// GetHTFPivotsInput 高周期枢轴点输入参数
+type GetHTFPivotsInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh600519"`
+}
+
+// GetHTFPivotsOutput 高周期枢轴点输出
+type GetHTFPivotsOutput struct {
+	Data string `json:"data" jsonschema:"周线、月线经典枢轴点P及R1-R3/S1-S3，以及现价所处的支撑压力区间"`
+}
+
+// htfPivotLevel 带名称的枢轴价位
+type htfPivotLevel struct {
+	Name  string
+	Price float64
+}
+
+// createHTFPivotsTool 创建周线/月线枢轴点工具
+func (r *Registry) createHTFPivotsTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetHTFPivotsInput) (GetHTFPivotsOutput, error) {
+		fmt.Printf("[Tool:get_htf_pivots] 调用开始, code=%s\n", input.Code)
+
+		if input.Code == "" {
+			return GetHTFPivotsOutput{Data: "请提供股票代码"}, nil
+		}
+
+		now := time.Now()
+		var sb strings.Builder
+		var price float64
+		var levels []htfPivotLevel
+		var bias []string
+		for _, tf := range []struct{ period, name, unit string }{{"1w", "周线", "周"}, {"1mo", "月线", "月"}} {
+			klines, err := r.marketService.GetKLineData(input.Code, tf.period, htfPivotKLines)
+			if err != nil {
+				fmt.Printf("[Tool:get_htf_pivots] %s 错误: %v\n", tf.name, err)
+				return GetHTFPivotsOutput{}, err
+			}
+			basis, ok := htfPivotBasis(klines, tf.period, now)
+			if !ok {
+				sb.WriteString(fmt.Sprintf("【%s】%s\n\n", tf.name, noData("上市时间较短，缺少完整的上一%s", tf.unit)))
+				continue
+			}
+			if price == 0 {
+				price = klines[len(klines)-1].Close
+			}
+
+			p := indicators.ComputeFloorPivots(basis.High, basis.Low, basis.Close)
+			sb.WriteString(fmt.Sprintf("【%s枢轴】基于 %s 所在%s（高%.2f 低%.2f 收%.2f）\n",
+				tf.name, basis.Time, tf.unit, basis.High, basis.Low, basis.Close))
+			sb.WriteString(fmt.Sprintf("R3 %.2f | R2 %.2f | R1 %.2f\n", p.R3, p.R2, p.R1))
+			sb.WriteString(fmt.Sprintf("P  %.2f\n", p.P))
+			sb.WriteString(fmt.Sprintf("S1 %.2f | S2 %.2f | S3 %.2f\n\n", p.S1, p.S2, p.S3))
+
+			levels = append(levels,
+				htfPivotLevel{tf.unit + "R3", p.R3}, htfPivotLevel{tf.unit + "R2", p.R2}, htfPivotLevel{tf.unit + "R1", p.R1},
+				htfPivotLevel{tf.unit + "P", p.P},
+				htfPivotLevel{tf.unit + "S1", p.S1}, htfPivotLevel{tf.unit + "S2", p.S2}, htfPivotLevel{tf.unit + "S3", p.S3},
+			)
+			if price >= p.P {
+				bias = append(bias, tf.name+"P上方（偏多）")
+			} else {
+				bias = append(bias, tf.name+"P下方（偏空）")
+			}
+		}
+		if len(levels) == 0 || price <= 0 {
+			return GetHTFPivotsOutput{Data: noData("%s 周K/月K数据不足，无法计算高周期枢轴点", input.Code)}, nil
+		}
+
+		header := fmt.Sprintf("=== %s 高周期枢轴点 ===\n现价: %.2f\n\n", input.Code, price)
+		sb.WriteString(htfPivotPosition(levels, price))
+		sb.WriteString("多空倾向: 现价位于" + strings.Join(bias, "、") + "\n")
+		sb.WriteString("\n说明: 周线/月线枢轴由上一完整周期的高低收计算，本周期内固定不变；周线与月线价位相近处为强支撑/压力，适合波段和中线参考")
+		return GetHTFPivotsOutput{Data: header + sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_htf_pivots",
+		Description: "基于上一完整周线、月线的高低收计算经典枢轴点（P、R1-R3、S1-S3），返回现价上方最近压力、下方最近支撑及距离，用于波段和中线交易的高周期支撑压力判断",
+	}, handler)
+}
+
+// htfPivotBasis 选取计算枢轴点的上一完整周期K线
+// 最后一根K线属于当前周（周一至周五）或当前月时视为未完成，改用倒数第二根
+func htfPivotBasis(klines []models.KLineData, period string, now time.Time) (models.KLineData, bool) {
+	n := len(klines)
+	if n == 0 || len(klines[n-1].Time) < 10 {
+		return models.KLineData{}, false
+	}
+	last, err := time.ParseInLocation("2006-01-02", klines[n-1].Time[:10], now.Location())
+	if err != nil {
+		return models.KLineData{}, false
+	}
+
+	var inProgress bool
+	switch period {
+	case "1w":
+		ly, lw := last.ISOWeek()
+		ny, nw := now.ISOWeek()
+		inProgress = ly == ny && lw == nw && now.Weekday() != time.Saturday && now.Weekday() != time.Sunday
+	default:
+		inProgress = last.Year() == now.Year() && last.Month() == now.Month()
+	}
+	if !inProgress {
+		return klines[n-1], true
+	}
+	if n < 2 {
+		return models.KLineData{}, false
+	}
+	return klines[n-2], true
+}
+
+// htfPivotPosition 描述现价在周线/月线枢轴价位中的位置：上方最近压力与下方最近支撑
+func htfPivotPosition(levels []htfPivotLevel, price float64) string {
+	sorted := make([]htfPivotLevel, len(levels))
+	copy(sorted, levels)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Price < sorted[j].Price })
+
+	var sb strings.Builder
+	sb.WriteString("【现价位置】\n")
+	idx := sort.Search(len(sorted), func(i int) bool { return sorted[i].Price > price })
+	if idx < len(sorted) {
+		above := sorted[idx]
+		sb.WriteString(fmt.Sprintf("上方最近压力: %s %.2f（+%.2f%%）\n", above.Name, above.Price, (above.Price-price)/price*100))
+	} else {
+		sb.WriteString("上方压力: 现价已突破全部周线/月线压力位\n")
+	}
+	if idx > 0 {
+		below := sorted[idx-1]
+		sb.WriteString(fmt.Sprintf("下方最近支撑: %s %.2f（%.2f%%）\n", below.Name, below.Price, (below.Price-price)/price*100))
+	} else {
+		sb.WriteString("下方支撑: 现价已跌破全部周线/月线支撑位\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// majorIndexCodes 默认展示的宽基指数
+var majorIndexCodes = map[string]bool{
+	"SH000016": true, // 上证50
+	"SH000300": true, // 沪深300
+	"SH000905": true, // 中证500
+	"SH000852": true, // 中证1000
+	"SZ399006": true, // 创业板指
+	"SH000688": true, // 科创50
+	"SH000922": true, // 中证红利
+}
+
+// GetIndexValuationInput 指数估值输入参数
+type GetIndexValuationInput struct {
+	Keyword string `json:"keyword,omitzero" jsonschema:"指数名称或代码关键词，如 沪深300、医药、SH000905；为空返回主要宽基指数"`
+}
+
+// GetIndexValuationOutput 指数估值输出
+type GetIndexValuationOutput struct {
+	Data string `json:"data" jsonschema:"指数PE/PB及历史百分位"`
+}
+
+// createIndexValuationTool 创建指数估值工具
+func (r *Registry) createIndexValuationTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetIndexValuationInput) (GetIndexValuationOutput, error) {
+		if r.indexValuationService == nil {
+			return GetIndexValuationOutput{Data: "指数估值服务不可用"}, nil
+		}
+
+		list, err := r.indexValuationService.GetIndexValuations()
+		if err != nil {
+			fmt.Printf("[Tool:get_index_valuation] 错误: %v\n", err)
+			return GetIndexValuationOutput{}, err
+		}
+
+		keyword := strings.TrimSpace(input.Keyword)
+		matched := make([]services.IndexValuation, 0, len(list))
+		for _, v := range list {
+			if keyword == "" {
+				if majorIndexCodes[v.Code] {
+					matched = append(matched, v)
+				}
+			} else if strings.Contains(v.Name, keyword) || strings.EqualFold(v.Code, keyword) ||
+				strings.HasSuffix(strings.ToUpper(v.Code), strings.ToUpper(keyword)) {
+				matched = append(matched, v)
+			}
+		}
+		if len(matched) == 0 {
+			return GetIndexValuationOutput{Data: noData("未找到与[%s]匹配的指数估值数据", keyword)}, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString("=== 指数估值（历史百分位越低越便宜）===\n")
+		for _, v := range matched {
+			sb.WriteString(fmt.Sprintf("%s(%s) PE:%.2f 分位:%.1f%% | PB:%.2f 分位:%.1f%% | ROE:%.2f%% 股息率:%.2f%% 【%s】\n",
+				v.Name, v.Code, v.PE, v.PEPercentile, v.PB, v.PBPercentile, v.ROE, v.DividendYield, v.Level))
+		}
+		if matched[0].Date != "" {
+			sb.WriteString(fmt.Sprintf("\n数据日期: %s\n", matched[0].Date))
+		}
+
+		return GetIndexValuationOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_index_valuation",
+		Description: "获取主要指数当前PE/PB及其历史百分位和估值水平（低估/适中/高估），用于判断大盘或行业指数的估值高低",
+	}, handler)
+}
@@ -0,0 +1,72 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// GetIndustryChainInput 产业链查询输入参数
+type GetIndustryChainInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh600519 或 600519"`
+}
+
+// GetIndustryChainOutput 产业链查询输出
+type GetIndustryChainOutput struct {
+	Data string `json:"data" jsonschema:"所属行业的产业链位置、上下游及主要产品"`
+}
+
+// createIndustryChainTool 创建产业链上下游查询工具
+func (r *Registry) createIndustryChainTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetIndustryChainInput) (GetIndustryChainOutput, error) {
+		if input.Code == "" {
+			return GetIndustryChainOutput{Data: "请提供股票代码"}, nil
+		}
+		if r.configService == nil {
+			return GetIndustryChainOutput{}, fmt.Errorf("配置服务未初始化")
+		}
+
+		// 统一为带前缀代码
+		code := strings.ToLower(input.Code)
+		if len(code) == 6 {
+			if info := r.configService.GetStockBasicInfo(code); info != nil {
+				code = info.Symbol
+			}
+		}
+
+		industry := r.getStockIndustry(code)
+		if industry == "" {
+			return GetIndustryChainOutput{Data: noData("未找到 %s 的行业信息", input.Code)}, nil
+		}
+
+		chain := services.GetIndustryChain(industry)
+		if chain == nil {
+			return GetIndustryChainOutput{Data: noData("%s 所属行业: %s（暂未收录该行业的产业链数据）", input.Code, industry)}, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 产业链位置 ===\n", input.Code))
+		sb.WriteString(fmt.Sprintf("所属行业: %s | 产业链: %s | 位置: %s\n", industry, chain.Chain, chain.Position))
+		sb.WriteString(fmt.Sprintf("行业主要产品: %s\n", strings.Join(chain.Products, "、")))
+		sb.WriteString(fmt.Sprintf("上游: %s\n", strings.Join(chain.Upstream, "、")))
+		sb.WriteString(fmt.Sprintf("下游: %s\n", strings.Join(chain.Downstream, "、")))
+		if len(chain.UpstreamIndustries) > 0 {
+			sb.WriteString(fmt.Sprintf("上游相关A股行业: %s\n", strings.Join(chain.UpstreamIndustries, "、")))
+		}
+		if len(chain.DownstreamIndustries) > 0 {
+			sb.WriteString(fmt.Sprintf("下游相关A股行业: %s\n", strings.Join(chain.DownstreamIndustries, "、")))
+		}
+		sb.WriteString("\n注: 产业链映射为行业层面的通用归纳，个股具体业务以公司公告为准")
+
+		return GetIndustryChainOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_industry_chain",
+		Description: "获取个股所属行业在产业链中的位置、上下游环节、主要产品及上下游对应的A股行业，用于追踪政策在产业链上的传导",
+	}, handler)
+}
@@ -0,0 +1,155 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// industryRankBatchSize 单次实时行情请求的股票数
+const industryRankBatchSize = 80
+
+// GetIndustryRankInput 行业涨跌幅排名输入参数
+type GetIndustryRankInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh600519 或 600519"`
+	TopN int    `json:"topN,omitzero" jsonschema:"列出领涨/领跌股数量，默认5，最大10"`
+}
+
+// GetIndustryRankOutput 行业涨跌幅排名输出
+type GetIndustryRankOutput struct {
+	Data string `json:"data" jsonschema:"个股在行业内的当日涨跌幅排名及领涨领跌股"`
+}
+
+// createIndustryRankTool 创建同行业涨跌幅排名工具
+func (r *Registry) createIndustryRankTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetIndustryRankInput) (GetIndustryRankOutput, error) {
+		if input.Code == "" {
+			return GetIndustryRankOutput{Data: "请提供股票代码"}, nil
+		}
+		if r.configService == nil {
+			return GetIndustryRankOutput{}, fmt.Errorf("行业排名服务未初始化")
+		}
+
+		topN := input.TopN
+		if topN <= 0 {
+			topN = 5
+		}
+		if topN > 10 {
+			topN = 10
+		}
+
+		// 统一为带前缀代码
+		code := strings.ToLower(input.Code)
+		if len(code) == 6 {
+			if info := r.configService.GetStockBasicInfo(code); info != nil {
+				code = info.Symbol
+			}
+		}
+
+		industry := r.getStockIndustry(code)
+		if industry == "" {
+			return GetIndustryRankOutput{Data: noData("未找到 %s 的行业信息，无法计算行业排名", input.Code)}, nil
+		}
+		members := r.configService.GetStocksByIndustry(industry)
+		if len(members) == 0 {
+			return GetIndustryRankOutput{Data: noData("未找到行业[%s]的成分股", industry)}, nil
+		}
+
+		codes := make([]string, 0, len(members))
+		for _, m := range members {
+			codes = append(codes, m.Symbol)
+		}
+		quotes, err := r.fetchQuotesInBatches(codes)
+		if err != nil {
+			fmt.Printf("[Tool:get_industry_rank] 错误: %v\n", err)
+			return GetIndustryRankOutput{}, err
+		}
+
+		rank := services.RankIndustryPeers(industry, code, quotes, topN)
+		if rank.Total == 0 {
+			return GetIndustryRankOutput{Data: noData("行业[%s]暂无有效行情数据", industry)}, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 行业涨跌幅排名（行业: %s，有效%d家） ===\n", input.Code, industry, rank.Total))
+		sb.WriteString(fmt.Sprintf("行业平均涨跌幅: %.2f%%，上涨%d家，下跌%d家\n\n", rank.AvgChange, rank.UpCount, rank.DownCount))
+
+		sb.WriteString("【目标定位】\n")
+		switch {
+		case rank.Target != nil && rank.Target.Suspended:
+			sb.WriteString(fmt.Sprintf("%s(%s) 今日停牌，不参与排名\n", rank.Target.Name, rank.Target.Symbol))
+		case rank.Rank == 0:
+			sb.WriteString("目标股票未取得有效行情\n")
+		default:
+			t := rank.Target
+			sb.WriteString(fmt.Sprintf("%s(%s) 涨跌幅 %.2f%%，行业排名 %d/%d，%s\n",
+				t.Name, t.Symbol, t.ChangePercent, rank.Rank, rank.Total, industryRankPosition(rank.Rank, rank.Total)))
+			sb.WriteString(fmt.Sprintf("相对行业均值: %+.2f%%\n", t.ChangePercent-rank.AvgChange))
+		}
+
+		sb.WriteString("\n【领涨】\n")
+		writeRankList(&sb, rank.Leaders, code)
+		sb.WriteString("\n【领跌】\n")
+		writeRankList(&sb, rank.Laggards, code)
+
+		return GetIndustryRankOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_industry_rank",
+		Description: "获取个股在所属行业内的当日涨跌幅排名、行业平均涨跌幅及领涨领跌股，判断个股是领涨还是拖后腿",
+	}, handler)
+}
+
+// fetchQuotesInBatches 分批获取实时行情，部分批次失败时返回已取得的数据
+func (r *Registry) fetchQuotesInBatches(codes []string) ([]models.Stock, error) {
+	var quotes []models.Stock
+	for start := 0; start < len(codes); start += industryRankBatchSize {
+		end := start + industryRankBatchSize
+		if end > len(codes) {
+			end = len(codes)
+		}
+		stocks, err := r.marketService.GetStockRealTimeData(codes[start:end]...)
+		if err != nil {
+			if len(quotes) > 0 {
+				break
+			}
+			return nil, err
+		}
+		quotes = append(quotes, stocks...)
+	}
+	return quotes, nil
+}
+
+// industryRankPosition 排名位置描述
+func industryRankPosition(rank, total int) string {
+	pct := float64(rank) / float64(total)
+	switch {
+	case rank <= 3 || pct <= 0.1:
+		return "行业领涨"
+	case pct <= 0.3:
+		return "强于行业"
+	case pct <= 0.7:
+		return "与行业同步"
+	case pct <= 0.9:
+		return "弱于行业"
+	default:
+		return "行业垫底"
+	}
+}
+
+// writeRankList 输出排名列表，目标股票加★标记
+func writeRankList(sb *strings.Builder, stocks []models.Stock, target string) {
+	for _, s := range stocks {
+		mark := ""
+		if s.Symbol == target {
+			mark = "★"
+		}
+		sb.WriteString(fmt.Sprintf("%s%s(%s) %.2f%% 现价%.2f\n", mark, s.Name, s.Symbol, s.ChangePercent, s.Price))
+	}
+}
@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"fmt"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// GetInsiderTradingInput 增减持输入参数
+type GetInsiderTradingInput struct {
+	Code  string `json:"code" jsonschema:"股票代码，如600519或sh600519"`
+	Limit int    `json:"limit,omitzero" jsonschema:"返回条数，默认15条"`
+}
+
+// GetInsiderTradingOutput 增减持输出
+type GetInsiderTradingOutput struct {
+	Data string `json:"data" jsonschema:"大股东及董监高增减持记录和汇总"`
+}
+
+// createInsiderTradingTool 创建大股东/董监高增减持工具
+func (r *Registry) createInsiderTradingTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetInsiderTradingInput) (GetInsiderTradingOutput, error) {
+		if r.shareholderService == nil {
+			return GetInsiderTradingOutput{Data: "股东数据服务不可用"}, nil
+		}
+		if input.Code == "" {
+			return GetInsiderTradingOutput{}, fmt.Errorf("股票代码不能为空")
+		}
+
+		trades, err := r.shareholderService.GetInsiderTrades(input.Code)
+		if err != nil {
+			fmt.Printf("[Tool:get_insider_trading] 错误: %v\n", err)
+			return GetInsiderTradingOutput{}, err
+		}
+		if len(trades) == 0 {
+			return GetInsiderTradingOutput{Data: noData("%s 近期无大股东/董监高增减持记录", input.Code)}, nil
+		}
+
+		limit := input.Limit
+		if limit <= 0 {
+			limit = 15
+		}
+		if limit > len(trades) {
+			limit = len(trades)
+		}
+
+		result := fmt.Sprintf("=== %s 大股东/董监高增减持 ===\n", input.Code)
+		var increaseShares, decreaseShares float64
+		var increaseCount, decreaseCount int
+		for i, t := range trades[:limit] {
+			who := t.Person
+			if t.Position != "" {
+				who += "(" + t.Position + ")"
+			}
+			result += fmt.Sprintf("%d. [%s] %s %s %s %.2f万股", i+1, t.Date, t.Category, who, t.Direction, t.Shares/10000)
+			if t.AvgPrice > 0 {
+				result += fmt.Sprintf(" 均价:%.2f", t.AvgPrice)
+			}
+			if t.Ratio != 0 {
+				result += fmt.Sprintf(" 占总股本:%.2f%%", t.Ratio)
+			}
+			if t.HoldAfter > 0 {
+				result += fmt.Sprintf(" 变动后持股:%.2f%%", t.HoldAfter)
+			}
+			result += "\n"
+
+			if t.Direction == "减持" {
+				decreaseShares += t.Shares
+				decreaseCount++
+			} else {
+				increaseShares += t.Shares
+				increaseCount++
+			}
+		}
+
+		result += fmt.Sprintf("\n【汇总】增持%d笔共%.2f万股，减持%d笔共%.2f万股\n",
+			increaseCount, increaseShares/10000, decreaseCount, decreaseShares/10000)
+
+		return GetInsiderTradingOutput{Data: result}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_insider_trading",
+		Description: "获取个股近期大股东及董监高增持/减持记录，包括人员、方向、股数、均价和日期，用于判断内部人态度",
+	}, handler)
+}
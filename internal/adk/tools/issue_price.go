@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// GetIssuePriceInput 增发价位置输入参数
+type GetIssuePriceInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh600519"`
+}
+
+// GetIssuePriceOutput 增发价位置输出
+type GetIssuePriceOutput struct {
+	Data string `json:"data" jsonschema:"最近一次增发/配股的发行价、发行日期及现价相对发行价的溢折价"`
+}
+
+// createIssuePriceTool 创建增发/配股价位置工具
+func (r *Registry) createIssuePriceTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetIssuePriceInput) (GetIssuePriceOutput, error) {
+		fmt.Printf("[Tool:get_issue_price] 调用开始, code=%s\n", input.Code)
+
+		if r.shareholderService == nil {
+			return GetIssuePriceOutput{Data: "股东数据服务不可用"}, nil
+		}
+		if input.Code == "" {
+			return GetIssuePriceOutput{}, fmt.Errorf("股票代码不能为空")
+		}
+
+		issue, err := r.shareholderService.GetRecentIssuePrice(input.Code)
+		if err != nil {
+			fmt.Printf("[Tool:get_issue_price] 错误: %v\n", err)
+			return GetIssuePriceOutput{}, err
+		}
+		if issue == nil {
+			return GetIssuePriceOutput{Data: noData("%s 近3年无增发或配股，不存在增发价参考位", input.Code)}, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 最近一次%s ===\n", input.Code, issue.Type))
+		if issue.Method != "" {
+			sb.WriteString(fmt.Sprintf("发行方式: %s\n", issue.Method))
+		}
+		sb.WriteString(fmt.Sprintf("发行价: %.2f元\n", issue.Price))
+		if issue.Shares > 0 {
+			sb.WriteString(fmt.Sprintf("发行数量: %.0f万股\n", issue.Shares/10000))
+		}
+		sb.WriteString(fmt.Sprintf("发行日期: %s\n", issue.IssueDate))
+		if issue.ListingDate != "" {
+			sb.WriteString(fmt.Sprintf("新增股份上市日: %s\n", issue.ListingDate))
+		}
+
+		stocks, err := r.marketService.GetStockRealTimeData(input.Code)
+		if err != nil || len(stocks) == 0 || stocks[0].Price <= 0 {
+			sb.WriteString("现价: 行情不可用，无法计算溢折价")
+			return GetIssuePriceOutput{Data: sb.String()}, nil
+		}
+		price := stocks[0].Price
+		premium, zone := services.IssuePriceZone(price, issue.Price)
+		sb.WriteString(fmt.Sprintf("现价: %.2f元，相对发行价: %+.2f%%（%s）\n", price, premium, zone))
+		switch zone {
+		case services.IssueZoneBelow:
+			sb.WriteString("解读: 参与方已浮亏，锁定期内有护盘动机，解禁后则可能止损离场\n")
+		case services.IssueZoneNear:
+			sb.WriteString("解读: 逼近发行价，参与机构成本线附近常有承接，可作为心理支撑位观察\n")
+		default:
+			sb.WriteString("解读: 参与方浮盈较厚，解禁后存在兑现压力\n")
+		}
+		sb.WriteString("说明: 发行价未做除权调整，期间有分红送转时实际成本略低")
+
+		return GetIssuePriceOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_issue_price",
+		Description: "获取个股最近3年内最近一次定增/公开增发或配股的发行价，计算现价相对发行价的溢折价，判断是否逼近或跌破参与机构的成本线",
+	}, handler)
+}
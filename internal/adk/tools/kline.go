@@ -2,8 +2,10 @@ package tools
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/run-bigpig/jcp/internal/indicators"
+	"github.com/run-bigpig/jcp/internal/models"
 	"github.com/run-bigpig/jcp/internal/services"
 
 	"google.golang.org/adk/tool"
@@ -16,8 +18,16 @@ type GetKLineInput struct {
 	Period string `json:"period,omitempty" jsonschema:"K线周期: 1m(5分钟), 1d(日线), 1w(周线), 1mo(月线)，默认1d"`
 	Days   int    `json:"days,omitzero" jsonschema:"K线根数，不传则按周期自动设置合理默认值"`
 	Mode   string `json:"mode,omitempty" jsonschema:"输出模式: raw(原始OHLCV,默认), analysis(含完整技术指标，仅日线有效)"`
+	// analysis 模式输出的时序天数
+	OutputDays int `json:"outputDays,omitzero" jsonschema:"analysis模式输出的时序天数，默认30，最大90"`
 }
 
+// analysis 模式输出窗口
+const (
+	defaultAnalysisOutputDays = 30
+	maxAnalysisOutputDays     = 90
+)
+
 // GetKLineOutput K线数据输出
 type GetKLineOutput struct {
 	Data string `json:"data" jsonschema:"K线数据"`
@@ -38,7 +48,7 @@ func (r *Registry) createKLineTool() (tool.Tool, error) {
 
 		// analysis 模式：日线 + 完整技术指标
 		if input.Mode == "analysis" && period == "1d" {
-			return r.handleAnalysisMode(input.Code)
+			return r.handleAnalysisMode(input.Code, input.OutputDays)
 		}
 
 		// raw 模式（默认）：原始 OHLCV
@@ -54,6 +64,10 @@ func (r *Registry) createKLineTool() (tool.Tool, error) {
 			return GetKLineOutput{}, err
 		}
 
+		if len(klines) == 0 {
+			return GetKLineOutput{Data: noData("%s 暂无%s K线数据", input.Code, period)}, nil
+		}
+
 		// 格式化输出（按周期截断避免过长）
 		var result string
 		start := 0
@@ -70,7 +84,7 @@ func (r *Registry) createKLineTool() (tool.Tool, error) {
 
 	return functiontool.New(functiontool.Config{
 		Name:        "get_kline_data",
-		Description: "获取股票K线数据，支持5分钟线、日线、周线、月线。设置mode=analysis可获取含MACD/KDJ/BOLL/DMI等完整技术指标的分析数据（仅日线有效）",
+		Description: "获取股票K线数据，支持5分钟线、日线、周线、月线。设置mode=analysis可获取含MACD/KDJ/BOLL/DMI等完整技术指标的分析数据（仅日线有效），outputDays可调整分析时序天数（默认30，最大90）",
 	}, handler)
 }
 
@@ -89,51 +103,89 @@ func periodDefaults(period string) (defaultDatalen, maxOutput int) {
 }
 
 // handleAnalysisMode 处理 analysis 模式
-func (r *Registry) handleAnalysisMode(code string) (GetKLineOutput, error) {
-	// 获取 250 根日K（为 EMA/MACD/ADX 等递推型指标提供充足预热期）
-	klines, err := r.marketService.GetKLineData(code, "1d", 250)
+func (r *Registry) handleAnalysisMode(code string, outputDays int) (GetKLineOutput, error) {
+	analysis, err := r.ComputeTechnicalAnalysisWithDays(code, outputDays)
 	if err != nil {
-		fmt.Printf("[Tool:get_kline_data:analysis] K线获取错误: %v\n", err)
 		return GetKLineOutput{}, err
 	}
+	if len(analysis.Series) == 0 {
+		return GetKLineOutput{Data: noData("%s 日K数据不足，无法计算技术指标", code)}, nil
+	}
 
-	// 获取流通股本（非ETF），用于计算换手率和成交额
-	var floatShares float64
-	if !services.IsETF(code) && r.stockInfoService != nil {
-		info, err := r.stockInfoService.GetExtendedInfo(code)
-		if err == nil && info.FloatMarketCap > 0 && len(klines) > 0 {
-			lastClose := klines[len(klines)-1].Close
-			if lastClose > 0 {
-				floatShares = info.FloatMarketCap / lastClose
-			}
-		}
+	// 格式化输出
+	result := indicators.FormatFullAnalysis(analysis)
+	return GetKLineOutput{Data: result}, nil
+}
+
+// ComputeTechnicalAnalysis 计算个股完整技术分析（含外部快照数据），输出默认30日时序
+func (r *Registry) ComputeTechnicalAnalysis(code string) (*indicators.FullAnalysis, error) {
+	return r.ComputeTechnicalAnalysisWithDays(code, defaultAnalysisOutputDays)
+}
+
+// ComputeTechnicalAnalysisWithDays 计算个股完整技术分析，outputDays 为输出时序天数
+// outputDays<=0 使用默认30日，超过90日按90日截断
+func (r *Registry) ComputeTechnicalAnalysisWithDays(code string, outputDays int) (*indicators.FullAnalysis, error) {
+	if outputDays <= 0 {
+		outputDays = defaultAnalysisOutputDays
+	}
+	if outputDays > maxAnalysisOutputDays {
+		outputDays = maxAnalysisOutputDays
 	}
 
-	// 补算成交额（新浪K线API不返回amount）和换手率序列
-	var turnoverRates []float64
-	if floatShares > 0 {
-		turnoverRates = make([]float64, len(klines))
+	// 指数使用新浪简化代码 s_sh000001 时去掉前缀，K线接口只认 sh000001
+	if services.IsIndex(code) {
+		code = strings.TrimPrefix(strings.ToLower(code), "s_")
 	}
+
+	// 获取 250 根日K（为 EMA/MACD/ADX 等递推型指标提供充足预热期，与输出窗口无关）
+	klines, err := r.marketService.GetKLineData(code, "1d", 250)
+	if err != nil {
+		fmt.Printf("[Tool:get_kline_data:analysis] K线获取错误: %v\n", err)
+		return nil, err
+	}
+
+	// 换手率序列（ETF、指数或无流通市值时为 nil）
+	turnoverRates := r.computeTurnoverRates(code, klines)
+
+	// 补算成交额（新浪K线API不返回amount）
 	for i := range klines {
 		// amount ≈ (open+close)/2 * volume（用均价近似）
 		if klines[i].Amount == 0 && klines[i].Volume > 0 {
 			avgPrice := (klines[i].Open + klines[i].Close) / 2
 			klines[i].Amount = avgPrice * float64(klines[i].Volume)
 		}
-		if floatShares > 0 {
-			turnoverRates[i] = float64(klines[i].Volume) / floatShares * 100
-		}
 	}
 
 	// 计算全部技术指标
-	analysis := indicators.ComputeAll(klines, 30, turnoverRates)
+	analysis := indicators.ComputeAll(klines, outputDays, turnoverRates)
 
 	// 填充外部数据到 snapshot
 	r.fillSnapshotExternalData(code, analysis)
 
-	// 格式化输出
-	result := indicators.FormatFullAnalysis(analysis)
-	return GetKLineOutput{Data: result}, nil
+	return analysis, nil
+}
+
+// computeTurnoverRates 根据流通股本计算与 klines 等长的换手率序列(%)
+// 流通股本由流通市值 / 最新收盘价估算；ETF、指数或获取失败时返回 nil
+func (r *Registry) computeTurnoverRates(code string, klines []models.KLineData) []float64 {
+	if services.IsETF(code) || services.IsIndex(code) || r.stockInfoService == nil || len(klines) == 0 {
+		return nil
+	}
+	info, err := r.stockInfoService.GetExtendedInfo(code)
+	if err != nil || info.FloatMarketCap <= 0 {
+		return nil
+	}
+	lastClose := klines[len(klines)-1].Close
+	if lastClose <= 0 {
+		return nil
+	}
+	floatShares := info.FloatMarketCap / lastClose
+
+	rates := make([]float64, len(klines))
+	for i := range klines {
+		rates[i] = float64(klines[i].Volume) / floatShares * 100
+	}
+	return rates
 }
 
 // fillSnapshotExternalData 填充快照的外部数据
@@ -142,10 +194,11 @@ func (r *Registry) fillSnapshotExternalData(code string, analysis *indicators.Fu
 		return
 	}
 
-	isETF := services.IsETF(code)
+	// ETF 和指数没有流通市值、所属板块，只保留纯价格指标和大盘数据
+	isStock := !services.IsETF(code) && !services.IsIndex(code)
 
-	// 流通市值/流通股本（非ETF）
-	if !isETF && r.stockInfoService != nil {
+	// 流通市值/流通股本（个股）
+	if isStock && r.stockInfoService != nil {
 		info, err := r.stockInfoService.GetExtendedInfo(code)
 		if err == nil {
 			analysis.Snapshot.FloatCap = indicators.FormatMarketCap(info.FloatMarketCap)
@@ -162,8 +215,8 @@ func (r *Registry) fillSnapshotExternalData(code string, analysis *indicators.Fu
 		}
 	}
 
-	// 板块/概念（非ETF）
-	if !isETF && r.sectorService != nil {
+	// 板块/概念（个股）
+	if isStock && r.sectorService != nil {
 		sectorData, err := r.sectorService.GetStockSectors(r.getStockIndustry(code))
 		if err == nil && sectorData != nil {
 			analysis.Snapshot.Sector = fmt.Sprintf("%s %.2f%%",
@@ -2,6 +2,7 @@ package tools
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/run-bigpig/jcp/internal/logger"
 
@@ -46,6 +47,10 @@ func (r *Registry) createLongHuBangTool() (tool.Tool, error) {
 			return GetLongHuBangOutput{}, err
 		}
 
+		if len(listResult.Items) == 0 {
+			return GetLongHuBangOutput{Data: noData("该交易日暂无龙虎榜数据（非交易日或尚未公布）")}, nil
+		}
+
 		var result string
 		for i, item := range listResult.Items {
 			// 格式化金额为万元
@@ -102,7 +107,7 @@ func (r *Registry) createLongHuBangDetailTool() (tool.Tool, error) {
 		}
 
 		if len(details) == 0 {
-			return GetLongHuBangDetailOutput{Data: "未找到该股票的龙虎榜营业部数据"}, nil
+			return GetLongHuBangDetailOutput{Data: noData("未找到该股票的龙虎榜营业部数据")}, nil
 		}
 
 		var result string
@@ -138,3 +143,58 @@ func (r *Registry) createLongHuBangDetailTool() (tool.Tool, error) {
 		Description: "获取个股龙虎榜营业部买卖明细，需要提供股票代码和交易日期",
 	}, handler)
 }
+
+// GetLongHuBangRankInput 龙虎榜净买入排名输入
+type GetLongHuBangRankInput struct {
+	Code      string `json:"code" jsonschema:"股票代码，如600477"`
+	TradeDate string `json:"trade_date,omitzero" jsonschema:"交易日期，格式YYYY-MM-DD，为空则取最近一个龙虎榜交易日"`
+}
+
+// GetLongHuBangRankOutput 龙虎榜净买入排名输出
+type GetLongHuBangRankOutput struct {
+	Data string `json:"data" jsonschema:"个股在当日上榜股中的净买入排名及机构/游资主导判断"`
+}
+
+// createLongHuBangRankTool 创建龙虎榜净买入排名工具
+func (r *Registry) createLongHuBangRankTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetLongHuBangRankInput) (GetLongHuBangRankOutput, error) {
+		lhbLog.Debug("净买入排名开始, code=%s, date=%s", input.Code, input.TradeDate)
+
+		if input.Code == "" {
+			return GetLongHuBangRankOutput{}, fmt.Errorf("股票代码不能为空")
+		}
+
+		rank, err := r.longHuBangService.GetNetBuyRank(input.Code, input.TradeDate)
+		if err != nil {
+			lhbLog.Error("获取龙虎榜排名失败: %v", err)
+			return GetLongHuBangRankOutput{}, err
+		}
+		if rank.Item == nil {
+			return GetLongHuBangRankOutput{Data: fmt.Sprintf("%s 未登上 %s 龙虎榜（当日共%d只股票上榜）", input.Code, rank.TradeDate, rank.Total)}, nil
+		}
+
+		item := rank.Item
+		var result string
+		result += fmt.Sprintf("=== %s(%s) 龙虎榜排名 (%s) ===\n\n", item.Name, item.Code, rank.TradeDate)
+		result += fmt.Sprintf("净买入排名: 第%d / %d 只\n", rank.Rank, rank.Total)
+		result += fmt.Sprintf("净买:%.0f万 买入:%.0f万 卖出:%.0f万 净买占成交:%.2f%%\n",
+			item.NetBuyAmt/10000, item.BuyAmt/10000, item.SellAmt/10000, item.NetRatio)
+		result += fmt.Sprintf("涨跌:%.2f%% 换手:%.2f%% 原因:%s\n", item.ChangePercent, item.TurnoverRate, item.Reason)
+
+		result += fmt.Sprintf("\n【买方构成】%s\n", rank.Driver)
+		result += fmt.Sprintf("机构席位(含沪深股通): %.0f万\n", rank.InstBuy/10000)
+		result += fmt.Sprintf("知名游资: %.0f万", rank.HotMoneyBuy/10000)
+		if len(rank.HotMoneySeats) > 0 {
+			result += " (" + strings.Join(rank.HotMoneySeats, "、") + ")"
+		}
+		result += fmt.Sprintf("\n其他营业部: %.0f万\n", rank.OtherBuy/10000)
+
+		lhbLog.Debug("净买入排名完成, rank=%d/%d", rank.Rank, rank.Total)
+		return GetLongHuBangRankOutput{Data: result}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_longhubang_rank",
+		Description: "获取个股在当日全部龙虎榜上榜股中的净买入排名，并根据营业部明细判断是机构主导还是游资主导，交易日期为空时取最近一个龙虎榜交易日",
+	}, handler)
+}
@@ -0,0 +1,116 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/indicators"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+const (
+	// maConfluenceDefaultTolerance 默认均线聚集容差(%)
+	maConfluenceDefaultTolerance = 1.5
+	// maConfluenceMinTolerance 最小均线聚集容差(%)
+	maConfluenceMinTolerance = 0.5
+	// maConfluenceMaxTolerance 最大均线聚集容差(%)，过大时密集区失去意义
+	maConfluenceMaxTolerance = 5
+	// maConfluenceKLines 获取的日K根数，需覆盖MA250
+	maConfluenceKLines = 260
+	// maConfluenceNearPercent 密集区距现价不超过该百分比视为价格正在其中博弈
+	maConfluenceNearPercent = 1
+)
+
+// GetMAConfluenceInput 均线密集区输入参数
+type GetMAConfluenceInput struct {
+	Code      string  `json:"code" jsonschema:"股票代码，如 sh600519"`
+	Tolerance float64 `json:"tolerance,omitempty" jsonschema:"均线聚集容差(%)，两条均线相差不超过该值视为聚集，默认1.5，范围0.5-5"`
+}
+
+// GetMAConfluenceOutput 均线密集区输出
+type GetMAConfluenceOutput struct {
+	Data string `json:"data" jsonschema:"MA5/10/20/60/120/250最新值及距现价百分比、均线密集区及其支撑/压力属性"`
+}
+
+// createMAConfluenceTool 创建均线密集区工具
+func (r *Registry) createMAConfluenceTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetMAConfluenceInput) (GetMAConfluenceOutput, error) {
+		fmt.Printf("[Tool:get_ma_confluence] 调用开始, code=%s, tolerance=%.2f\n", input.Code, input.Tolerance)
+
+		if input.Code == "" {
+			return GetMAConfluenceOutput{Data: "请提供股票代码"}, nil
+		}
+		tolerance := input.Tolerance
+		if tolerance <= 0 {
+			tolerance = maConfluenceDefaultTolerance
+		}
+		tolerance = max(maConfluenceMinTolerance, min(tolerance, maConfluenceMaxTolerance))
+
+		klines, err := r.marketService.GetKLineData(input.Code, "1d", maConfluenceKLines)
+		if err != nil {
+			fmt.Printf("[Tool:get_ma_confluence] 错误: %v\n", err)
+			return GetMAConfluenceOutput{}, err
+		}
+		closes := make([]float64, len(klines))
+		for i, k := range klines {
+			closes[i] = k.Close
+		}
+
+		levels, zones := indicators.ComputeMAConfluence(closes, indicators.ConfluencePeriods, tolerance)
+		if len(levels) < 2 {
+			return GetMAConfluenceOutput{Data: noData("%s 日K数据不足（%d根），无法计算均线密集区", input.Code, len(klines))}, nil
+		}
+		last := closes[len(closes)-1]
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 均线密集区（%s，容差%.1f%%）===\n", input.Code, klines[len(klines)-1].Time, tolerance))
+		sb.WriteString(fmt.Sprintf("最新收盘: %.2f\n", last))
+		sb.WriteString("\n【均线】\n均线,价格,距现价\n")
+		for _, l := range levels {
+			sb.WriteString(fmt.Sprintf("MA%d,%.2f,%+.2f%%\n", l.Period, l.Value, (l.Value-last)/last*100))
+		}
+		if len(levels) < len(indicators.ConfluencePeriods) {
+			sb.WriteString("注: 上市时间较短，长周期均线数据不足已跳过\n")
+		}
+
+		sb.WriteString("\n【密集区】\n")
+		if len(zones) == 0 {
+			sb.WriteString(fmt.Sprintf("各均线相互间距均超过%.1f%%，无明显密集区，均线呈发散排列\n", tolerance))
+		}
+		for _, z := range zones {
+			sb.WriteString(fmt.Sprintf("%.2f-%.2f（%s，中心距现价%+.2f%%）: %s\n",
+				z.Low, z.High, maPeriodNames(z.Periods), z.Distance, maConfluenceRole(z, last)))
+		}
+
+		sb.WriteString("\n说明: 聚集的均线越多、周期越长，支撑/压力越强；价格有效跌破下方密集区后，该区域转为压力")
+		return GetMAConfluenceOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_ma_confluence",
+		Description: "计算MA5/10/20/60/120/250最新值，找出多条均线相互靠近形成的密集区，返回各密集区价格范围、距现价百分比及支撑/压力属性，用于确定客观的均线支撑压力位",
+	}, handler)
+}
+
+// maPeriodNames 拼接均线名称，如 MA20/MA60
+func maPeriodNames(periods []int) string {
+	names := make([]string, len(periods))
+	for i, p := range periods {
+		names[i] = fmt.Sprintf("MA%d", p)
+	}
+	return strings.Join(names, "/")
+}
+
+// maConfluenceRole 根据密集区与现价的相对位置判断其支撑/压力属性
+func maConfluenceRole(z indicators.MAConfluenceZone, last float64) string {
+	switch {
+	case z.Low <= last && last <= z.High, z.Distance >= -maConfluenceNearPercent && z.Distance <= maConfluenceNearPercent:
+		return "价格正处于密集区内，多空在此博弈，突破方向决定后续走势"
+	case z.Distance < 0:
+		return fmt.Sprintf("下方支撑区（%d线聚集）", len(z.Periods))
+	default:
+		return fmt.Sprintf("上方压力区（%d线聚集）", len(z.Periods))
+	}
+}
@@ -28,6 +28,10 @@ func (r *Registry) createMarketBreadthTool() (tool.Tool, error) {
 			return GetMarketBreadthOutput{}, err
 		}
 
+		if breadth.TotalCount == 0 {
+			return GetMarketBreadthOutput{Data: noData("暂未获取到全市场涨跌家数统计")}, nil
+		}
+
 		result := fmt.Sprintf(
 			"全市场: 上涨%d家 下跌%d家 平盘%d家 | 涨停%d家 跌停%d家 | 共%d家",
 			breadth.AdvanceCount, breadth.DeclineCount, breadth.FlatCount,
@@ -0,0 +1,106 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// GetMoneyFlowLevelsInput 主力资金价格区间输入参数
+type GetMoneyFlowLevelsInput struct {
+	Code  string `json:"code" jsonschema:"股票代码，如 sh600519 或 600519"`
+	Days  int    `json:"days,omitzero" jsonschema:"统计最近N个交易日，默认60，最大120"`
+	Zones int    `json:"zones,omitzero" jsonschema:"价格区间划分数量，默认8，最大20"`
+}
+
+// GetMoneyFlowLevelsOutput 主力资金价格区间输出
+type GetMoneyFlowLevelsOutput struct {
+	Data string `json:"data" jsonschema:"按价格区间汇总的主力净流入、估算主力成本及净流入最多的价格区间"`
+}
+
+// createMoneyFlowPriceTool 创建主力资金价格区间估算工具
+func (r *Registry) createMoneyFlowPriceTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetMoneyFlowLevelsInput) (GetMoneyFlowLevelsOutput, error) {
+		if r.moneyFlowService == nil {
+			return GetMoneyFlowLevelsOutput{Data: "资金流向服务不可用"}, nil
+		}
+		if input.Code == "" {
+			return GetMoneyFlowLevelsOutput{Data: "请提供股票代码"}, nil
+		}
+
+		days := input.Days
+		if days <= 0 {
+			days = 60
+		}
+		if days > 120 {
+			days = 120
+		}
+		zones := input.Zones
+		if zones <= 0 {
+			zones = 8
+		}
+		if zones > 20 {
+			zones = 20
+		}
+
+		// 统一为带前缀代码
+		code := strings.ToLower(input.Code)
+		if len(code) == 6 && r.configService != nil {
+			if info := r.configService.GetStockBasicInfo(code); info != nil {
+				code = info.Symbol
+			}
+		}
+
+		flows, err := r.moneyFlowService.GetDailyMoneyFlow(code)
+		if err != nil {
+			fmt.Printf("[Tool:get_money_flow_levels] 错误: %v\n", err)
+			return GetMoneyFlowLevelsOutput{}, err
+		}
+		if len(flows) == 0 {
+			return GetMoneyFlowLevelsOutput{Data: noData("%s 暂无资金流向数据", input.Code)}, nil
+		}
+		if len(flows) > days {
+			flows = flows[len(flows)-days:]
+		}
+
+		levels := services.ComputeMoneyFlowLevels(flows, zones, 3)
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 主力资金价格区间（估算，%s 至 %s 共%d个交易日） ===\n",
+			input.Code, flows[0].Date, flows[len(flows)-1].Date, len(flows)))
+		sb.WriteString("说明: 数据源仅提供日级主力(超大单+大单)净流入，每日资金整体按收盘价归入区间，非逐笔成交统计，仅供参考\n")
+		sb.WriteString(fmt.Sprintf("统计期主力累计净流入: %.2f亿\n", levels.TotalMainNet/1e8))
+		if levels.CostEstimate > 0 {
+			latest := flows[len(flows)-1].Close
+			sb.WriteString(fmt.Sprintf("估算主力成本(净流入日加权): %.2f，最新收盘%.2f，偏离%+.2f%%\n",
+				levels.CostEstimate, latest, (latest-levels.CostEstimate)/levels.CostEstimate*100))
+		}
+
+		sb.WriteString("\n【主力净流入最多的价格区间】\n")
+		if len(levels.TopInflow) == 0 {
+			sb.WriteString("统计期内无净流入为正的价格区间，主力以流出为主\n")
+		}
+		for i, z := range levels.TopInflow {
+			sb.WriteString(fmt.Sprintf("%d. %.2f-%.2f 净流入%.2f亿（%d天）\n", i+1, z.Low, z.High, z.MainNet/1e8, z.Days))
+		}
+
+		sb.WriteString("\n价格区间,主力净流入(亿),交易日数\n")
+		for _, z := range levels.Zones {
+			if z.Days == 0 {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("%.2f-%.2f,%.2f,%d\n", z.Low, z.High, z.MainNet/1e8, z.Days))
+		}
+
+		return GetMoneyFlowLevelsOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_money_flow_levels",
+		Description: "按价格区间汇总个股近期日级主力净流入，估算主力成本区间及资金最集中的价位（基于日级数据的估算值）",
+	}, handler)
+}
@@ -0,0 +1,113 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/indicators"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// mtfTimeframes 多周期趋势使用的周期（由短到长）
+var mtfTimeframes = []struct {
+	Period string
+	Name   string
+}{
+	{"1d", "日线"},
+	{"1w", "周线"},
+	{"1mo", "月线"},
+}
+
+// mtfKLineCount 每个周期获取的K线数量，需覆盖 MACD 的34根预热
+const mtfKLineCount = 60
+
+// maTrendNames 均线排列中文说明
+var maTrendNames = map[string]string{
+	"bull":  "多头排列",
+	"bear":  "空头排列",
+	"cross": "均线纠缠",
+}
+
+// GetMTFTrendInput 多周期趋势输入参数
+type GetMTFTrendInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh600519"`
+}
+
+// GetMTFTrendOutput 多周期趋势输出
+type GetMTFTrendOutput struct {
+	Data string `json:"data" jsonschema:"日线/周线/月线的均线排列、MACD方向及趋势一致性"`
+}
+
+// createMultiTimeframeTool 创建多周期趋势共振工具
+func (r *Registry) createMultiTimeframeTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetMTFTrendInput) (GetMTFTrendOutput, error) {
+		fmt.Printf("[Tool:get_mtf_trend] 调用开始, code=%s\n", input.Code)
+
+		if input.Code == "" {
+			return GetMTFTrendOutput{Data: "请提供股票代码"}, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 多周期趋势 ===\n", input.Code))
+		sb.WriteString("周期,收盘,均线排列,MACD,方向\n")
+
+		directions := make([]string, 0, len(mtfTimeframes))
+		summary := make([]string, 0, len(mtfTimeframes))
+		for _, tf := range mtfTimeframes {
+			klines, err := r.marketService.GetKLineData(input.Code, tf.Period, mtfKLineCount)
+			if err != nil {
+				fmt.Printf("[Tool:get_mtf_trend] 获取%s错误: %v\n", tf.Name, err)
+				directions = append(directions, "")
+				sb.WriteString(fmt.Sprintf("%s,-,-,-,数据获取失败\n", tf.Name))
+				continue
+			}
+
+			closes := make([]float64, len(klines))
+			for i, k := range klines {
+				closes[i] = k.Close
+			}
+			t := indicators.ComputeTimeframeTrend(closes)
+			dir := t.Direction()
+			directions = append(directions, dir)
+			if dir == "" {
+				sb.WriteString(fmt.Sprintf("%s,-,-,-,数据不足\n", tf.Name))
+				continue
+			}
+			summary = append(summary, tf.Name+dir)
+			sb.WriteString(fmt.Sprintf("%s,%.2f,%s,%s,%s\n", tf.Name, t.Close, maTrendLabel(t.MATrend), macdDirLabel(t.MACDDir, t.Hist), dir))
+		}
+
+		if len(summary) > 0 {
+			sb.WriteString(fmt.Sprintf("\n【结论】%s → %s\n", strings.Join(summary, "/"), indicators.TrendAgreement(directions)))
+		}
+		sb.WriteString("说明: 均线排列基于MA5/10/20，MACD方向为DIF相对DEA的位置")
+		return GetMTFTrendOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_mtf_trend",
+		Description: "多周期趋势共振分析：分别计算日线、周线、月线的均线排列和MACD方向，判断各周期趋势是否一致",
+	}, handler)
+}
+
+// maTrendLabel 均线排列说明
+func maTrendLabel(trend string) string {
+	if name, ok := maTrendNames[trend]; ok {
+		return name
+	}
+	return "-"
+}
+
+// macdDirLabel MACD 方向说明
+func macdDirLabel(dir string, hist float64) string {
+	switch dir {
+	case "up":
+		return fmt.Sprintf("DIF在DEA上方(柱%.3f)", hist)
+	case "down":
+		return fmt.Sprintf("DIF在DEA下方(柱%.3f)", hist)
+	default:
+		return "-"
+	}
+}
@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// newListingKLineCount 次新股分析获取的日K数量，覆盖上市一年内的全部交易日
+const newListingKLineCount = 300
+
+// GetNewListingInfoInput 次新股信息输入参数
+type GetNewListingInfoInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh603xxx"`
+}
+
+// GetNewListingInfoOutput 次新股信息输出
+type GetNewListingInfoOutput struct {
+	Data string `json:"data" jsonschema:"上市日期、上市天数、是否次新、开板状态及上市以来涨跌"`
+}
+
+// createNewListingTool 创建次新股信息工具
+func (r *Registry) createNewListingTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetNewListingInfoInput) (GetNewListingInfoOutput, error) {
+		fmt.Printf("[Tool:get_new_listing_info] 调用开始, code=%s\n", input.Code)
+
+		if input.Code == "" {
+			return GetNewListingInfoOutput{Data: "请提供股票代码"}, nil
+		}
+		if services.IsETF(input.Code) || services.IsIndex(input.Code) {
+			return GetNewListingInfoOutput{Data: fmt.Sprintf("%s 不是个股，无次新股信息", input.Code)}, nil
+		}
+		if r.stockInfoService == nil {
+			return GetNewListingInfoOutput{Data: "个股信息服务不可用"}, nil
+		}
+
+		listingDate, err := r.stockInfoService.GetListingDate(input.Code)
+		if err != nil {
+			fmt.Printf("[Tool:get_new_listing_info] 错误: %v\n", err)
+			return GetNewListingInfoOutput{}, err
+		}
+
+		now := time.Now()
+		info := services.AnalyzeNewListing(input.Code, listingDate, nil, now)
+		if info.IsSubNew {
+			klines, err := r.marketService.GetKLineData(input.Code, "1d", newListingKLineCount)
+			if err != nil {
+				fmt.Printf("[Tool:get_new_listing_info] 获取日K错误: %v\n", err)
+			}
+			info = services.AnalyzeNewListing(input.Code, listingDate, klines, now)
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 上市信息 ===\n", input.Code))
+		sb.WriteString(fmt.Sprintf("上市日期: %s（上市%d天）\n", info.ListingDate, info.DaysSinceIPO))
+		if !info.IsSubNew {
+			sb.WriteString("次新股: 否（上市已满一年，按普通个股分析）\n")
+			return GetNewListingInfoOutput{Data: sb.String()}, nil
+		}
+
+		sb.WriteString("次新股: 是（上市不满一年）\n")
+		if info.TradingDays > 0 {
+			sb.WriteString(fmt.Sprintf("上市以来交易日: %d\n", info.TradingDays))
+		}
+		switch {
+		case info.NoLimitIPO:
+			sb.WriteString("开板状态: 注册制新股，上市前5日不设涨跌幅，无一字连板开板\n")
+		case info.TradingDays == 0:
+			sb.WriteString("开板状态: 日K数据不足，无法判断\n")
+		case info.BoardOpened:
+			sb.WriteString(fmt.Sprintf("开板状态: 已开板（%s 开板，此前一字涨停%d天）\n", info.BoardOpenDate, info.LimitUpStreak))
+		default:
+			sb.WriteString(fmt.Sprintf("开板状态: 未开板（首日后已连续一字涨停%d天）\n", info.LimitUpStreak))
+		}
+		if info.FirstDayClose > 0 {
+			sb.WriteString(fmt.Sprintf("首日收盘: %.2f | 上市以来最高: %.2f\n", info.FirstDayClose, info.HighSinceIPO))
+			sb.WriteString(fmt.Sprintf("较首日收盘: %+.2f%% | 距最高价: %+.2f%%\n", info.ChangeSinceIPO, info.FromHigh))
+		}
+		sb.WriteString("提示: 次新股流通盘小、无长期均线参考，换手高、游资参与度高，技术指标和估值对比需谨慎")
+		return GetNewListingInfoOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_new_listing_info",
+		Description: "获取个股上市日期、上市天数、是否次新股、开板状态及上市以来涨跌，用于次新股的技术形态和资金行为分析",
+	}, handler)
+}
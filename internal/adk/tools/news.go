@@ -39,7 +39,11 @@ func (r *Registry) createNewsTool() (tool.Tool, error) {
 		var result string
 		for i := 0; i < limit; i++ {
 			n := news[i]
-			result += fmt.Sprintf("[%s] %s\n", n.Time, n.Content)
+			result += fmt.Sprintf("[%s][%s] %s\n", n.Time, n.Source, n.Content)
+		}
+
+		if result == "" {
+			result = noData("各快讯来源均未返回内容")
 		}
 
 		fmt.Printf("[Tool:get_news] 调用完成, 返回%d条快讯\n", limit)
@@ -48,6 +52,6 @@ func (r *Registry) createNewsTool() (tool.Tool, error) {
 
 	return functiontool.New(functiontool.Config{
 		Name:        "get_news",
-		Description: "获取最新财经快讯，来源于财联社",
+		Description: "获取最新财经快讯，合并财联社、新浪财经、东方财富等来源并去重",
 	}, handler)
 }
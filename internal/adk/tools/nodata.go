@@ -0,0 +1,12 @@
+package tools
+
+import "fmt"
+
+// NoDataPrefix 数据源请求成功但没有返回有效数据时，工具结果统一以此开头
+// 与请求失败（返回 error）区分，避免模型把空结果误读为“数值为零”
+const NoDataPrefix = "【无数据】"
+
+// noData 生成带无数据标记的工具结果，format 说明缺失的数据及原因
+func noData(format string, args ...any) string {
+	return NoDataPrefix + fmt.Sprintf(format, args...)
+}
@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"fmt"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// GetNorthboundTop10Input 北向十大成交股输入参数
+type GetNorthboundTop10Input struct{}
+
+// GetNorthboundTop10Output 北向十大成交股输出
+type GetNorthboundTop10Output struct {
+	Data string `json:"data" jsonschema:"沪股通/深股通十大成交股及净买额"`
+}
+
+// createNorthboundTop10Tool 创建北向十大成交股工具
+func (r *Registry) createNorthboundTop10Tool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetNorthboundTop10Input) (GetNorthboundTop10Output, error) {
+		if r.northboundService == nil {
+			return GetNorthboundTop10Output{Data: "北向资金服务不可用"}, nil
+		}
+
+		items, err := r.northboundService.GetTop10()
+		if err != nil {
+			fmt.Printf("[Tool:get_northbound_top10] 错误: %v\n", err)
+			return GetNorthboundTop10Output{}, err
+		}
+		if len(items) == 0 {
+			return GetNorthboundTop10Output{Data: noData("暂无北向十大成交股数据")}, nil
+		}
+
+		result := fmt.Sprintf("=== 北向十大成交股 (%s) ===\n", items[0].TradeDate)
+		var totalNet float64
+		for _, channel := range []string{"沪股通", "深股通"} {
+			result += fmt.Sprintf("\n【%s】\n", channel)
+			for _, item := range items {
+				if item.Channel != channel {
+					continue
+				}
+				totalNet += item.NetBuyAmt
+				result += fmt.Sprintf("%d. %s(%s) 收盘:%.2f 涨跌:%.2f%% 净买:%.0f万 成交:%.0f万\n",
+					item.Rank, item.Name, item.Code, item.ClosePrice, item.ChangePercent,
+					item.NetBuyAmt/10000, item.DealAmt/10000)
+			}
+		}
+		result += fmt.Sprintf("\n十大成交股合计净买: %.2f亿\n", totalNet/1e8)
+
+		return GetNorthboundTop10Output{Data: result}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_northbound_top10",
+		Description: "获取北向资金（沪股通/深股通）当日十大成交活跃股，包括成交额排名、净买入金额、涨跌幅",
+	}, handler)
+}
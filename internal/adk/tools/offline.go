@@ -0,0 +1,161 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/logger"
+)
+
+var snapshotLog = logger.New("tool:snapshot")
+
+const (
+	// toolSnapshotFile 工具最近结果快照文件，供离线模式使用
+	toolSnapshotFile = "tool_snapshots.json"
+	// toolSnapshotMaxEntries 最多保留的快照条数，超出时淘汰最旧的
+	toolSnapshotMaxEntries = 1000
+	// toolSnapshotFlushInterval 快照落盘间隔
+	toolSnapshotFlushInterval = time.Minute
+)
+
+// offlineLiveTools 只读本地数据、离线模式下仍可正常调用的工具
+var offlineLiveTools = map[string]bool{
+	"search_stocks": true,
+}
+
+// toolSnapshot 单个工具调用的最近一次成功结果
+type toolSnapshot struct {
+	Result  map[string]any `json:"result"`
+	SavedAt time.Time      `json:"savedAt"`
+}
+
+// toolSnapshotStore 工具最近结果快照，按工具名+参数保存，不设过期，定时落盘
+type toolSnapshotStore struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]toolSnapshot
+	dirty   bool
+}
+
+// newToolSnapshotStore 创建快照存储，未启用落盘前只保存在内存
+func newToolSnapshotStore() *toolSnapshotStore {
+	return &toolSnapshotStore{entries: make(map[string]toolSnapshot)}
+}
+
+// record 记录工具的最新成功结果，超出上限时淘汰最旧的条目
+func (s *toolSnapshotStore) record(key string, result map[string]any, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = toolSnapshot{Result: result, SavedAt: now}
+	s.dirty = true
+	if len(s.entries) <= toolSnapshotMaxEntries {
+		return
+	}
+	var oldestKey string
+	var oldest time.Time
+	for k, e := range s.entries {
+		if oldestKey == "" || e.SavedAt.Before(oldest) {
+			oldestKey, oldest = k, e.SavedAt
+		}
+	}
+	delete(s.entries, oldestKey)
+}
+
+// lookup 获取工具的最近一次成功结果
+func (s *toolSnapshotStore) lookup(key string) (toolSnapshot, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	return e, ok
+}
+
+// flush 有新记录时写入快照文件
+func (s *toolSnapshotStore) flush() {
+	s.mu.Lock()
+	if s.path == "" || !s.dirty {
+		s.mu.Unlock()
+		return
+	}
+	data, err := json.Marshal(s.entries)
+	s.dirty = false
+	path := s.path
+	s.mu.Unlock()
+
+	if err != nil {
+		snapshotLog.Warn("序列化工具快照失败: %v", err)
+		return
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		snapshotLog.Warn("保存工具快照失败: %v", err)
+	}
+}
+
+// EnableToolSnapshots 从 dataDir 加载工具快照并开启落盘，重启后离线模式仍可使用上次的数据
+func (r *Registry) EnableToolSnapshots(dataDir string) {
+	s := r.snapshots
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.path = filepath.Join(dataDir, toolSnapshotFile)
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return
+	}
+	if err := json.Unmarshal(data, &s.entries); err != nil {
+		snapshotLog.Warn("解析工具快照失败: %v", err)
+		s.entries = make(map[string]toolSnapshot)
+	}
+}
+
+// StartSnapshotFlusher 后台定时保存工具快照，ctx 取消时退出
+func (r *Registry) StartSnapshotFlusher(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(toolSnapshotFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.snapshots.flush()
+			}
+		}
+	}()
+}
+
+// FlushToolSnapshots 立即保存工具快照（应用关闭时调用）
+func (r *Registry) FlushToolSnapshots() {
+	r.snapshots.flush()
+}
+
+// IsOfflineMode 是否开启离线模式
+func (r *Registry) IsOfflineMode() bool {
+	if r.configService == nil {
+		return false
+	}
+	config := r.configService.GetConfig()
+	return config != nil && config.OfflineMode
+}
+
+// offlineResult 离线模式下返回工具最近一次成功结果，文本字段前加离线标注；无快照时返回无数据提示
+func (r *Registry) offlineResult(name, key string) map[string]any {
+	snap, ok := r.snapshots.lookup(key)
+	if !ok {
+		return map[string]any{"data": noData("离线模式下没有 %s 的缓存数据（相同参数需联网成功调用过一次）", name)}
+	}
+
+	label := fmt.Sprintf("（离线/缓存数据，获取于 %s）\n", snap.SavedAt.Format("2006-01-02 15:04"))
+	result := make(map[string]any, len(snap.Result))
+	for k, v := range snap.Result {
+		if text, ok := v.(string); ok && strings.TrimSpace(text) != "" {
+			v = label + text
+		}
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestToolSnapshotEviction 测试超出上限时淘汰最旧的快照
+func TestToolSnapshotEviction(t *testing.T) {
+	s := newToolSnapshotStore()
+	base := time.Date(2024, 1, 5, 10, 30, 0, 0, time.Local)
+	for i := 0; i < toolSnapshotMaxEntries; i++ {
+		s.record(fmt.Sprintf("k%d", i), map[string]any{"data": "x"}, base.Add(time.Duration(i)*time.Second))
+	}
+	if len(s.entries) != toolSnapshotMaxEntries {
+		t.Fatalf("未超上限时不应淘汰: %d", len(s.entries))
+	}
+
+	s.record("new", map[string]any{"data": "y"}, base.Add(time.Hour))
+	if len(s.entries) != toolSnapshotMaxEntries {
+		t.Errorf("超出上限后条数应保持 %d: %d", toolSnapshotMaxEntries, len(s.entries))
+	}
+	if _, ok := s.lookup("k0"); ok {
+		t.Error("应淘汰最旧的快照")
+	}
+	if _, ok := s.lookup("k1"); !ok {
+		t.Error("不应淘汰较新的快照")
+	}
+	if _, ok := s.lookup("new"); !ok {
+		t.Error("新快照应保留")
+	}
+}
+
+// TestOfflineResult 测试离线结果的标注和无快照提示
+func TestOfflineResult(t *testing.T) {
+	r := newTestRegistry(t, nil)
+	savedAt := time.Date(2024, 1, 5, 10, 30, 0, 0, time.Local)
+	r.snapshots.record("key", map[string]any{"data": "行情", "count": 3, "empty": " "}, savedAt)
+
+	result := r.offlineResult("stub", "key")
+	if data := result["data"]; data != "（离线/缓存数据，获取于 2024-01-05 10:30）\n行情" {
+		t.Errorf("文本字段应加离线标注: %q", data)
+	}
+	if result["count"] != 3 || result["empty"] != " " {
+		t.Errorf("非文本字段和空文本应原样返回: %v", result)
+	}
+	if snap, _ := r.snapshots.lookup("key"); snap.Result["data"] != "行情" {
+		t.Errorf("不应修改快照本身: %v", snap.Result["data"])
+	}
+
+	data, _ := r.offlineResult("stub", "missing")["data"].(string)
+	if !strings.HasPrefix(data, NoDataPrefix) || !strings.Contains(data, "stub") {
+		t.Errorf("无快照时应返回无数据提示: %q", data)
+	}
+}
+
+// TestCachedToolOffline 测试离线模式下使用快照、不执行工具，本地工具仍正常调用
+func TestCachedToolOffline(t *testing.T) {
+	r := newTestRegistry(t, func(c *models.AppConfig) {
+		c.OfflineMode = true
+	})
+
+	s := newStubTool(t, r, "stub")
+	key, _ := toolCacheKey("stub", map[string]any{"code": "sh600519"})
+	r.snapshots.record(key, map[string]any{"data": "sh600519#0"}, time.Now())
+	if data, _ := s.run(t, "sh600519"); !strings.HasSuffix(data, "\nsh600519#0") || s.calls != 0 {
+		t.Errorf("离线模式应返回快照: %q calls=%d", data, s.calls)
+	}
+	if data, _ := s.run(t, "sz000001"); !strings.HasPrefix(data, NoDataPrefix) || s.calls != 0 {
+		t.Errorf("无快照时应返回无数据提示: %q calls=%d", data, s.calls)
+	}
+
+	// 参数无法序列化时同样不执行工具
+	result, err := s.Run(nil, map[string]any{"code": make(chan int)})
+	if data, _ := result["data"].(string); err != nil || !strings.HasPrefix(data, NoDataPrefix) || s.calls != 0 {
+		t.Errorf("参数无法序列化时不应执行工具: %q %v calls=%d", data, err, s.calls)
+	}
+
+	live := newStubTool(t, r, "search_stocks")
+	if data, _ := live.run(t, "茅台"); data != "茅台#1" || live.calls != 1 {
+		t.Errorf("本地工具离线时应正常调用: %q calls=%d", data, live.calls)
+	}
+}
+
+// TestToolSnapshotPersistence 测试快照落盘后重新加载
+func TestToolSnapshotPersistence(t *testing.T) {
+	dir := t.TempDir()
+	r := newTestRegistry(t, nil)
+	r.EnableToolSnapshots(dir)
+
+	s := newStubTool(t, r, "stub")
+	if _, err := s.run(t, "sh600519"); err != nil {
+		t.Fatal(err)
+	}
+	r.FlushToolSnapshots()
+	if r.snapshots.dirty {
+		t.Error("落盘后应清除脏标记")
+	}
+
+	loaded := newTestRegistry(t, func(c *models.AppConfig) {
+		c.OfflineMode = true
+	})
+	loaded.EnableToolSnapshots(dir)
+	offline := newStubTool(t, loaded, "stub")
+	if data, _ := offline.run(t, "sh600519"); !strings.HasSuffix(data, "\nsh600519#1") || offline.calls != 0 {
+		t.Errorf("重新加载后离线模式应返回上次的结果: %q calls=%d", data, offline.calls)
+	}
+}
@@ -0,0 +1,226 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/indicators"
+	"github.com/run-bigpig/jcp/internal/models"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// 开盘扫描参数
+const (
+	openScanKLineCount   = 30  // 每只股票获取的日K数量
+	openScanBreakoutDays = 20  // 突破判定参考的前期高点天数
+	openScanGapUp        = 2.0 // 竞价高开幅度(%)
+	openScanVolumeRatio  = 2.0 // 昨日放量倍数（相对前5日均量）
+	openScanMaxStocks    = 50  // 单次最多扫描的自选股数量
+)
+
+// ScanOpenInput 开盘扫描输入参数
+type ScanOpenInput struct {
+	Limit int `json:"limit,omitzero" jsonschema:"最多扫描的自选股数量，默认20，最大50"`
+}
+
+// ScanOpenOutput 开盘扫描输出
+type ScanOpenOutput struct {
+	Data string `json:"data" jsonschema:"自选股竞价高开幅度、前一日技术形态及涨停/突破候选标记"`
+}
+
+// openScanResult 单只股票的开盘扫描结果
+type openScanResult struct {
+	Stock   models.Stock
+	Gap     float64  // 竞价高开幅度(%)
+	HasGap  bool     // 是否已有竞价价格
+	Signals []string // 命中的信号
+}
+
+// createOpenScanTool 创建自选股开盘扫描工具
+func (r *Registry) createOpenScanTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input ScanOpenInput) (ScanOpenOutput, error) {
+		if r.configService == nil {
+			return ScanOpenOutput{}, fmt.Errorf("配置服务未初始化")
+		}
+
+		limit := input.Limit
+		if limit <= 0 {
+			limit = 20
+		}
+		if limit > openScanMaxStocks {
+			limit = openScanMaxStocks
+		}
+
+		watchlist := r.configService.GetWatchlist()
+		if len(watchlist) == 0 {
+			return ScanOpenOutput{Data: "自选股列表为空，请先添加自选股"}, nil
+		}
+		if len(watchlist) > limit {
+			watchlist = watchlist[:limit]
+		}
+
+		codes := make([]string, 0, len(watchlist))
+		for _, s := range watchlist {
+			codes = append(codes, s.Symbol)
+		}
+		quotes, err := r.fetchQuotesInBatches(codes)
+		if err != nil {
+			fmt.Printf("[Tool:scan_open] 错误: %v\n", err)
+			return ScanOpenOutput{}, err
+		}
+
+		today := time.Now().Format("2006-01-02")
+		var results []openScanResult
+		for _, q := range quotes {
+			if q.Suspended {
+				continue
+			}
+			klines, err := r.marketService.GetKLineData(q.Symbol, "1d", openScanKLineCount)
+			if err != nil {
+				fmt.Printf("[Tool:scan_open] 获取%s日K错误: %v\n", q.Symbol, err)
+				continue
+			}
+			// 只使用前一交易日及之前的K线
+			if n := len(klines); n > 0 && strings.HasPrefix(klines[n-1].Time, today) {
+				klines = klines[:n-1]
+			}
+			results = append(results, scanOpenStock(q, klines))
+		}
+
+		// 信号多的排前面，同信号数按高开幅度排序
+		sort.SliceStable(results, func(i, j int) bool {
+			if len(results[i].Signals) != len(results[j].Signals) {
+				return len(results[i].Signals) > len(results[j].Signals)
+			}
+			return results[i].Gap > results[j].Gap
+		})
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== 自选股开盘扫描（%s，扫描%d只） ===\n", time.Now().Format("2006-01-02 15:04"), len(results)))
+		sb.WriteString("说明: 竞价数据以9:25撮合后的开盘价为准，9:25前暂无竞价价格\n")
+
+		var candidates, others []string
+		for _, res := range results {
+			gap := "竞价未出"
+			if res.HasGap {
+				gap = fmt.Sprintf("竞价%+.2f%%", res.Gap)
+			}
+			line := fmt.Sprintf("%s(%s) 昨收%.2f %s", res.Stock.Name, res.Stock.Symbol, res.Stock.PreClose, gap)
+			if len(res.Signals) == 0 {
+				others = append(others, line)
+				continue
+			}
+			candidates = append(candidates, line+" | "+strings.Join(res.Signals, "、"))
+		}
+
+		sb.WriteString("\n【涨停/突破候选】\n")
+		if len(candidates) == 0 {
+			sb.WriteString("暂无符合条件的候选股\n")
+		}
+		for i, c := range candidates {
+			sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, c))
+		}
+		if len(others) > 0 {
+			sb.WriteString("\n【无明显信号】\n")
+			sb.WriteString(strings.Join(others, "\n"))
+			sb.WriteString("\n")
+		}
+
+		return ScanOpenOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "scan_open",
+		Description: "盘前/开盘扫描自选股：结合集合竞价高开幅度和前一日均线、量能、涨停及前期高点位置，标记可能涨停或突破的候选股",
+	}, handler)
+}
+
+// scanOpenStock 根据竞价价格和前一日技术形态判断候选信号
+// klines 需为前一交易日及之前的日K（按时间升序）
+func scanOpenStock(q models.Stock, klines []models.KLineData) openScanResult {
+	res := openScanResult{Stock: q}
+	if q.Open > 0 && q.PreClose > 0 {
+		res.HasGap = true
+		res.Gap = (q.Open - q.PreClose) / q.PreClose * 100
+	}
+
+	limitPct := priceLimitPercent(q.Symbol, q.Name)
+	if res.HasGap {
+		switch {
+		case res.Gap >= limitPct-0.2:
+			res.Signals = append(res.Signals, "竞价一字涨停")
+		case res.Gap >= openScanGapUp:
+			res.Signals = append(res.Signals, "竞价高开")
+		}
+	}
+
+	n := len(klines)
+	if n < 2 {
+		return res
+	}
+	closes := make([]float64, n)
+	volumes := make([]int64, n)
+	for i, k := range klines {
+		closes[i] = k.Close
+		volumes[i] = k.Volume
+	}
+	last := n - 1
+
+	// 昨日涨停
+	if prev := closes[last-1]; prev > 0 && (closes[last]-prev)/prev*100 >= limitPct-0.2 {
+		res.Signals = append(res.Signals, "昨日涨停(关注连板)")
+	}
+
+	// 均线多头排列
+	if n >= 20 && indicators.MATrend(indicators.SMA(closes, 5)[last], indicators.SMA(closes, 10)[last], indicators.SMA(closes, 20)[last]) == "bull" {
+		res.Signals = append(res.Signals, "均线多头")
+	}
+
+	// 昨日放量：对比此前5日均量
+	if n > 5 {
+		if avg := indicators.VolMA(volumes, 5)[last-1]; avg > 0 && float64(volumes[last])/avg >= openScanVolumeRatio {
+			res.Signals = append(res.Signals, fmt.Sprintf("昨日放量%.1f倍", float64(volumes[last])/avg))
+		}
+	}
+
+	// 前期高点：不含昨日的近20日最高价
+	start := last - openScanBreakoutDays
+	if start < 0 {
+		start = 0
+	}
+	var high float64
+	for _, k := range klines[start:last] {
+		if k.High > high {
+			high = k.High
+		}
+	}
+	if high > 0 {
+		switch {
+		case res.HasGap && q.Open > high:
+			res.Signals = append(res.Signals, fmt.Sprintf("竞价突破%d日高点%.2f", openScanBreakoutDays, high))
+		case closes[last] > high:
+			res.Signals = append(res.Signals, fmt.Sprintf("昨日收盘突破%d日高点%.2f", openScanBreakoutDays, high))
+		case closes[last] >= high*0.97:
+			res.Signals = append(res.Signals, fmt.Sprintf("逼近%d日高点%.2f", openScanBreakoutDays, high))
+		}
+	}
+	return res
+}
+
+// priceLimitPercent 按板块返回涨跌幅限制(%)
+func priceLimitPercent(symbol, name string) float64 {
+	switch {
+	case strings.Contains(strings.ToUpper(name), "ST"):
+		return 5
+	case strings.HasPrefix(symbol, "bj"):
+		return 30
+	case strings.HasPrefix(symbol, "sh688"), strings.HasPrefix(symbol, "sz300"), strings.HasPrefix(symbol, "sz301"):
+		return 20
+	default:
+		return 10
+	}
+}
@@ -0,0 +1,99 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// GetOptionsInput 期权数据输入参数
+type GetOptionsInput struct {
+	Code  string `json:"code" jsonschema:"标的代码，支持50ETF(sh510050)、300ETF(sh510300/sz159919)、500ETF、科创50ETF、创业板ETF等，或对应指数代码如 sh000300"`
+	Month string `json:"month,omitempty" jsonschema:"到期月份，格式YYMM如2412，为空取最近到期月份"`
+}
+
+// GetOptionsOutput 期权数据输出
+type GetOptionsOutput struct {
+	Data string `json:"data" jsonschema:"期权链摘要：平值隐含波动率、认沽认购比、最大痛点及主要行权价持仓"`
+}
+
+// createOptionsTool 创建ETF期权数据工具
+func (r *Registry) createOptionsTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetOptionsInput) (GetOptionsOutput, error) {
+		fmt.Printf("[Tool:get_options] 调用开始, code=%s, month=%s\n", input.Code, input.Month)
+
+		underlying, ok := services.OptionUnderlying(input.Code)
+		if !ok {
+			return GetOptionsOutput{Data: noData("%s: 该标的无期权", input.Code)}, nil
+		}
+		if r.stockInfoService == nil {
+			return GetOptionsOutput{Data: "期权行情服务不可用"}, nil
+		}
+
+		chain, err := r.stockInfoService.GetOptionChain(underlying, input.Month)
+		if err != nil {
+			fmt.Printf("[Tool:get_options] 错误: %v\n", err)
+			return GetOptionsOutput{}, err
+		}
+
+		var spot float64
+		if quotes, err := r.marketService.GetStockRealTimeData(underlying); err == nil && len(quotes) > 0 {
+			spot = quotes[0].Price
+		}
+		sum := services.SummarizeOptionChain(chain.Contracts, spot)
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s(%s) 期权 %s 到期 ===\n", services.OptionUnderlyingName(underlying), underlying, chain.Month))
+		if underlying != strings.ToLower(strings.TrimSpace(input.Code)) {
+			sb.WriteString(fmt.Sprintf("说明: %s 无直接期权，使用跟踪该指数的 %s 期权\n", input.Code, underlying))
+		}
+		if spot > 0 {
+			sb.WriteString(fmt.Sprintf("标的最新价: %.3f\n", spot))
+		}
+		if sum.ATMIV > 0 {
+			sb.WriteString(fmt.Sprintf("平值隐含波动率: %.2f%%（行权价%.3f）\n", sum.ATMIV, sum.ATMStrike))
+		}
+		sb.WriteString(fmt.Sprintf("成交量 认购%.0f张 / 认沽%.0f张，PCR %.2f\n", sum.CallVolume, sum.PutVolume, sum.PCRVolume))
+		sb.WriteString(fmt.Sprintf("持仓量 认购%.0f张 / 认沽%.0f张，PCR %.2f\n", sum.CallOI, sum.PutOI, sum.PCROI))
+		sb.WriteString(fmt.Sprintf("最大痛点: %.3f\n", sum.MaxPain))
+
+		sb.WriteString("\n【各行权价】行权价 | 认购 价格/持仓/IV | 认沽 价格/持仓/IV\n")
+		type row struct{ call, put *services.OptionContract }
+		var strikes []float64
+		rows := make(map[float64]*row)
+		for i := range chain.Contracts {
+			c := &chain.Contracts[i]
+			rw, ok := rows[c.Strike]
+			if !ok {
+				rw = &row{}
+				rows[c.Strike] = rw
+				strikes = append(strikes, c.Strike)
+			}
+			if c.IsCall {
+				rw.call = c
+			} else {
+				rw.put = c
+			}
+		}
+		side := func(c *services.OptionContract) string {
+			if c == nil {
+				return "-"
+			}
+			return fmt.Sprintf("%.4f/%.0f/%.1f%%", c.Price, c.OpenInterest, c.IV)
+		}
+		for _, k := range strikes {
+			sb.WriteString(fmt.Sprintf("%.3f | %s | %s\n", k, side(rows[k].call), side(rows[k].put)))
+		}
+		sb.WriteString("\n解读: PCR>1 表示认沽更活跃，偏防御/看空；最大痛点为期权卖方最有利的到期价格，临近到期时对标的有一定牵引")
+		return GetOptionsOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_options",
+		Description: "获取ETF期权（50ETF、300ETF、500ETF、科创50、创业板等）期权链摘要：平值隐含波动率、认沽认购比(PCR)、最大痛点及各行权价持仓，用于对冲与风险评估",
+	}, handler)
+}
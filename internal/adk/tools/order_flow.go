@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// GetOrderFlowInput 分时内外盘输入参数
+type GetOrderFlowInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh600519 或 600519"`
+}
+
+// GetOrderFlowOutput 分时内外盘输出
+type GetOrderFlowOutput struct {
+	Data string `json:"data" jsonschema:"当日累计外盘/内盘、内外盘比、大单内外盘及按半小时分段的累计内外盘比走势"`
+}
+
+// createOrderFlowTool 创建分时内外盘工具
+func (r *Registry) createOrderFlowTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetOrderFlowInput) (GetOrderFlowOutput, error) {
+		fmt.Printf("[Tool:get_order_flow] 调用开始, code=%s\n", input.Code)
+
+		if r.moneyFlowService == nil {
+			return GetOrderFlowOutput{Data: "资金流向服务不可用"}, nil
+		}
+		if input.Code == "" {
+			return GetOrderFlowOutput{Data: "请提供股票代码"}, nil
+		}
+
+		// 统一为带前缀代码
+		code := strings.ToLower(input.Code)
+		if len(code) == 6 && r.configService != nil {
+			if info := r.configService.GetStockBasicInfo(code); info != nil {
+				code = info.Symbol
+			}
+		}
+
+		flow, err := r.moneyFlowService.GetOrderFlow(code)
+		if err != nil {
+			fmt.Printf("[Tool:get_order_flow] 错误: %v\n", err)
+			return GetOrderFlowOutput{}, err
+		}
+		if flow.OuterVolume == 0 && flow.InnerVolume == 0 {
+			return GetOrderFlowOutput{Data: noData("%s 暂无连续竞价成交明细（未开盘或停牌）", input.Code)}, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 分时内外盘（截至 %s，%d笔成交）===\n", code, flow.LastTime, flow.Ticks))
+		if flow.PrePrice > 0 {
+			sb.WriteString(fmt.Sprintf("最新价: %.2f（%+.2f%%）\n", flow.LastPrice, (flow.LastPrice-flow.PrePrice)/flow.PrePrice*100))
+		}
+		sb.WriteString(fmt.Sprintf("外盘(主动买入): %d手，内盘(主动卖出): %d手\n", flow.OuterVolume, flow.InnerVolume))
+		if flow.InnerVolume > 0 {
+			sb.WriteString(fmt.Sprintf("内外盘比(外/内): %.2f → %s\n", flow.Ratio, flow.Dominance))
+		} else {
+			sb.WriteString(fmt.Sprintf("内盘为0 → %s\n", flow.Dominance))
+		}
+		if flow.LargeOuter+flow.LargeInner > 0 {
+			sb.WriteString(fmt.Sprintf("大单(单笔≥50万元): 外盘%d手，内盘%d手，大单净主动买入%+d手\n",
+				flow.LargeOuter, flow.LargeInner, flow.LargeOuter-flow.LargeInner))
+		}
+		if flow.AuctionVolume > 0 {
+			sb.WriteString(fmt.Sprintf("集合竞价及中性成交: %d手（不计入内外盘）\n", flow.AuctionVolume))
+		}
+
+		sb.WriteString("\n【分段累计】\n时段,本段外盘,本段内盘,累计外/内,段末价\n")
+		for _, b := range flow.Buckets {
+			sb.WriteString(fmt.Sprintf("%s,%d,%d,%.2f,%.2f\n", b.Period, b.OuterVolume, b.InnerVolume, b.CumRatio, b.LastPrice))
+		}
+
+		if signal := orderFlowSignal(flow); signal != "" {
+			sb.WriteString("\n信号: " + signal + "\n")
+		}
+		sb.WriteString("说明: 外盘为以卖价成交的主动买入，内盘为以买价成交的主动卖出；内外盘比大于1.2为买方主导，小于0.83为卖方主导。对倒和拆单会扭曲统计，需结合价格走势判断")
+
+		return GetOrderFlowOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_order_flow",
+		Description: "基于当日逐笔成交统计个股外盘(主动买入)与内盘(主动卖出)、内外盘比及大单内外盘，并按半小时分段给出累计内外盘比走势，判断盘中买卖哪方占主导",
+	}, handler)
+}
+
+// orderFlowSignal 结合内外盘比与价格走势给出盘中信号
+func orderFlowSignal(flow *services.OrderFlow) string {
+	if flow.PrePrice <= 0 {
+		return ""
+	}
+	change := (flow.LastPrice - flow.PrePrice) / flow.PrePrice * 100
+	switch {
+	case flow.Dominance == services.OrderFlowBuyDominant && change < 0:
+		return "外盘占优但股价下跌，主动买盘未能推升价格，上方抛压较重或有资金对倒"
+	case flow.Dominance == services.OrderFlowSellDominant && change > 0:
+		return "内盘占优但股价上涨，主动卖盘被挂单承接，可能是主力压价吸筹"
+	case flow.Dominance == services.OrderFlowBuyDominant:
+		return "外盘占优且价格上行，主动买盘推动上涨，量价配合"
+	case flow.Dominance == services.OrderFlowSellDominant:
+		return "内盘占优且价格下行，主动卖盘主导，短线承接偏弱"
+	default:
+		return ""
+	}
+}
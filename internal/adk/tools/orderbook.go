@@ -3,6 +3,8 @@ package tools
 import (
 	"fmt"
 
+	"github.com/run-bigpig/jcp/internal/models"
+
 	"google.golang.org/adk/tool"
 	"google.golang.org/adk/tool/functiontool"
 )
@@ -33,17 +35,12 @@ func (r *Registry) createOrderBookTool() (tool.Tool, error) {
 			return GetOrderBookOutput{}, err
 		}
 
-		// 格式化输出
-		result := "【卖盘】\n"
-		for i := len(ob.Asks) - 1; i >= 0; i-- {
-			a := ob.Asks[i]
-			result += fmt.Sprintf("卖%d: %.2f x %d手\n", i+1, a.Price, a.Size)
-		}
-		result += "【买盘】\n"
-		for i, b := range ob.Bids {
-			result += fmt.Sprintf("买%d: %.2f x %d手\n", i+1, b.Price, b.Size)
+		if len(ob.Bids) == 0 && len(ob.Asks) == 0 {
+			return GetOrderBookOutput{Data: noData("%s 暂无盘口挂单数据", input.Code)}, nil
 		}
 
+		result := formatOrderBook(ob)
+
 		fmt.Printf("[Tool:get_orderbook] 调用完成, 买盘%d档, 卖盘%d档\n", len(ob.Bids), len(ob.Asks))
 		return GetOrderBookOutput{Data: result}, nil
 	}
@@ -53,3 +50,17 @@ func (r *Registry) createOrderBookTool() (tool.Tool, error) {
 		Description: "获取股票五档盘口数据，显示买卖五档的价格和挂单量",
 	}, handler)
 }
+
+// formatOrderBook 格式化五档盘口：卖盘由高到低，买盘由高到低
+func formatOrderBook(ob models.OrderBook) string {
+	result := "【卖盘】\n"
+	for i := len(ob.Asks) - 1; i >= 0; i-- {
+		a := ob.Asks[i]
+		result += fmt.Sprintf("卖%d: %.2f x %d手\n", i+1, a.Price, a.Size)
+	}
+	result += "【买盘】\n"
+	for i, b := range ob.Bids {
+		result += fmt.Sprintf("买%d: %.2f x %d手\n", i+1, b.Price, b.Size)
+	}
+	return result
+}
@@ -0,0 +1,119 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/indicators"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+const (
+	// pairsDefaultLookback 默认回看的共同交易日数
+	pairsDefaultLookback = 60
+	// pairsMinLookback 最小回看交易日数，过短时均值和标准差不稳定
+	pairsMinLookback = 20
+	// pairsMaxLookback 最大回看交易日数
+	pairsMaxLookback = 250
+	// pairsKLineBuffer 额外多取的日K数，弥补停牌等造成的日期不对齐
+	pairsKLineBuffer = 30
+	// pairsRecentRows 输出最近比值明细的行数
+	pairsRecentRows = 10
+	// pairsMinCorrelation 日收益率相关系数低于该值时提示配对关系不可靠
+	pairsMinCorrelation = 0.5
+)
+
+// GetPairsSpreadInput 配对价差输入参数
+type GetPairsSpreadInput struct {
+	CodeA    string `json:"code_a" jsonschema:"股票A代码，如 sh600519"`
+	CodeB    string `json:"code_b" jsonschema:"股票B代码，如 sz000858"`
+	Lookback int    `json:"lookback,omitempty" jsonschema:"回看共同交易日数，默认60，范围20-250"`
+}
+
+// GetPairsSpreadOutput 配对价差输出
+type GetPairsSpreadOutput struct {
+	Data string `json:"data" jsonschema:"价格比值A/B的均值、标准差、当前Z分数、收益率相关系数及背离判断"`
+}
+
+// createPairsSpreadTool 创建配对交易价差工具
+func (r *Registry) createPairsSpreadTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetPairsSpreadInput) (GetPairsSpreadOutput, error) {
+		fmt.Printf("[Tool:get_pairs_spread] 调用开始, a=%s, b=%s, lookback=%d\n", input.CodeA, input.CodeB, input.Lookback)
+
+		if input.CodeA == "" || input.CodeB == "" {
+			return GetPairsSpreadOutput{Data: "请提供两只股票代码"}, nil
+		}
+		if strings.EqualFold(input.CodeA, input.CodeB) {
+			return GetPairsSpreadOutput{Data: "两只股票代码相同，无法计算配对价差"}, nil
+		}
+
+		lookback := input.Lookback
+		if lookback <= 0 {
+			lookback = pairsDefaultLookback
+		}
+		lookback = max(pairsMinLookback, min(lookback, pairsMaxLookback))
+
+		klinesA, err := r.marketService.GetKLineData(input.CodeA, "1d", lookback+pairsKLineBuffer)
+		if err != nil {
+			fmt.Printf("[Tool:get_pairs_spread] %s 错误: %v\n", input.CodeA, err)
+			return GetPairsSpreadOutput{}, err
+		}
+		klinesB, err := r.marketService.GetKLineData(input.CodeB, "1d", lookback+pairsKLineBuffer)
+		if err != nil {
+			fmt.Printf("[Tool:get_pairs_spread] %s 错误: %v\n", input.CodeB, err)
+			return GetPairsSpreadOutput{}, err
+		}
+
+		datesA, closesA := performanceSeries(klinesA)
+		datesB, closesB := performanceSeries(klinesB)
+		spread, ok := indicators.ComputePairSpread(datesA, closesA, datesB, closesB, lookback)
+		if !ok {
+			return GetPairsSpreadOutput{Data: noData("%s 与 %s 共同交易日不足%d天或比值无波动，无法计算配对价差", input.CodeA, input.CodeB, lookback)}, nil
+		}
+
+		n := len(spread.Dates)
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s / %s 配对价差（%s 至 %s，%d个共同交易日）===\n", input.CodeA, input.CodeB, spread.Dates[0], spread.Dates[n-1], n))
+		sb.WriteString(fmt.Sprintf("当前比值: %.4f\n", spread.Current))
+		sb.WriteString(fmt.Sprintf("比值均值: %.4f，标准差: %.4f\n", spread.Mean, spread.Std))
+		sb.WriteString(fmt.Sprintf("当前Z分数: %+.2f\n", spread.ZScore))
+		sb.WriteString(fmt.Sprintf("日收益率相关系数: %.2f\n", spread.Correlation))
+
+		sb.WriteString("\n【最近比值】\n日期,比值,Z分数\n")
+		for i := max(0, n-pairsRecentRows); i < n; i++ {
+			sb.WriteString(fmt.Sprintf("%s,%.4f,%+.2f\n", spread.Dates[i], spread.Ratios[i], (spread.Ratios[i]-spread.Mean)/spread.Std))
+		}
+
+		sb.WriteString("\n判断: " + pairsVerdict(spread, input.CodeA, input.CodeB) + "\n")
+		sb.WriteString("说明: 比值按不复权收盘价计算，区间内有除权除息时比值会跳变；Z分数假设比值均值回归，趋势性分化时会持续偏离。A股做空需借助融券或股指期货，个人投资者多用于调仓换股参考")
+
+		return GetPairsSpreadOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_pairs_spread",
+		Description: "计算两只相关股票的价格比值价差：回看窗口内比值的均值、标准差和当前Z分数，以及日收益率相关系数，用于配对交易/市场中性策略判断两者是否显著背离",
+	}, handler)
+}
+
+// pairsVerdict 根据Z分数和相关系数给出配对背离判断
+func pairsVerdict(s indicators.PairSpread, codeA, codeB string) string {
+	var verdict string
+	switch z := math.Abs(s.ZScore); {
+	case z >= 2 && s.ZScore > 0:
+		verdict = fmt.Sprintf("显著背离：%s 相对 %s 偏贵（Z≥2），均值回归思路为减%s、增%s", codeA, codeB, codeA, codeB)
+	case z >= 2:
+		verdict = fmt.Sprintf("显著背离：%s 相对 %s 偏便宜（Z≤-2），均值回归思路为增%s、减%s", codeA, codeB, codeA, codeB)
+	case z >= 1:
+		verdict = "温和偏离：比值偏离均值1-2个标准差，可继续观察是否扩大"
+	default:
+		verdict = "正常区间：比值在均值附近，无明显配对机会"
+	}
+	if s.Correlation < pairsMinCorrelation {
+		verdict += fmt.Sprintf("；注意两者日收益率相关系数仅%.2f，配对关系不稳定，背离可能是基本面分化而非错误定价", s.Correlation)
+	}
+	return verdict
+}
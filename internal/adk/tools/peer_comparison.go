@@ -0,0 +1,196 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/indicators"
+	"github.com/run-bigpig/jcp/internal/logger"
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+var peerLog = logger.New("tool:peers")
+
+// ComparePeersInput 同行估值对比输入参数
+type ComparePeersInput struct {
+	Code  string `json:"code" jsonschema:"股票代码，如 sh600519"`
+	Limit int    `json:"limit,omitzero" jsonschema:"按总市值取前N家同行，默认10，最大20"`
+}
+
+// ComparePeersOutput 同行估值对比输出
+type ComparePeersOutput struct {
+	Data string `json:"data" jsonschema:"同行业估值对比表"`
+}
+
+// peerRow 同行对比行
+type peerRow struct {
+	Symbol string
+	Name   string
+	Info   *services.StockExtendedInfo
+}
+
+// createPeerComparisonTool 创建同行估值对比工具
+func (r *Registry) createPeerComparisonTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input ComparePeersInput) (ComparePeersOutput, error) {
+		peerLog.Debug("调用开始, code=%s, limit=%d", input.Code, input.Limit)
+
+		if input.Code == "" {
+			return ComparePeersOutput{Data: "请提供股票代码"}, nil
+		}
+		if r.configService == nil || r.stockInfoService == nil {
+			return ComparePeersOutput{}, fmt.Errorf("同行对比服务未初始化")
+		}
+
+		limit := input.Limit
+		if limit <= 0 {
+			limit = 10
+		}
+		if limit > 20 {
+			limit = 20
+		}
+
+		// 统一为带前缀代码
+		code := strings.ToLower(input.Code)
+		if len(code) == 6 {
+			if info := r.configService.GetStockBasicInfo(code); info != nil {
+				code = info.Symbol
+			}
+		}
+
+		industry := r.getStockIndustry(code)
+		if industry == "" {
+			return ComparePeersOutput{Data: noData("未找到 %s 的行业信息，无法进行同行对比", input.Code)}, nil
+		}
+
+		peers := r.configService.GetStocksByIndustry(industry)
+		if len(peers) == 0 {
+			return ComparePeersOutput{Data: noData("未找到行业[%s]的同行数据", industry)}, nil
+		}
+
+		codes := make([]string, 0, len(peers))
+		for _, p := range peers {
+			codes = append(codes, p.Symbol)
+		}
+		infos, err := r.stockInfoService.GetBatchExtendedInfo(codes)
+		if err != nil {
+			peerLog.Error("获取同行估值失败: %v", err)
+			return ComparePeersOutput{}, err
+		}
+
+		var rows []peerRow
+		for _, p := range peers {
+			if info, ok := infos[p.Symbol]; ok && info.TotalMarketCap > 0 {
+				rows = append(rows, peerRow{Symbol: p.Symbol, Name: p.Name, Info: info})
+			}
+		}
+		if len(rows) == 0 {
+			return ComparePeersOutput{Data: noData("行业[%s]暂无有效估值数据", industry)}, nil
+		}
+
+		// 按总市值降序
+		sort.Slice(rows, func(i, j int) bool {
+			return rows[i].Info.TotalMarketCap > rows[j].Info.TotalMarketCap
+		})
+
+		targetRank := -1
+		for i, row := range rows {
+			if row.Symbol == code {
+				targetRank = i
+				break
+			}
+		}
+
+		result := fmt.Sprintf("=== %s 同行估值对比（行业: %s，共%d家） ===\n\n", input.Code, industry, len(rows))
+		result += "排名,代码,名称,总市值,PE,PB\n"
+		for i, row := range rows {
+			if i >= limit && i != targetRank {
+				continue
+			}
+			mark := ""
+			if i == targetRank {
+				mark = "★"
+			}
+			result += fmt.Sprintf("%d,%s,%s%s,%s,%s,%s\n", i+1, row.Symbol, mark, row.Name,
+				indicators.FormatMarketCap(row.Info.TotalMarketCap),
+				formatValuation(row.Info.PE), formatValuation(row.Info.PB))
+		}
+
+		result += "\n【目标定位】\n"
+		if targetRank < 0 {
+			result += "目标股票未取得估值数据\n"
+		} else {
+			target := rows[targetRank].Info
+			result += fmt.Sprintf("市值排名: %d/%d\n", targetRank+1, len(rows))
+			result += fmt.Sprintf("PE: %s，行业中位数 %s，低于%.0f%%的同行\n",
+				formatValuation(target.PE), formatValuation(peerMedian(rows, peerPE)), peerPercentile(rows, peerPE, target.PE))
+			result += fmt.Sprintf("PB: %s，行业中位数 %s，低于%.0f%%的同行\n",
+				formatValuation(target.PB), formatValuation(peerMedian(rows, peerPB)), peerPercentile(rows, peerPB, target.PB))
+		}
+
+		peerLog.Debug("调用完成, 行业=%s, 同行数=%d", industry, len(rows))
+		return ComparePeersOutput{Data: result}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "compare_with_peers",
+		Description: "同行业估值对比，按总市值列出同行业前N家公司的PE/PB/市值，并给出目标股票在行业中的估值位置",
+	}, handler)
+}
+
+// peerPE 取市盈率
+func peerPE(info *services.StockExtendedInfo) float64 { return info.PE }
+
+// peerPB 取市净率
+func peerPB(info *services.StockExtendedInfo) float64 { return info.PB }
+
+// peerValues 提取有效（正值）估值序列
+func peerValues(rows []peerRow, get func(*services.StockExtendedInfo) float64) []float64 {
+	var values []float64
+	for _, row := range rows {
+		if v := get(row.Info); v > 0 {
+			values = append(values, v)
+		}
+	}
+	sort.Float64s(values)
+	return values
+}
+
+// peerMedian 计算行业估值中位数（剔除亏损/无效值）
+func peerMedian(rows []peerRow, get func(*services.StockExtendedInfo) float64) float64 {
+	values := peerValues(rows, get)
+	n := len(values)
+	if n == 0 {
+		return 0
+	}
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
+
+// peerPercentile 计算目标估值低于多少比例的同行
+func peerPercentile(rows []peerRow, get func(*services.StockExtendedInfo) float64, target float64) float64 {
+	values := peerValues(rows, get)
+	if len(values) == 0 || target <= 0 {
+		return 0
+	}
+	higher := 0
+	for _, v := range values {
+		if v > target {
+			higher++
+		}
+	}
+	return float64(higher) / float64(len(values)) * 100
+}
+
+// formatValuation 格式化估值，亏损或无效时显示"-"
+func formatValuation(v float64) string {
+	if v <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", v)
+}
@@ -0,0 +1,132 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/indicators"
+	"github.com/run-bigpig/jcp/internal/models"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+const (
+	// performanceBenchmark 超额收益比较基准：上证指数
+	performanceBenchmark = "sh000001"
+	// performanceKLines 获取的日K数量，覆盖近一年并留出节假日余量
+	performanceKLines = 300
+)
+
+// GetPerformanceInput 区间表现输入参数
+type GetPerformanceInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh600519"`
+}
+
+// GetPerformanceOutput 区间表现输出
+type GetPerformanceOutput struct {
+	Data string `json:"data" jsonschema:"近5日/20日/60日/年初至今/近一年涨跌幅及相对上证指数的超额收益"`
+}
+
+// performancePeriod 统计区间
+type performancePeriod struct {
+	label string
+	bars  int // 按交易日数统计，0 表示按日期统计
+	// cutoff 按日期统计时的基准日，参数为最新交易日
+	cutoff func(latest time.Time) string
+}
+
+// performancePeriods 标准统计区间
+var performancePeriods = []performancePeriod{
+	{label: "近5日", bars: 5},
+	{label: "近20日", bars: 20},
+	{label: "近60日", bars: 60},
+	{label: "年初至今", cutoff: func(latest time.Time) string {
+		return fmt.Sprintf("%d-12-31", latest.Year()-1)
+	}},
+	{label: "近一年", cutoff: func(latest time.Time) string {
+		return latest.AddDate(-1, 0, 0).Format("2006-01-02")
+	}},
+}
+
+// createPerformanceTool 创建区间表现工具
+func (r *Registry) createPerformanceTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetPerformanceInput) (GetPerformanceOutput, error) {
+		fmt.Printf("[Tool:get_performance] 调用开始, code=%s\n", input.Code)
+
+		if input.Code == "" {
+			return GetPerformanceOutput{Data: "请提供股票代码"}, nil
+		}
+
+		klines, err := r.marketService.GetKLineData(input.Code, "1d", performanceKLines)
+		if err != nil {
+			fmt.Printf("[Tool:get_performance] 错误: %v\n", err)
+			return GetPerformanceOutput{}, err
+		}
+		if len(klines) < 2 {
+			return GetPerformanceOutput{Data: noData("%s 日K数据不足，无法计算区间表现", input.Code)}, nil
+		}
+		// 基准获取失败时只输出个股涨跌
+		benchmark, err := r.marketService.GetKLineData(performanceBenchmark, "1d", performanceKLines)
+		if err != nil {
+			fmt.Printf("[Tool:get_performance] 上证指数获取失败: %v\n", err)
+		}
+
+		dates, closes := performanceSeries(klines)
+		benchDates, benchCloses := performanceSeries(benchmark)
+		latestDate := dates[len(dates)-1]
+		latest, err := time.Parse("2006-01-02", latestDate)
+		if err != nil {
+			return GetPerformanceOutput{}, fmt.Errorf("解析K线日期失败: %w", err)
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 区间表现（截至%s，收盘%.2f） ===\n", input.Code, latestDate, closes[len(closes)-1]))
+		sb.WriteString("区间,个股涨跌,上证涨跌,超额收益\n")
+		for _, p := range performancePeriods {
+			var ret, benchRet float64
+			var ok, benchOK bool
+			if p.bars > 0 {
+				ret, ok = indicators.PeriodReturn(closes, p.bars)
+				benchRet, benchOK = indicators.PeriodReturn(benchCloses, p.bars)
+			} else {
+				cutoff := p.cutoff(latest)
+				ret, ok = indicators.ReturnSince(dates, closes, cutoff)
+				benchRet, benchOK = indicators.ReturnSince(benchDates, benchCloses, cutoff)
+			}
+
+			if !ok {
+				sb.WriteString(fmt.Sprintf("%s,数据不足,-,-\n", p.label))
+				continue
+			}
+			if !benchOK {
+				sb.WriteString(fmt.Sprintf("%s,%+.2f%%,-,-\n", p.label, ret))
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("%s,%+.2f%%,%+.2f%%,%+.2f%%\n", p.label, ret, benchRet, ret-benchRet))
+		}
+		sb.WriteString("说明: 超额收益=个股涨跌-上证涨跌；按不复权收盘价计算，区间内有除权除息时个股涨幅会被低估")
+
+		return GetPerformanceOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_performance",
+		Description: "获取个股区间表现：近5日、20日、60日、年初至今、近一年涨跌幅，以及相对上证指数的超额收益",
+	}, handler)
+}
+
+// performanceSeries 提取日K的日期和收盘价序列
+func performanceSeries(klines []models.KLineData) ([]string, []float64) {
+	dates := make([]string, len(klines))
+	closes := make([]float64, len(klines))
+	for i, k := range klines {
+		dates[i] = k.Time
+		if len(dates[i]) > 10 {
+			dates[i] = dates[i][:10]
+		}
+		closes[i] = k.Close
+	}
+	return dates, closes
+}
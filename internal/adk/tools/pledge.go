@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"fmt"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// GetPledgeRiskInput 股权质押风险输入参数
+type GetPledgeRiskInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如600519或sh600519"`
+}
+
+// GetPledgeRiskOutput 股权质押风险输出
+type GetPledgeRiskOutput struct {
+	Data string `json:"data" jsonschema:"整体质押比例、重要股东质押情况及风险等级"`
+}
+
+// createPledgeRiskTool 创建股权质押风险工具
+func (r *Registry) createPledgeRiskTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetPledgeRiskInput) (GetPledgeRiskOutput, error) {
+		if r.shareholderService == nil {
+			return GetPledgeRiskOutput{Data: "股东数据服务不可用"}, nil
+		}
+		if input.Code == "" {
+			return GetPledgeRiskOutput{}, fmt.Errorf("股票代码不能为空")
+		}
+
+		risk, err := r.shareholderService.GetPledgeRisk(input.Code)
+		if err != nil {
+			fmt.Printf("[Tool:get_pledge_risk] 错误: %v\n", err)
+			return GetPledgeRiskOutput{}, err
+		}
+		if risk.TradeDate == "" && len(risk.Holders) == 0 {
+			return GetPledgeRiskOutput{Data: noData("%s 暂无股权质押数据（可能无质押或非A股）", risk.Code)}, nil
+		}
+
+		result := fmt.Sprintf("=== %s%s 股权质押风险 ===\n", risk.Name, risk.Code)
+		if risk.TradeDate != "" {
+			result += fmt.Sprintf("统计日期: %s\n", risk.TradeDate)
+			result += fmt.Sprintf("整体质押比例: %.2f%% | 质押笔数: %d | 质押市值: %.2f亿\n",
+				risk.PledgeRatio, risk.PledgeDealNum, risk.PledgeMktCap/1e8)
+		}
+
+		if len(risk.Holders) > 0 {
+			result += "\n【重要股东未解押质押】\n"
+			for i, h := range risk.Holders {
+				if i >= 5 {
+					break
+				}
+				result += fmt.Sprintf("%d. %s 质押%.0f万股 占其持股:%.2f%% 占总股本:%.2f%% 笔数:%d 最近公告:%s",
+					i+1, h.HolderName, h.PledgeShares/10000, h.HoldRatio, h.TotalRatio, h.RecordCount, h.LatestNotice)
+				if h.ClosePriceLine > 0 {
+					result += fmt.Sprintf(" 预估平仓线:%.2f", h.ClosePriceLine)
+				}
+				result += "\n"
+			}
+		} else {
+			result += "\n重要股东当前无未解押质押记录\n"
+		}
+
+		result += fmt.Sprintf("\n【风险等级】%s\n", risk.RiskLevel)
+		for _, reason := range risk.RiskReasons {
+			result += fmt.Sprintf("- %s\n", reason)
+		}
+
+		return GetPledgeRiskOutput{Data: result}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_pledge_risk",
+		Description: "获取个股股权质押风险，包括整体质押比例、控股股东等重要股东的质押占比、预估平仓线及风险等级",
+	}, handler)
+}
@@ -0,0 +1,107 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/run-bigpig/jcp/internal/indicators"
+)
+
+// preflightNewsLimit 会前预取的快讯条数
+const preflightNewsLimit = 10
+
+// BuildPreflightContext 会前统一获取个股公共数据（实时行情、技术分析、快讯），供所有专家共享
+// 返回注入专家的上下文及其中的技术分析原文；三类数据并发获取，单项失败时跳过，全部失败返回空字符串
+// 离线模式下不预取，由专家调用工具读取快照
+func (r *Registry) BuildPreflightContext(code string) (string, string) {
+	if r.IsOfflineMode() {
+		return "", ""
+	}
+
+	var (
+		wg                       sync.WaitGroup
+		realtime, analysis, news string
+	)
+
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		realtime = r.preflightRealtime(code)
+	}()
+	go func() {
+		defer wg.Done()
+		result, err := r.ComputeTechnicalAnalysis(code)
+		if err != nil {
+			fmt.Printf("[Preflight] 技术分析获取失败: %v\n", err)
+			return
+		}
+		analysis = indicators.FormatFullAnalysis(result)
+	}()
+	go func() {
+		defer wg.Done()
+		news = r.preflightNews()
+	}()
+	wg.Wait()
+
+	if realtime == "" && analysis == "" && news == "" {
+		return "", ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("【会前公共数据】以下数据已在会前统一获取，可直接引用；仅在需要更多细节或其他维度时再调用工具\n")
+	if realtime != "" {
+		sb.WriteString("\n[实时行情]\n")
+		sb.WriteString(realtime)
+	}
+	if analysis != "" {
+		sb.WriteString("\n[技术分析 get_kline_data(mode=analysis)]\n")
+		sb.WriteString(analysis)
+		sb.WriteString("\n")
+	}
+	if news != "" {
+		sb.WriteString("\n[最新快讯]\n")
+		sb.WriteString(news)
+	}
+	return sb.String(), analysis
+}
+
+// preflightRealtime 个股实时行情及大盘指数
+func (r *Registry) preflightRealtime(code string) string {
+	var sb strings.Builder
+	stocks, err := r.marketService.GetStockRealTimeData(code)
+	if err != nil {
+		fmt.Printf("[Preflight] 实时行情获取失败: %v\n", err)
+	}
+	for _, s := range stocks {
+		sb.WriteString(fmt.Sprintf("【%s(%s)】价格:%.2f 涨跌:%.2f%% 开盘:%.2f 最高:%.2f 最低:%.2f 成交量:%d\n",
+			s.Name, s.Symbol, s.Price, s.ChangePercent, s.Open, s.High, s.Low, s.Volume))
+	}
+	if indices, err := r.marketService.GetMarketIndices(); err == nil {
+		for _, idx := range indices {
+			sb.WriteString(fmt.Sprintf("【%s】点位:%.2f 涨跌:%.2f(%.2f%%)\n",
+				idx.Name, idx.Price, idx.Change, idx.ChangePercent))
+		}
+	}
+	return sb.String()
+}
+
+// preflightNews 最新快讯
+func (r *Registry) preflightNews() string {
+	if r.newsService == nil {
+		return ""
+	}
+	news, err := r.newsService.GetTelegraphList()
+	if err != nil {
+		fmt.Printf("[Preflight] 快讯获取失败: %v\n", err)
+		return ""
+	}
+	var sb strings.Builder
+	for i, n := range news {
+		if i >= preflightNewsLimit {
+			break
+		}
+		sb.WriteString(fmt.Sprintf("[%s][%s] %s\n", n.Time, n.Source, n.Content))
+	}
+	return sb.String()
+}
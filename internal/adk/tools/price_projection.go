@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/indicators"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// projectionKLineCount 蒙特卡洛模拟抽样使用的日K数量（约一年）
+const projectionKLineCount = 250
+
+// ProjectPriceInput 价格预测输入参数
+type ProjectPriceInput struct {
+	Code    string `json:"code" jsonschema:"股票代码，如 sh600519"`
+	Horizon int    `json:"horizon,omitzero" jsonschema:"预测交易日数，默认20，最大250"`
+	Paths   int    `json:"paths,omitzero" jsonschema:"模拟路径数，默认2000，最大5000"`
+}
+
+// ProjectPriceOutput 价格预测输出
+type ProjectPriceOutput struct {
+	Data string `json:"data" jsonschema:"蒙特卡洛模拟的5%/50%/95%分位预测价格及上涨概率"`
+}
+
+// createPriceProjectionTool 创建蒙特卡洛价格预测工具
+func (r *Registry) createPriceProjectionTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input ProjectPriceInput) (ProjectPriceOutput, error) {
+		fmt.Printf("[Tool:project_price] 调用开始, code=%s, horizon=%d, paths=%d\n", input.Code, input.Horizon, input.Paths)
+
+		if input.Code == "" {
+			return ProjectPriceOutput{Data: "请提供股票代码"}, nil
+		}
+		horizon := input.Horizon
+		if horizon <= 0 {
+			horizon = 20
+		}
+		paths := input.Paths
+		if paths <= 0 {
+			paths = 2000
+		}
+
+		klines, err := r.marketService.GetKLineData(input.Code, "1d", projectionKLineCount)
+		if err != nil {
+			fmt.Printf("[Tool:project_price] 错误: %v\n", err)
+			return ProjectPriceOutput{}, err
+		}
+		closes := make([]float64, len(klines))
+		for i, k := range klines {
+			closes[i] = k.Close
+		}
+
+		rnd := rand.New(rand.NewSource(time.Now().UnixNano()))
+		p := indicators.MonteCarloProjection(closes, horizon, paths, rnd)
+		if p == nil {
+			return ProjectPriceOutput{Data: noData("%s 日K数据不足，无法进行价格模拟", input.Code)}, nil
+		}
+
+		change := func(price float64) float64 { return (price - p.LastPrice) / p.LastPrice * 100 }
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s %d个交易日价格分布（蒙特卡洛%d条路径） ===\n", input.Code, p.Horizon, p.Paths))
+		sb.WriteString(fmt.Sprintf("最新收盘价: %.2f\n", p.LastPrice))
+		sb.WriteString(fmt.Sprintf("悲观(5%%分位): %.2f (%+.2f%%)\n", p.P5, change(p.P5)))
+		sb.WriteString(fmt.Sprintf("中性(50%%分位): %.2f (%+.2f%%)\n", p.P50, change(p.P50)))
+		sb.WriteString(fmt.Sprintf("乐观(95%%分位): %.2f (%+.2f%%)\n", p.P95, change(p.P95)))
+		sb.WriteString(fmt.Sprintf("90%%区间: %.2f ~ %.2f | 到期上涨概率: %.1f%%\n", p.P5, p.P95, p.ProbUp))
+		sb.WriteString(fmt.Sprintf("说明: 基于近%d个日收益率有放回抽样，假设未来收益分布与历史一致，未考虑涨跌停、停牌及突发事件，仅供衡量风险区间", p.Samples))
+		return ProjectPriceOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "project_price",
+		Description: "基于个股历史日收益率做蒙特卡洛模拟，给出未来N个交易日价格的5%/50%/95%分位区间和上涨概率，用概率分布而非单点目标价描述风险",
+	}, handler)
+}
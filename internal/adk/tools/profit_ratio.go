@@ -0,0 +1,159 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/indicators"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+const (
+	// profitRatioDefaultDays 默认统计的日K根数
+	profitRatioDefaultDays = 120
+	// profitRatioMinDays 最少统计的日K根数
+	profitRatioMinDays = 60
+	// profitRatioMaxDays 最多统计的日K根数
+	profitRatioMaxDays = 250
+	// profitRatioBins 筹码分布的价格档数
+	profitRatioBins = 60
+	// profitRatioTrappedZones 列出的现价上方主要套牢区个数
+	profitRatioTrappedZones = 3
+)
+
+// GetProfitRatioInput 筹码获利比例输入参数
+type GetProfitRatioInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh600519"`
+	Days int    `json:"days,omitempty" jsonschema:"统计的日K根数，默认120，范围60-250"`
+}
+
+// GetProfitRatioOutput 筹码获利比例输出
+type GetProfitRatioOutput struct {
+	Data string `json:"data" jsonschema:"获利盘比例、平均持仓成本、70%/90%筹码成本区间及集中度、现价上方主要套牢区（均为估算值）"`
+}
+
+// createProfitRatioTool 创建筹码获利比例工具
+func (r *Registry) createProfitRatioTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetProfitRatioInput) (GetProfitRatioOutput, error) {
+		fmt.Printf("[Tool:get_profit_ratio] 调用开始, code=%s, days=%d\n", input.Code, input.Days)
+
+		if input.Code == "" {
+			return GetProfitRatioOutput{Data: "请提供股票代码"}, nil
+		}
+		days := input.Days
+		if days <= 0 {
+			days = profitRatioDefaultDays
+		}
+		days = max(profitRatioMinDays, min(days, profitRatioMaxDays))
+
+		klines, err := r.marketService.GetKLineData(input.Code, "1d", days)
+		if err != nil {
+			fmt.Printf("[Tool:get_profit_ratio] 错误: %v\n", err)
+			return GetProfitRatioOutput{}, err
+		}
+
+		n := len(klines)
+		highs, lows, closes, volumes := make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+		for i, k := range klines {
+			highs[i], lows[i], closes[i], volumes[i] = k.High, k.Low, k.Close, float64(k.Volume)
+		}
+		turnovers := r.computeTurnoverRates(input.Code, klines)
+
+		d, ok := indicators.ComputeChipDistribution(highs, lows, closes, volumes, turnovers, profitRatioBins)
+		if !ok {
+			return GetProfitRatioOutput{Data: noData("%s 日K数据不足（%d根），无法估算筹码分布", input.Code, n)}, nil
+		}
+		last := d.LastPrice
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 筹码获利比例估算（%s，近%d个交易日）===\n", input.Code, klines[n-1].Time, n))
+		sb.WriteString(fmt.Sprintf("最新收盘: %.2f\n", last))
+		sb.WriteString(fmt.Sprintf("获利盘比例: %.1f%%（套牢盘 %.1f%%）\n", d.ProfitRatio, 100-d.ProfitRatio))
+		sb.WriteString(fmt.Sprintf("平均成本: %.2f（现价%s平均成本 %+.2f%%）\n", d.AvgCost, profitRatioSide(last, d.AvgCost), (last-d.AvgCost)/d.AvgCost*100))
+		sb.WriteString(fmt.Sprintf("70%%筹码成本: %.2f-%.2f，集中度 %.1f%%\n", d.Cost70Low, d.Cost70High, d.Concentration))
+		sb.WriteString(fmt.Sprintf("90%%筹码成本: %.2f-%.2f\n", d.Cost90Low, d.Cost90High))
+
+		sb.WriteString("\n【现价上方主要套牢区】\n")
+		trapped := profitRatioTrapped(d)
+		if len(trapped) == 0 {
+			sb.WriteString("现价上方几乎无筹码堆积，上行套牢盘抛压较轻\n")
+		}
+		for _, j := range trapped {
+			sb.WriteString(fmt.Sprintf("%.2f附近: 占比%.1f%%（距现价%+.2f%%）\n", d.Prices[j], d.Weights[j]*100, (d.Prices[j]-last)/last*100))
+		}
+
+		sb.WriteString("\n【解读】\n")
+		sb.WriteString(profitRatioInterpretation(d))
+
+		sb.WriteString("\n注意: 以上为近似估算，并非交易所或券商公布的真实持仓数据。")
+		if d.TurnoverDecay {
+			sb.WriteString("估算假设每日成交在最高价与最低价之间均匀分布，并按换手率衰减历史筹码；")
+		} else {
+			sb.WriteString("该标的无流通股本数据（如ETF/指数），仅按成交量在日内高低价间均匀分布累加，未做换手衰减，结果偏差更大；")
+		}
+		sb.WriteString("统计窗口之前的筹码未计入，除权除息也会造成偏差，仅作为支撑压力和抛压的参考")
+		return GetProfitRatioOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_profit_ratio",
+		Description: "根据近期日K成交量的价格分布（筹码分布）估算获利盘比例和平均持仓成本，返回70%/90%筹码成本区间、集中度及现价上方主要套牢区；结果为近似估算，用于判断获利抛压和套牢盘压力",
+	}, handler)
+}
+
+// profitRatioSide 现价相对平均成本的方位描述
+func profitRatioSide(price, cost float64) string {
+	if price >= cost {
+		return "高于"
+	}
+	return "低于"
+}
+
+// profitRatioTrapped 选出现价上方筹码占比最高的价格档（最多 profitRatioTrappedZones 个），按价格升序
+func profitRatioTrapped(d indicators.ChipDistribution) []int {
+	var idx []int
+	for j, p := range d.Prices {
+		// 套牢区至少占 1/档数 的平均水平，避免列出零星筹码
+		if p > d.LastPrice && d.Weights[j] >= 1/float64(len(d.Weights)) {
+			idx = append(idx, j)
+		}
+	}
+	sort.Slice(idx, func(a, b int) bool { return d.Weights[idx[a]] > d.Weights[idx[b]] })
+	if len(idx) > profitRatioTrappedZones {
+		idx = idx[:profitRatioTrappedZones]
+	}
+	sort.Ints(idx)
+	return idx
+}
+
+// profitRatioInterpretation 根据获利盘比例和筹码集中度给出解读
+func profitRatioInterpretation(d indicators.ChipDistribution) string {
+	var sb strings.Builder
+	switch {
+	case d.ProfitRatio >= 90:
+		sb.WriteString("- 获利盘超过90%，绝大多数持仓者盈利，若同时接近压力位或放量滞涨，获利回吐抛压较大；强势股突破后也常见此状态\n")
+	case d.ProfitRatio >= 70:
+		sb.WriteString("- 获利盘较多，持仓者整体盈利，上涨中需留意获利了结带来的分歧\n")
+	case d.ProfitRatio <= 10:
+		sb.WriteString("- 获利盘不足10%，几乎全部筹码套牢，反弹至成本密集区易遇解套抛压，但继续杀跌的卖盘也趋于枯竭\n")
+	case d.ProfitRatio <= 30:
+		sb.WriteString("- 套牢盘占多数，上方解套压力较重，反弹高度可能受限\n")
+	default:
+		sb.WriteString("- 获利盘与套牢盘相对均衡，价格处于筹码主体区域内，多空分歧较大\n")
+	}
+	switch {
+	case d.Concentration < 10:
+		sb.WriteString("- 筹码高度集中，成本趋同，一旦选择方向容易走出单边行情\n")
+	case d.Concentration > 25:
+		sb.WriteString("- 筹码较为分散，持仓成本分布宽，上下方均有一定承接与抛压\n")
+	}
+	if d.LastPrice < d.AvgCost {
+		sb.WriteString(fmt.Sprintf("- 现价低于平均成本，平均成本 %.2f 附近可视为反弹压力参考\n", d.AvgCost))
+	} else {
+		sb.WriteString(fmt.Sprintf("- 现价高于平均成本，平均成本 %.2f 附近可视为回调支撑参考\n", d.AvgCost))
+	}
+	return sb.String()
+}
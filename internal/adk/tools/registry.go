@@ -7,10 +7,17 @@ import (
 	"google.golang.org/adk/tool"
 )
 
+// 工具分类，前端会议工具模式（仅技术分析/仅基本面）按分类生成工具白名单
+const (
+	CategoryTechnical   = "technical"
+	CategoryFundamental = "fundamental"
+)
+
 // ToolInfo 工具信息
 type ToolInfo struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Categories  []string `json:"categories,omitempty"` // 所属分类，未分类的工具仅在不限制工具时可用
 }
 
 // Registry 工具注册中心
@@ -24,8 +31,16 @@ type Registry struct {
 	stockInfoService      *services.StockInfoService
 	sectorService         *services.SectorService
 	marketBreadthService  *services.MarketBreadthService
+	northboundService     *services.NorthboundService
+	shareholderService    *services.ShareholderService
+	indexValuationService *services.IndexValuationService
+	marginService         *services.MarginService
+	gubaService           *services.GubaService
+	moneyFlowService      *services.MoneyFlowService
 	tools                 map[string]tool.Tool
 	toolInfos             map[string]ToolInfo // 工具信息映射
+	toolCache             *toolResultCache    // 工具结果缓存（按配置的 TTL 生效）
+	snapshots             *toolSnapshotStore  // 工具最近结果快照（离线模式使用）
 }
 
 // NewRegistry 创建工具注册中心
@@ -39,6 +54,12 @@ func NewRegistry(
 	stockInfoService *services.StockInfoService,
 	sectorService *services.SectorService,
 	marketBreadthService *services.MarketBreadthService,
+	northboundService *services.NorthboundService,
+	shareholderService *services.ShareholderService,
+	indexValuationService *services.IndexValuationService,
+	marginService *services.MarginService,
+	gubaService *services.GubaService,
+	moneyFlowService *services.MoneyFlowService,
 ) *Registry {
 	r := &Registry{
 		marketService:         marketService,
@@ -50,8 +71,16 @@ func NewRegistry(
 		stockInfoService:      stockInfoService,
 		sectorService:         sectorService,
 		marketBreadthService:  marketBreadthService,
+		northboundService:     northboundService,
+		shareholderService:    shareholderService,
+		indexValuationService: indexValuationService,
+		marginService:         marginService,
+		gubaService:           gubaService,
+		moneyFlowService:      moneyFlowService,
 		tools:                 make(map[string]tool.Tool),
 		toolInfos:             make(map[string]ToolInfo),
+		toolCache:             newToolResultCache(),
+		snapshots:             newToolSnapshotStore(),
 	}
 	r.registerAllTools()
 	return r
@@ -60,25 +89,25 @@ func NewRegistry(
 // registerAllTools 注册所有工具
 func (r *Registry) registerAllTools() {
 	// 注册股票实时数据工具
-	r.registerTool("get_stock_realtime", "获取股票实时行情数据，包括当前价格、涨跌幅、开盘价、最高价、最低价、成交量等", r.createStockRealtimeTool)
+	r.registerTool("get_stock_realtime", "获取股票实时行情数据，包括当前价格、涨跌幅、开盘价、最高价、最低价、成交量等", r.createStockRealtimeTool, CategoryTechnical, CategoryFundamental)
 
 	// 注册K线数据工具
-	r.registerTool("get_kline_data", "获取股票K线数据，支持5分钟线、日线、周线、月线", r.createKLineTool)
+	r.registerTool("get_kline_data", "获取股票K线数据，支持5分钟线、日线、周线、月线", r.createKLineTool, CategoryTechnical)
 
 	// 注册盘口数据工具
-	r.registerTool("get_orderbook", "获取股票五档盘口数据，包括买卖五档价格和数量", r.createOrderBookTool)
+	r.registerTool("get_orderbook", "获取股票五档盘口数据，包括买卖五档价格和数量", r.createOrderBookTool, CategoryTechnical)
 
 	// 注册快讯工具
-	r.registerTool("get_news", "获取最新财经快讯，来源于财联社", r.createNewsTool)
+	r.registerTool("get_news", "获取最新财经快讯，合并财联社、新浪财经、东方财富等来源", r.createNewsTool)
 
 	// 注册股票搜索工具
-	r.registerTool("search_stocks", "搜索股票，根据关键词搜索股票代码和名称", r.createSearchStocksTool)
+	r.registerTool("search_stocks", "搜索股票，根据关键词搜索股票代码和名称", r.createSearchStocksTool, CategoryTechnical, CategoryFundamental)
 
 	// 注册研报查询工具
-	r.registerTool("get_research_report", "获取个股研报列表，包括券商评级、研究员、预测EPS/PE等信息", r.createResearchReportTool)
+	r.registerTool("get_research_report", "获取个股研报列表，包括券商评级、研究员、预测EPS/PE等信息", r.createResearchReportTool, CategoryFundamental)
 
 	// 注册研报内容查询工具
-	r.registerTool("get_report_content", "获取研报正文内容，需要先通过 get_research_report 获取 infoCode", r.createReportContentTool)
+	r.registerTool("get_report_content", "获取研报正文内容，需要先通过 get_research_report 获取 infoCode", r.createReportContentTool, CategoryFundamental)
 
 	// 注册舆情热点工具
 	r.registerTool("get_hottrend", "获取全网舆情热点，支持微博、知乎、B站、百度、抖音、头条等平台的实时热搜榜单", r.createHotTrendTool)
@@ -89,15 +118,159 @@ func (r *Registry) registerAllTools() {
 	// 注册龙虎榜营业部明细工具
 	r.registerTool("get_longhubang_detail", "获取个股龙虎榜营业部买卖明细，需要提供股票代码和交易日期", r.createLongHuBangDetailTool)
 
+	// 注册游资识别工具
+	r.registerTool("identify_hot_money", "识别龙虎榜营业部中的知名游资席位，标注游资名称及操作风格", r.createKnownHotMoneyTool)
+
+	// 注册同行估值对比工具
+	r.registerTool("compare_with_peers", "同行业估值对比，列出同行业按市值排名的PE/PB并定位目标股票", r.createPeerComparisonTool, CategoryFundamental)
+
 	// 注册市场广度工具
-	r.registerTool("get_market_breadth", "获取全市场涨跌统计数据，包括上涨/下跌/平盘家数、涨停/跌停家数", r.createMarketBreadthTool)
+	r.registerTool("get_market_breadth", "获取全市场涨跌统计数据，包括上涨/下跌/平盘家数、涨停/跌停家数", r.createMarketBreadthTool, CategoryTechnical)
+
+	// 注册北向十大成交股工具
+	r.registerTool("get_northbound_top10", "获取北向资金（沪股通/深股通）当日十大成交活跃股及净买入金额", r.createNorthboundTop10Tool)
+
+	// 注册行业ETF工具
+	r.registerTool("get_sector_etfs", "获取主要行业/主题ETF的实时涨跌幅及溢价率，可按主题筛选", r.createETFScreenerTool)
+
+	// 注册股权质押风险工具
+	r.registerTool("get_pledge_risk", "获取个股股权质押比例及控股股东质押情况，评估质押爆仓风险", r.createPledgeRiskTool, CategoryFundamental)
+
+	// 注册大股东增减持工具
+	r.registerTool("get_insider_trading", "获取个股近期大股东及董监高增持/减持记录", r.createInsiderTradingTool, CategoryFundamental)
+
+	// 注册研报一致目标价工具
+	r.registerTool("get_report_target", "根据研报预测EPS×PE计算隐含目标价及一致目标价的上行/下行空间", r.createReportTargetTool, CategoryFundamental)
+
+	// 注册指数估值工具
+	r.registerTool("get_index_valuation", "获取主要指数PE/PB及历史百分位，判断指数估值高低", r.createIndexValuationTool, CategoryFundamental)
+
+	// 注册相对成交量工具
+	r.registerTool("get_relative_volume", "计算个股同时刻/全日相对成交量(RVOL)，标记异常放量", r.createRelativeVolumeTool, CategoryTechnical)
+
+	// 注册产业链上下游工具
+	r.registerTool("get_industry_chain", "获取个股所属行业的产业链位置及上下游关系", r.createIndustryChainTool, CategoryFundamental)
+
+	// 注册换手率趋势工具
+	r.registerTool("get_turnover_trend", "获取个股最近N日换手率及60日分位等级", r.createTurnoverTrendTool, CategoryTechnical)
+
+	// 注册停牌检测工具
+	r.registerTool("check_suspension", "检测个股当前是否停牌及停牌前最后交易日", r.createSuspensionCheckTool, CategoryTechnical, CategoryFundamental)
+
+	// 注册同行业涨跌幅排名工具
+	r.registerTool("get_industry_rank", "获取个股在所属行业内的当日涨跌幅排名及领涨领跌股", r.createIndustryRankTool, CategoryTechnical)
+
+	// 注册融券做空数据工具
+	r.registerTool("get_short_selling", "获取个股近期融券卖出量及余量趋势，标记做空异动", r.createShortSellingTool)
+
+	// 注册多周期趋势共振工具
+	r.registerTool("get_mtf_trend", "计算日线/周线/月线的均线排列和MACD方向，判断多周期趋势是否共振", r.createMultiTimeframeTool, CategoryTechnical)
+
+	// 注册股吧讨论热度工具
+	r.registerTool("get_discussion_trend", "获取个股近期股吧人气排名走势，判断散户关注度升温或降温", r.createDiscussionTrendTool)
+
+	// 注册主力资金价格区间工具
+	r.registerTool("get_money_flow_levels", "按价格区间汇总主力净流入，估算主力成本区间", r.createMoneyFlowPriceTool, CategoryTechnical)
+
+	// 注册自选股开盘扫描工具
+	r.registerTool("scan_open", "盘前扫描自选股竞价高开及前一日形态，标记涨停/突破候选", r.createOpenScanTool, CategoryTechnical)
+
+	// 注册波动率状态工具
+	r.registerTool("get_volatility_regime", "计算10/20/60日已实现波动率，判断当前波动状态", r.createVolatilityRegimeTool, CategoryTechnical)
+
+	// 注册主题快讯工具
+	r.registerTool("get_theme_news", "按主题关键词筛选最新财经快讯", r.createThemeNewsTool)
+
+	// 注册最大回撤统计工具
+	r.registerTool("get_drawdown_stats", "计算多窗口最大回撤、当前回撤及平均修复天数", r.createDrawdownTool, CategoryTechnical)
+
+	// 注册盘后情绪工具
+	r.registerTool("get_after_hours", "获取股指期货、A50夜盘及宽基ETF收盘折溢价，辅助次日预判", r.createAfterHoursTool)
+
+	// 注册蒙特卡洛价格预测工具
+	r.registerTool("project_price", "蒙特卡洛模拟未来价格分布，给出5%/50%/95%分位预测价", r.createPriceProjectionTool, CategoryTechnical)
+
+	// 注册ETF期权工具
+	r.registerTool("get_options", "获取ETF期权的隐含波动率、认沽认购比及最大痛点", r.createOptionsTool)
+
+	// 注册次新股信息工具
+	r.registerTool("get_new_listing_info", "获取上市日期、上市天数、是否次新及开板状态", r.createNewListingTool)
+
+	// 注册外盘关联行情工具
+	r.registerTool("get_cross_market", "获取A股关联的港股/美股标的及外盘隔夜涨跌", r.createCrossMarketTool)
+
+	// 注册限售股解禁工具
+	r.registerTool("get_unlock_schedule", "获取限售股解禁日期、数量及占流通股比例", r.createUnlockScheduleTool, CategoryFundamental)
+
+	// 注册趋势通道工具
+	r.registerTool("get_trend_channel", "计算趋势通道上下轨、斜率及当前价位置", r.createTrendChannelTool, CategoryTechnical)
+
+	// 注册分红质量工具
+	r.registerTool("get_dividend_quality", "计算股息率、连续分红年数及平均分红率，评估分红质量", r.createDividendQualityTool, CategoryFundamental)
+
+	// 注册区间表现工具
+	r.registerTool("get_performance", "计算近5日/20日/60日/年初至今/近一年涨跌幅及相对上证指数的超额收益", r.createPerformanceTool, CategoryTechnical)
+
+	// 注册龙虎榜净买入排名工具
+	r.registerTool("get_longhubang_rank", "获取个股在当日龙虎榜中的净买入排名及机构/游资主导判断", r.createLongHuBangRankTool)
+
+	// 注册季节性统计工具
+	r.registerTool("get_seasonality", "统计个股或指数历年各月平均涨跌幅及当月历史倾向", r.createSeasonalityTool)
+
+	// 注册定期报告经营情况摘录工具
+	r.registerTool("get_report_highlights", "获取最新年报/半年报经营情况讨论与分析摘录", r.createReportHighlightsTool, CategoryFundamental)
+
+	// 注册涨跌量比工具
+	r.registerTool("get_updown_volume", "计算上涨日与下跌日成交量之比，量化买卖力量", r.createUpDownVolumeTool, CategoryTechnical)
+
+	// 注册市场宽度趋势工具
+	r.registerTool("get_breadth_trend", "获取最近N个交易日全市场涨跌家数及腾落线", r.createBreadthTrendTool, CategoryTechnical)
+
+	// 注册ETF申赎资金流工具
+	r.registerTool("get_etf_flow", "获取沪市ETF份额变化与净申赎，识别价格与资金流背离", r.createETFFlowTool)
+
+	// 注册综合技术评分工具
+	r.registerTool("get_tech_score", "汇总多项技术信号为0-100综合评分，列出各项权重和理由", r.createTechScoreTool, CategoryTechnical)
+
+	// 注册大宗交易游资动向工具
+	r.registerTool("get_block_trades", "获取个股近期大宗交易及折溢价，标注游资席位并判断是否折价吸筹", r.createBlockTradesTool)
+
+	// 注册增发价位置工具
+	r.registerTool("get_issue_price", "获取最近一次增发/配股发行价及现价相对发行价的溢折价", r.createIssuePriceTool)
+
+	// 注册配对价差工具
+	r.registerTool("get_pairs_spread", "计算两只股票价格比值的均值、标准差及当前Z分数，识别配对背离", r.createPairsSpreadTool)
+
+	// 注册股东户数趋势工具
+	r.registerTool("get_shareholder_count", "获取最近几个报告期的股东户数及环比变化，判断筹码集中或分散", r.createShareholderCountTool)
+
+	// 注册均线密集区工具
+	r.registerTool("get_ma_confluence", "计算MA5至MA250并找出多条均线聚集的支撑/压力区", r.createMAConfluenceTool, CategoryTechnical)
+
+	// 注册分时内外盘工具
+	r.registerTool("get_order_flow", "统计当日外盘/内盘、内外盘比及分段累计走势，判断盘中买卖主导方", r.createOrderFlowTool)
+
+	// 注册高周期枢轴点工具
+	r.registerTool("get_htf_pivots", "计算周线/月线经典枢轴点及现价所处的支撑压力区间", r.createHTFPivotsTool, CategoryTechnical)
+
+	// 注册筹码获利比例工具
+	r.registerTool("get_profit_ratio", "估算筹码分布的获利盘比例与平均持仓成本", r.createProfitRatioTool)
+
+	// 注册概念题材共振工具
+	r.registerTool("get_concept_resonance", "个股所属概念题材板块及当日涨跌幅、热点与共振标记", r.createConceptResonanceTool)
+
+	// 注册换手速度工具
+	r.registerTool("get_turnover_velocity", "估算流通盘换手速度与隐含平均持股天数", r.createTurnoverVelocityTool)
 }
 
-// registerTool 注册单个工具并保存信息
-func (r *Registry) registerTool(name, description string, creator func() (tool.Tool, error)) {
+// registerTool 注册单个工具并保存信息，函数工具统一包装结果缓存
+func (r *Registry) registerTool(name, description string, creator func() (tool.Tool, error), categories ...string) {
 	if t, err := creator(); err == nil {
+		if ft, ok := t.(functionTool); ok {
+			t = &cachedTool{functionTool: ft, registry: r}
+		}
 		r.tools[name] = t
-		r.toolInfos[name] = ToolInfo{Name: name, Description: description}
+		r.toolInfos[name] = ToolInfo{Name: name, Description: description, Categories: categories}
 	}
 }
 
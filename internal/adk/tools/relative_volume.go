@@ -0,0 +1,108 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/indicators"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// rvolLookback 相对成交量对比的历史交易日数
+const rvolLookback = 20
+
+// GetRelativeVolumeInput 相对成交量输入参数
+type GetRelativeVolumeInput struct {
+	Code      string  `json:"code" jsonschema:"股票代码，如 sh600519"`
+	Threshold float64 `json:"threshold,omitzero" jsonschema:"异常放量阈值(倍)，默认2.0"`
+}
+
+// GetRelativeVolumeOutput 相对成交量输出
+type GetRelativeVolumeOutput struct {
+	Data string `json:"data" jsonschema:"同时刻及全日相对成交量(RVOL)和异常放量标记"`
+}
+
+// createRelativeVolumeTool 创建相对成交量(RVOL)工具
+func (r *Registry) createRelativeVolumeTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetRelativeVolumeInput) (GetRelativeVolumeOutput, error) {
+		fmt.Printf("[Tool:get_relative_volume] 调用开始, code=%s, threshold=%.2f\n", input.Code, input.Threshold)
+
+		if input.Code == "" {
+			return GetRelativeVolumeOutput{Data: "请提供股票代码"}, nil
+		}
+
+		threshold := input.Threshold
+		if threshold <= 0 {
+			threshold = 2.0
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 相对成交量(RVOL) ===\n", input.Code))
+
+		// 同时刻 RVOL：5分钟线约48根/日，多取一天覆盖今日
+		var intraday indicators.IntradayRVOL
+		bars, err := r.marketService.GetKLineData(input.Code, "5m", (rvolLookback+1)*48)
+		if err != nil {
+			fmt.Printf("[Tool:get_relative_volume] 获取5分钟线错误: %v\n", err)
+		} else {
+			times := make([]string, len(bars))
+			volumes := make([]int64, len(bars))
+			for i, b := range bars {
+				times[i] = b.Time
+				volumes[i] = b.Volume
+			}
+			intraday = indicators.TimeOfDayRVOL(times, volumes, rvolLookback)
+		}
+		if intraday.Days > 0 {
+			sb.WriteString(fmt.Sprintf("同时刻RVOL: %.2f倍（截至%s 今日累计%.0f手，近%d日同时刻均值%.0f手）%s\n",
+				intraday.Ratio, intraday.AsOf, intraday.TodayCum/100, intraday.Days, intraday.AvgCum/100,
+				rvolFlag(intraday.Ratio, threshold)))
+		} else {
+			sb.WriteString("同时刻RVOL: 分时数据不足，无法计算\n")
+		}
+
+		// 全日 RVOL：当日成交量 / 前20日均量
+		daily, err := r.marketService.GetKLineData(input.Code, "1d", rvolLookback+1)
+		if err != nil {
+			fmt.Printf("[Tool:get_relative_volume] 错误: %v\n", err)
+			return GetRelativeVolumeOutput{}, err
+		}
+		n := len(daily)
+		if n > rvolLookback {
+			volumes := make([]int64, n)
+			for i, k := range daily {
+				volumes[i] = k.Volume
+			}
+			volMA := indicators.VolMA(volumes, rvolLookback)
+			if avg := volMA[n-2]; avg > 0 {
+				ratio := float64(volumes[n-1]) / avg
+				sb.WriteString(fmt.Sprintf("全日RVOL: %.2f倍（%s 成交%.0f手，前%d日均量%.0f手）%s\n",
+					ratio, daily[n-1].Time, float64(volumes[n-1])/100, rvolLookback, avg/100, rvolFlag(ratio, threshold)))
+			}
+		} else {
+			sb.WriteString("全日RVOL: 日线数据不足，无法计算\n")
+		}
+
+		sb.WriteString("\n说明: 盘中优先参考同时刻RVOL，全日RVOL在收盘后更准确")
+		return GetRelativeVolumeOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_relative_volume",
+		Description: "计算个股相对成交量(RVOL)：今日截至当前时刻成交量与近20日同时刻均值之比，以及全日成交量与20日均量之比，超过阈值标记异常放量",
+	}, handler)
+}
+
+// rvolFlag 相对成交量标记
+func rvolFlag(ratio, threshold float64) string {
+	switch {
+	case ratio >= threshold:
+		return "【异常放量】"
+	case ratio > 0 && ratio < 0.5:
+		return "【明显缩量】"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,65 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// reportHighlightsMaxRunes 返回的报告摘录字数上限，避免占满上下文
+const reportHighlightsMaxRunes = 4000
+
+// GetReportHighlightsInput 定期报告摘录输入参数
+type GetReportHighlightsInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh600519 或 600519"`
+}
+
+// GetReportHighlightsOutput 定期报告摘录输出
+type GetReportHighlightsOutput struct {
+	Data string `json:"data" jsonschema:"最新年报/半年报经营情况讨论与分析章节摘录"`
+}
+
+// createReportHighlightsTool 创建定期报告经营情况摘录工具
+func (r *Registry) createReportHighlightsTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetReportHighlightsInput) (GetReportHighlightsOutput, error) {
+		fmt.Printf("[Tool:get_report_highlights] 调用开始, code=%s\n", input.Code)
+
+		if input.Code == "" {
+			return GetReportHighlightsOutput{Data: "请提供股票代码"}, nil
+		}
+		if r.researchReportService == nil {
+			return GetReportHighlightsOutput{Data: "公告服务不可用"}, nil
+		}
+
+		h, err := r.researchReportService.GetReportHighlights(input.Code, reportHighlightsMaxRunes)
+		if err != nil {
+			fmt.Printf("[Tool:get_report_highlights] 错误: %v\n", err)
+			return GetReportHighlightsOutput{}, err
+		}
+		if strings.TrimSpace(h.Content) == "" {
+			return GetReportHighlightsOutput{Data: noData("%s 未能获取报告正文，可查看原文: %s", h.Title, h.PDFUrl)}, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s（%s）===\n", h.Title, h.NoticeDate))
+		if h.Section != "" {
+			sb.WriteString(fmt.Sprintf("【%s】\n", h.Section))
+		} else {
+			sb.WriteString("【未定位到经营情况讨论与分析章节，以下为报告开头】\n")
+		}
+		sb.WriteString(h.Content)
+		if h.Truncated && h.PDFUrl != "" {
+			sb.WriteString(fmt.Sprintf("\n完整原文: %s", h.PDFUrl))
+		}
+
+		fmt.Printf("[Tool:get_report_highlights] 调用完成, 内容长度=%d\n", len(h.Content))
+		return GetReportHighlightsOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_report_highlights",
+		Description: "获取公司最新年报或半年报中\"经营情况讨论与分析/管理层讨论与分析\"章节原文摘录（超长截断），了解主营业务、经营回顾和未来规划等定性信息",
+	}, handler)
+}
@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// GetReportTargetInput 研报目标价输入参数
+type GetReportTargetInput struct {
+	Code  string `json:"code" jsonschema:"股票代码，如 sh600519 或 600519"`
+	Count int    `json:"count,omitzero" jsonschema:"参与统计的最新研报数量，默认20，最大50"`
+}
+
+// GetReportTargetOutput 研报目标价输出
+type GetReportTargetOutput struct {
+	Data string `json:"data" jsonschema:"各研报隐含目标价及一致目标价"`
+}
+
+// createReportTargetTool 创建研报一致目标价工具
+func (r *Registry) createReportTargetTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetReportTargetInput) (GetReportTargetOutput, error) {
+		fmt.Printf("[Tool:get_report_target] 调用开始, code=%s, count=%d\n", input.Code, input.Count)
+
+		if input.Code == "" {
+			return GetReportTargetOutput{Data: "请提供股票代码"}, nil
+		}
+		if r.researchReportService == nil {
+			return GetReportTargetOutput{Data: "研报服务不可用"}, nil
+		}
+
+		count := input.Count
+		if count <= 0 {
+			count = 20
+		}
+		if count > 50 {
+			count = 50
+		}
+
+		resp, err := r.researchReportService.GetResearchReports(input.Code, count, 1)
+		if err != nil {
+			fmt.Printf("[Tool:get_report_target] 错误: %v\n", err)
+			return GetReportTargetOutput{}, err
+		}
+
+		// 当前价获取失败不影响目标价统计
+		currentPrice := r.getCurrentPrice(input.Code)
+
+		consensus := services.ComputeReportTargets(resp.Data, currentPrice)
+		if consensus == nil {
+			return GetReportTargetOutput{Data: noData("%s 近期研报缺少有效的预测EPS/PE，无法计算目标价", input.Code)}, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 研报隐含目标价（今年预测EPS × 预测PE）===\n", input.Code))
+		for i, t := range consensus.Targets {
+			sb.WriteString(fmt.Sprintf("%d. [%s] %s 【%s】 EPS:%.2f × PE:%.1f = %.2f\n",
+				i+1, t.PublishDate, t.OrgSName, t.Rating, t.EPS, t.PE, t.Target))
+		}
+
+		sb.WriteString(fmt.Sprintf("\n【一致目标价】中位数:%.2f 均值:%.2f 区间:%.2f ~ %.2f (有效研报%d篇)\n",
+			consensus.Median, consensus.Mean, consensus.Min, consensus.Max, len(consensus.Targets)))
+		if consensus.CurrentPrice > 0 {
+			direction := "上行空间"
+			if consensus.UpsidePct < 0 {
+				direction = "下行空间"
+			}
+			sb.WriteString(fmt.Sprintf("当前价:%.2f，相对一致目标价%s:%.2f%%\n", consensus.CurrentPrice, direction, consensus.UpsidePct))
+		} else {
+			sb.WriteString("当前价获取失败，未计算上行/下行空间\n")
+		}
+
+		return GetReportTargetOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_report_target",
+		Description: "根据券商研报的今年预测EPS和PE计算隐含目标价，汇总一致目标价并给出相对当前价的上行/下行空间",
+	}, handler)
+}
+
+// getCurrentPrice 获取股票当前价，失败返回0
+func (r *Registry) getCurrentPrice(code string) float64 {
+	if r.marketService == nil {
+		return 0
+	}
+	symbol := strings.ToLower(code)
+	if len(symbol) == 6 && r.configService != nil {
+		if info := r.configService.GetStockBasicInfo(symbol); info != nil {
+			symbol = info.Symbol
+		}
+	}
+	stocks, err := r.marketService.GetStockRealTimeData(symbol)
+	if err != nil || len(stocks) == 0 {
+		return 0
+	}
+	return stocks[0].Price
+}
@@ -46,6 +46,11 @@ func (r *Registry) createResearchReportTool() (tool.Tool, error) {
 			return GetResearchReportOutput{}, err
 		}
 
+		if len(result.Data) == 0 {
+			fmt.Printf("[Tool:get_research_report] 调用完成, 无研报\n")
+			return GetResearchReportOutput{Data: noData("%s 暂无券商研报", input.Code)}, nil
+		}
+
 		text := r.researchReportService.FormatReportsToText(result.Data)
 		fmt.Printf("[Tool:get_research_report] 调用完成, 返回%d条研报\n", len(result.Data))
 
@@ -90,8 +95,12 @@ func (r *Registry) createReportContentTool() (tool.Tool, error) {
 
 		fmt.Printf("[Tool:get_report_content] 调用完成, 内容长度=%d\n", len(result.Content))
 
+		content := result.Content
+		if content == "" {
+			content = noData("未能获取研报正文，可查看PDF原文")
+		}
 		return GetReportContentOutput{
-			Content: result.Content,
+			Content: content,
 			PDFUrl:  result.PDFUrl,
 		}, nil
 	}
@@ -42,7 +42,7 @@ func (r *Registry) createSearchStocksTool() (tool.Tool, error) {
 		}
 
 		if result == "" {
-			result = "未找到匹配的股票"
+			result = noData("未找到与[%s]匹配的股票", input.Keyword)
 		}
 
 		fmt.Printf("[Tool:search_stocks] 调用完成, 返回%d条结果\n", len(results))
@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/indicators"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// seasonalityKLines 获取的日K数量（新浪接口单次上限，约四年）
+const seasonalityKLines = 1023
+
+// seasonTendencyNames 季节性倾向中文名称
+var seasonTendencyNames = map[string]string{
+	indicators.SeasonStrong:       "历史偏强",
+	indicators.SeasonWeak:         "历史偏弱",
+	indicators.SeasonNeutral:      "无明显规律",
+	indicators.SeasonInsufficient: "样本不足",
+}
+
+// GetSeasonalityInput 季节性统计输入参数
+type GetSeasonalityInput struct {
+	Code string `json:"code" jsonschema:"股票或指数代码，如 sh600519、sh000001"`
+}
+
+// GetSeasonalityOutput 季节性统计输出
+type GetSeasonalityOutput struct {
+	Data string `json:"data" jsonschema:"历年各月平均涨跌幅、上涨概率及当月历史倾向"`
+}
+
+// createSeasonalityTool 创建季节性统计工具
+func (r *Registry) createSeasonalityTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetSeasonalityInput) (GetSeasonalityOutput, error) {
+		fmt.Printf("[Tool:get_seasonality] 调用开始, code=%s\n", input.Code)
+
+		if input.Code == "" {
+			return GetSeasonalityOutput{Data: "请提供股票或指数代码"}, nil
+		}
+
+		klines, err := r.marketService.GetKLineData(input.Code, "1d", seasonalityKLines)
+		if err != nil {
+			fmt.Printf("[Tool:get_seasonality] 错误: %v\n", err)
+			return GetSeasonalityOutput{}, err
+		}
+		dates, closes := performanceSeries(klines)
+		returns := indicators.MonthlyReturns(dates, closes)
+		if len(returns) == 0 {
+			return GetSeasonalityOutput{Data: noData("%s 日K数据不足一个完整月份，无法统计季节性", input.Code)}, nil
+		}
+		seasons := indicators.Seasonality(returns)
+
+		latestDate := dates[len(dates)-1]
+		latest, err := time.Parse("2006-01-02", latestDate)
+		if err != nil {
+			return GetSeasonalityOutput{}, fmt.Errorf("解析K线日期失败: %w", err)
+		}
+		first := returns[0]
+		current := seasons[latest.Month()-1]
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 月度季节性（%d-%02d 至 %s，共%d个完整月） ===\n",
+			input.Code, first.Year, first.Month, latestDate, len(returns)))
+		sb.WriteString("月份,样本年数,平均涨跌,上涨概率,最好,最差,倾向\n")
+		for _, s := range seasons {
+			if s.Samples == 0 {
+				sb.WriteString(fmt.Sprintf("%d月,0,-,-,-,-,%s\n", s.Month, seasonTendencyNames[s.Tendency]))
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("%d月,%d,%+.2f%%,%.0f%%,%+.2f%%,%+.2f%%,%s\n",
+				s.Month, s.Samples, s.AvgReturn, s.WinRate, s.Best, s.Worst, seasonTendencyNames[s.Tendency]))
+		}
+
+		sb.WriteString(fmt.Sprintf("\n【当月】%d月 %s", current.Month, seasonTendencyNames[current.Tendency]))
+		if current.Samples > 0 {
+			sb.WriteString(fmt.Sprintf("：历年平均%+.2f%%，%d年中上涨%.0f%%", current.AvgReturn, current.Samples, current.WinRate))
+		}
+		// 本月至今：以上月最后一个交易日收盘为基准
+		monthStart := time.Date(latest.Year(), latest.Month(), 1, 0, 0, 0, 0, time.Local)
+		if mtd, ok := indicators.ReturnSince(dates, closes, monthStart.AddDate(0, 0, -1).Format("2006-01-02")); ok {
+			sb.WriteString(fmt.Sprintf("；本月至今%+.2f%%", mtd))
+		}
+		sb.WriteString("\n说明: 仅为历史统计倾向，样本仅数年且按不复权收盘价计算，不构成对本月走势的预测，须结合基本面和当下市场环境判断")
+
+		return GetSeasonalityOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_seasonality",
+		Description: "统计个股或指数近约四年各自然月的平均涨跌幅和上涨概率，标注当前月份的历史倾向（如春节行情、年报季），仅为历史统计而非预测",
+	}, handler)
+}
@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// GetSectorETFsInput 行业ETF输入参数
+type GetSectorETFsInput struct {
+	Theme string `json:"theme,omitempty" jsonschema:"主题关键词，如 半导体、军工、证券，为空则返回全部行业ETF"`
+	Limit int    `json:"limit,omitzero" jsonschema:"返回数量，默认20条"`
+}
+
+// GetSectorETFsOutput 行业ETF输出
+type GetSectorETFsOutput struct {
+	Data string `json:"data" jsonschema:"行业/主题ETF行情及溢价率"`
+}
+
+// createETFScreenerTool 创建行业ETF筛选工具
+func (r *Registry) createETFScreenerTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetSectorETFsInput) (GetSectorETFsOutput, error) {
+		if r.stockInfoService == nil {
+			return GetSectorETFsOutput{Data: "ETF行情服务不可用"}, nil
+		}
+
+		limit := input.Limit
+		if limit <= 0 {
+			limit = 20
+		}
+
+		etfs := services.GetSectorETFs(input.Theme)
+		if len(etfs) == 0 {
+			return GetSectorETFsOutput{Data: noData("未找到与[%s]相关的行业ETF", input.Theme)}, nil
+		}
+
+		codes := make([]string, 0, len(etfs))
+		themes := make(map[string]string, len(etfs))
+		for _, etf := range etfs {
+			codes = append(codes, etf.Code)
+			themes[etf.Code] = etf.Theme
+		}
+
+		quotes, err := r.stockInfoService.GetETFQuotes(codes)
+		if err != nil {
+			fmt.Printf("[Tool:get_sector_etfs] 错误: %v\n", err)
+			return GetSectorETFsOutput{}, err
+		}
+
+		list := make([]*services.ETFQuote, 0, len(quotes))
+		for _, q := range quotes {
+			list = append(list, q)
+		}
+		// 按涨跌幅降序
+		sort.Slice(list, func(i, j int) bool {
+			return list[i].ChangePercent > list[j].ChangePercent
+		})
+		if len(list) > limit {
+			list = list[:limit]
+		}
+
+		result := "代码,名称,主题,最新价,涨跌幅,成交额,溢价率\n"
+		for _, q := range list {
+			premium := "-"
+			if q.IOPV > 0 {
+				premium = fmt.Sprintf("%+.2f%%", q.Premium)
+			}
+			result += fmt.Sprintf("%s,%s,%s,%.3f,%+.2f%%,%.0f万,%s\n",
+				q.Code, q.Name, themes[q.Code], q.Price, q.ChangePercent, q.Amount/10000, premium)
+		}
+
+		return GetSectorETFsOutput{Data: result}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_sector_etfs",
+		Description: "获取主要行业/主题ETF的实时涨跌幅、成交额及溢价率，可按主题筛选，用于将热点主题映射到可投资的ETF",
+	}, handler)
+}
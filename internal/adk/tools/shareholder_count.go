@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/services"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// GetShareholderCountInput 股东户数输入参数
+type GetShareholderCountInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如600519或sh600519"`
+}
+
+// GetShareholderCountOutput 股东户数输出
+type GetShareholderCountOutput struct {
+	Data string `json:"data" jsonschema:"最近几个报告期的股东户数、环比变化、户均持股及筹码集中/分散判断"`
+}
+
+// createShareholderCountTool 创建股东户数趋势工具
+func (r *Registry) createShareholderCountTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetShareholderCountInput) (GetShareholderCountOutput, error) {
+		fmt.Printf("[Tool:get_shareholder_count] 调用开始, code=%s\n", input.Code)
+
+		if r.shareholderService == nil {
+			return GetShareholderCountOutput{Data: "股东数据服务不可用"}, nil
+		}
+		if input.Code == "" {
+			return GetShareholderCountOutput{}, fmt.Errorf("股票代码不能为空")
+		}
+
+		trend, err := r.shareholderService.GetHolderCountTrend(input.Code)
+		if err != nil {
+			fmt.Printf("[Tool:get_shareholder_count] 错误: %v\n", err)
+			return GetShareholderCountOutput{}, err
+		}
+		if len(trend.Periods) == 0 {
+			return GetShareholderCountOutput{Data: noData("%s 暂无股东户数数据", input.Code)}, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s(%s) 股东户数 ===\n", trend.Name, trend.Code))
+		sb.WriteString("截止日,公告日,股东户数,较上期,区间股价涨跌,户均持股(股),户均市值(万元)\n")
+		for _, p := range trend.Periods {
+			sb.WriteString(fmt.Sprintf("%s,%s,%.0f,%+.2f%%,%+.2f%%,%.0f,%.2f\n",
+				p.EndDate, p.NoticeDate, p.HolderNum, p.ChangePercent, p.PriceChange, p.AvgHoldShares, p.AvgMarketCap/10000))
+		}
+
+		sb.WriteString(fmt.Sprintf("\n趋势: %s", trend.Trend))
+		if trend.Trend != services.HolderTrendUnknown {
+			sb.WriteString(fmt.Sprintf("（最近几期户数累计%+.2f%%，同期股价累计%+.2f%%）", trend.CumHolderChange, trend.CumPriceChange))
+		}
+		sb.WriteString("\n")
+		if signal := holderCountSignal(trend); signal != "" {
+			sb.WriteString("信号: " + signal + "\n")
+		}
+		sb.WriteString("说明: 股东户数按报告期披露，存在1-2个月滞后；户数减少说明筹码向少数账户集中")
+
+		return GetShareholderCountOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_shareholder_count",
+		Description: "获取个股最近几个报告期的股东户数及环比变化、户均持股，判断筹码集中还是分散；户数持续下降而股价企稳或上涨是主力吸筹的经典信号",
+	}, handler)
+}
+
+// holderCountSignal 结合户数趋势与同期股价给出量价信号
+func holderCountSignal(trend *services.HolderCountTrend) string {
+	switch {
+	case trend.Trend == services.HolderTrendConcentrating && trend.CumPriceChange >= -5:
+		return "户数下降且股价企稳或上涨，筹码向主力集中，符合吸筹特征"
+	case trend.Trend == services.HolderTrendConcentrating:
+		return "户数下降但股价同步下跌，可能是散户割肉离场，需结合成交量确认是否有承接"
+	case trend.Trend == services.HolderTrendDispersing && trend.CumPriceChange > 5:
+		return "户数增加且股价上涨，筹码向散户扩散，警惕主力借涨派发"
+	case trend.Trend == services.HolderTrendDispersing:
+		return "户数增加，筹码趋于分散，短期缺乏主力资金维护"
+	default:
+		return ""
+	}
+}
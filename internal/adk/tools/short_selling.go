@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// GetShortSellingInput 融券做空数据输入参数
+type GetShortSellingInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如600519或sh600519"`
+	Days int    `json:"days,omitzero" jsonschema:"展示最近N个交易日，默认10，最大30"`
+}
+
+// GetShortSellingOutput 融券做空数据输出
+type GetShortSellingOutput struct {
+	Data string `json:"data" jsonschema:"近期融券卖出量、融券余量趋势及做空异动标记"`
+}
+
+// createShortSellingTool 创建融券做空数据工具
+func (r *Registry) createShortSellingTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetShortSellingInput) (GetShortSellingOutput, error) {
+		if r.marginService == nil {
+			return GetShortSellingOutput{Data: "融资融券服务不可用"}, nil
+		}
+		if input.Code == "" {
+			return GetShortSellingOutput{}, fmt.Errorf("股票代码不能为空")
+		}
+
+		days := input.Days
+		if days <= 0 {
+			days = 10
+		}
+		if days > 30 {
+			days = 30
+		}
+
+		data, err := r.marginService.GetShortSelling(input.Code)
+		if err != nil {
+			fmt.Printf("[Tool:get_short_selling] 错误: %v\n", err)
+			return GetShortSellingOutput{}, err
+		}
+		if len(data.Records) == 0 {
+			return GetShortSellingOutput{Data: noData("%s 暂无融资融券数据（可能不是两融标的）", data.Code)}, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s%s 融券做空数据 ===\n", data.Name, data.Code))
+		latest := data.Records[0]
+		sb.WriteString(fmt.Sprintf("最新(%s): 融券卖出%.0f股 偿还%.0f股 余量%.0f股 融券余额%.2f万 | 融资余额%.2f亿\n",
+			latest.Date, latest.ShortSellVol, latest.ShortRepayVol, latest.ShortRemainVol,
+			latest.ShortBalance/1e4, latest.FinBalance/1e8))
+		if data.AvgSellVol > 0 {
+			sb.WriteString(fmt.Sprintf("融券卖出量为前期均值(%.0f股)的%.2f倍\n", data.AvgSellVol, data.SpikeRatio))
+		}
+		sb.WriteString(fmt.Sprintf("融券余量趋势: %s\n", data.RemainTrend))
+		if data.Spike {
+			sb.WriteString("【做空异动】最新一日融券卖出量显著放大，警惕做空压力\n")
+		}
+
+		sb.WriteString("\n日期,收盘,涨跌幅,融券卖出(股),融券偿还(股),融券余量(股),融资余额(亿)\n")
+		for i, rec := range data.Records {
+			if i >= days {
+				break
+			}
+			sb.WriteString(fmt.Sprintf("%s,%.2f,%.2f%%,%.0f,%.0f,%.0f,%.2f\n",
+				rec.Date, rec.Close, rec.ChangePercent, rec.ShortSellVol, rec.ShortRepayVol, rec.ShortRemainVol, rec.FinBalance/1e8))
+		}
+
+		return GetShortSellingOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_short_selling",
+		Description: "获取个股近期融券卖出量、偿还量、融券余量趋势及融资余额，标记融券卖出异常放大的做空异动",
+	}, handler)
+}
@@ -3,13 +3,16 @@ package tools
 import (
 	"fmt"
 
+	"github.com/run-bigpig/jcp/internal/models"
+
 	"google.golang.org/adk/tool"
 	"google.golang.org/adk/tool/functiontool"
 )
 
 // GetStockRealtimeInput 获取股票实时数据输入参数
 type GetStockRealtimeInput struct {
-	Codes []string `json:"codes" jsonschema:"股票代码列表，如 sh600519, sz000001"`
+	Codes            []string `json:"codes" jsonschema:"股票代码列表，如 sh600519, sz000001"`
+	IncludeOrderBook bool     `json:"include_orderbook,omitempty" jsonschema:"是否同时返回五档盘口，默认false；需要盘口时设为true可省去单独调用get_orderbook"`
 }
 
 // GetStockRealtimeOutput 获取股票实时数据输出
@@ -28,17 +31,40 @@ func (r *Registry) createStockRealtimeTool() (tool.Tool, error) {
 			return GetStockRealtimeOutput{Data: "请提供股票代码"}, nil
 		}
 
-		stocks, err := r.marketService.GetStockRealTimeData(input.Codes...)
-		if err != nil {
-			fmt.Printf("[Tool:get_stock_realtime] 错误: %v\n", err)
-			return GetStockRealtimeOutput{}, err
+		// 需要盘口时改用含五档的行情接口，一次请求同时取得价格和盘口
+		var stocks []models.Stock
+		var books []models.OrderBook
+		if input.IncludeOrderBook {
+			data, err := r.marketService.GetStockDataWithOrderBook(input.Codes...)
+			if err != nil {
+				fmt.Printf("[Tool:get_stock_realtime] 错误: %v\n", err)
+				return GetStockRealtimeOutput{}, err
+			}
+			for _, d := range data {
+				stocks = append(stocks, d.Stock)
+				books = append(books, d.OrderBook)
+			}
+		} else {
+			var err error
+			stocks, err = r.marketService.GetStockRealTimeData(input.Codes...)
+			if err != nil {
+				fmt.Printf("[Tool:get_stock_realtime] 错误: %v\n", err)
+				return GetStockRealtimeOutput{}, err
+			}
 		}
 
 		// 格式化股票数据输出
 		var result string
-		for _, s := range stocks {
+		for i, s := range stocks {
 			result += fmt.Sprintf("【%s(%s)】价格:%.2f 涨跌:%.2f%% 开盘:%.2f 最高:%.2f 最低:%.2f 成交量:%d\n",
 				s.Name, s.Symbol, s.Price, s.ChangePercent, s.Open, s.High, s.Low, s.Volume)
+			if books != nil {
+				result += formatOrderBook(books[i])
+			}
+		}
+
+		if result == "" {
+			result = noData("未获取到 %v 的行情（代码可能有误或已退市）", input.Codes)
 		}
 
 		// 获取大盘指数数据
@@ -59,6 +85,6 @@ func (r *Registry) createStockRealtimeTool() (tool.Tool, error) {
 
 	return functiontool.New(functiontool.Config{
 		Name:        "get_stock_realtime",
-		Description: "获取股票实时行情数据，包括当前价格、涨跌幅、开盘价、最高价、最低价、成交量等，以及大盘指数数据",
+		Description: "获取股票实时行情数据，包括当前价格、涨跌幅、开盘价、最高价、最低价、成交量等，以及大盘指数数据；设置 include_orderbook=true 时同时附带五档盘口",
 	}, handler)
 }
@@ -0,0 +1,58 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// CheckSuspensionInput 停牌检测输入参数
+type CheckSuspensionInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh600519 或 600519"`
+}
+
+// CheckSuspensionOutput 停牌检测输出
+type CheckSuspensionOutput struct {
+	Data string `json:"data" jsonschema:"是否停牌及最后交易日"`
+}
+
+// createSuspensionCheckTool 创建停牌检测工具
+func (r *Registry) createSuspensionCheckTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input CheckSuspensionInput) (CheckSuspensionOutput, error) {
+		if input.Code == "" {
+			return CheckSuspensionOutput{Data: "请提供股票代码"}, nil
+		}
+
+		// 统一为带前缀代码
+		code := strings.ToLower(input.Code)
+		if len(code) == 6 && r.configService != nil {
+			if info := r.configService.GetStockBasicInfo(code); info != nil {
+				code = info.Symbol
+			}
+		}
+
+		status, err := r.marketService.CheckSuspension(code)
+		if err != nil {
+			fmt.Printf("[Tool:check_suspension] 错误: %v\n", err)
+			return CheckSuspensionOutput{}, err
+		}
+
+		if !status.Suspended {
+			return CheckSuspensionOutput{Data: fmt.Sprintf("%s(%s) 正常交易，最近交易日: %s", status.Name, status.Code, status.LastTradeDate)}, nil
+		}
+
+		result := fmt.Sprintf("%s(%s) 当前停牌", status.Name, status.Code)
+		if status.LastTradeDate != "" {
+			result += fmt.Sprintf("，停牌前最后交易日: %s", status.LastTradeDate)
+		}
+		result += "\n注意: 停牌期间行情为停牌前数据，不可交易，分析时需考虑复牌后补涨/补跌风险"
+		return CheckSuspensionOutput{Data: result}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "check_suspension",
+		Description: "检测个股当前是否停牌及停牌前最后交易日，避免把停牌股的陈旧行情当作实时数据分析",
+	}, handler)
+}
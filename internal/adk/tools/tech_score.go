@@ -0,0 +1,64 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/indicators"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// GetTechScoreInput 综合技术评分输入参数
+type GetTechScoreInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh600519"`
+}
+
+// GetTechScoreOutput 综合技术评分输出
+type GetTechScoreOutput struct {
+	Data string `json:"data" jsonschema:"0-100综合技术评分、各项权重与得分及理由"`
+}
+
+// createTechScoreTool 创建综合技术评分工具
+func (r *Registry) createTechScoreTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetTechScoreInput) (GetTechScoreOutput, error) {
+		fmt.Printf("[Tool:get_tech_score] 调用开始, code=%s\n", input.Code)
+
+		if input.Code == "" {
+			return GetTechScoreOutput{Data: "请提供股票代码"}, nil
+		}
+
+		// 取最长时序用于计算 RSI，保证 Wilder 平滑有足够预热
+		analysis, err := r.ComputeTechnicalAnalysisWithDays(input.Code, maxAnalysisOutputDays)
+		if err != nil {
+			fmt.Printf("[Tool:get_tech_score] 错误: %v\n", err)
+			return GetTechScoreOutput{}, err
+		}
+		if len(analysis.Series) == 0 {
+			return GetTechScoreOutput{Data: noData("%s 无K线数据", input.Code)}, nil
+		}
+
+		closes := make([]float64, len(analysis.Series))
+		for i, row := range analysis.Series {
+			closes[i] = row.Close
+		}
+		rsi := indicators.RSI(closes, 14)
+		score := indicators.ComputeTechScore(analysis, rsi[len(rsi)-1])
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 综合技术评分: %d/100（%s）===\n", input.Code, score.Score, score.Level))
+		sb.WriteString("指标,权重,看多程度,得分,理由\n")
+		for _, item := range score.Items {
+			sb.WriteString(fmt.Sprintf("%s,%d,%.2f,%.1f,%s\n", item.Name, item.Weight, item.Bullish, item.Points(), item.Reason))
+		}
+		sb.WriteString("说明: 得分=权重×看多程度(0~1)，合计为综合评分；70以上偏多，55-70略偏多，45-55中性，30-45略偏空，30以下偏空。评分仅概括技术面信号，不含基本面和消息面")
+
+		return GetTechScoreOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_tech_score",
+		Description: "将均线排列、MACD、KDJ、RSI、OBV、量能等技术信号按公开权重汇总为0-100的综合看多评分，并列出每项权重、得分和理由，便于向普通用户用一个数字概括技术面",
+	}, handler)
+}
@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// GetThemeNewsInput 主题快讯输入参数
+type GetThemeNewsInput struct {
+	Theme string `json:"theme" jsonschema:"主题关键词，多个关键词用空格或顿号分隔，如 机器人、减速器"`
+	Limit int    `json:"limit,omitzero" jsonschema:"返回条数，默认10条，最大30条"`
+}
+
+// GetThemeNewsOutput 主题快讯输出
+type GetThemeNewsOutput struct {
+	Data string `json:"data" jsonschema:"与主题相关的最新财经快讯"`
+}
+
+// createThemeNewsTool 创建主题快讯工具
+func (r *Registry) createThemeNewsTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetThemeNewsInput) (GetThemeNewsOutput, error) {
+		fmt.Printf("[Tool:get_theme_news] 调用开始, theme=%s, limit=%d\n", input.Theme, input.Limit)
+
+		if strings.TrimSpace(input.Theme) == "" {
+			return GetThemeNewsOutput{Data: "请提供主题关键词"}, nil
+		}
+
+		limit := input.Limit
+		if limit <= 0 {
+			limit = 10
+		}
+		if limit > 30 {
+			limit = 30
+		}
+
+		news, err := r.newsService.GetThemeTelegraphs(input.Theme, limit)
+		if err != nil {
+			fmt.Printf("[Tool:get_theme_news] 错误: %v\n", err)
+			return GetThemeNewsOutput{}, err
+		}
+		if len(news) == 0 {
+			return GetThemeNewsOutput{Data: noData("近期快讯中未找到与「%s」相关的内容", input.Theme)}, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== 主题「%s」相关快讯（%d条，按相关度排序） ===\n", input.Theme, len(news)))
+		for _, n := range news {
+			sb.WriteString(fmt.Sprintf("[%s][%s] %s\n", n.Time, n.Source, n.Content))
+		}
+
+		fmt.Printf("[Tool:get_theme_news] 调用完成, 返回%d条快讯\n", len(news))
+		return GetThemeNewsOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_theme_news",
+		Description: "按主题关键词筛选最新财经快讯（合并多来源），只返回与行业/题材相关的内容，比 get_news 更聚焦",
+	}, handler)
+}
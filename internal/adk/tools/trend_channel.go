@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/indicators"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// trendChannelMaxWindow 趋势通道最大计算窗口（交易日）
+const trendChannelMaxWindow = 250
+
+// trendChannelDirectionNames 通道方向中文名称
+var trendChannelDirectionNames = map[string]string{
+	indicators.ChannelUp:   "上升通道",
+	indicators.ChannelDown: "下降通道",
+	indicators.ChannelFlat: "横盘通道",
+}
+
+// GetTrendChannelInput 趋势通道输入参数
+type GetTrendChannelInput struct {
+	Code   string `json:"code" jsonschema:"股票代码，如 sh600519"`
+	Window int    `json:"window,omitempty" jsonschema:"计算窗口（交易日），默认60，最大250"`
+}
+
+// GetTrendChannelOutput 趋势通道输出
+type GetTrendChannelOutput struct {
+	Data string `json:"data" jsonschema:"通道上下轨价格、斜率、通道方向及当前价在通道中的位置"`
+}
+
+// createTrendChannelTool 创建趋势通道工具
+func (r *Registry) createTrendChannelTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetTrendChannelInput) (GetTrendChannelOutput, error) {
+		fmt.Printf("[Tool:get_trend_channel] 调用开始, code=%s, window=%d\n", input.Code, input.Window)
+
+		if input.Code == "" {
+			return GetTrendChannelOutput{Data: "请提供股票代码"}, nil
+		}
+		window := input.Window
+		if window <= 0 {
+			window = indicators.ChannelDefaultWindow
+		}
+		if window > trendChannelMaxWindow {
+			window = trendChannelMaxWindow
+		}
+
+		klines, err := r.marketService.GetKLineData(input.Code, "1d", window)
+		if err != nil {
+			fmt.Printf("[Tool:get_trend_channel] 错误: %v\n", err)
+			return GetTrendChannelOutput{}, err
+		}
+		highs := make([]float64, len(klines))
+		lows := make([]float64, len(klines))
+		closes := make([]float64, len(klines))
+		for i, k := range klines {
+			highs[i], lows[i], closes[i] = k.High, k.Low, k.Close
+		}
+
+		ch := indicators.ComputeTrendChannel(highs, lows, closes, window)
+		if ch.Insufficient {
+			return GetTrendChannelOutput{Data: noData("%s 日K数据不足（%d根），无法计算趋势通道", input.Code, len(klines))}, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 趋势通道（近%d个交易日） ===\n", input.Code, ch.Window))
+		sb.WriteString(fmt.Sprintf("通道方向: %s", trendChannelDirectionNames[ch.Direction]))
+		if ch.Converging {
+			sb.WriteString("（上下轨收敛，三角形整理）")
+		}
+		sb.WriteString("\n")
+		sb.WriteString(fmt.Sprintf("上轨: %.2f（日均斜率 %+.2f%%）\n", ch.Upper, ch.UpperSlope))
+		sb.WriteString(fmt.Sprintf("下轨: %.2f（日均斜率 %+.2f%%）\n", ch.Lower, ch.LowerSlope))
+		sb.WriteString(fmt.Sprintf("最新收盘: %.2f，位于通道 %.1f%% 处（0%%为下轨，100%%为上轨）\n", ch.LastPrice, ch.Position))
+		sb.WriteString(fmt.Sprintf("拟合样本: 摆动高点%d个，摆动低点%d个", ch.SwingHighs, ch.SwingLows))
+		if ch.SwingHighs < 2 || ch.SwingLows < 2 {
+			sb.WriteString("（摆动点不足，已改用全部最高/最低价拟合）")
+		}
+		sb.WriteString("\n")
+
+		switch {
+		case ch.Position > 100:
+			sb.WriteString("提示: 价格已向上突破通道上轨")
+		case ch.Position < 0:
+			sb.WriteString("提示: 价格已向下跌破通道下轨")
+		case ch.Position >= 80:
+			sb.WriteString("提示: 价格接近上轨，注意压力")
+		case ch.Position <= 20:
+			sb.WriteString("提示: 价格接近下轨，关注支撑")
+		default:
+			sb.WriteString(fmt.Sprintf("提示: 价格处于通道中部，距上轨 %.2f%%，距下轨 %.2f%%",
+				(ch.Upper-ch.LastPrice)/ch.LastPrice*100, (ch.LastPrice-ch.Lower)/ch.LastPrice*100))
+		}
+		return GetTrendChannelOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_trend_channel",
+		Description: "计算趋势通道：对近期摆动高点、摆动低点分别做线性回归，返回上下轨价格、斜率、通道方向（上升/下降/横盘）及当前价在通道中的位置百分比",
+	}, handler)
+}
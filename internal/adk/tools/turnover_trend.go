@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/indicators"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// turnoverLevelNames 换手率分位等级中文说明
+var turnoverLevelNames = map[string]string{
+	"extreme": "极高",
+	"high":    "偏高",
+	"normal":  "正常",
+	"low":     "偏低",
+}
+
+// GetTurnoverTrendInput 换手率趋势输入参数
+type GetTurnoverTrendInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh600519"`
+	Days int    `json:"days,omitzero" jsonschema:"返回最近N个交易日，默认20，最大60"`
+}
+
+// GetTurnoverTrendOutput 换手率趋势输出
+type GetTurnoverTrendOutput struct {
+	Data string `json:"data" jsonschema:"逐日换手率及60日分位等级"`
+}
+
+// createTurnoverTrendTool 创建换手率趋势工具
+func (r *Registry) createTurnoverTrendTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetTurnoverTrendInput) (GetTurnoverTrendOutput, error) {
+		fmt.Printf("[Tool:get_turnover_trend] 调用开始, code=%s, days=%d\n", input.Code, input.Days)
+
+		if input.Code == "" {
+			return GetTurnoverTrendOutput{Data: "请提供股票代码"}, nil
+		}
+
+		days := input.Days
+		if days <= 0 {
+			days = 20
+		}
+		if days > 60 {
+			days = 60
+		}
+
+		// 多取60根用于计算每日的60日分位
+		klines, err := r.marketService.GetKLineData(input.Code, "1d", days+60)
+		if err != nil {
+			fmt.Printf("[Tool:get_turnover_trend] 错误: %v\n", err)
+			return GetTurnoverTrendOutput{}, err
+		}
+
+		rates := r.computeTurnoverRates(input.Code, klines)
+		if rates == nil {
+			return GetTurnoverTrendOutput{Data: noData("%s 无法计算换手率（ETF或缺少流通股本数据）", input.Code)}, nil
+		}
+
+		n := len(rates)
+		start := n - days
+		if start < 0 {
+			start = 0
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 近%d日换手率 ===\n", input.Code, n-start))
+		var sum float64
+		for i := start; i < n; i++ {
+			start60 := i - 59
+			if start60 < 0 {
+				start60 = 0
+			}
+			level := indicators.TurnoverLevel(rates[start60:i+1], rates[i])
+			sb.WriteString(fmt.Sprintf("%s 换手:%.2f%% 60日分位:%s\n", klines[i].Time, rates[i], turnoverLevelName(level)))
+			sum += rates[i]
+		}
+
+		avg := sum / float64(n-start)
+		sb.WriteString(fmt.Sprintf("\n【汇总】区间平均换手:%.2f%%，最新换手:%.2f%%", avg, rates[n-1]))
+		if avg > 0 {
+			sb.WriteString(fmt.Sprintf("（为区间均值的%.2f倍）", rates[n-1]/avg))
+		}
+		sb.WriteString("\n")
+
+		return GetTurnoverTrendOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_turnover_trend",
+		Description: "获取个股最近N日换手率序列及每日在60日内的分位等级（极高/偏高/正常/偏低），用于判断筹码活跃度变化",
+	}, handler)
+}
+
+// turnoverLevelName 换手率分位等级转中文
+func turnoverLevelName(level string) string {
+	if name, ok := turnoverLevelNames[level]; ok {
+		return name
+	}
+	return "-"
+}
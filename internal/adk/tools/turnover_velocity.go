@@ -0,0 +1,177 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+const (
+	// turnoverVelocityDefaultDays 默认统计的交易日数
+	turnoverVelocityDefaultDays = 20
+	// turnoverVelocityMinDays 最少统计的交易日数
+	turnoverVelocityMinDays = 5
+	// turnoverVelocityMaxDays 最多统计的交易日数
+	turnoverVelocityMaxDays = 120
+	// turnoverVelocityPriorDays 用于对比的前一段区间交易日数
+	turnoverVelocityPriorDays = 60
+	// turnoverVelocityRecentDays 近期换手速度的统计交易日数
+	turnoverVelocityRecentDays = 5
+	// turnoverVelocityHotMoney 日均换手率(%)不低于该值视为游资博弈迹象
+	turnoverVelocityHotMoney = 7
+)
+
+// GetTurnoverVelocityInput 换手速度输入参数
+type GetTurnoverVelocityInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh600519"`
+	Days int    `json:"days,omitzero" jsonschema:"统计最近N个交易日，默认20，范围5-120"`
+}
+
+// GetTurnoverVelocityOutput 换手速度输出
+type GetTurnoverVelocityOutput struct {
+	Data string `json:"data" jsonschema:"区间日均换手率、隐含平均持股天数、流通盘累计换手遍数、近5日与前60日对比及持股/换手特征判断"`
+}
+
+// turnoverVelocityStats 一段区间的换手统计（停牌日不计入）
+type turnoverVelocityStats struct {
+	tradingDays int
+	avg         float64 // 日均换手率(%)
+	total       float64 // 累计换手率(%)
+	maxRate     float64
+	maxTime     string
+}
+
+// holdingDays 按日均换手率估算的平均持股天数，即流通盘全部换手一遍所需的交易日数
+func (s turnoverVelocityStats) holdingDays() float64 {
+	if s.avg <= 0 {
+		return 0
+	}
+	return 100 / s.avg
+}
+
+// createTurnoverVelocityTool 创建换手速度（隐含平均持股周期）工具
+func (r *Registry) createTurnoverVelocityTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetTurnoverVelocityInput) (GetTurnoverVelocityOutput, error) {
+		fmt.Printf("[Tool:get_turnover_velocity] 调用开始, code=%s, days=%d\n", input.Code, input.Days)
+
+		if input.Code == "" {
+			return GetTurnoverVelocityOutput{Data: "请提供股票代码"}, nil
+		}
+		days := input.Days
+		if days <= 0 {
+			days = turnoverVelocityDefaultDays
+		}
+		days = max(turnoverVelocityMinDays, min(days, turnoverVelocityMaxDays))
+
+		// 多取前60根用于对比换手速度的变化
+		klines, err := r.marketService.GetKLineData(input.Code, "1d", days+turnoverVelocityPriorDays)
+		if err != nil {
+			fmt.Printf("[Tool:get_turnover_velocity] 错误: %v\n", err)
+			return GetTurnoverVelocityOutput{}, err
+		}
+
+		rates := r.computeTurnoverRates(input.Code, klines)
+		if rates == nil {
+			return GetTurnoverVelocityOutput{Data: noData("%s 无法计算换手率（ETF、指数或缺少流通股本数据）", input.Code)}, nil
+		}
+
+		n := len(rates)
+		start := max(0, n-days)
+		times := make([]string, n)
+		for i, k := range klines {
+			times[i] = k.Time
+		}
+		window := turnoverVelocityStatsOf(rates[start:], times[start:])
+		if window.tradingDays == 0 {
+			return GetTurnoverVelocityOutput{Data: noData("%s 近%d个交易日无成交（可能长期停牌）", input.Code, n-start)}, nil
+		}
+		recentStart := max(start, n-turnoverVelocityRecentDays)
+		recent := turnoverVelocityStatsOf(rates[recentStart:], times[recentStart:])
+		prior := turnoverVelocityStatsOf(rates[:start], times[:start])
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 换手速度（%s 至 %s，%d个交易日）===\n", input.Code, klines[start].Time, klines[n-1].Time, n-start))
+		sb.WriteString(fmt.Sprintf("日均换手率: %.2f%%\n", window.avg))
+		sb.WriteString(fmt.Sprintf("隐含平均持股天数: %.1f个交易日（100 / 日均换手率）\n", window.holdingDays()))
+		sb.WriteString(fmt.Sprintf("区间累计换手: %.1f%%（流通盘约换手 %.2f 遍）\n", window.total, window.total/100))
+		sb.WriteString(fmt.Sprintf("单日最高换手: %.2f%%（%s）\n", window.maxRate, window.maxTime))
+		if skipped := n - start - window.tradingDays; skipped > 0 {
+			sb.WriteString(fmt.Sprintf("停牌/无成交: %d日，未计入均值\n", skipped))
+		}
+
+		sb.WriteString("\n【速度变化】\n")
+		if recent.tradingDays > 0 && recentStart > start {
+			sb.WriteString(fmt.Sprintf("近%d日: 日均换手 %.2f%%，隐含持股 %.1f日（为区间均值的%.2f倍）\n",
+				n-recentStart, recent.avg, recent.holdingDays(), recent.avg/window.avg))
+		}
+		if prior.tradingDays > 0 {
+			sb.WriteString(fmt.Sprintf("此前%d日: 日均换手 %.2f%%，隐含持股 %.1f日（本区间为其%.2f倍）\n",
+				start, prior.avg, prior.holdingDays(), window.avg/prior.avg))
+		} else {
+			sb.WriteString("区间之前无可对比的历史数据（上市时间较短）\n")
+		}
+
+		sb.WriteString("\n【判断】\n")
+		sb.WriteString(turnoverVelocityInterpretation(window, recent, prior))
+		sb.WriteString("\n说明: 换手率由成交量 / 流通股本估算，流通股本按当前流通市值 / 最新收盘价推算；平均持股天数假设每日换手均匀，只反映筹码流转速度，长线持有者与高频交易者混合时实际分布差异很大")
+		return GetTurnoverVelocityOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_turnover_velocity",
+		Description: "根据近N日换手率估算流通盘的换手速度和隐含平均持股天数（约100/日均换手率），对比近5日及前60日的变化，判断筹码是被长期持有还是被快速倒手，标注游资博弈迹象",
+	}, handler)
+}
+
+// turnoverVelocityStatsOf 统计一段区间的换手率，换手率为 0 的停牌日跳过
+func turnoverVelocityStatsOf(rates []float64, times []string) turnoverVelocityStats {
+	var s turnoverVelocityStats
+	for i, rate := range rates {
+		if rate <= 0 {
+			continue
+		}
+		s.tradingDays++
+		s.total += rate
+		if rate > s.maxRate {
+			s.maxRate, s.maxTime = rate, times[i]
+		}
+	}
+	if s.tradingDays > 0 {
+		s.avg = s.total / float64(s.tradingDays)
+	}
+	return s
+}
+
+// turnoverVelocityInterpretation 根据日均换手率和速度变化给出持股/换手特征判断
+func turnoverVelocityInterpretation(window, recent, prior turnoverVelocityStats) string {
+	var sb strings.Builder
+	switch {
+	case window.avg >= 15:
+		sb.WriteString("- 极度换手：流通盘每周换手一遍以上，筹码几乎全部为短线资金，典型游资主战场，波动剧烈，接力失败时容易快速杀跌\n")
+	case window.avg >= turnoverVelocityHotMoney:
+		sb.WriteString("- 高频换手：筹码流转很快，短线资金主导，需警惕游资快进快出\n")
+	case window.avg >= 3:
+		sb.WriteString("- 换手活跃：交投积极，资金关注度较高，持股周期以中短线为主\n")
+	case window.avg >= 1:
+		sb.WriteString("- 换手正常：筹码流转适中，持股结构相对稳定\n")
+	default:
+		sb.WriteString("- 换手低迷：筹码以长期持有为主，惜售明显或关注度低，缩量状态下需放量确认方向\n")
+	}
+	if window.avg >= turnoverVelocityHotMoney || recent.avg >= turnoverVelocityHotMoney*2 {
+		sb.WriteString("- 【游资博弈】换手速度达到游资活跃水平，可结合龙虎榜席位确认是否有知名游资参与\n")
+	}
+	if prior.tradingDays > 0 && prior.avg > 0 {
+		switch ratio := window.avg / prior.avg; {
+		case ratio >= 2:
+			sb.WriteString("- 换手速度较此前明显加快，资金开始集中博弈，若价格同步走高为放量抢筹，滞涨则警惕高位换手出货\n")
+		case ratio <= 0.5:
+			sb.WriteString("- 换手速度较此前明显放缓，筹码趋于沉淀，上涨中缩量为锁仓，下跌中缩量为抛压减轻\n")
+		}
+	}
+	if recent.tradingDays > 0 && window.avg > 0 && recent.avg >= window.avg*1.5 {
+		sb.WriteString("- 近几日换手明显放大，短期筹码交换加速\n")
+	}
+	return sb.String()
+}
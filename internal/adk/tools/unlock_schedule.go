@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// GetUnlockScheduleInput 限售解禁输入参数
+type GetUnlockScheduleInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如600519或sh600519"`
+}
+
+// GetUnlockScheduleOutput 限售解禁输出
+type GetUnlockScheduleOutput struct {
+	Data string `json:"data" jsonschema:"近期已解禁及待解禁批次、解禁数量、占流通股比例和抛压风险评估"`
+}
+
+// createUnlockScheduleTool 创建限售股解禁工具
+func (r *Registry) createUnlockScheduleTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetUnlockScheduleInput) (GetUnlockScheduleOutput, error) {
+		if r.shareholderService == nil {
+			return GetUnlockScheduleOutput{Data: "股东数据服务不可用"}, nil
+		}
+		if input.Code == "" {
+			return GetUnlockScheduleOutput{}, fmt.Errorf("股票代码不能为空")
+		}
+
+		schedule, err := r.shareholderService.GetUnlockSchedule(input.Code)
+		if err != nil {
+			fmt.Printf("[Tool:get_unlock_schedule] 错误: %v\n", err)
+			return GetUnlockScheduleOutput{}, err
+		}
+		if len(schedule.Events) == 0 {
+			return GetUnlockScheduleOutput{Data: noData("%s 近期无限售股解禁安排", input.Code)}, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s(%s) 限售股解禁 ===\n", schedule.Name, schedule.Code))
+		for _, e := range schedule.Events {
+			status := "待解禁"
+			if !e.Upcoming {
+				status = "已解禁"
+			}
+			sb.WriteString(fmt.Sprintf("[%s] %s %s %.2f万股 占流通股:%.2f%%", e.Date, status, e.ShareType, e.Shares/10000, e.FloatRatio))
+			if e.MarketCap > 0 {
+				sb.WriteString(fmt.Sprintf(" 市值:%.2f亿", e.MarketCap/1e8))
+			}
+			sb.WriteString("\n")
+		}
+
+		sb.WriteString(fmt.Sprintf("\n【解禁抛压】%s\n", schedule.RiskLevel))
+		for _, reason := range schedule.RiskReasons {
+			sb.WriteString("- " + reason + "\n")
+		}
+		sb.WriteString("说明: 首发原股东和定增解禁减持意愿通常较强，股权激励解禁规模较小；解禁前后股价常提前承压")
+
+		return GetUnlockScheduleOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_unlock_schedule",
+		Description: "获取个股限售股解禁安排：近期及未来解禁日期、解禁数量、限售股类型、占流通股比例，并评估解禁抛压风险",
+	}, handler)
+}
@@ -0,0 +1,156 @@
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/indicators"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+const (
+	// updownMaxWindow 统计窗口上限（交易日）
+	updownMaxWindow = 60
+	// updownStrongRatio 上涨日量/下跌日量超过该值视为买方占优，低于其倒数视为卖方占优
+	updownStrongRatio = 1.5
+)
+
+// updownDefaultWindows 默认统计窗口
+var updownDefaultWindows = []int{5, 10, 20}
+
+// GetUpDownVolumeInput 涨跌量比输入参数
+type GetUpDownVolumeInput struct {
+	Code    string `json:"code" jsonschema:"股票代码，如 sh600519"`
+	Windows []int  `json:"windows,omitempty" jsonschema:"统计窗口（交易日），默认 [5,10,20]，单个窗口最大60"`
+}
+
+// GetUpDownVolumeOutput 涨跌量比输出
+type GetUpDownVolumeOutput struct {
+	Data string `json:"data" jsonschema:"各窗口上涨日与下跌日成交量之比及买卖力量判断"`
+}
+
+// createUpDownVolumeTool 创建涨跌量比工具
+func (r *Registry) createUpDownVolumeTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetUpDownVolumeInput) (GetUpDownVolumeOutput, error) {
+		fmt.Printf("[Tool:get_updown_volume] 调用开始, code=%s, windows=%v\n", input.Code, input.Windows)
+
+		if input.Code == "" {
+			return GetUpDownVolumeOutput{Data: "请提供股票代码"}, nil
+		}
+		windows := normalizeUpDownWindows(input.Windows)
+
+		// 多取一根用于判断窗口首日涨跌
+		longest := windows[len(windows)-1]
+		klines, err := r.marketService.GetKLineData(input.Code, "1d", longest+1)
+		if err != nil {
+			fmt.Printf("[Tool:get_updown_volume] 错误: %v\n", err)
+			return GetUpDownVolumeOutput{}, err
+		}
+		closes := make([]float64, len(klines))
+		volumes := make([]int64, len(klines))
+		for i, k := range klines {
+			closes[i] = k.Close
+			volumes[i] = k.Volume
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 涨跌量比（上涨日成交量/下跌日成交量）===\n", input.Code))
+		sb.WriteString("窗口,上涨日,下跌日,上涨日均量,下跌日均量,量比,判断\n")
+		var ratios []float64
+		for _, w := range windows {
+			u := indicators.ComputeUpDownVolume(closes, volumes, w)
+			if u.Insufficient {
+				sb.WriteString(fmt.Sprintf("%d日,数据不足,-,-,-,-,-\n", w))
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("%d日,%d,%d,%s,%s,%s,%s\n", w, u.UpDays, u.DownDays,
+				formatAvgVolume(u.UpVolume, u.UpDays), formatAvgVolume(u.DownVolume, u.DownDays),
+				formatUpDownRatio(u), updownVerdict(u)))
+			if u.DownVolume > 0 {
+				ratios = append(ratios, u.Ratio)
+			}
+		}
+
+		// 短窗口量比依次高于长窗口，说明上涨日放量在增强
+		if len(ratios) == len(windows) && len(ratios) >= 2 {
+			rising, falling := true, true
+			for i := 1; i < len(ratios); i++ {
+				rising = rising && ratios[i-1] > ratios[i]
+				falling = falling && ratios[i-1] < ratios[i]
+			}
+			switch {
+			case rising && ratios[0] > 1:
+				sb.WriteString("\n趋势: 短期量比高于长期且逐级抬升，上涨日放量占优增强，存在资金吸筹迹象\n")
+			case falling && ratios[0] < 1:
+				sb.WriteString("\n趋势: 短期量比低于长期且逐级走低，下跌日放量增多，警惕资金派发\n")
+			}
+		}
+		sb.WriteString("说明: 按收盘价较前一日涨跌划分上涨日/下跌日（与OBV口径一致），平盘日不计入；量比>1.5买方占优，<0.67卖方占优")
+
+		return GetUpDownVolumeOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_updown_volume",
+		Description: "计算个股最近5/10/20日（可自定义）上涨日与下跌日成交量之比，量化买卖力量；短期量比持续抬升提示资金吸筹，与OBV累计值互补",
+	}, handler)
+}
+
+// normalizeUpDownWindows 去重、限制范围并升序排列统计窗口
+func normalizeUpDownWindows(windows []int) []int {
+	seen := make(map[int]bool)
+	var result []int
+	for _, w := range windows {
+		if w < 2 {
+			continue
+		}
+		if w > updownMaxWindow {
+			w = updownMaxWindow
+		}
+		if !seen[w] {
+			seen[w] = true
+			result = append(result, w)
+		}
+	}
+	if len(result) == 0 {
+		return updownDefaultWindows
+	}
+	sort.Ints(result)
+	return result
+}
+
+// formatAvgVolume 日均成交量（万手）
+func formatAvgVolume(total float64, days int) string {
+	if days == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f万手", total/float64(days)/10000)
+}
+
+// formatUpDownRatio 格式化量比
+func formatUpDownRatio(u indicators.UpDownVolume) string {
+	if u.DownVolume == 0 {
+		if u.UpVolume == 0 {
+			return "-"
+		}
+		return "无下跌日"
+	}
+	return fmt.Sprintf("%.2f", u.Ratio)
+}
+
+// updownVerdict 根据量比判断买卖力量
+func updownVerdict(u indicators.UpDownVolume) string {
+	switch {
+	case u.UpVolume == 0 && u.DownVolume == 0:
+		return "无涨跌"
+	case u.DownVolume == 0 || u.Ratio >= updownStrongRatio:
+		return "买方占优"
+	case u.Ratio <= 1/updownStrongRatio:
+		return "卖方占优"
+	default:
+		return "多空均衡"
+	}
+}
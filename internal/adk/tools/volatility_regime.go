@@ -0,0 +1,72 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/indicators"
+
+	"google.golang.org/adk/tool"
+	"google.golang.org/adk/tool/functiontool"
+)
+
+// volatilityKLineCount 波动率状态使用的日K数量，需覆盖60日窗口
+const volatilityKLineCount = 80
+
+// volatilityRegimeAdvice 各波动状态下的仓位提示
+var volatilityRegimeAdvice = map[string]string{
+	"低波":    "波动处于低位，可按常规仓位，但需留意低波之后的变盘",
+	"正常":    "波动与长期水平相当，按常规仓位管理",
+	"高波":    "波动高于长期水平，建议降低仓位、放宽止损",
+	"波动扩张中": "短期波动快速放大，风险上升期，建议减仓观望",
+}
+
+// GetVolatilityRegimeInput 波动率状态输入参数
+type GetVolatilityRegimeInput struct {
+	Code string `json:"code" jsonschema:"股票代码，如 sh600519"`
+}
+
+// GetVolatilityRegimeOutput 波动率状态输出
+type GetVolatilityRegimeOutput struct {
+	Data string `json:"data" jsonschema:"10/20/60日年化已实现波动率及当前波动状态"`
+}
+
+// createVolatilityRegimeTool 创建波动率状态工具
+func (r *Registry) createVolatilityRegimeTool() (tool.Tool, error) {
+	handler := func(ctx tool.Context, input GetVolatilityRegimeInput) (GetVolatilityRegimeOutput, error) {
+		fmt.Printf("[Tool:get_volatility_regime] 调用开始, code=%s\n", input.Code)
+
+		if input.Code == "" {
+			return GetVolatilityRegimeOutput{Data: "请提供股票代码"}, nil
+		}
+
+		klines, err := r.marketService.GetKLineData(input.Code, "1d", volatilityKLineCount)
+		if err != nil {
+			fmt.Printf("[Tool:get_volatility_regime] 错误: %v\n", err)
+			return GetVolatilityRegimeOutput{}, err
+		}
+		closes := make([]float64, len(klines))
+		for i, k := range klines {
+			closes[i] = k.Close
+		}
+
+		v := indicators.ComputeVolatilityRegime(closes)
+		if v.Regime == "" {
+			return GetVolatilityRegimeOutput{Data: noData("%s 日K数据不足61根，无法计算波动率状态", input.Code)}, nil
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("=== %s 波动率状态 ===\n", input.Code))
+		sb.WriteString(fmt.Sprintf("年化已实现波动率: 10日 %.2f%% | 20日 %.2f%% | 60日 %.2f%%\n", v.Vol10, v.Vol20, v.Vol60))
+		sb.WriteString(fmt.Sprintf("短长期波动比(10日/60日): %.2f\n", v.Ratio))
+		sb.WriteString(fmt.Sprintf("当前状态: %s\n", v.Regime))
+		sb.WriteString(fmt.Sprintf("仓位提示: %s\n", volatilityRegimeAdvice[v.Regime]))
+		sb.WriteString("说明: 波动率为日对数收益率标准差按242个交易日年化")
+		return GetVolatilityRegimeOutput{Data: sb.String()}, nil
+	}
+
+	return functiontool.New(functiontool.Config{
+		Name:        "get_volatility_regime",
+		Description: "计算个股10/20/60日年化已实现波动率，按短长期波动比判断低波/正常/高波/波动扩张中，用于按波动调整仓位",
+	}, handler)
+}
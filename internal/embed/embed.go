@@ -9,3 +9,19 @@ import (
 //
 //go:embed stock_basic.json
 var StockBasicJSON []byte
+
+// HotMoneySeatsJSON 嵌入的知名游资席位数据
+// 营业部关键字到游资标签、操作风格的映射
+//
+//go:embed hot_money_seats.json
+var HotMoneySeatsJSON []byte
+
+// SectorETFsJSON 嵌入的行业/主题ETF列表
+//
+//go:embed sector_etfs.json
+var SectorETFsJSON []byte
+
+// IndustryChainsJSON 嵌入的主要行业产业链上下游映射
+//
+//go:embed industry_chains.json
+var IndustryChainsJSON []byte
@@ -0,0 +1,152 @@
+package indicators
+
+// 趋势通道参数
+const (
+	channelSwingSpan = 2   // 摆动高低点判定：高于/低于左右各 2 根K线
+	channelFlatSlope = 0.1 // 日均斜率绝对值低于该值(%)视为横盘通道
+	channelMinSwings = 2   // 拟合通道线所需的最少摆动点数
+	channelMinKLines = 10  // 计算通道所需的最少K线数
+)
+
+// ChannelDefaultWindow 趋势通道默认计算窗口（交易日）
+const ChannelDefaultWindow = 60
+
+// 通道方向
+const (
+	ChannelUp   = "up"   // 上升通道
+	ChannelDown = "down" // 下降通道
+	ChannelFlat = "flat" // 横盘通道
+)
+
+// TrendChannel 趋势通道：分别对摆动高点、摆动低点做线性回归得到上下轨
+type TrendChannel struct {
+	Window       int     // 实际计算窗口
+	Upper        float64 // 当前上轨价格
+	Lower        float64 // 当前下轨价格
+	UpperSlope   float64 // 上轨日均斜率(%)，相对当前上轨
+	LowerSlope   float64 // 下轨日均斜率(%)，相对当前下轨
+	Direction    string  // up/down/flat
+	Position     float64 // 当前价在通道中的位置(%)，0 为下轨，100 为上轨，可超出 0~100
+	SwingHighs   int     // 参与拟合的摆动高点数
+	SwingLows    int     // 参与拟合的摆动低点数
+	Converging   bool    // 上下轨收敛（三角形整理）
+	LastPrice    float64 // 最新收盘价
+	Insufficient bool    // 数据不足无法计算
+}
+
+// ComputeTrendChannel 基于最近 window 根K线计算趋势通道
+// 摆动点不足时退化为对全部最高价/最低价回归
+func ComputeTrendChannel(highs, lows, closes []float64, window int) TrendChannel {
+	n := len(closes)
+	if window <= 0 {
+		window = ChannelDefaultWindow
+	}
+	if window > n {
+		window = n
+	}
+	ch := TrendChannel{Window: window}
+	if window < channelMinKLines || len(highs) != n || len(lows) != n {
+		ch.Insufficient = true
+		return ch
+	}
+
+	start := n - window
+	h, l := highs[start:], lows[start:]
+	ch.LastPrice = closes[n-1]
+
+	hx, hy := swingPoints(h, true)
+	lx, ly := swingPoints(l, false)
+	ch.SwingHighs, ch.SwingLows = len(hx), len(lx)
+	if len(hx) < channelMinSwings {
+		hx, hy = allPoints(h)
+	}
+	if len(lx) < channelMinSwings {
+		lx, ly = allPoints(l)
+	}
+
+	last := float64(window - 1)
+	upSlope, upIntercept := linearRegression(hx, hy)
+	lowSlope, lowIntercept := linearRegression(lx, ly)
+	ch.Upper = upSlope*last + upIntercept
+	ch.Lower = lowSlope*last + lowIntercept
+	// 回归线交叉时按上下轨互换，保证上轨不低于下轨
+	if ch.Upper < ch.Lower {
+		ch.Upper, ch.Lower = ch.Lower, ch.Upper
+		upSlope, lowSlope = lowSlope, upSlope
+	}
+	if ch.Upper > 0 {
+		ch.UpperSlope = upSlope / ch.Upper * 100
+	}
+	if ch.Lower > 0 {
+		ch.LowerSlope = lowSlope / ch.Lower * 100
+	}
+
+	avg := (ch.UpperSlope + ch.LowerSlope) / 2
+	switch {
+	case avg >= channelFlatSlope:
+		ch.Direction = ChannelUp
+	case avg <= -channelFlatSlope:
+		ch.Direction = ChannelDown
+	default:
+		ch.Direction = ChannelFlat
+	}
+	ch.Converging = upSlope < lowSlope
+
+	if width := ch.Upper - ch.Lower; width > 0 {
+		ch.Position = (ch.LastPrice - ch.Lower) / width * 100
+	}
+	return ch
+}
+
+// swingPoints 找出摆动高点（high=true）或摆动低点，返回下标和价格
+func swingPoints(values []float64, high bool) ([]float64, []float64) {
+	var xs, ys []float64
+	for i := channelSwingSpan; i < len(values)-channelSwingSpan; i++ {
+		isSwing := true
+		for j := i - channelSwingSpan; j <= i+channelSwingSpan; j++ {
+			if j == i {
+				continue
+			}
+			if (high && values[j] > values[i]) || (!high && values[j] < values[i]) {
+				isSwing = false
+				break
+			}
+		}
+		if isSwing {
+			xs = append(xs, float64(i))
+			ys = append(ys, values[i])
+		}
+	}
+	return xs, ys
+}
+
+// allPoints 全部点作为回归样本
+func allPoints(values []float64) ([]float64, []float64) {
+	xs := make([]float64, len(values))
+	for i := range values {
+		xs[i] = float64(i)
+	}
+	return xs, values
+}
+
+// linearRegression 最小二乘拟合 y = slope*x + intercept
+func linearRegression(xs, ys []float64) (slope, intercept float64) {
+	n := float64(len(xs))
+	if n == 0 {
+		return 0, 0
+	}
+	sumX, sumY, sumXY, sumX2 := 0.0, 0.0, 0.0, 0.0
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+		sumXY += xs[i] * ys[i]
+		sumX2 += xs[i] * xs[i]
+	}
+	denom := n*sumX2 - sumX*sumX
+	if denom == 0 {
+		return 0, sumY / n
+	}
+	slope = (n*sumXY - sumX*sumY) / denom
+	intercept = (sumY - slope*sumX) / n
+	return slope, intercept
+}
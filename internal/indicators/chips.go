@@ -0,0 +1,104 @@
+package indicators
+
+// chipMinDays 估算筹码分布所需的最少K线数
+const chipMinDays = 20
+
+// ChipDistribution 筹码（持仓成本）分布估算
+type ChipDistribution struct {
+	Prices        []float64 // 各价格档中心价，升序
+	Weights       []float64 // 各价格档筹码占比，合计为 1
+	LastPrice     float64   // 最新收盘价
+	ProfitRatio   float64   // 获利盘比例(%)：成本低于最新收盘价的筹码占比
+	AvgCost       float64   // 平均持仓成本
+	Cost70Low     float64   // 70% 筹码成本区间下沿（15% 分位）
+	Cost70High    float64   // 70% 筹码成本区间上沿（85% 分位）
+	Cost90Low     float64   // 90% 筹码成本区间下沿（5% 分位）
+	Cost90High    float64   // 90% 筹码成本区间上沿（95% 分位）
+	Concentration float64   // 70% 筹码集中度(%) = (上沿-下沿)/(上沿+下沿)*100，越小越集中
+	TurnoverDecay bool      // 是否按换手率衰减历史筹码；false 时为简单成交量分布
+}
+
+// ComputeChipDistribution 按日K估算筹码分布，所有序列等长、按时间升序
+// 每日成交量视为在当日最高价与最低价之间均匀成交：
+// 提供 turnovers(换手率%) 时，每日先按换手率衰减已有筹码再加入当日成交（经典筹码衰减模型）；
+// turnovers 为空时退化为区间内成交量累加的价格分布。结果为估算值，K线不足 20 根时返回 false
+func ComputeChipDistribution(highs, lows, closes, volumes, turnovers []float64, bins int) (ChipDistribution, bool) {
+	n := len(closes)
+	if n < chipMinDays || len(highs) != n || len(lows) != n || len(volumes) != n || bins < 2 {
+		return ChipDistribution{}, false
+	}
+	decay := len(turnovers) == n
+
+	minPrice, maxPrice := lows[0], highs[0]
+	for i := 1; i < n; i++ {
+		minPrice = min(minPrice, lows[i])
+		maxPrice = max(maxPrice, highs[i])
+	}
+	if minPrice <= 0 || maxPrice <= minPrice {
+		return ChipDistribution{}, false
+	}
+	step := (maxPrice - minPrice) / float64(bins)
+
+	chips := make([]float64, bins)
+	for i := 0; i < n; i++ {
+		lo := int((lows[i] - minPrice) / step)
+		hi := int((highs[i] - minPrice) / step)
+		lo, hi = max(0, min(lo, bins-1)), max(0, min(hi, bins-1))
+		share := 1 / float64(hi-lo+1)
+
+		if decay {
+			t := min(max(turnovers[i]/100, 0), 1)
+			for j := range chips {
+				chips[j] *= 1 - t
+			}
+			for j := lo; j <= hi; j++ {
+				chips[j] += t * share
+			}
+		} else {
+			for j := lo; j <= hi; j++ {
+				chips[j] += volumes[i] * share
+			}
+		}
+	}
+
+	var total float64
+	for _, c := range chips {
+		total += c
+	}
+	if total <= 0 {
+		return ChipDistribution{}, false
+	}
+
+	d := ChipDistribution{
+		Prices:        make([]float64, bins),
+		Weights:       make([]float64, bins),
+		LastPrice:     closes[n-1],
+		TurnoverDecay: decay,
+	}
+	for j := range chips {
+		d.Prices[j] = minPrice + step*(float64(j)+0.5)
+		d.Weights[j] = chips[j] / total
+		d.AvgCost += d.Prices[j] * d.Weights[j]
+		if d.Prices[j] <= d.LastPrice {
+			d.ProfitRatio += d.Weights[j] * 100
+		}
+	}
+	d.Cost70Low, d.Cost70High = chipPercentile(d, 0.15), chipPercentile(d, 0.85)
+	d.Cost90Low, d.Cost90High = chipPercentile(d, 0.05), chipPercentile(d, 0.95)
+	if d.Cost70High+d.Cost70Low > 0 {
+		d.Concentration = (d.Cost70High - d.Cost70Low) / (d.Cost70High + d.Cost70Low) * 100
+	}
+	return d, true
+}
+
+// chipPercentile 累计筹码占比达到 p 时所在价格档的中心价
+func chipPercentile(d ChipDistribution, p float64) float64 {
+	var cum float64
+	for j, w := range d.Weights {
+		cum += w
+		if cum >= p {
+			return d.Prices[j]
+		}
+	}
+	return d.Prices[len(d.Prices)-1]
+}
@@ -43,49 +43,54 @@ type StatusSummary struct {
 	OBVSlope    string  `json:"obv_slope,omitempty"`
 	VolRatio    float64 `json:"vol_ratio"`
 	BandWidth   float64 `json:"band_width"`
+	ATRPctLevel string  `json:"atr_pct_level,omitempty"` // ATR% 60日分位: low/normal/high
+	BIASSignal  string  `json:"bias_signal,omitempty"`   // BIAS6 60日极值: overheated/oversold
+	BIAS12      float64 `json:"bias12"`
+	BIAS24      float64 `json:"bias24"`
 }
 
 // DayRow 单日时序数据行
 type DayRow struct {
-	Date          string
-	Open          float64
-	High          float64
-	Low           float64
-	Close         float64
-	ChangePct     float64
-	Volume        int64
-	Amount        float64
-	MA5           float64
-	MA10          float64
-	MA20          float64
-	DIF           float64
-	DEA           float64
-	MACDHist      float64
-	MACDSignal    string // MACD信号：gold/dead/top_div/bot_div
-	K             float64
-	D             float64
-	J             float64
-	KDJSignal     string // KDJ信号：gold/dead/ob/os
-	BOLLUpper     float64
-	BOLLMid       float64
-	BOLLLower     float64
-	BOLLWidth     float64 // 带宽 (Upper-Lower)/Mid
-	ADX           float64
-	VolMA5        float64
-	TurnoverRate  float64
-	TurnoverLevel string
-	OBVVal        float64
-	ATRVal        float64
-	BIASVal       float64
-	BRVal         float64
-	ARVal         float64
+	Date          string  `json:"date"`
+	Open          float64 `json:"open"`
+	High          float64 `json:"high"`
+	Low           float64 `json:"low"`
+	Close         float64 `json:"close"`
+	ChangePct     float64 `json:"change_pct"`
+	Volume        int64   `json:"volume"`
+	Amount        float64 `json:"amount"`
+	MA5           float64 `json:"ma5"`
+	MA10          float64 `json:"ma10"`
+	MA20          float64 `json:"ma20"`
+	DIF           float64 `json:"dif"`
+	DEA           float64 `json:"dea"`
+	MACDHist      float64 `json:"macd_hist"`
+	MACDSignal    string  `json:"macd_signal"` // MACD信号：gold/dead/top_div/bot_div
+	K             float64 `json:"k"`
+	D             float64 `json:"d"`
+	J             float64 `json:"j"`
+	KDJSignal     string  `json:"kdj_signal"` // KDJ信号：gold/dead/ob/os
+	BOLLUpper     float64 `json:"boll_upper"`
+	BOLLMid       float64 `json:"boll_mid"`
+	BOLLLower     float64 `json:"boll_lower"`
+	BOLLWidth     float64 `json:"boll_width"` // 带宽 (Upper-Lower)/Mid
+	ADX           float64 `json:"adx"`
+	VolMA5        float64 `json:"vol_ma5"`
+	TurnoverRate  float64 `json:"turnover_rate"`
+	TurnoverLevel string  `json:"turnover_level"`
+	OBVVal        float64 `json:"obv"`
+	ATRVal        float64 `json:"atr"`
+	ATRPercent    float64 `json:"atr_pct"` // ATR / Close * 100
+	BIASVal       float64 `json:"bias"`
+	BRVal         float64 `json:"br"`
+	ARVal         float64 `json:"ar"`
 }
 
 // FullAnalysis 完整分析结果
 type FullAnalysis struct {
-	Snapshot TechnicalSnapshot
-	Status   StatusSummary
-	Series   []DayRow
+	Snapshot TechnicalSnapshot `json:"snapshot"`
+	Status   StatusSummary     `json:"status"`
+	Series   []DayRow          `json:"series"`
 }
 
 // ComputeAll 计算全部技术指标
@@ -127,6 +132,7 @@ func ComputeAll(klines []models.KLineData, outputDays int, turnoverRates []float
 	obvAll := OBV(closes, volumes)
 	volMA5 := VolMA(volumes, 5)
 	atrAll := ATR(highs, lows, closes)
+	atrPctAll := ATRPercent(atrAll, closes)
 	biasAll := BIAS(closes)
 	brarAll := BRAR(opens, highs, lows, closes)
 
@@ -137,8 +143,9 @@ func ComputeAll(klines []models.KLineData, outputDays int, turnoverRates []float
 	// 构建 Status
 	status := buildStatus(
 		ma5, ma10, ma20, macdAll, kdjAll, bollAll, dmiAll,
-		obvAll, volumes, volMA5, last,
+		obvAll, volumes, volMA5, atrPctAll, last,
 	)
+	status.BIASSignal, status.BIAS12, status.BIAS24 = biasStatus(closes, biasAll, last)
 
 	// 构建 Series（最近 outputDays 天）
 	start := n - outputDays
@@ -148,7 +155,7 @@ func ComputeAll(klines []models.KLineData, outputDays int, turnoverRates []float
 	series := buildSeries(
 		klines, closes, ma5, ma10, ma20,
 		macdAll, kdjAll, bollAll, dmiAll,
-		obvAll, volMA5, atrAll, biasAll, brarAll,
+		obvAll, volMA5, atrAll, atrPctAll, biasAll, brarAll,
 		turnoverRates, start, n,
 	)
 
@@ -204,6 +211,7 @@ func buildStatus(
 	obvAll []float64,
 	volumes []int64,
 	volMA5 []float64,
+	atrPctAll []float64,
 	last int,
 ) StatusSummary {
 	s := StatusSummary{}
@@ -240,9 +248,29 @@ func buildStatus(
 	// 量比
 	s.VolRatio = round2(VolRatio(volumes[last], volMA5[last]))
 
+	// ATR% 60日分位
+	start60 := last - 59
+	if start60 < 0 {
+		start60 = 0
+	}
+	s.ATRPctLevel = ATRPctLevel(atrPctAll[start60:last+1], atrPctAll[last])
+
 	return s
 }
 
+// biasStatus 计算 BIAS6 的60日极值信号及 BIAS12/BIAS24 当前值
+func biasStatus(closes, biasAll []float64, last int) (string, float64, float64) {
+	if last < 0 {
+		return "", 0, 0
+	}
+	start60 := last - 59
+	if start60 < 0 {
+		start60 = 0
+	}
+	signal := BIASSignal(biasAll[start60:last+1], biasAll[last])
+	return signal, round2(BIASPeriod(closes, 12)[last]), round2(BIASPeriod(closes, 24)[last])
+}
+
 // detectMACDCross 检测 MACD 金叉/死叉及持续天数
 func detectMACDCross(macd []MACDResult, last int) string {
 	if last < 1 {
@@ -368,7 +396,7 @@ func buildSeries(
 	kdjAll []KDJResult,
 	bollAll []BOLLResult,
 	dmiAll []DMIResult,
-	obvAll, volMA5, atrAll, biasAll []float64,
+	obvAll, volMA5, atrAll, atrPctAll, biasAll []float64,
 	brarAll []BRARResult,
 	turnoverRates []float64,
 	start, end int,
@@ -440,6 +468,7 @@ func buildSeries(
 
 		// ATR, BIAS, BRAR
 		row.ATRVal = atrAll[i]
+		row.ATRPercent = round2(atrPctAll[i])
 		row.BIASVal = biasAll[i]
 		if i < len(brarAll) {
 			row.BRVal = brarAll[i].BR
@@ -0,0 +1,67 @@
+package indicators
+
+import "sort"
+
+// ConfluencePeriods 均线密集区默认计算的均线周期
+var ConfluencePeriods = []int{5, 10, 20, 60, 120, 250}
+
+// MALevel 最新一根K线的单条均线值
+type MALevel struct {
+	Period int     // 均线周期
+	Value  float64 // 均线价格
+}
+
+// MAConfluenceZone 多条均线聚集形成的价格区间
+type MAConfluenceZone struct {
+	Low      float64 // 区间下沿（区间内最低均线）
+	High     float64 // 区间上沿（区间内最高均线）
+	Center   float64 // 区间内均线的平均值
+	Periods  []int   // 区间内的均线周期，按均线价格升序
+	Distance float64 // 区间中心相对最新收盘价的距离(%)，负值在现价下方
+}
+
+// ComputeMAConfluence 计算最新一根K线的各周期均线，并找出相互距离不超过 tolerance(%) 的均线密集区
+// 均线按价格升序依次归组：与组内最低均线相差不超过 tolerance 即并入当前组，至少两条均线才构成密集区
+// 数据不足某周期时跳过该均线；返回的 levels 按周期升序，zones 按区间中心价格升序
+func ComputeMAConfluence(closes []float64, periods []int, tolerance float64) ([]MALevel, []MAConfluenceZone) {
+	n := len(closes)
+	if n == 0 || closes[n-1] <= 0 {
+		return nil, nil
+	}
+	last := closes[n-1]
+
+	var levels []MALevel
+	for _, p := range periods {
+		if p <= 0 || n < p {
+			continue
+		}
+		if v := SMA(closes, p)[n-1]; v > 0 {
+			levels = append(levels, MALevel{Period: p, Value: v})
+		}
+	}
+
+	sorted := make([]MALevel, len(levels))
+	copy(sorted, levels)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Value < sorted[j].Value })
+
+	var zones []MAConfluenceZone
+	for i := 0; i < len(sorted); {
+		j := i + 1
+		for j < len(sorted) && (sorted[j].Value-sorted[i].Value)/sorted[i].Value*100 <= tolerance {
+			j++
+		}
+		if j-i >= 2 {
+			zone := MAConfluenceZone{Low: sorted[i].Value, High: sorted[j-1].Value}
+			var sum float64
+			for _, l := range sorted[i:j] {
+				zone.Periods = append(zone.Periods, l.Period)
+				sum += l.Value
+			}
+			zone.Center = sum / float64(j-i)
+			zone.Distance = (zone.Center - last) / last * 100
+			zones = append(zones, zone)
+		}
+		i = j
+	}
+	return levels, zones
+}
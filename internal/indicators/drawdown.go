@@ -0,0 +1,69 @@
+package indicators
+
+// drawdownRecoveryMin 计入平均修复时间的最小回撤幅度(%)，过滤日常小波动
+const drawdownRecoveryMin = 5.0
+
+// DrawdownStats 回撤统计
+type DrawdownStats struct {
+	MaxDrawdown     float64 // 最大回撤(%)，正数表示跌幅
+	PeakIndex       int     // 最大回撤起点（前高）下标
+	TroughIndex     int     // 最大回撤终点（谷底）下标
+	CurrentDrawdown float64 // 当前价距窗口内最高点的回撤(%)
+	PeakCloseIndex  int     // 窗口内最高收盘价下标
+	Recoveries      int     // 已修复（重新创新高）的回撤次数
+	AvgRecoveryDays float64 // 已修复回撤的平均修复天数（前高到重新站上前高）
+}
+
+// ComputeDrawdown 基于收盘价序列计算最近 window 根K线的回撤统计
+// window<=0 或超过序列长度时使用全部数据
+func ComputeDrawdown(closes []float64, window int) DrawdownStats {
+	n := len(closes)
+	if window <= 0 || window > n {
+		window = n
+	}
+	stats := DrawdownStats{}
+	if window == 0 {
+		return stats
+	}
+	start := n - window
+
+	peak := start
+	stats.PeakIndex, stats.TroughIndex, stats.PeakCloseIndex = start, start, start
+
+	// 当前回撤段的最大深度，用于判断修复后是否计入统计
+	episodeDepth := 0.0
+	var recoveryDays int
+	for i := start; i < n; i++ {
+		c := closes[i]
+		if c >= closes[peak] {
+			if episodeDepth >= drawdownRecoveryMin {
+				stats.Recoveries++
+				recoveryDays += i - peak
+			}
+			peak = i
+			episodeDepth = 0
+			continue
+		}
+		if closes[peak] <= 0 {
+			continue
+		}
+		dd := (closes[peak] - c) / closes[peak] * 100
+		if dd > episodeDepth {
+			episodeDepth = dd
+		}
+		if dd > stats.MaxDrawdown {
+			stats.MaxDrawdown = dd
+			stats.PeakIndex = peak
+			stats.TroughIndex = i
+		}
+	}
+
+	stats.PeakCloseIndex = peak
+	if closes[peak] > 0 {
+		stats.CurrentDrawdown = (closes[peak] - closes[n-1]) / closes[peak] * 100
+	}
+	if stats.Recoveries > 0 {
+		stats.AvgRecoveryDays = float64(recoveryDays) / float64(stats.Recoveries)
+	}
+	return stats
+}
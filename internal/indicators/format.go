@@ -70,14 +70,14 @@ func formatOscillatorSeries(rows []DayRow) string {
 	return sb.String()
 }
 
-// formatVolatilitySeries 波动组：BOLL + BandWidth + BIAS + ATR
+// formatVolatilitySeries 波动组：BOLL + BandWidth + BIAS + ATR + ATR%
 func formatVolatilitySeries(rows []DayRow) string {
 	var sb strings.Builder
-	sb.WriteString("Date,BOLL_Upper,BOLL_Mid,BOLL_Lower,BandWidth%,BIAS,ATR\n")
+	sb.WriteString("Date,BOLL_Upper,BOLL_Mid,BOLL_Lower,BandWidth%,BIAS,ATR,ATR%\n")
 	for _, r := range rows {
-		sb.WriteString(fmt.Sprintf("%s,%.2f,%.2f,%.2f,%.2f,%s,%.2f\n",
+		sb.WriteString(fmt.Sprintf("%s,%.2f,%.2f,%.2f,%.2f,%s,%.2f,%.2f\n",
 			r.Date, r.BOLLUpper, r.BOLLMid, r.BOLLLower, r.BOLLWidth,
-			fmtSign(r.BIASVal), r.ATRVal))
+			fmtSign(r.BIASVal), r.ATRVal, r.ATRPercent))
 	}
 	return sb.String()
 }
@@ -129,6 +129,15 @@ func FormatFullAnalysis(analysis *FullAnalysis) string {
 	return sb.String()
 }
 
+// MarshalFullAnalysisJSON 将完整分析结果序列化为纯结构化 JSON
+// 供前端图表和外部工具直接使用，LLM 工具仍使用 FormatFullAnalysis
+func MarshalFullAnalysisJSON(analysis *FullAnalysis) ([]byte, error) {
+	if analysis == nil {
+		return nil, fmt.Errorf("分析结果为空")
+	}
+	return json.Marshal(analysis)
+}
+
 // formatVolume 格式化成交量（股），使用 K/M 量词
 func formatVolume(v int64) string {
 	f := float64(v)
@@ -0,0 +1,82 @@
+package indicators
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// 蒙特卡洛模拟参数上限，避免单次调用耗时过长
+const (
+	MaxProjectionPaths   = 5000 // 最大模拟路径数
+	MaxProjectionHorizon = 250  // 最大预测交易日数
+)
+
+// PriceProjection 蒙特卡洛价格预测结果
+type PriceProjection struct {
+	Horizon   int     // 预测交易日数
+	Paths     int     // 模拟路径数
+	Samples   int     // 参与抽样的历史日收益率数量
+	LastPrice float64 // 最新收盘价
+	P5        float64 // 5% 分位价格
+	P50       float64 // 50% 分位价格
+	P95       float64 // 95% 分位价格
+	ProbUp    float64 // 到期价格高于最新价的路径占比(%)
+}
+
+// MonteCarloProjection 对历史日对数收益率有放回抽样，模拟 horizon 日后的价格分布
+// 采用历史收益自助抽样而非正态假设，保留个股收益的肥尾特征；数据不足返回 nil
+func MonteCarloProjection(closes []float64, horizon, paths int, rnd *rand.Rand) *PriceProjection {
+	if horizon <= 0 || paths <= 0 {
+		return nil
+	}
+	horizon = min(horizon, MaxProjectionHorizon)
+	paths = min(paths, MaxProjectionPaths)
+
+	returns := make([]float64, 0, len(closes))
+	for i := 1; i < len(closes); i++ {
+		if closes[i-1] > 0 && closes[i] > 0 {
+			returns = append(returns, math.Log(closes[i]/closes[i-1]))
+		}
+	}
+	if len(returns) < 20 {
+		return nil
+	}
+
+	last := closes[len(closes)-1]
+	finals := make([]float64, paths)
+	var up int
+	for p := range finals {
+		var sum float64
+		for d := 0; d < horizon; d++ {
+			sum += returns[rnd.Intn(len(returns))]
+		}
+		finals[p] = last * math.Exp(sum)
+		if finals[p] > last {
+			up++
+		}
+	}
+	sort.Float64s(finals)
+
+	return &PriceProjection{
+		Horizon:   horizon,
+		Paths:     paths,
+		Samples:   len(returns),
+		LastPrice: last,
+		P5:        percentile(finals, 0.05),
+		P50:       percentile(finals, 0.50),
+		P95:       percentile(finals, 0.95),
+		ProbUp:    float64(up) / float64(paths) * 100,
+	}
+}
+
+// percentile 计算已升序排列数据的分位数（线性插值）
+func percentile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(pos-float64(lo))
+}
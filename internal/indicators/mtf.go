@@ -0,0 +1,89 @@
+package indicators
+
+// TimeframeTrend 单一周期的趋势状态
+type TimeframeTrend struct {
+	MATrend string  // 均线排列: bull/bear/cross，数据不足时为空
+	MACDDir string  // MACD 方向: up(DIF在DEA之上)/down，数据不足时为空
+	Hist    float64 // 最新 MACD 柱
+	Close   float64 // 最新收盘价
+}
+
+// ComputeTimeframeTrend 根据收盘价序列计算均线排列和 MACD 方向
+func ComputeTimeframeTrend(closes []float64) TimeframeTrend {
+	n := len(closes)
+	if n == 0 {
+		return TimeframeTrend{}
+	}
+	last := n - 1
+	t := TimeframeTrend{Close: closes[last]}
+
+	t.MATrend = MATrend(SMA(closes, 5)[last], SMA(closes, 10)[last], SMA(closes, 20)[last])
+
+	// DEA 从第34个值开始有效
+	if n >= 34 {
+		macd := MACD(closes)[last]
+		t.Hist = macd.Hist
+		if macd.DIF > macd.DEA {
+			t.MACDDir = "up"
+		} else {
+			t.MACDDir = "down"
+		}
+	}
+	return t
+}
+
+// Direction 综合均线和 MACD 给出方向: 多头/空头/震荡，数据不足返回空
+func (t TimeframeTrend) Direction() string {
+	switch {
+	case t.MATrend == "bull" && t.MACDDir != "down":
+		return "多头"
+	case t.MATrend == "bear" && t.MACDDir != "up":
+		return "空头"
+	case t.MATrend == "" && t.MACDDir == "":
+		return ""
+	default:
+		return "震荡"
+	}
+}
+
+// TrendAgreement 判断多周期方向是否一致
+// directions 按周期从短到长排列（如 日/周/月），数据不足的周期应传空字符串
+func TrendAgreement(directions []string) string {
+	var valid []string
+	for _, d := range directions {
+		if d != "" {
+			valid = append(valid, d)
+		}
+	}
+	if len(valid) < 2 {
+		return "数据不足"
+	}
+
+	allSame := true
+	for _, d := range valid[1:] {
+		if d != valid[0] {
+			allSame = false
+			break
+		}
+	}
+	if allSame {
+		switch valid[0] {
+		case "多头":
+			return "多周期多头共振"
+		case "空头":
+			return "多周期空头共振"
+		default:
+			return "多周期震荡"
+		}
+	}
+
+	short, long := valid[0], valid[len(valid)-1]
+	switch {
+	case short == "多头" && long == "空头":
+		return "分歧：短周期反弹，长周期仍空头"
+	case short == "空头" && long == "多头":
+		return "分歧：长周期多头中的短期回调"
+	default:
+		return "分歧：方向不一致"
+	}
+}
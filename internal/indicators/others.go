@@ -39,6 +39,49 @@ func ATR(highs, lows, closes []float64) []float64 {
 	return result
 }
 
+// ATRPercent 计算 ATR 百分比序列 ATR% = ATR / Close * 100
+// 消除价格水平差异，便于跨股票比较波动率
+func ATRPercent(atrs, closes []float64) []float64 {
+	result := make([]float64, len(closes))
+	for i := range closes {
+		if i < len(atrs) && atrs[i] > 0 && closes[i] > 0 {
+			result[i] = atrs[i] / closes[i] * 100
+		}
+	}
+	return result
+}
+
+// ATRPctLevel 计算 ATR% 在序列中的分位等级: low(<20%)/normal/high(>=80%)
+func ATRPctLevel(pcts []float64, current float64) string {
+	if current <= 0 {
+		return ""
+	}
+
+	total, count := 0, 0
+	for _, p := range pcts {
+		if p <= 0 {
+			continue
+		}
+		total++
+		if p <= current {
+			count++
+		}
+	}
+	if total < 10 {
+		return ""
+	}
+	percentile := float64(count) / float64(total)
+
+	switch {
+	case percentile >= 0.8:
+		return "high"
+	case percentile < 0.2:
+		return "low"
+	default:
+		return "normal"
+	}
+}
+
 // BIAS 计算乖离率 (周期6)
 // BIAS6 = (Close - MA6) / MA6 * 100
 func BIAS(closes []float64) []float64 {
@@ -54,6 +97,50 @@ func BIAS(closes []float64) []float64 {
 	return result
 }
 
+// BIASPeriod 计算指定周期的乖离率 BIASN = (Close - MAN) / MAN * 100
+func BIASPeriod(closes []float64, period int) []float64 {
+	n := len(closes)
+	result := make([]float64, n)
+	if period <= 0 {
+		return result
+	}
+	ma := SMA(closes, period)
+	for i := period - 1; i < n; i++ {
+		if ma[i] > 0 {
+			result[i] = (closes[i] - ma[i]) / ma[i] * 100
+		}
+	}
+	return result
+}
+
+// BIASSignal 判断乖离率是否处于近期极端位置，提示均值回归
+// 当前 BIAS 为正且处于序列 95% 分位以上为 overheated，为负且处于 5% 分位以下为 oversold
+func BIASSignal(biases []float64, current float64) string {
+	total, count := 0, 0
+	for _, b := range biases {
+		if b == 0 {
+			continue
+		}
+		total++
+		if b <= current {
+			count++
+		}
+	}
+	if total < 20 {
+		return ""
+	}
+	percentile := float64(count) / float64(total)
+
+	switch {
+	case current > 0 && percentile >= 0.95:
+		return "overheated"
+	case current < 0 && percentile <= 0.05:
+		return "oversold"
+	default:
+		return ""
+	}
+}
+
 // BRARResult 单日 BRAR 结果
 type BRARResult struct {
 	BR float64
@@ -98,3 +185,51 @@ func BRAR(opens, highs, lows, closes []float64) []BRARResult {
 	}
 	return result
 }
+
+// RSI 计算相对强弱指标（Wilder 平滑）
+// 前 period 个值为 0（预热期），全部上涨时为 100
+func RSI(closes []float64, period int) []float64 {
+	n := len(closes)
+	result := make([]float64, n)
+	if period <= 0 || n <= period {
+		return result
+	}
+
+	var gain, loss float64
+	for i := 1; i <= period; i++ {
+		diff := closes[i] - closes[i-1]
+		if diff > 0 {
+			gain += diff
+		} else {
+			loss -= diff
+		}
+	}
+	gain /= float64(period)
+	loss /= float64(period)
+	result[period] = rsiValue(gain, loss)
+
+	for i := period + 1; i < n; i++ {
+		diff := closes[i] - closes[i-1]
+		up, down := 0.0, 0.0
+		if diff > 0 {
+			up = diff
+		} else {
+			down = -diff
+		}
+		gain = (gain*float64(period-1) + up) / float64(period)
+		loss = (loss*float64(period-1) + down) / float64(period)
+		result[i] = rsiValue(gain, loss)
+	}
+	return result
+}
+
+// rsiValue 由平均涨幅和平均跌幅计算 RSI
+func rsiValue(gain, loss float64) float64 {
+	if loss == 0 {
+		if gain == 0 {
+			return 50
+		}
+		return 100
+	}
+	return 100 - 100/(1+gain/loss)
+}
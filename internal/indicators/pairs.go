@@ -0,0 +1,104 @@
+package indicators
+
+import "math"
+
+// PairSpread 两只股票价格比值（A/B）价差统计
+type PairSpread struct {
+	Dates       []string  // 对齐后的交易日（升序，仅回看窗口内）
+	Ratios      []float64 // 每日收盘价比值 A/B
+	Mean        float64   // 窗口内比值均值
+	Std         float64   // 窗口内比值标准差
+	Current     float64   // 最新比值
+	ZScore      float64   // 最新比值的 Z 分数 = (Current-Mean)/Std
+	Correlation float64   // 窗口内两者日收益率的相关系数
+}
+
+// ComputePairSpread 按日期对齐两组收盘价，计算最近 lookback 个共同交易日的比值价差及 Z 分数
+// dates 与 closes 一一对应且按日期升序；仅一方有行情的日期（如停牌）跳过；共同交易日不足 lookback 或比值无波动时返回 false
+func ComputePairSpread(datesA []string, closesA []float64, datesB []string, closesB []float64, lookback int) (PairSpread, bool) {
+	closeB := make(map[string]float64, len(datesB))
+	for i, d := range datesB {
+		if i < len(closesB) && closesB[i] > 0 {
+			closeB[d] = closesB[i]
+		}
+	}
+
+	var dates []string
+	var a, b []float64
+	for i, d := range datesA {
+		if i >= len(closesA) || closesA[i] <= 0 {
+			continue
+		}
+		if cb, ok := closeB[d]; ok {
+			dates = append(dates, d)
+			a = append(a, closesA[i])
+			b = append(b, cb)
+		}
+	}
+	if lookback < 2 || len(dates) < lookback {
+		return PairSpread{}, false
+	}
+	start := len(dates) - lookback
+	dates, a, b = dates[start:], a[start:], b[start:]
+
+	ratios := make([]float64, lookback)
+	for i := range ratios {
+		ratios[i] = a[i] / b[i]
+	}
+	mean, std := meanStd(ratios)
+	if std == 0 {
+		return PairSpread{}, false
+	}
+
+	current := ratios[lookback-1]
+	return PairSpread{
+		Dates:       dates,
+		Ratios:      ratios,
+		Mean:        mean,
+		Std:         std,
+		Current:     current,
+		ZScore:      (current - mean) / std,
+		Correlation: returnCorrelation(a, b),
+	}, true
+}
+
+// meanStd 均值与总体标准差
+func meanStd(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	var sq float64
+	for _, v := range values {
+		sq += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(sq / float64(len(values)))
+}
+
+// returnCorrelation 两组收盘价日收益率的皮尔逊相关系数，数据不足或无波动时返回 0
+func returnCorrelation(a, b []float64) float64 {
+	n := len(a) - 1
+	if n < 2 || len(b) != len(a) {
+		return 0
+	}
+	ra := make([]float64, n)
+	rb := make([]float64, n)
+	for i := 1; i <= n; i++ {
+		ra[i-1] = a[i]/a[i-1] - 1
+		rb[i-1] = b[i]/b[i-1] - 1
+	}
+	meanA, stdA := meanStd(ra)
+	meanB, stdB := meanStd(rb)
+	if stdA == 0 || stdB == 0 {
+		return 0
+	}
+	var cov float64
+	for i := range ra {
+		cov += (ra[i] - meanA) * (rb[i] - meanB)
+	}
+	return cov / float64(n) / (stdA * stdB)
+}
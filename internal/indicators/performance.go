@@ -0,0 +1,28 @@
+package indicators
+
+// PeriodReturn 最近 bars 个交易日的涨跌幅(%)：最新收盘相对 bars 个交易日前收盘，数据不足返回 false
+func PeriodReturn(closes []float64, bars int) (float64, bool) {
+	n := len(closes)
+	if bars <= 0 || n <= bars || closes[n-1-bars] <= 0 {
+		return 0, false
+	}
+	return (closes[n-1]/closes[n-1-bars] - 1) * 100, true
+}
+
+// ReturnSince 以 cutoff 当日及之前最后一个交易日收盘为基准的涨跌幅(%)
+// dates 与 closes 一一对应且按日期升序，日期格式 YYYY-MM-DD；序列起点晚于 cutoff（如上市不足）时返回 false
+func ReturnSince(dates []string, closes []float64, cutoff string) (float64, bool) {
+	n := len(closes)
+	if n == 0 || len(dates) != n {
+		return 0, false
+	}
+	for i := n - 1; i >= 0; i-- {
+		if dates[i] <= cutoff {
+			if i == n-1 || closes[i] <= 0 {
+				return 0, false
+			}
+			return (closes[n-1]/closes[i] - 1) * 100, true
+		}
+	}
+	return 0, false
+}
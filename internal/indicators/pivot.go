@@ -0,0 +1,26 @@
+package indicators
+
+// FloorPivots 经典（floor trader）枢轴点及三档支撑压力位
+type FloorPivots struct {
+	P  float64 // 枢轴点 = (H+L+C)/3
+	R1 float64 // 压力1 = 2P-L
+	R2 float64 // 压力2 = P+(H-L)
+	R3 float64 // 压力3 = H+2(P-L)
+	S1 float64 // 支撑1 = 2P-H
+	S2 float64 // 支撑2 = P-(H-L)
+	S3 float64 // 支撑3 = L-2(H-P)
+}
+
+// ComputeFloorPivots 由上一周期的最高、最低、收盘价计算本周期的枢轴点
+func ComputeFloorPivots(high, low, close float64) FloorPivots {
+	p := (high + low + close) / 3
+	return FloorPivots{
+		P:  p,
+		R1: 2*p - low,
+		R2: p + (high - low),
+		R3: high + 2*(p-low),
+		S1: 2*p - high,
+		S2: p - (high - low),
+		S3: low - 2*(high-p),
+	}
+}
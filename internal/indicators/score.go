@@ -0,0 +1,205 @@
+package indicators
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// 综合技术评分各项权重（合计 100），每项先按信号给出 0~1 的看多程度，再乘以权重累加
+const (
+	ScoreWeightMA     = 25 // 均线排列：MA5/MA10/MA20 多头或空头
+	ScoreWeightMACD   = 20 // MACD：金叉死叉及柱体扩张收缩
+	ScoreWeightKDJ    = 15 // KDJ：低位金叉、高位死叉及超买超卖钝化
+	ScoreWeightRSI    = 15 // RSI14：强弱区间，极端超买超卖按均值回归降权
+	ScoreWeightOBV    = 15 // OBV：近5日能量潮方向
+	ScoreWeightVolume = 10 // 量能：量比配合当日涨跌
+)
+
+// ScoreItem 单项评分
+type ScoreItem struct {
+	Name    string  `json:"name"`
+	Weight  int     `json:"weight"`  // 权重（该项满分）
+	Bullish float64 `json:"bullish"` // 看多程度 0~1
+	Reason  string  `json:"reason"`
+}
+
+// Points 该项得分 = 权重 × 看多程度
+func (s ScoreItem) Points() float64 {
+	return float64(s.Weight) * s.Bullish
+}
+
+// TechScore 综合技术评分
+type TechScore struct {
+	Score int         `json:"score"` // 0~100，越高越偏多
+	Level string      `json:"level"`
+	Items []ScoreItem `json:"items"`
+}
+
+// ComputeTechScore 根据 ComputeAll 的状态信号和 RSI14 计算 0~100 综合看多评分
+// 信号缺失的项按中性 0.5 计入
+func ComputeTechScore(a *FullAnalysis, rsi14 float64) TechScore {
+	var last *DayRow
+	if len(a.Series) > 0 {
+		last = &a.Series[len(a.Series)-1]
+	}
+
+	items := []ScoreItem{
+		scoreMA(a.Status.MATrend),
+		scoreMACD(a.Status.MACDCross, a.Status.MACDStatus),
+		scoreKDJ(a.Status.KDJStatus, last),
+		scoreRSI(rsi14),
+		scoreOBV(a.Status.OBVSlope),
+		scoreVolume(a.Status.VolRatio, last),
+	}
+
+	total := 0.0
+	for _, item := range items {
+		total += item.Points()
+	}
+	score := int(math.Round(total))
+	return TechScore{Score: score, Level: techScoreLevel(score), Items: items}
+}
+
+// techScoreLevel 评分区间描述
+func techScoreLevel(score int) string {
+	switch {
+	case score >= 70:
+		return "偏多"
+	case score >= 55:
+		return "略偏多"
+	case score > 45:
+		return "中性"
+	case score > 30:
+		return "略偏空"
+	default:
+		return "偏空"
+	}
+}
+
+// scoreMA 均线排列评分
+func scoreMA(trend string) ScoreItem {
+	item := ScoreItem{Name: "均线排列", Weight: ScoreWeightMA}
+	switch trend {
+	case "bull":
+		item.Bullish, item.Reason = 1, "MA5>MA10>MA20 多头排列"
+	case "bear":
+		item.Bullish, item.Reason = 0, "MA5<MA10<MA20 空头排列"
+	case "cross":
+		item.Bullish, item.Reason = 0.5, "均线交织，方向未明"
+	default:
+		item.Bullish, item.Reason = 0.5, "数据不足"
+	}
+	return item
+}
+
+// scoreMACD MACD 评分：柱体状态定基调，3日内的金叉/死叉修正
+func scoreMACD(cross, status string) ScoreItem {
+	item := ScoreItem{Name: "MACD", Weight: ScoreWeightMACD, Bullish: 0.5, Reason: "信号不明"}
+	switch status {
+	case "widen_up":
+		item.Bullish, item.Reason = 1, "红柱放大"
+	case "narrow_up":
+		item.Bullish, item.Reason = 0.65, "红柱缩短"
+	case "narrow_dn":
+		item.Bullish, item.Reason = 0.35, "绿柱缩短"
+	case "widen_dn":
+		item.Bullish, item.Reason = 0, "绿柱放大"
+	}
+
+	if days, ok := crossDays(cross, "gold_"); ok && days <= 3 {
+		item.Bullish = math.Max(item.Bullish, 0.8)
+		item.Reason = fmt.Sprintf("金叉第%d天，%s", days, item.Reason)
+	} else if days, ok := crossDays(cross, "dead_"); ok && days <= 3 {
+		item.Bullish = math.Min(item.Bullish, 0.2)
+		item.Reason = fmt.Sprintf("死叉第%d天，%s", days, item.Reason)
+	}
+	return item
+}
+
+// crossDays 解析 gold_N/dead_N 的天数
+func crossDays(cross, prefix string) (int, bool) {
+	if !strings.HasPrefix(cross, prefix) {
+		return 0, false
+	}
+	days, err := strconv.Atoi(strings.TrimPrefix(cross, prefix))
+	return days, err == nil
+}
+
+// scoreKDJ KDJ 评分：钝化和高低位交叉优先，否则看 K 与 D 的相对位置
+func scoreKDJ(status string, last *DayRow) ScoreItem {
+	item := ScoreItem{Name: "KDJ", Weight: ScoreWeightKDJ, Bullish: 0.5, Reason: "数据不足"}
+	switch {
+	case status == "bottom_gold":
+		item.Bullish, item.Reason = 0.9, "低位金叉"
+	case status == "top_dead":
+		item.Bullish, item.Reason = 0.1, "高位死叉"
+	case strings.HasPrefix(status, "j_ob_"):
+		item.Bullish, item.Reason = 0.4, "J值超买钝化，短线回落风险"
+	case strings.HasPrefix(status, "j_os_"):
+		item.Bullish, item.Reason = 0.6, "J值超卖钝化，存在反弹需求"
+	case last != nil && last.K > last.D:
+		item.Bullish, item.Reason = 0.6, "K在D上方"
+	case last != nil && last.K < last.D:
+		item.Bullish, item.Reason = 0.4, "K在D下方"
+	}
+	return item
+}
+
+// scoreRSI RSI14 评分：50 以上越强越偏多，进入 80 以上超买或 20 以下超卖按均值回归处理
+func scoreRSI(rsi float64) ScoreItem {
+	item := ScoreItem{Name: "RSI14", Weight: ScoreWeightRSI}
+	switch {
+	case rsi <= 0:
+		item.Bullish, item.Reason = 0.5, "数据不足"
+	case rsi >= 80:
+		item.Bullish, item.Reason = 0.4, fmt.Sprintf("RSI=%.1f 超买", rsi)
+	case rsi >= 50:
+		item.Bullish, item.Reason = 0.5+(rsi-50)/30*0.4, fmt.Sprintf("RSI=%.1f 强势区", rsi)
+	case rsi >= 20:
+		item.Bullish, item.Reason = (rsi-20)/30*0.5, fmt.Sprintf("RSI=%.1f 弱势区", rsi)
+	default:
+		item.Bullish, item.Reason = 0.3, fmt.Sprintf("RSI=%.1f 超卖，存在反弹需求", rsi)
+	}
+	return item
+}
+
+// scoreOBV OBV 方向评分
+func scoreOBV(slope string) ScoreItem {
+	item := ScoreItem{Name: "OBV", Weight: ScoreWeightOBV}
+	switch slope {
+	case "up":
+		item.Bullish, item.Reason = 1, "能量潮上行，量能配合"
+	case "down":
+		item.Bullish, item.Reason = 0, "能量潮下行，资金流出"
+	default:
+		item.Bullish, item.Reason = 0.5, "能量潮走平"
+	}
+	return item
+}
+
+// scoreVolume 量能评分：放量上涨最强、放量下跌最弱，缩量按中性处理
+func scoreVolume(volRatio float64, last *DayRow) ScoreItem {
+	item := ScoreItem{Name: "量能", Weight: ScoreWeightVolume, Bullish: 0.5}
+	if last == nil || volRatio <= 0 {
+		item.Reason = "数据不足"
+		return item
+	}
+	up := last.ChangePct > 0
+	switch {
+	case volRatio >= 1.5 && up:
+		item.Bullish, item.Reason = 1, fmt.Sprintf("量比%.2f 放量上涨", volRatio)
+	case volRatio >= 1.5 && last.ChangePct < 0:
+		item.Bullish, item.Reason = 0, fmt.Sprintf("量比%.2f 放量下跌", volRatio)
+	case volRatio < 0.7:
+		item.Reason = fmt.Sprintf("量比%.2f 缩量", volRatio)
+	case up:
+		item.Bullish, item.Reason = 0.65, fmt.Sprintf("量比%.2f 温和上涨", volRatio)
+	case last.ChangePct < 0:
+		item.Bullish, item.Reason = 0.35, fmt.Sprintf("量比%.2f 温和下跌", volRatio)
+	default:
+		item.Reason = fmt.Sprintf("量比%.2f 平盘", volRatio)
+	}
+	return item
+}
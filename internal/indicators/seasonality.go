@@ -0,0 +1,143 @@
+package indicators
+
+import "strconv"
+
+// 季节性倾向判定参数
+const (
+	seasonMinSamples = 3    // 少于该年数视为样本不足
+	seasonStrongAvg  = 1.0  // 平均月涨幅(%)不低于该值且胜率达标视为偏强
+	seasonStrongWin  = 60.0 // 偏强所需上涨年份占比(%)
+	seasonWeakWin    = 40.0 // 偏弱所需上涨年份占比上限(%)
+)
+
+// 季节性倾向
+const (
+	SeasonStrong       = "strong"       // 历史偏强
+	SeasonWeak         = "weak"         // 历史偏弱
+	SeasonNeutral      = "neutral"      // 无明显规律
+	SeasonInsufficient = "insufficient" // 样本不足
+)
+
+// MonthReturn 单个自然月的涨跌幅
+type MonthReturn struct {
+	Year   int
+	Month  int
+	Return float64 // 月末收盘相对上月末收盘(%)
+}
+
+// MonthSeason 某一日历月份的历年统计
+type MonthSeason struct {
+	Month     int
+	Samples   int     // 参与统计的年数
+	AvgReturn float64 // 平均涨跌幅(%)
+	WinRate   float64 // 上涨年份占比(%)
+	Best      float64 // 最大涨幅(%)
+	Worst     float64 // 最大跌幅(%)
+	Tendency  string  // strong/weak/neutral/insufficient
+}
+
+// MonthlyReturns 由日线计算各完整自然月的涨跌幅
+// dates 与 closes 一一对应且按日期升序，日期格式 YYYY-MM-DD；首月缺少上月末收盘、末月未走完，均不计入
+func MonthlyReturns(dates []string, closes []float64) []MonthReturn {
+	n := len(closes)
+	if n == 0 || len(dates) != n {
+		return nil
+	}
+
+	// 各月最后一个交易日收盘
+	type monthEnd struct {
+		year, month int
+		close       float64
+	}
+	var ends []monthEnd
+	for i := range dates {
+		year, month, ok := parseYearMonth(dates[i])
+		if !ok {
+			continue
+		}
+		if len(ends) > 0 && ends[len(ends)-1].year == year && ends[len(ends)-1].month == month {
+			ends[len(ends)-1].close = closes[i]
+			continue
+		}
+		ends = append(ends, monthEnd{year: year, month: month, close: closes[i]})
+	}
+
+	// 末月视为未走完
+	if len(ends) > 0 {
+		ends = ends[:len(ends)-1]
+	}
+	var result []MonthReturn
+	for i := 1; i < len(ends); i++ {
+		prev := ends[i-1]
+		// 相邻记录须为连续月份，停牌跨月时跳过
+		if prev.year*12+prev.month+1 != ends[i].year*12+ends[i].month || prev.close <= 0 {
+			continue
+		}
+		result = append(result, MonthReturn{
+			Year:   ends[i].year,
+			Month:  ends[i].month,
+			Return: (ends[i].close/prev.close - 1) * 100,
+		})
+	}
+	return result
+}
+
+// Seasonality 按日历月份汇总历年涨跌幅，返回 1~12 月统计
+func Seasonality(returns []MonthReturn) [12]MonthSeason {
+	var seasons [12]MonthSeason
+	for i := range seasons {
+		seasons[i].Month = i + 1
+	}
+	for _, r := range returns {
+		if r.Month < 1 || r.Month > 12 {
+			continue
+		}
+		s := &seasons[r.Month-1]
+		if s.Samples == 0 || r.Return > s.Best {
+			s.Best = r.Return
+		}
+		if s.Samples == 0 || r.Return < s.Worst {
+			s.Worst = r.Return
+		}
+		s.Samples++
+		s.AvgReturn += r.Return
+		if r.Return > 0 {
+			s.WinRate++
+		}
+	}
+
+	for i := range seasons {
+		s := &seasons[i]
+		if s.Samples > 0 {
+			s.AvgReturn /= float64(s.Samples)
+			s.WinRate = s.WinRate / float64(s.Samples) * 100
+		}
+		switch {
+		case s.Samples < seasonMinSamples:
+			s.Tendency = SeasonInsufficient
+		case s.AvgReturn >= seasonStrongAvg && s.WinRate >= seasonStrongWin:
+			s.Tendency = SeasonStrong
+		case s.AvgReturn <= -seasonStrongAvg && s.WinRate <= seasonWeakWin:
+			s.Tendency = SeasonWeak
+		default:
+			s.Tendency = SeasonNeutral
+		}
+	}
+	return seasons
+}
+
+// parseYearMonth 解析 YYYY-MM-DD 的年月
+func parseYearMonth(date string) (int, int, bool) {
+	if len(date) < 7 {
+		return 0, 0, false
+	}
+	year, err := strconv.Atoi(date[:4])
+	if err != nil {
+		return 0, 0, false
+	}
+	month, err := strconv.Atoi(date[5:7])
+	if err != nil || month < 1 || month > 12 {
+		return 0, 0, false
+	}
+	return year, month, true
+}
@@ -0,0 +1,72 @@
+package indicators
+
+import "math"
+
+// tradingDaysPerYear 年化波动率使用的年交易日数
+const tradingDaysPerYear = 242
+
+// VolatilityRegime 波动率状态
+type VolatilityRegime struct {
+	Vol10  float64 // 10日年化已实现波动率(%)
+	Vol20  float64 // 20日年化已实现波动率(%)
+	Vol60  float64 // 60日年化已实现波动率(%)
+	Ratio  float64 // 短长期波动比 Vol10/Vol60
+	Regime string  // 低波/正常/高波/波动扩张中，数据不足时为空
+}
+
+// RealizedVolatility 计算最近 window 日对数收益率的年化标准差(%)，数据不足返回 0
+func RealizedVolatility(closes []float64, window int) float64 {
+	n := len(closes)
+	if window < 2 || n < window+1 {
+		return 0
+	}
+	returns := make([]float64, 0, window)
+	for i := n - window; i < n; i++ {
+		if closes[i-1] <= 0 || closes[i] <= 0 {
+			continue
+		}
+		returns = append(returns, math.Log(closes[i]/closes[i-1]))
+	}
+	if len(returns) < 2 {
+		return 0
+	}
+
+	var mean float64
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+	var variance float64
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	variance /= float64(len(returns) - 1)
+	return math.Sqrt(variance*tradingDaysPerYear) * 100
+}
+
+// ComputeVolatilityRegime 根据 10/20/60 日已实现波动率判断当前波动状态
+// 短期波动明显高于中期且高于长期视为扩张中，否则按 Vol20/Vol60 划分高低波
+func ComputeVolatilityRegime(closes []float64) VolatilityRegime {
+	v := VolatilityRegime{
+		Vol10: RealizedVolatility(closes, 10),
+		Vol20: RealizedVolatility(closes, 20),
+		Vol60: RealizedVolatility(closes, 60),
+	}
+	if v.Vol10 == 0 || v.Vol20 == 0 || v.Vol60 == 0 {
+		return v
+	}
+	v.Ratio = v.Vol10 / v.Vol60
+
+	level := v.Vol20 / v.Vol60
+	switch {
+	case v.Vol10/v.Vol20 >= 1.2 && v.Ratio >= 1.2:
+		v.Regime = "波动扩张中"
+	case level >= 1.25:
+		v.Regime = "高波"
+	case level <= 0.75:
+		v.Regime = "低波"
+	default:
+		v.Regime = "正常"
+	}
+	return v
+}
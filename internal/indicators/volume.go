@@ -11,17 +11,58 @@ func OBV(closes []float64, volumes []int64) []float64 {
 
 	result[0] = float64(volumes[0])
 	for i := 1; i < n; i++ {
-		if closes[i] > closes[i-1] {
-			result[i] = result[i-1] + float64(volumes[i])
-		} else if closes[i] < closes[i-1] {
-			result[i] = result[i-1] - float64(volumes[i])
-		} else {
-			result[i] = result[i-1]
-		}
+		result[i] = result[i-1] + float64(dayDirection(closes[i-1], closes[i]))*float64(volumes[i])
 	}
 	return result
 }
 
+// dayDirection 按收盘价较前一日涨跌划分当日方向（OBV 口径）：1 上涨，-1 下跌，0 平盘
+func dayDirection(prevClose, close float64) int {
+	switch {
+	case close > prevClose:
+		return 1
+	case close < prevClose:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// UpDownVolume 窗口内上涨日与下跌日的成交量对比
+type UpDownVolume struct {
+	Window       int
+	UpDays       int
+	DownDays     int
+	UpVolume     float64 // 上涨日成交量合计
+	DownVolume   float64 // 下跌日成交量合计
+	Ratio        float64 // 上涨日量/下跌日量，无下跌日时为 0
+	Insufficient bool    // 数据不足 window+1 根K线
+}
+
+// ComputeUpDownVolume 统计最近 window 个交易日上涨日与下跌日的成交量，平盘日不计入
+func ComputeUpDownVolume(closes []float64, volumes []int64, window int) UpDownVolume {
+	n := len(closes)
+	u := UpDownVolume{Window: window}
+	if window <= 0 || n < window+1 || len(volumes) != n {
+		u.Insufficient = true
+		return u
+	}
+	for i := n - window; i < n; i++ {
+		switch dayDirection(closes[i-1], closes[i]) {
+		case 1:
+			u.UpDays++
+			u.UpVolume += float64(volumes[i])
+		case -1:
+			u.DownDays++
+			u.DownVolume += float64(volumes[i])
+		}
+	}
+	if u.DownVolume > 0 {
+		u.Ratio = u.UpVolume / u.DownVolume
+	}
+	return u
+}
+
 // OBVSlopeDir 判断 OBV 5日斜率方向
 // 使用简单线性回归方向
 func OBVSlopeDir(obv []float64, idx int) string {
@@ -77,6 +118,62 @@ func VolRatio(volume int64, volMA5 float64) float64 {
 	return float64(volume) / volMA5
 }
 
+// IntradayRVOL 同时刻相对成交量
+type IntradayRVOL struct {
+	AsOf     string  // 对比截止时刻，如 "10:30:00"
+	TodayCum float64 // 今日截至该时刻累计成交量
+	AvgCum   float64 // 历史各日截至同一时刻累计成交量均值
+	Ratio    float64 // TodayCum / AvgCum
+	Days     int     // 参与对比的历史交易日数
+}
+
+// TimeOfDayRVOL 计算同时刻相对成交量（盘中不同时段可比）
+// times 为 "2006-01-02 15:04:05" 格式且按时间升序，最后一个交易日视为今日
+// lookback: 参与对比的历史交易日数
+func TimeOfDayRVOL(times []string, volumes []int64, lookback int) IntradayRVOL {
+	var result IntradayRVOL
+	n := len(times)
+	if n == 0 || n != len(volumes) || len(times[n-1]) < 19 {
+		return result
+	}
+
+	today := times[n-1][:10]
+	result.AsOf = times[n-1][11:19]
+
+	// 按交易日累计截至 AsOf 的成交量
+	var dates []string
+	cums := make(map[string]float64)
+	for i, t := range times {
+		if len(t) < 19 {
+			continue
+		}
+		date := t[:10]
+		if _, ok := cums[date]; !ok {
+			dates = append(dates, date)
+			cums[date] = 0
+		}
+		if t[11:19] <= result.AsOf {
+			cums[date] += float64(volumes[i])
+		}
+	}
+	result.TodayCum = cums[today]
+
+	// 取今日之前最近 lookback 个交易日
+	var sum float64
+	for i := len(dates) - 1; i >= 0 && result.Days < lookback; i-- {
+		if dates[i] == today || cums[dates[i]] <= 0 {
+			continue
+		}
+		sum += cums[dates[i]]
+		result.Days++
+	}
+	if result.Days > 0 {
+		result.AvgCum = sum / float64(result.Days)
+		result.Ratio = result.TodayCum / result.AvgCum
+	}
+	return result
+}
+
 // TurnoverLevel 根据60日换手率分位数判断换手水平
 // rates: 最近60日换手率序列, current: 当日换手率
 func TurnoverLevel(rates []float64, current float64) string {
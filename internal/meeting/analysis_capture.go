@@ -0,0 +1,29 @@
+package meeting
+
+import (
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// AnalysisCallback 技术分析原文回调：专家（或会前预取）拿到 get_kline_data(mode=analysis) 结果时调用
+// 并行模式下可能被并发调用
+type AnalysisCallback func(agentName, content string)
+
+// PreflightAnalysisSource 会前预取的技术分析来源名称
+const PreflightAnalysisSource = "会前预取"
+
+// analysisHeader FormatFullAnalysis 输出的起始标记，用于区分 analysis 模式与原始K线
+const analysisHeader = "[Snapshot]"
+
+// extractAnalysis 从 get_kline_data 的返回结果中提取技术分析原文
+func extractAnalysis(part *genai.Part) (string, bool) {
+	if part == nil || part.FunctionResponse == nil || part.FunctionResponse.Name != "get_kline_data" {
+		return "", false
+	}
+	data, _ := part.FunctionResponse.Response["data"].(string)
+	if !strings.HasPrefix(data, analysisHeader) {
+		return "", false
+	}
+	return data, true
+}
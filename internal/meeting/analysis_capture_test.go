@@ -0,0 +1,27 @@
+package meeting
+
+import (
+	"testing"
+
+	"google.golang.org/genai"
+)
+
+func TestExtractAnalysis(t *testing.T) {
+	analysis := &genai.Part{FunctionResponse: &genai.FunctionResponse{Name: "get_kline_data", Response: map[string]any{"data": "[Snapshot]\nprice=10"}}}
+	if content, ok := extractAnalysis(analysis); !ok || content != "[Snapshot]\nprice=10" {
+		t.Errorf("应提取 analysis 模式结果: %q %v", content, ok)
+	}
+
+	raw := &genai.Part{FunctionResponse: &genai.FunctionResponse{Name: "get_kline_data", Response: map[string]any{"data": "2026-01-05: 开10.00"}}}
+	if _, ok := extractAnalysis(raw); ok {
+		t.Error("原始K线不应记录")
+	}
+
+	other := &genai.Part{FunctionResponse: &genai.FunctionResponse{Name: "get_news", Response: map[string]any{"data": "[Snapshot]"}}}
+	if _, ok := extractAnalysis(other); ok {
+		t.Error("其他工具不应记录")
+	}
+	if _, ok := extractAnalysis(&genai.Part{Text: "hello"}); ok {
+		t.Error("非工具结果不应记录")
+	}
+}
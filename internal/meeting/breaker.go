@@ -0,0 +1,226 @@
+package meeting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+
+	"google.golang.org/adk/model"
+)
+
+// 熔断器状态
+const (
+	BreakerClosed   = "closed"    // 正常放行
+	BreakerOpen     = "open"      // 熔断中，直接拒绝
+	BreakerHalfOpen = "half_open" // 冷却结束，放行一次试探
+)
+
+// BreakerStatus 单个 AI 配置的熔断状态
+type BreakerStatus struct {
+	ConfigID  string `json:"configId"`
+	State     string `json:"state"`
+	Failures  int    `json:"failures"`  // 当前连续失败次数
+	OpenUntil int64  `json:"openUntil"` // 熔断结束时间(Unix 毫秒)，未熔断为 0
+	LastError string `json:"lastError"` // 最近一次失败原因
+}
+
+// breakerEntry 单个 AI 配置的熔断记录
+type breakerEntry struct {
+	state        string
+	failures     int
+	firstFailure time.Time
+	openedAt     time.Time
+	probing      bool      // 半开状态下试探请求已放行
+	probeAt      time.Time // 试探放行时间，试探被取消等未产生结果时超过冷却时长可再次试探
+	lastError    string
+}
+
+// circuitBreaker 按 AI 配置 ID 统计连续失败，达到阈值后在冷却期内拒绝新会议
+type circuitBreaker struct {
+	mu      sync.Mutex
+	config  models.CircuitBreakerConfig
+	entries map[string]*breakerEntry
+}
+
+// newCircuitBreaker 创建熔断器
+func newCircuitBreaker() *circuitBreaker {
+	return &circuitBreaker{entries: make(map[string]*breakerEntry)}
+}
+
+// setConfig 更新熔断参数，关闭熔断时清空状态
+func (b *circuitBreaker) setConfig(cfg models.CircuitBreakerConfig) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.config = cfg
+	if cfg.Threshold <= 0 {
+		b.entries = make(map[string]*breakerEntry)
+	}
+}
+
+// window 连续失败统计窗口
+func (b *circuitBreaker) window() time.Duration {
+	if b.config.WindowSec <= 0 {
+		return 5 * time.Minute
+	}
+	return time.Duration(b.config.WindowSec) * time.Second
+}
+
+// cooldown 熔断冷却时长
+func (b *circuitBreaker) cooldown() time.Duration {
+	if b.config.CooldownSec <= 0 {
+		return time.Minute
+	}
+	return time.Duration(b.config.CooldownSec) * time.Second
+}
+
+// allow 判断是否放行该配置的新会议，熔断中返回 ErrAIUnavailable
+// 冷却结束后转为半开状态，仅放行一次试探，结果出来前其余请求仍被拒绝
+func (b *circuitBreaker) allow(id string, now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.config.Threshold <= 0 {
+		return nil
+	}
+	e := b.entries[id]
+	if e == nil {
+		return nil
+	}
+
+	switch e.state {
+	case BreakerOpen:
+		until := e.openedAt.Add(b.cooldown())
+		if now.Before(until) {
+			return fmt.Errorf("%w，请 %d 秒后重试（%s）", ErrAIUnavailable, int(until.Sub(now).Seconds())+1, e.lastError)
+		}
+		e.state = BreakerHalfOpen
+		e.probing = true
+		e.probeAt = now
+		return nil
+	case BreakerHalfOpen:
+		if e.probing && now.Sub(e.probeAt) < b.cooldown() {
+			return fmt.Errorf("%w，正在检测服务是否恢复", ErrAIUnavailable)
+		}
+		e.probing = true
+		e.probeAt = now
+	}
+	return nil
+}
+
+// record 记录一次调用结果：成功即复位，失败累计；用户主动取消不计入
+func (b *circuitBreaker) record(id string, err error, now time.Time) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.config.Threshold <= 0 {
+		return
+	}
+
+	if err == nil {
+		delete(b.entries, id)
+		return
+	}
+
+	e := b.entries[id]
+	if e == nil {
+		e = &breakerEntry{state: BreakerClosed}
+		b.entries[id] = e
+	}
+	e.lastError = err.Error()
+
+	switch e.state {
+	case BreakerOpen:
+		return
+	case BreakerHalfOpen:
+		// 试探失败，重新熔断
+		e.state = BreakerOpen
+		e.openedAt = now
+		e.probing = false
+		log.Warn("AI config %s probe failed, circuit reopened: %v", id, err)
+		return
+	}
+
+	if e.failures == 0 || now.Sub(e.firstFailure) > b.window() {
+		e.failures = 0
+		e.firstFailure = now
+	}
+	e.failures++
+	if e.failures >= b.config.Threshold {
+		e.state = BreakerOpen
+		e.openedAt = now
+		log.Warn("AI config %s failed %d times, circuit opened for %v", id, e.failures, b.cooldown())
+	}
+}
+
+// status 返回所有存在失败记录的配置状态，按配置 ID 排序
+func (b *circuitBreaker) status(now time.Time) []BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	result := make([]BreakerStatus, 0, len(b.entries))
+	for id, e := range b.entries {
+		st := BreakerStatus{ConfigID: id, State: e.state, Failures: e.failures, LastError: e.lastError}
+		if e.state == BreakerOpen {
+			until := e.openedAt.Add(b.cooldown())
+			if now.Before(until) {
+				st.OpenUntil = until.UnixMilli()
+			} else {
+				st.State = BreakerHalfOpen
+			}
+		}
+		result = append(result, st)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ConfigID < result[j].ConfigID })
+	return result
+}
+
+// breakerLLM 包装模型，将每次生成的成败计入熔断器
+type breakerLLM struct {
+	model.LLM
+	id      string
+	breaker *circuitBreaker
+}
+
+// GenerateContent 透传生成结果，结束后记录成败；调用方提前停止接收且未出错时不计入
+func (l *breakerLLM) GenerateContent(ctx context.Context, req *model.LLMRequest, stream bool) iter.Seq2[*model.LLMResponse, error] {
+	return func(yield func(*model.LLMResponse, error) bool) {
+		var failure error
+		for resp, err := range l.LLM.GenerateContent(ctx, req, stream) {
+			if err != nil {
+				failure = err
+			}
+			if !yield(resp, err) {
+				if failure != nil {
+					l.breaker.record(l.id, failure, time.Now())
+				}
+				return
+			}
+		}
+		l.breaker.record(l.id, failure, time.Now())
+	}
+}
+
+// withBreaker 模型创建成功时包装熔断统计，失败时直接记录
+func (s *Service) withBreaker(id string, llm model.LLM, err error) (model.LLM, error) {
+	if err != nil {
+		s.breaker.record(id, err, time.Now())
+		return nil, err
+	}
+	return &breakerLLM{LLM: llm, id: id, breaker: s.breaker}, nil
+}
+
+// SetCircuitBreaker 设置 AI 服务熔断参数，Threshold 为 0 表示关闭
+func (s *Service) SetCircuitBreaker(cfg models.CircuitBreakerConfig) {
+	s.breaker.setConfig(cfg)
+}
+
+// GetBreakerStatus 返回各 AI 配置的熔断状态（仅包含有失败记录的配置）
+func (s *Service) GetBreakerStatus() []BreakerStatus {
+	return s.breaker.status(time.Now())
+}
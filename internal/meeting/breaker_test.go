@@ -0,0 +1,85 @@
+package meeting
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestCircuitBreaker 测试连续失败熔断、冷却后半开试探及恢复
+func TestCircuitBreaker(t *testing.T) {
+	b := newCircuitBreaker()
+	b.setConfig(models.CircuitBreakerConfig{Threshold: 3, WindowSec: 60, CooldownSec: 30})
+	now := time.Date(2024, 10, 14, 10, 0, 0, 0, time.Local)
+	fail := errors.New("HTTP 503")
+
+	// 用户取消不计入，成功复位计数
+	b.record("a", context.Canceled, now)
+	b.record("a", fail, now)
+	b.record("a", nil, now)
+	b.record("a", fail, now)
+	b.record("a", fail, now.Add(time.Second))
+	if err := b.allow("a", now.Add(2*time.Second)); err != nil {
+		t.Fatalf("未达阈值不应熔断: %v", err)
+	}
+
+	b.record("a", fail, now.Add(2*time.Second))
+	err := b.allow("a", now.Add(3*time.Second))
+	if !errors.Is(err, ErrAIUnavailable) {
+		t.Fatalf("连续失败3次应熔断: %v", err)
+	}
+	if err := b.allow("b", now.Add(3*time.Second)); err != nil {
+		t.Errorf("其他配置不受影响: %v", err)
+	}
+	if st := b.status(now.Add(3 * time.Second)); len(st) != 1 || st[0].State != BreakerOpen || st[0].OpenUntil == 0 {
+		t.Errorf("熔断状态错误: %+v", st)
+	}
+
+	// 冷却结束放行一次试探，试探期间其余请求仍拒绝
+	probe := now.Add(33 * time.Second)
+	if err := b.allow("a", probe); err != nil {
+		t.Fatalf("冷却结束应放行试探: %v", err)
+	}
+	if err := b.allow("a", probe); !errors.Is(err, ErrAIUnavailable) {
+		t.Errorf("试探期间应拒绝: %v", err)
+	}
+	// 试探失败重新熔断
+	b.record("a", fail, probe)
+	if err := b.allow("a", probe.Add(time.Second)); !errors.Is(err, ErrAIUnavailable) {
+		t.Errorf("试探失败应重新熔断: %v", err)
+	}
+
+	// 再次试探成功后恢复
+	probe = probe.Add(31 * time.Second)
+	if err := b.allow("a", probe); err != nil {
+		t.Fatalf("冷却结束应放行试探: %v", err)
+	}
+	b.record("a", nil, probe)
+	if err := b.allow("a", probe); err != nil || len(b.status(probe)) != 0 {
+		t.Errorf("试探成功应恢复: %v", err)
+	}
+}
+
+// TestCircuitBreakerWindow 测试超出统计窗口的失败重新计数，阈值为0时关闭
+func TestCircuitBreakerWindow(t *testing.T) {
+	b := newCircuitBreaker()
+	b.setConfig(models.CircuitBreakerConfig{Threshold: 2, WindowSec: 10})
+	now := time.Now()
+	fail := errors.New("timeout")
+
+	b.record("a", fail, now)
+	b.record("a", fail, now.Add(20*time.Second))
+	if err := b.allow("a", now.Add(20*time.Second)); err != nil {
+		t.Errorf("窗口外的失败不应累计: %v", err)
+	}
+
+	b.setConfig(models.CircuitBreakerConfig{})
+	b.record("a", fail, now)
+	b.record("a", fail, now)
+	if err := b.allow("a", now); err != nil {
+		t.Errorf("关闭熔断时应始终放行: %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package meeting
+
+import (
+	"context"
+	"errors"
+
+	"github.com/run-bigpig/jcp/internal/models"
+
+	"google.golang.org/adk/model"
+)
+
+// 会议模式
+const (
+	MeetingModeSmart  = "smart"  // 小韭菜编排
+	MeetingModeDirect = "direct" // @ 指定专家
+)
+
+// MeetingComplete 会议结束时的完整快照，前端据此确认会议结束并校准已收到的发言
+type MeetingComplete struct {
+	MeetingID string            `json:"meetingId"`
+	Mode      string            `json:"mode"`            // smart/direct
+	Responses []ChatResponse    `json:"responses"`       // 全部发言（按产生顺序）
+	Usage     models.TokenUsage `json:"usage"`           // 专家发言 token 用量合计
+	Completed bool              `json:"completed"`       // 是否完整结束
+	Truncated bool              `json:"truncated"`       // 是否因超时只返回了部分结果
+	Error     string            `json:"error,omitempty"` // 失败原因
+}
+
+// NewMeetingComplete 根据会议返回结果构建结束快照
+func NewMeetingComplete(meetingID, mode string, responses []ChatResponse, err error) MeetingComplete {
+	result := MeetingComplete{
+		MeetingID: meetingID,
+		Mode:      mode,
+		Responses: responses,
+		Completed: err == nil,
+		Truncated: errors.Is(err, ErrMeetingTimeout) || errors.Is(err, context.DeadlineExceeded),
+	}
+	if result.Responses == nil {
+		result.Responses = []ChatResponse{}
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	for _, resp := range responses {
+		if resp.Usage != nil {
+			result.Usage.Add(*resp.Usage)
+		}
+	}
+	return result
+}
+
+// usageRecorder 累计单个专家发言过程中各次模型调用的 token 用量
+type usageRecorder struct {
+	usage    models.TokenUsage
+	reported bool
+}
+
+// observe 记录事件中的用量，流式分片不计入，避免与最终响应重复累加
+func (u *usageRecorder) observe(resp *model.LLMResponse) {
+	if resp == nil || resp.Partial || resp.UsageMetadata == nil {
+		return
+	}
+	md := resp.UsageMetadata
+	u.usage.Add(models.TokenUsage{
+		PromptTokens:     int(md.PromptTokenCount),
+		CompletionTokens: int(md.CandidatesTokenCount),
+		TotalTokens:      int(md.TotalTokenCount),
+	})
+	u.reported = true
+}
+
+// result 返回累计用量，模型未上报用量时返回 nil
+func (u *usageRecorder) result() *models.TokenUsage {
+	if !u.reported {
+		return nil
+	}
+	usage := u.usage
+	return &usage
+}
@@ -0,0 +1,57 @@
+package meeting
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+
+	"google.golang.org/adk/model"
+	"google.golang.org/genai"
+)
+
+func TestNewMeetingComplete(t *testing.T) {
+	responses := []ChatResponse{
+		{AgentID: "moderator", MsgType: "opening"},
+		{AgentID: "a", MsgType: "opinion", Usage: &models.TokenUsage{PromptTokens: 100, CompletionTokens: 20, TotalTokens: 120}},
+		{AgentID: "b", MsgType: "opinion", Usage: &models.TokenUsage{PromptTokens: 50, CompletionTokens: 10, TotalTokens: 60}},
+	}
+
+	done := NewMeetingComplete("sh600519", MeetingModeSmart, responses, nil)
+	if !done.Completed || done.Truncated || done.Error != "" {
+		t.Errorf("正常结束状态错误: %+v", done)
+	}
+	if done.Usage.TotalTokens != 180 || done.Usage.PromptTokens != 150 {
+		t.Errorf("用量合计错误: %+v", done.Usage)
+	}
+	if len(done.Responses) != 3 || done.Responses[2].AgentID != "b" {
+		t.Errorf("发言顺序应保持不变: %+v", done.Responses)
+	}
+
+	partial := NewMeetingComplete("sh600519", MeetingModeSmart, responses[:1], ErrMeetingTimeout)
+	if partial.Completed || !partial.Truncated || partial.Error == "" {
+		t.Errorf("超时应标记为截断: %+v", partial)
+	}
+
+	failed := NewMeetingComplete("sh600519", MeetingModeDirect, nil, errors.New("create model error"))
+	if failed.Completed || failed.Truncated || failed.Responses == nil {
+		t.Errorf("失败不应标记为截断，发言应为空数组: %+v", failed)
+	}
+}
+
+func TestUsageRecorder(t *testing.T) {
+	var rec usageRecorder
+	if rec.result() != nil {
+		t.Error("未上报用量时应返回 nil")
+	}
+
+	usage := &genai.GenerateContentResponseUsageMetadata{PromptTokenCount: 80, CandidatesTokenCount: 30, TotalTokenCount: 110}
+	rec.observe(&model.LLMResponse{Partial: true, UsageMetadata: usage}) // 流式分片不计入
+	rec.observe(&model.LLMResponse{UsageMetadata: usage})
+	rec.observe(&model.LLMResponse{UsageMetadata: usage}) // 工具调用后的第二次模型调用
+
+	got := rec.result()
+	if got == nil || got.TotalTokens != 220 || got.CompletionTokens != 60 {
+		t.Errorf("用量累计错误: %+v", got)
+	}
+}
@@ -0,0 +1,151 @@
+package meeting
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// DecisionLogMaxBytes 决策日志文件大小上限，超出后轮转为 .1 备份（仅保留一份）
+const DecisionLogMaxBytes = 5 << 20
+
+// DecisionRecord 一次智能会议的路由决策记录，用于评估小韭菜的专家选择
+type DecisionRecord struct {
+	Time        int64    `json:"time"` // 会议开始时间(Unix 毫秒)
+	StockCode   string   `json:"stockCode"`
+	Query       string   `json:"query"`
+	Intent      string   `json:"intent"`
+	Topic       string   `json:"topic"`
+	Selected    []string `json:"selected"`    // 小韭菜选择的专家 ID（按选择顺序）
+	Speakers    []string `json:"speakers"`    // 实际完成发言的专家 ID（含兜底、邀请全部及数量上限的影响）
+	Summarized  bool     `json:"summarized"`  // 是否生成了总结
+	OutcomeHash string   `json:"outcomeHash"` // 全部发言内容的 SHA-256 前 16 位，用于比对同一问题的输出是否变化
+	DurationMs  int64    `json:"durationMs"`
+}
+
+// DecisionLog 会议决策日志，以 JSONL 追加写入
+type DecisionLog struct {
+	mu      sync.Mutex
+	path    string
+	enabled bool
+}
+
+// NewDecisionLog 创建会议决策日志，默认关闭
+func NewDecisionLog(path string) *DecisionLog {
+	return &DecisionLog{path: path}
+}
+
+// SetEnabled 开启或关闭决策记录，关闭后已有日志仍可读取
+func (l *DecisionLog) SetEnabled(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.enabled = enabled
+}
+
+// Append 追加一条记录，未开启时忽略；文件超过上限时先轮转
+func (l *DecisionLog) Append(record DecisionRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.enabled {
+		return nil
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	if info, err := os.Stat(l.path); err == nil && info.Size()+int64(len(line)) > DecisionLogMaxBytes {
+		if err := os.Rename(l.path, l.path+".1"); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(line)
+	return err
+}
+
+// Read 读取全部记录（含轮转备份），按时间升序；无法解析的行跳过
+func (l *DecisionLog) Read() ([]DecisionRecord, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var records []DecisionRecord
+	for _, path := range []string{l.path + ".1", l.path} {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Buffer(make([]byte, 0, 64*1024), DecisionLogMaxBytes)
+		for scanner.Scan() {
+			var r DecisionRecord
+			if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+				continue
+			}
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+// SetDecisionLog 设置会议决策日志，nil 表示不记录
+func (s *Service) SetDecisionLog(l *DecisionLog) {
+	s.decisionLog = l
+}
+
+// logDecision 记录一次智能会议的专家选择与结果摘要
+func (s *Service) logDecision(req *ChatRequest, decision *ModeratorDecision, responses []ChatResponse, start time.Time) {
+	if s.decisionLog == nil || decision == nil {
+		return
+	}
+	record := DecisionRecord{
+		Time:        start.UnixMilli(),
+		StockCode:   req.Stock.Symbol,
+		Query:       req.Query,
+		Intent:      decision.Intent,
+		Topic:       decision.Topic,
+		Selected:    decision.Selected,
+		Speakers:    []string{},
+		OutcomeHash: outcomeHash(responses),
+		DurationMs:  time.Since(start).Milliseconds(),
+	}
+	for _, resp := range responses {
+		switch resp.MsgType {
+		case "opinion":
+			record.Speakers = append(record.Speakers, resp.AgentID)
+		case "summary":
+			record.Summarized = true
+		}
+	}
+	if err := s.decisionLog.Append(record); err != nil {
+		log.Warn("write meeting decision log error: %v", err)
+	}
+}
+
+// outcomeHash 计算会议全部发言内容的摘要
+func outcomeHash(responses []ChatResponse) string {
+	h := sha256.New()
+	for _, resp := range responses {
+		h.Write([]byte(resp.AgentID))
+		h.Write([]byte{0})
+		h.Write([]byte(resp.Content))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
@@ -0,0 +1,70 @@
+package meeting
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestDecisionLog 测试开关、追加读取及超过上限时轮转
+func TestDecisionLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "decisions.jsonl")
+	l := NewDecisionLog(path)
+
+	if err := l.Append(DecisionRecord{Query: "关闭时不记录"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("未开启时不应创建文件: %v", err)
+	}
+
+	l.SetEnabled(true)
+	l.Append(DecisionRecord{Query: "q1", Selected: []string{"a"}})
+	l.Append(DecisionRecord{Query: "q2", Selected: []string{"b"}})
+	records, err := l.Read()
+	if err != nil || len(records) != 2 || records[1].Query != "q2" {
+		t.Fatalf("读取记录错误: %v, %+v", err, records)
+	}
+
+	// 超过上限后轮转，读取时包含备份
+	big := DecisionRecord{Query: strings.Repeat("x", DecisionLogMaxBytes/2)}
+	l.Append(big)
+	l.Append(big)
+	if _, err := os.Stat(path + ".1"); err != nil {
+		t.Fatalf("超过上限应轮转: %v", err)
+	}
+	if info, _ := os.Stat(path); info.Size() > DecisionLogMaxBytes {
+		t.Errorf("当前文件超过上限: %d", info.Size())
+	}
+	records, _ = l.Read()
+	if len(records) != 4 || records[0].Query != "q1" {
+		t.Errorf("轮转后读取错误: %d", len(records))
+	}
+}
+
+// TestLogDecision 测试从会议结果提取实际发言专家和总结标记
+func TestLogDecision(t *testing.T) {
+	s := &Service{decisionLog: NewDecisionLog(filepath.Join(t.TempDir(), "d.jsonl"))}
+	s.decisionLog.SetEnabled(true)
+	responses := []ChatResponse{
+		{AgentID: "moderator", MsgType: "opening", Content: "开场"},
+		{AgentID: "a", MsgType: "opinion", Content: "观点"},
+		{AgentID: "moderator", MsgType: "summary", Content: "总结"},
+	}
+	decision := &ModeratorDecision{Selected: []string{"a", "b"}, Topic: "估值"}
+	s.logDecision(&ChatRequest{Query: "贵不贵"}, decision, responses, time.Now())
+
+	records, _ := s.decisionLog.Read()
+	if len(records) != 1 {
+		t.Fatalf("应记录1条: %d", len(records))
+	}
+	r := records[0]
+	if len(r.Speakers) != 1 || r.Speakers[0] != "a" || !r.Summarized || len(r.OutcomeHash) != 16 || r.Topic != "估值" {
+		t.Errorf("记录内容错误: %+v", r)
+	}
+	if outcomeHash(responses[:2]) == r.OutcomeHash {
+		t.Error("不同结果的摘要应不同")
+	}
+}
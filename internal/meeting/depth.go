@@ -0,0 +1,36 @@
+package meeting
+
+import "github.com/run-bigpig/jcp/internal/models"
+
+// applyAnalysisDepth 将分析深度预设解析为请求中的具体会议参数
+// 请求未指定深度时使用 defaultDepth；standard 及未知取值保持原有行为，不修改请求
+func applyAnalysisDepth(req *ChatRequest, defaultDepth models.AnalysisDepth) {
+	depth := req.Depth
+	if depth == "" {
+		depth = defaultDepth
+	}
+	switch depth {
+	case models.AnalysisDepthQuick:
+		req.MaxExperts = 1
+		req.SkipSummary = true
+	case models.AnalysisDepthDeep:
+		req.InviteAll = true
+		req.Preflight = true
+	}
+	req.Depth = depth
+}
+
+// appendRemainingAgents 在已选专家之后按原顺序追加其余已启用的专家
+func appendRemainingAgents(selected, all []models.AgentConfig) []models.AgentConfig {
+	seen := make(map[string]bool, len(selected))
+	for _, a := range selected {
+		seen[a.ID] = true
+	}
+	result := append([]models.AgentConfig{}, selected...)
+	for _, a := range all {
+		if a.Enabled && !seen[a.ID] {
+			result = append(result, a)
+		}
+	}
+	return result
+}
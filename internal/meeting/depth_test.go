@@ -0,0 +1,44 @@
+package meeting
+
+import (
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestApplyAnalysisDepth(t *testing.T) {
+	req := ChatRequest{}
+	applyAnalysisDepth(&req, models.AnalysisDepthQuick)
+	if req.MaxExperts != 1 || !req.SkipSummary || req.InviteAll || req.Depth != models.AnalysisDepthQuick {
+		t.Errorf("quick 预设解析错误: %+v", req)
+	}
+
+	// 请求指定的深度优先于默认深度
+	req = ChatRequest{Depth: models.AnalysisDepthDeep}
+	applyAnalysisDepth(&req, models.AnalysisDepthQuick)
+	if !req.InviteAll || !req.Preflight || req.MaxExperts != 0 || req.SkipSummary {
+		t.Errorf("deep 预设解析错误: %+v", req)
+	}
+
+	// standard 及未配置时保持原有行为
+	req = ChatRequest{}
+	applyAnalysisDepth(&req, "")
+	if req.MaxExperts != 0 || req.SkipSummary || req.InviteAll || req.Preflight {
+		t.Errorf("standard 不应修改请求: %+v", req)
+	}
+}
+
+func TestAppendRemainingAgents(t *testing.T) {
+	all := []models.AgentConfig{{ID: "a", Enabled: true}, {ID: "b", Enabled: true}, {ID: "c", Enabled: true}}
+	got := appendRemainingAgents([]models.AgentConfig{{ID: "c"}}, all)
+	if len(got) != 3 || got[0].ID != "c" || got[1].ID != "a" || got[2].ID != "b" {
+		t.Errorf("追加结果错误: %+v", got)
+	}
+
+	// 未启用的专家不追加
+	all[1].Enabled = false
+	got = appendRemainingAgents([]models.AgentConfig{{ID: "c"}}, all)
+	if len(got) != 2 || got[0].ID != "c" || got[1].ID != "a" {
+		t.Errorf("不应追加未启用的专家: %+v", got)
+	}
+}
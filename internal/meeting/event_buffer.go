@@ -0,0 +1,130 @@
+package meeting
+
+import (
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// DefaultEventBufferSize 单场会议最多缓存的事件数
+const DefaultEventBufferSize = 500
+
+// 会议事件类型
+const (
+	EventTypeMessage  = "message"
+	EventTypeProgress = "progress"
+)
+
+// MeetingEvent 会议事件（发言或进度），用于前端重连后回放
+type MeetingEvent struct {
+	Seq       int64               `json:"seq"`                // 会议内递增序号
+	Type      string              `json:"type"`               // message/progress
+	Message   *models.ChatMessage `json:"message,omitempty"`  // 发言内容
+	Progress  *ProgressEvent      `json:"progress,omitempty"` // 进度事件
+	Timestamp int64               `json:"timestamp"`          // 毫秒时间戳
+}
+
+// MeetingEvents 会议事件回放结果
+type MeetingEvents struct {
+	MeetingID string         `json:"meetingId"`
+	Active    bool           `json:"active"` // 会议是否仍在进行
+	Events    []MeetingEvent `json:"events"`
+}
+
+// meetingEventLog 单场会议的事件记录
+type meetingEventLog struct {
+	generation int64
+	active     bool
+	seq        int64
+	events     []MeetingEvent
+}
+
+// EventBuffer 按会议ID（股票代码）缓存最近事件的环形缓冲
+// 会议结束后保留最后一场的事件，直到同一ID开始新会议
+type EventBuffer struct {
+	mu         sync.Mutex
+	size       int
+	generation int64
+	logs       map[string]*meetingEventLog
+}
+
+// NewEventBuffer 创建事件缓冲，size<=0 时使用默认容量
+func NewEventBuffer(size int) *EventBuffer {
+	if size <= 0 {
+		size = DefaultEventBufferSize
+	}
+	return &EventBuffer{
+		size: size,
+		logs: make(map[string]*meetingEventLog),
+	}
+}
+
+// Begin 开始新会议并清空旧事件，返回会议代次供 End 校验
+func (b *EventBuffer) Begin(meetingID string) int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.generation++
+	b.logs[meetingID] = &meetingEventLog{generation: b.generation, active: true}
+	return b.generation
+}
+
+// End 标记会议结束；若该ID已开始新会议则忽略
+func (b *EventBuffer) End(meetingID string, generation int64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if l, ok := b.logs[meetingID]; ok && l.generation == generation {
+		l.active = false
+	}
+}
+
+// AppendMessage 记录发言事件
+func (b *EventBuffer) AppendMessage(meetingID string, msg models.ChatMessage) {
+	b.append(meetingID, MeetingEvent{Type: EventTypeMessage, Message: &msg})
+}
+
+// AppendProgress 记录进度事件，同一专家连续的流式片段合并为一条
+func (b *EventBuffer) AppendProgress(meetingID string, event ProgressEvent) {
+	b.mu.Lock()
+	if l, ok := b.logs[meetingID]; ok && event.Type == "streaming" && len(l.events) > 0 {
+		last := l.events[len(l.events)-1]
+		if last.Progress != nil && last.Progress.Type == "streaming" && last.Progress.AgentID == event.AgentID {
+			merged := *last.Progress
+			merged.Content += event.Content
+			l.events[len(l.events)-1].Progress = &merged
+			b.mu.Unlock()
+			return
+		}
+	}
+	b.mu.Unlock()
+	b.append(meetingID, MeetingEvent{Type: EventTypeProgress, Progress: &event})
+}
+
+// append 追加事件，超出容量时丢弃最旧的事件
+func (b *EventBuffer) append(meetingID string, event MeetingEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	l, ok := b.logs[meetingID]
+	if !ok {
+		return
+	}
+	l.seq++
+	event.Seq = l.seq
+	event.Timestamp = time.Now().UnixMilli()
+	l.events = append(l.events, event)
+	if len(l.events) > b.size {
+		l.events = append(l.events[:0:0], l.events[len(l.events)-b.size:]...)
+	}
+}
+
+// Events 获取会议已缓存的事件副本
+func (b *EventBuffer) Events(meetingID string) MeetingEvents {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	result := MeetingEvents{MeetingID: meetingID, Events: []MeetingEvent{}}
+	if l, ok := b.logs[meetingID]; ok {
+		result.Active = l.active
+		result.Events = append(result.Events, l.events...)
+	}
+	return result
+}
@@ -0,0 +1,48 @@
+package meeting
+
+import (
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestEventBuffer(t *testing.T) {
+	buf := NewEventBuffer(3)
+	gen := buf.Begin("sh600519")
+
+	buf.AppendProgress("sh600519", ProgressEvent{Type: "agent_start", AgentID: "a"})
+	buf.AppendProgress("sh600519", ProgressEvent{Type: "streaming", AgentID: "a", Content: "你"})
+	buf.AppendProgress("sh600519", ProgressEvent{Type: "streaming", AgentID: "a", Content: "好"})
+
+	got := buf.Events("sh600519")
+	if !got.Active || len(got.Events) != 2 {
+		t.Fatalf("流式片段应合并: %+v", got)
+	}
+	if got.Events[1].Progress.Content != "你好" {
+		t.Errorf("合并内容错误: %q", got.Events[1].Progress.Content)
+	}
+
+	buf.AppendMessage("sh600519", models.ChatMessage{AgentID: "a", Content: "结论"})
+	buf.AppendProgress("sh600519", ProgressEvent{Type: "agent_done", AgentID: "a"})
+	got = buf.Events("sh600519")
+	if len(got.Events) != 3 || got.Events[0].Seq != 2 || got.Events[2].Seq != 4 {
+		t.Errorf("超出容量应丢弃最旧事件: %+v", got.Events)
+	}
+
+	// 新会议开始后，旧会议的结束不应影响新会议状态
+	newGen := buf.Begin("sh600519")
+	buf.End("sh600519", gen)
+	if got = buf.Events("sh600519"); !got.Active || len(got.Events) != 0 {
+		t.Errorf("新会议状态错误: %+v", got)
+	}
+	buf.End("sh600519", newGen)
+	if buf.Events("sh600519").Active {
+		t.Error("会议结束后应标记为非活跃")
+	}
+
+	// 未开始的会议不记录事件
+	buf.AppendMessage("sz000001", models.ChatMessage{Content: "x"})
+	if len(buf.Events("sz000001").Events) != 0 {
+		t.Error("未开始的会议不应记录事件")
+	}
+}
@@ -12,9 +12,43 @@ import (
 	"google.golang.org/genai"
 )
 
+// 小韭菜意图分析 Prompt 模板占位符
+const (
+	PlaceholderStock  = "{{stock}}"  // 股票名称、代码、现价和涨跌幅
+	PlaceholderQuery  = "{{query}}"  // 用户的问题
+	PlaceholderAgents = "{{agents}}" // 可邀请的专家列表（每行一位：名称、ID、角色）
+	PlaceholderUser   = "{{user}}"   // 对用户的称呼，未设置时为 models.DefaultUserName
+)
+
+// DefaultModeratorPrompt 内置的意图分析 Prompt 模板
+const DefaultModeratorPrompt = `你是「财经会议室」的小韭菜，负责组织专家讨论。
+
+## 当前股票
+{{stock}}
+
+## {{user}}问题
+{{query}}
+
+## 可邀请的专家
+{{agents}}
+
+## 你的任务
+1. 分析{{user}}问题的核心意图
+2. 选择 1-3 位最相关的专家
+3. 生成讨论议题和开场白`
+
+// moderatorOutputFormat 输出格式要求，始终追加在模板之后以保证可解析
+const moderatorOutputFormat = "\n\n## 输出格式（仅输出JSON）\n" +
+	`{"intent":"意图","selected":["id1"],"weights":{"id1":0.6},"topic":"议题","opening":"开场白"}` +
+	"\nweights 为各专家观点对本问题的重要程度(0-1)，可省略"
+
 // Moderator 小韭菜 Agent
 type Moderator struct {
-	llm model.LLM
+	llm            model.LLM
+	promptTemplate string               // 意图分析 Prompt 模板，为空时使用内置模板
+	summaryParams  models.SummaryParams // 总结生成参数
+	userName       string               // 对用户的称呼，为空使用 models.DefaultUserName
+	userPersona    string               // 用户投资画像
 }
 
 // NewModerator 创建小韭菜
@@ -22,12 +56,37 @@ func NewModerator(llm model.LLM) *Moderator {
 	return &Moderator{llm: llm}
 }
 
+// NewModeratorWithPrompt 创建使用自定义意图分析模板的小韭菜
+func NewModeratorWithPrompt(llm model.LLM, promptTemplate string) *Moderator {
+	return &Moderator{llm: llm, promptTemplate: promptTemplate}
+}
+
+// SetSummaryParams 设置总结使用的生成参数
+func (m *Moderator) SetSummaryParams(params models.SummaryParams) {
+	m.summaryParams = params
+}
+
+// SetUserProfile 设置对用户的称呼和投资画像，用于意图分析和总结
+func (m *Moderator) SetUserProfile(name, persona string) {
+	m.userName = name
+	m.userPersona = persona
+}
+
+// displayUserName 对用户的称呼，未设置时使用默认称呼
+func (m *Moderator) displayUserName() string {
+	if m.userName == "" {
+		return models.DefaultUserName
+	}
+	return m.userName
+}
+
 // ModeratorDecision 小韭菜决策结果
 type ModeratorDecision struct {
-	Intent   string   `json:"intent"`
-	Selected []string `json:"selected"`
-	Topic    string   `json:"topic"`
-	Opening  string   `json:"opening"`
+	Intent   string             `json:"intent"`
+	Selected []string           `json:"selected"`
+	Weights  map[string]float64 `json:"weights,omitempty"` // 各专家观点对问题的重要程度，可省略
+	Topic    string             `json:"topic"`
+	Opening  string             `json:"opening"`
 }
 
 // DiscussionEntry 讨论条目
@@ -37,12 +96,14 @@ type DiscussionEntry struct {
 	AgentName string `json:"agentName"`
 	Role      string `json:"role"`
 	Content   string `json:"content"`
+	// 专家观点在总结中的权重（选中专家之间归一化）
+	Weight float64 `json:"weight,omitempty"`
 }
 
 // Analyze 分析用户意图并选择专家
 func (m *Moderator) Analyze(ctx context.Context, stock *models.Stock, query string, agents []models.AgentConfig) (*ModeratorDecision, error) {
 	prompt := m.buildAnalyzePrompt(stock, query, agents)
-	content, err := m.generate(ctx, prompt)
+	content, err := m.generate(ctx, prompt, nil)
 	if err != nil {
 		return nil, fmt.Errorf("moderator analyze error: %w", err)
 	}
@@ -52,15 +113,31 @@ func (m *Moderator) Analyze(ctx context.Context, stock *models.Stock, query stri
 // Summarize 总结讨论并给出结论
 func (m *Moderator) Summarize(ctx context.Context, stock *models.Stock, query string, history []DiscussionEntry) (string, error) {
 	prompt := m.buildSummarizePrompt(stock, query, history)
-	return m.generate(ctx, prompt)
+	return m.generate(ctx, prompt, summaryGenerateConfig(m.summaryParams))
 }
 
-// generate 调用 LLM 生成内容
-func (m *Moderator) generate(ctx context.Context, prompt string) (string, error) {
+// summaryGenerateConfig 将总结参数转换为生成配置，未设置任何参数时返回 nil
+func summaryGenerateConfig(params models.SummaryParams) *genai.GenerateContentConfig {
+	if params.Temperature == nil && params.MaxTokens <= 0 {
+		return nil
+	}
+	cfg := &genai.GenerateContentConfig{}
+	if params.Temperature != nil {
+		cfg.Temperature = genai.Ptr(float32(*params.Temperature))
+	}
+	if params.MaxTokens > 0 {
+		cfg.MaxOutputTokens = int32(params.MaxTokens)
+	}
+	return cfg
+}
+
+// generate 调用 LLM 生成内容，config 为 nil 时使用模型默认参数
+func (m *Moderator) generate(ctx context.Context, prompt string, config *genai.GenerateContentConfig) (string, error) {
 	req := &model.LLMRequest{
 		Contents: []*genai.Content{
 			{Role: "user", Parts: []*genai.Part{genai.NewPartFromText(prompt)}},
 		},
+		Config: config,
 	}
 
 	var result strings.Builder
@@ -84,45 +161,111 @@ func (m *Moderator) generate(ctx context.Context, prompt string) (string, error)
 
 // buildAnalyzePrompt 构建意图分析 Prompt
 func (m *Moderator) buildAnalyzePrompt(stock *models.Stock, query string, agents []models.AgentConfig) string {
-	var sb strings.Builder
-	sb.WriteString("你是「财经会议室」的小韭菜，负责组织专家讨论。\n\n")
-	sb.WriteString("## 当前股票\n")
-	sb.WriteString(fmt.Sprintf("%s (%s)，现价 %.2f，涨跌幅 %.2f%%\n\n",
-		stock.Name, stock.Symbol, stock.Price, stock.ChangePercent))
-	sb.WriteString("## 老韭菜问题\n")
-	sb.WriteString(query + "\n\n")
-	sb.WriteString("## 可邀请的专家\n")
-	for _, a := range agents {
-		sb.WriteString(fmt.Sprintf("- %s（ID: %s）：%s\n", a.Name, a.ID, a.Role))
-	}
-	sb.WriteString("\n## 你的任务\n")
-	sb.WriteString("1. 分析老韭菜问题的核心意图\n")
-	sb.WriteString("2. 选择 1-3 位最相关的专家\n")
-	sb.WriteString("3. 生成讨论议题和开场白\n\n")
-	sb.WriteString("## 输出格式（仅输出JSON）\n")
-	sb.WriteString(`{"intent":"意图","selected":["id1"],"topic":"议题","opening":"开场白"}`)
-	return sb.String()
+	tmpl := m.promptTemplate
+	if strings.TrimSpace(tmpl) == "" {
+		tmpl = DefaultModeratorPrompt
+	}
+
+	var agentList strings.Builder
+	for i, a := range agents {
+		if i > 0 {
+			agentList.WriteString("\n")
+		}
+		agentList.WriteString(fmt.Sprintf("- %s（ID: %s）：%s", a.Name, a.ID, a.Role))
+	}
+
+	replacer := strings.NewReplacer(
+		PlaceholderStock, fmt.Sprintf("%s (%s)，现价 %.2f，涨跌幅 %.2f%%",
+			stock.Name, stock.Symbol, stock.Price, stock.ChangePercent),
+		PlaceholderQuery, query,
+		PlaceholderAgents, agentList.String(),
+		PlaceholderUser, m.displayUserName(),
+	)
+	return strings.TrimRight(replacer.Replace(tmpl), "\n") + moderatorOutputFormat
 }
 
 // buildSummarizePrompt 构建总结 Prompt
 func (m *Moderator) buildSummarizePrompt(stock *models.Stock, query string, history []DiscussionEntry) string {
+	userName := m.displayUserName()
+
 	var sb strings.Builder
-	sb.WriteString("你是会议小韭菜，请总结讨论并给老韭菜结论。\n\n")
+	sb.WriteString(fmt.Sprintf("你是会议小韭菜，请总结讨论并给%s结论。\n\n", userName))
 	sb.WriteString(fmt.Sprintf("## 股票：%s (%s)\n\n", stock.Name, stock.Symbol))
-	sb.WriteString("## 老韭菜问题\n")
+	if m.userPersona != "" {
+		sb.WriteString(fmt.Sprintf("## 用户画像\n%s，建议须与其风险偏好相符\n\n", m.userPersona))
+	}
+	sb.WriteString(fmt.Sprintf("## %s问题\n", userName))
 	sb.WriteString(query + "\n\n")
 	sb.WriteString("## 讨论记录\n")
+	weighted := m.summaryParams.Weighted && hasDistinctWeights(history)
 	for _, e := range history {
+		if weighted {
+			sb.WriteString(fmt.Sprintf("【%s（%s），权重 %.2f】\n%s\n\n", e.AgentName, e.Role, e.Weight, e.Content))
+			continue
+		}
 		sb.WriteString(fmt.Sprintf("【%s（%s）】\n%s\n\n", e.AgentName, e.Role, e.Content))
 	}
 	sb.WriteString("## 输出要求\n")
-	sb.WriteString("1. 核心结论（直接回答老韭菜）\n")
+	if weighted {
+		sb.WriteString("权重表示该专家观点与本问题的相关程度，结论应以高权重专家的分析为主，低权重观点作为补充。\n")
+	}
+	sb.WriteString(fmt.Sprintf("1. 核心结论（直接回答%s）\n", userName))
 	sb.WriteString("2. 各方观点摘要\n")
 	sb.WriteString("3. 综合建议\n\n")
 	sb.WriteString("控制在 300 字以内。")
 	return sb.String()
 }
 
+// expertWeights 计算选中专家的总结权重并归一化
+// 优先使用小韭菜给出的权重，未给出时按选择顺序递减（越靠前越相关）
+func expertWeights(selected []models.AgentConfig, decided map[string]float64) map[string]float64 {
+	weights := make(map[string]float64, len(selected))
+	var total float64
+	for i, a := range selected {
+		w := decided[a.ID]
+		if w <= 0 {
+			if len(decided) > 0 {
+				// 小韭菜只给了部分权重时，缺失的专家按最低权重计
+				w = minPositiveWeight(decided)
+			} else {
+				w = float64(len(selected) - i)
+			}
+		}
+		weights[a.ID] = w
+		total += w
+	}
+	if total > 0 {
+		for id := range weights {
+			weights[id] /= total
+		}
+	}
+	return weights
+}
+
+// minPositiveWeight 返回权重中的最小正值，没有时返回 1
+func minPositiveWeight(weights map[string]float64) float64 {
+	lowest := 0.0
+	for _, w := range weights {
+		if w > 0 && (lowest == 0 || w < lowest) {
+			lowest = w
+		}
+	}
+	if lowest == 0 {
+		return 1
+	}
+	return lowest
+}
+
+// hasDistinctWeights 判断讨论记录中的权重是否有差异，权重全部相同时无需强调
+func hasDistinctWeights(history []DiscussionEntry) bool {
+	for i := 1; i < len(history); i++ {
+		if history[i].Weight != history[0].Weight {
+			return true
+		}
+	}
+	return false
+}
+
 // parseDecision 解析小韭菜决策 JSON（增强健壮性）
 func (m *Moderator) parseDecision(content string) (*ModeratorDecision, error) {
 	content = strings.TrimSpace(content)
@@ -138,11 +281,7 @@ func (m *Moderator) parseDecision(content string) (*ModeratorDecision, error) {
 		return nil, fmt.Errorf("JSON 解析失败: %w, 原文: %s", err, truncateString(jsonStr, 200))
 	}
 
-	// 验证必要字段
-	if len(decision.Selected) == 0 {
-		return nil, fmt.Errorf("小韭菜未选择任何专家")
-	}
-
+	// 未选择任何专家时不视为错误，由会议服务按优先级兜底
 	return &decision, nil
 }
 
@@ -0,0 +1,32 @@
+package meeting
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestBuildAnalyzePromptUser 测试意图分析 Prompt 按设置替换对用户的称呼，未设置时使用默认称呼
+func TestBuildAnalyzePromptUser(t *testing.T) {
+	stock := &models.Stock{Symbol: "sh600519", Name: "贵州茅台"}
+	agents := []models.AgentConfig{{ID: "a", Name: "老陈", Role: "基本面"}}
+
+	m := NewModerator(nil)
+	prompt := m.buildAnalyzePrompt(stock, "还能买吗", agents)
+	if strings.Contains(prompt, PlaceholderUser) || !strings.Contains(prompt, "## "+models.DefaultUserName+"问题") {
+		t.Errorf("未设置称呼时应使用默认称呼: %s", prompt)
+	}
+
+	m.SetUserProfile("老王", "")
+	prompt = m.buildAnalyzePrompt(stock, "还能买吗", agents)
+	if strings.Contains(prompt, models.DefaultUserName) || !strings.Contains(prompt, "分析老王问题的核心意图") {
+		t.Errorf("应使用设置的称呼: %s", prompt)
+	}
+
+	custom := NewModeratorWithPrompt(nil, "{{user}}问：{{query}}")
+	custom.SetUserProfile("老王", "")
+	if prompt := custom.buildAnalyzePrompt(stock, "还能买吗", agents); !strings.HasPrefix(prompt, "老王问：还能买吗") {
+		t.Errorf("自定义模板应替换称呼占位符: %s", prompt)
+	}
+}
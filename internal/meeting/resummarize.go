@@ -0,0 +1,78 @@
+package meeting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// SummaryHistoryFromMessages 从会话消息中按 ID 挑选专家发言重建讨论历史
+// 只保留专家观点（opinion），开场白、旧总结和错误提示即使被选中也忽略；
+// query 取第一条选中发言之前最近的用户提问
+func SummaryHistoryFromMessages(messages []models.ChatMessage, ids []string) (string, []DiscussionEntry) {
+	include := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		include[id] = true
+	}
+
+	var query string
+	var history []DiscussionEntry
+	for _, msg := range messages {
+		if msg.AgentID == "user" && len(history) == 0 {
+			query = msg.Content
+			continue
+		}
+		if !include[msg.ID] || msg.MsgType != "opinion" {
+			continue
+		}
+		history = append(history, DiscussionEntry{
+			Round:     msg.Round,
+			AgentID:   msg.AgentID,
+			AgentName: msg.AgentName,
+			Role:      msg.Role,
+			Content:   msg.Content,
+		})
+	}
+	return query, history
+}
+
+// Resummarize 仅基于给定的专家发言重新生成小韭菜总结，不重新运行专家
+func (s *Service) Resummarize(ctx context.Context, aiConfig *models.AIConfig, stock *models.Stock, query string, history []DiscussionEntry) (string, error) {
+	if aiConfig == nil {
+		return "", ErrNoAIConfig
+	}
+	if len(history) == 0 {
+		return "", ErrNoOpinions
+	}
+	if err := s.breaker.allow(aiConfig.ID, time.Now()); err != nil {
+		return "", err
+	}
+
+	modelCtx, modelCancel := context.WithTimeout(ctx, ModelCreationTimeout)
+	llm, err := s.modelFactory.CreateModel(modelCtx, aiConfig)
+	modelCancel()
+	llm, err = s.withBreaker(aiConfig.ID, llm, err)
+	if err != nil {
+		return "", fmt.Errorf("create model error: %w", err)
+	}
+
+	settings := s.getSettings()
+	moderator := NewModeratorWithPrompt(llm, settings.moderatorPrompt)
+	moderator.SetSummaryParams(settings.summaryParams)
+	moderator.SetUserProfile(settings.userName, settings.userPersona)
+
+	summaryCtx, summaryCancel := context.WithTimeout(ctx, ModeratorTimeout)
+	defer summaryCancel()
+	summary, err := moderator.Summarize(summaryCtx, stock, query, history)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "", fmt.Errorf("%w: 小韭菜总结超时", ErrModeratorTimeout)
+	}
+	if err != nil {
+		return "", fmt.Errorf("summarize error: %w", err)
+	}
+	log.Info("resummarized %s with %d opinions", stock.Symbol, len(history))
+	return summary, nil
+}
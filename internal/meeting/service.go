@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -33,21 +36,51 @@ const (
 	ModelCreationTimeout = 10 * time.Second // 模型创建的最大时长
 )
 
+// ModelCreationConcurrency 会议开始前并发预建专家模型的数量上限，避免同时发起大量鉴权握手
+const ModelCreationConcurrency = 3
+
 // 错误定义
 var (
 	ErrMeetingTimeout   = errors.New("会议超时，已返回部分结果")
 	ErrModeratorTimeout = errors.New("小韭菜响应超时")
 	ErrNoAIConfig       = errors.New("未配置 AI 服务")
 	ErrNoAgents         = errors.New("没有可用的专家")
+	ErrAIUnavailable    = errors.New("AI服务暂时不可用")
+	ErrNoOpinions       = errors.New("未选择可用于总结的专家发言")
 )
 
 // Service 会议室服务，编排多专家并行分析
 type Service struct {
-	modelFactory   *adk.ModelFactory
-	toolRegistry   *tools.Registry
-	mcpManager     *mcp.Manager
-	memoryManager  *memory.Manager
-	memoryAIConfig *models.AIConfig // 记忆管理使用的 LLM 配置
+	modelFactory *adk.ModelFactory
+	toolRegistry *tools.Registry
+	mcpManager   *mcp.Manager
+
+	aiConfigsMu sync.RWMutex
+	aiConfigs   map[string]models.AIConfig // 全部 AI 配置，供专家按 ProviderID 选择模型
+
+	settingsMu sync.RWMutex
+	settings   serviceSettings // 运行时可修改的会议设置，进行中的会议通过 getSettings 读取副本
+
+	breaker     *circuitBreaker // 按 AI 配置 ID 的熔断器
+	decisionLog *DecisionLog    // 智能会议路由决策日志，nil 表示不记录
+}
+
+// serviceSettings 会议设置，由 Set* 方法在配置变更时修改
+type serviceSettings struct {
+	memoryManager    *memory.Manager      // 记忆管理器，nil 表示未启用记忆
+	memoryAIConfig   *models.AIConfig     // 记忆管理使用的 LLM 配置
+	moderatorPrompt  string               // 小韭菜意图分析 Prompt 模板（为空使用内置模板）
+	agentDelay       time.Duration        // 专家发言间隔，用于规避 API 限流（0 表示不等待）
+	includeToolTrace bool                 // 是否在发言中附带工具调用记录
+	summaryParams    models.SummaryParams // 小韭菜总结生成参数
+	globalTools      []string             // 所有专家始终可用的工具
+	maxContextMsgs   int                  // 注入专家上下文的最近会话消息条数上限
+	fallbackAgents   int                  // 小韭菜未选中专家时兜底邀请的专家数量
+	preflight        bool                 // 智能模式专家发言前统一预取公共数据
+	depth            models.AnalysisDepth // 默认分析深度预设
+	userName         string               // 对用户的称呼
+	userPersona      string               // 用户投资画像
+	memoryCheckpoint bool                 // 每位专家发言后更新记忆草稿，会议中断时保留已发言观点
 }
 
 // NewServiceFull 创建完整配置的会议室服务
@@ -56,17 +89,125 @@ func NewServiceFull(registry *tools.Registry, mcpMgr *mcp.Manager) *Service {
 		modelFactory: adk.NewModelFactory(),
 		toolRegistry: registry,
 		mcpManager:   mcpMgr,
+		breaker:      newCircuitBreaker(),
 	}
 }
 
+// getSettings 获取当前会议设置的副本，可与 Set* 方法并发调用
+func (s *Service) getSettings() serviceSettings {
+	s.settingsMu.RLock()
+	defer s.settingsMu.RUnlock()
+	return s.settings
+}
+
+// updateSettings 在写锁内修改会议设置
+func (s *Service) updateSettings(update func(*serviceSettings)) {
+	s.settingsMu.Lock()
+	defer s.settingsMu.Unlock()
+	update(&s.settings)
+}
+
 // SetMemoryManager 设置记忆管理器
 func (s *Service) SetMemoryManager(memMgr *memory.Manager) {
-	s.memoryManager = memMgr
+	s.updateSettings(func(c *serviceSettings) { c.memoryManager = memMgr })
 }
 
-// SetMemoryAIConfig 设置记忆管理使用的 LLM 配置
+// SetMemoryAIConfig 设置记忆管理使用的 LLM 配置（保存副本，调用方后续修改不影响进行中的会议）
 func (s *Service) SetMemoryAIConfig(aiConfig *models.AIConfig) {
-	s.memoryAIConfig = aiConfig
+	if aiConfig != nil {
+		c := *aiConfig
+		aiConfig = &c
+	}
+	s.updateSettings(func(c *serviceSettings) { c.memoryAIConfig = aiConfig })
+}
+
+// SetAIConfigs 设置可供专家单独选用的 AI 配置列表
+func (s *Service) SetAIConfigs(configs []models.AIConfig) {
+	m := make(map[string]models.AIConfig, len(configs))
+	for _, c := range configs {
+		m[c.ID] = c
+	}
+	s.aiConfigsMu.Lock()
+	s.aiConfigs = m
+	s.aiConfigsMu.Unlock()
+}
+
+// getAIConfig 按 ID 查找 AI 配置
+func (s *Service) getAIConfig(id string) (models.AIConfig, bool) {
+	s.aiConfigsMu.RLock()
+	defer s.aiConfigsMu.RUnlock()
+	c, ok := s.aiConfigs[id]
+	return c, ok
+}
+
+// SetModeratorPrompt 设置小韭菜意图分析 Prompt 模板
+func (s *Service) SetModeratorPrompt(promptTemplate string) {
+	s.updateSettings(func(c *serviceSettings) { c.moderatorPrompt = promptTemplate })
+}
+
+// SetAgentDelay 设置专家发言间隔
+// 智能模式下串行专家之间固定等待该时长；并行模式下各专家在 [0, delay) 内随机错开启动
+func (s *Service) SetAgentDelay(delay time.Duration) {
+	if delay < 0 {
+		delay = 0
+	}
+	s.updateSettings(func(c *serviceSettings) { c.agentDelay = delay })
+}
+
+// SetIncludeToolTrace 设置是否在专家发言中附带工具调用及返回结果
+func (s *Service) SetIncludeToolTrace(enabled bool) {
+	s.updateSettings(func(c *serviceSettings) { c.includeToolTrace = enabled })
+}
+
+// SetSummaryParams 设置小韭菜总结的生成参数
+func (s *Service) SetSummaryParams(params models.SummaryParams) {
+	s.updateSettings(func(c *serviceSettings) { c.summaryParams = params })
+}
+
+// SetGlobalTools 设置所有专家始终可用的工具
+func (s *Service) SetGlobalTools(names []string) {
+	names = slices.Clone(names)
+	s.updateSettings(func(c *serviceSettings) { c.globalTools = names })
+}
+
+// SetMaxContextMessages 设置注入专家上下文的最近会话消息条数上限，0 表示不注入
+func (s *Service) SetMaxContextMessages(max int) {
+	if max < 0 {
+		max = 0
+	}
+	s.updateSettings(func(c *serviceSettings) { c.maxContextMsgs = max })
+}
+
+// SetFallbackAgentCount 设置小韭菜未选中专家时兜底邀请的专家数量，0 表示不兜底
+func (s *Service) SetFallbackAgentCount(n int) {
+	if n < 0 {
+		n = 0
+	}
+	s.updateSettings(func(c *serviceSettings) { c.fallbackAgents = n })
+}
+
+// SetPreflightGather 设置智能模式是否在专家发言前统一预取行情、技术分析和快讯
+func (s *Service) SetPreflightGather(enabled bool) {
+	s.updateSettings(func(c *serviceSettings) { c.preflight = enabled })
+}
+
+// SetAnalysisDepth 设置智能模式默认分析深度预设，请求未指定深度时使用
+func (s *Service) SetAnalysisDepth(depth models.AnalysisDepth) {
+	s.updateSettings(func(c *serviceSettings) { c.depth = depth })
+}
+
+// SetMemoryCheckpoint 设置是否在每位专家发言后更新记忆草稿
+func (s *Service) SetMemoryCheckpoint(enabled bool) {
+	s.updateSettings(func(c *serviceSettings) { c.memoryCheckpoint = enabled })
+}
+
+// SetUserProfile 设置对用户的称呼和投资画像，注入专家发言及小韭菜总结
+func (s *Service) SetUserProfile(name, persona string) {
+	name, persona = strings.TrimSpace(name), strings.TrimSpace(persona)
+	s.updateSettings(func(c *serviceSettings) {
+		c.userName = name
+		c.userPersona = persona
+	})
 }
 
 // ChatRequest 聊天请求
@@ -78,6 +219,28 @@ type ChatRequest struct {
 	ReplyContent string                `json:"replyContent"`
 	AllAgents    []models.AgentConfig  `json:"allAgents"` // 所有可用专家（智能模式用）
 	Position     *models.StockPosition `json:"position"`  // 用户持仓信息
+	Seed         int                   `json:"seed"`      // 随机种子，非 0 时覆盖 AI 配置中的种子，用于复现输出
+	// 此前的会话消息（按时间升序），按 maxContextMessages 截取最近部分注入专家上下文
+	SessionHistory []models.ChatMessage `json:"sessionHistory"`
+	// 技术分析原文回调，非空时记录喂给专家的 analysis 数据，用于事后核对
+	OnAnalysis AnalysisCallback `json:"-"`
+	// 小韭菜决策回调，智能模式选定专家后调用，用于结构化输出
+	OnDecision DecisionCallback `json:"-"`
+	// 分析深度预设，为空时使用服务默认深度；智能模式开始时解析为下列具体参数
+	Depth models.AnalysisDepth `json:"depth"`
+	// 发言专家数量上限，0 表示不限
+	MaxExperts int `json:"maxExperts"`
+	// 跳过小韭菜总结
+	SkipSummary bool `json:"skipSummary"`
+	// 邀请全部专家发言，小韭菜选中的专家优先
+	InviteAll bool `json:"inviteAll"`
+	// 专家发言前强制会前数据预取（不受 preflightGather 配置影响）
+	Preflight bool `json:"preflight"`
+	// 本次会议的工具白名单/黑名单，仅作用于本次会议，不修改专家配置
+	// 在专家自身 Tools（含全局工具和 MCP 工具）基础上收窄：AllowedTools 非空时只保留其中的工具，
+	// 不会为专家新增未配置的工具；DeniedTools 优先级最高，同时出现在两个列表时以禁用为准
+	AllowedTools []string `json:"allowedTools"`
+	DeniedTools  []string `json:"deniedTools"`
 }
 
 // ChatResponse 聊天响应
@@ -87,7 +250,11 @@ type ChatResponse struct {
 	Role      string `json:"role"`
 	Content   string `json:"content"`
 	Round     int    `json:"round"`
-	MsgType   string `json:"msgType"` // opening/opinion/summary
+	MsgType   string `json:"msgType"` // opening/opinion/summary/error
+	// 工具调用记录，仅在开启 includeToolTrace 时填充
+	ToolTrace []models.ToolTrace `json:"toolTrace,omitempty"`
+	// 本次发言的 token 用量，模型未上报时为空
+	Usage *models.TokenUsage `json:"usage,omitempty"`
 }
 
 // ResponseCallback 响应回调函数类型
@@ -108,14 +275,19 @@ type ProgressCallback func(event ProgressEvent)
 
 // SendMessage 发送会议消息，生成多专家回复（并行执行）
 func (s *Service) SendMessage(ctx context.Context, aiConfig *models.AIConfig, req ChatRequest) ([]ChatResponse, error) {
+	aiConfig = withRequestSeed(aiConfig, req.Seed)
+	if err := s.breaker.allow(aiConfig.ID, time.Now()); err != nil {
+		return nil, err
+	}
 	llm, err := s.modelFactory.CreateModel(ctx, aiConfig)
+	llm, err = s.withBreaker(aiConfig.ID, llm, err)
 	if err != nil {
 		log.Error("CreateModel error: %v", err)
 		return nil, err
 	}
 	log.Info("model created successfully")
 
-	return s.runAgentsParallel(ctx, llm, req)
+	return s.runAgentsParallel(ctx, s.newBuilderPool(aiConfig, llm, &req), req)
 }
 
 // RunSmartMeeting 智能会议模式（小韭菜编排）
@@ -134,6 +306,13 @@ func (s *Service) RunSmartMeetingWithCallback(ctx context.Context, aiConfig *mod
 	if len(req.AllAgents) == 0 {
 		return nil, ErrNoAgents
 	}
+	settings := s.getSettings()
+	aiConfig = withRequestSeed(aiConfig, req.Seed)
+	applyAnalysisDepth(&req, settings.depth)
+	if err := s.breaker.allow(aiConfig.ID, time.Now()); err != nil {
+		return nil, err
+	}
+	start := time.Now()
 
 	// 设置整个会议的超时上下文
 	meetingCtx, meetingCancel := context.WithTimeout(ctx, MeetingTimeout)
@@ -143,41 +322,49 @@ func (s *Service) RunSmartMeetingWithCallback(ctx context.Context, aiConfig *mod
 	modelCtx, modelCancel := context.WithTimeout(meetingCtx, ModelCreationTimeout)
 	llm, err := s.modelFactory.CreateModel(modelCtx, aiConfig)
 	modelCancel()
+	llm, err = s.withBreaker(aiConfig.ID, llm, err)
 	if err != nil {
 		return nil, fmt.Errorf("create model error: %w", err)
 	}
 
 	var responses []ChatResponse
-	moderator := NewModerator(llm)
+	moderator := NewModeratorWithPrompt(llm, settings.moderatorPrompt)
+	moderator.SetSummaryParams(settings.summaryParams)
+	moderator.SetUserProfile(settings.userName, settings.userPersona)
 
 	// 设置 LLM 到记忆管理器（启用摘要功能）
-	if s.memoryManager != nil {
+	if settings.memoryManager != nil {
 		// 优先使用配置的记忆 LLM，否则使用会议 LLM
-		if s.memoryAIConfig != nil {
-			memoryLLM, err := s.modelFactory.CreateModel(meetingCtx, s.memoryAIConfig)
+		if settings.memoryAIConfig != nil {
+			memoryLLM, err := s.modelFactory.CreateModel(meetingCtx, settings.memoryAIConfig)
 			if err == nil {
-				s.memoryManager.SetLLM(memoryLLM)
-				log.Debug("using dedicated memory LLM: %s", s.memoryAIConfig.ModelName)
+				settings.memoryManager.SetLLM(memoryLLM)
+				log.Debug("using dedicated memory LLM: %s", settings.memoryAIConfig.ModelName)
 			} else {
 				log.Warn("create memory LLM error, fallback to meeting LLM: %v", err)
-				s.memoryManager.SetLLM(llm)
+				settings.memoryManager.SetLLM(llm)
 			}
 		} else {
-			s.memoryManager.SetLLM(llm)
+			settings.memoryManager.SetLLM(llm)
 		}
 	}
 
 	// 加载股票记忆（如果启用了记忆管理）
 	var stockMemory *memory.StockMemory
 	var memoryContext string
-	if s.memoryManager != nil {
-		stockMemory, _ = s.memoryManager.GetOrCreate(req.Stock.Symbol, req.Stock.Name)
-		memoryContext = s.memoryManager.BuildContext(stockMemory, req.Query)
+	if settings.memoryManager != nil {
+		stockMemory, _ = settings.memoryManager.GetOrCreate(req.Stock.Symbol, req.Stock.Name)
+		memoryContext = settings.memoryManager.BuildContext(stockMemory, req.Query)
 		if memoryContext != "" {
 			log.Debug("loaded memory context for %s, len: %d", req.Stock.Symbol, len(memoryContext))
 		}
 	}
 
+	// 合并此前的会话记录，保证追问时的连续性
+	if sessionContext := buildSessionContext(req.SessionHistory, settings.maxContextMsgs); sessionContext != "" {
+		memoryContext = strings.TrimSpace(memoryContext + "\n" + sessionContext)
+	}
+
 	log.Info("stock: %s, query: %s, agents: %d", req.Stock.Symbol, req.Query, len(req.AllAgents))
 
 	// 第0轮：小韭菜分析意图并选择专家（带超时）
@@ -217,6 +404,12 @@ func (s *Service) RunSmartMeetingWithCallback(ctx context.Context, aiConfig *mod
 	}
 
 	log.Debug("decision: selected=%v, topic=%s", decision.Selected, decision.Topic)
+	if req.OnDecision != nil {
+		req.OnDecision(*decision)
+	}
+
+	// 会议结束时（含超时提前返回）记录专家选择与结果摘要
+	defer func() { s.logDecision(&req, decision, responses, start) }()
 
 	// 添加开场白并立即回调
 	openingResp := ChatResponse{
@@ -234,13 +427,52 @@ func (s *Service) RunSmartMeetingWithCallback(ctx context.Context, aiConfig *mod
 
 	// 筛选被选中的专家（按小韭菜选择的顺序）
 	selectedAgents := s.filterAgentsOrdered(req.AllAgents, decision.Selected)
+	if req.InviteAll {
+		selectedAgents = appendRemainingAgents(selectedAgents, req.AllAgents)
+	}
 	if len(selectedAgents) == 0 {
-		return responses, nil
+		// 小韭菜过于保守时按优先级兜底，避免只有开场白的空会议
+		selectedAgents = fallbackAgentsByPriority(req.AllAgents, settings.fallbackAgents)
+		if len(selectedAgents) == 0 {
+			return responses, nil
+		}
+		log.Info("moderator selected no agents, fallback to %d by priority", len(selectedAgents))
+	}
+	if req.MaxExperts > 0 && len(selectedAgents) > req.MaxExperts {
+		selectedAgents = selectedAgents[:req.MaxExperts]
+	}
+	weights := expertWeights(selectedAgents, decision.Weights)
+
+	// 会前统一预取公共数据，注入每位专家的上下文，减少重复的工具调用
+	if (settings.preflight || req.Preflight) && s.toolRegistry != nil {
+		if progressCallback != nil {
+			progressCallback(ProgressEvent{
+				Type:      "agent_start",
+				AgentID:   "moderator",
+				AgentName: "小韭菜",
+				Detail:    "会前数据准备",
+			})
+		}
+		preflight, analysis := s.toolRegistry.BuildPreflightContext(req.Stock.Symbol)
+		if preflight != "" {
+			memoryContext = strings.TrimSpace(memoryContext + "\n" + preflight)
+			log.Debug("preflight context loaded for %s, len: %d", req.Stock.Symbol, len(preflight))
+		}
+		if analysis != "" && req.OnAnalysis != nil {
+			req.OnAnalysis(PreflightAnalysisSource, analysis)
+		}
+		if progressCallback != nil {
+			progressCallback(ProgressEvent{
+				Type:      "agent_done",
+				AgentID:   "moderator",
+				AgentName: "小韭菜",
+			})
+		}
 	}
 
 	// 第1轮：专家串行发言，后一个参考前面的内容
 	var history []DiscussionEntry
-	builder := s.createBuilder(llm)
+	pool := s.newBuilderPool(aiConfig, llm, &req)
 
 	for i, agentCfg := range selectedAgents {
 		// 检查会议是否已超时
@@ -251,6 +483,14 @@ func (s *Service) RunSmartMeetingWithCallback(ctx context.Context, aiConfig *mod
 		default:
 		}
 
+		// 专家之间间隔等待，避免连续请求触发限流
+		if i > 0 && settings.agentDelay > 0 {
+			if err := sleepContext(meetingCtx, settings.agentDelay); err != nil {
+				log.Warn("meeting timeout, got %d responses", len(responses))
+				return responses, ErrMeetingTimeout
+			}
+		}
+
 		log.Debug("agent %d/%d: %s starting", i+1, len(selectedAgents), agentCfg.Name)
 
 		// 发送专家开始事件
@@ -272,7 +512,8 @@ func (s *Service) RunSmartMeetingWithCallback(ctx context.Context, aiConfig *mod
 
 		// 运行单个专家（带超时控制）
 		agentCtx, agentCancel := context.WithTimeout(meetingCtx, AgentTimeout)
-		content, err := s.runSingleAgentWithHistory(agentCtx, builder, &agentCfg, &req.Stock, req.Query, previousContext, progressCallback, req.Position)
+		builder := pool.get(agentCtx, &agentCfg)
+		content, toolTrace, usage, err := s.runSingleAgentWithHistory(agentCtx, builder, &agentCfg, &req.Stock, req.Query, previousContext, progressCallback, req.Position, req.OnAnalysis)
 		agentCancel()
 
 		if err != nil {
@@ -309,6 +550,8 @@ func (s *Service) RunSmartMeetingWithCallback(ctx context.Context, aiConfig *mod
 			Content:   content,
 			Round:     1,
 			MsgType:   "opinion",
+			ToolTrace: toolTrace,
+			Usage:     usage,
 		}
 		responses = append(responses, resp)
 		if respCallback != nil {
@@ -322,11 +565,18 @@ func (s *Service) RunSmartMeetingWithCallback(ctx context.Context, aiConfig *mod
 			AgentName: agentCfg.Name,
 			Role:      agentCfg.Role,
 			Content:   content,
+			Weight:    weights[agentCfg.ID],
 		})
+		s.checkpointMemory(stockMemory, req.Query, history)
 
 		log.Debug("agent %s done, content len: %d", agentCfg.ID, len(content))
 	}
 
+	// 快速模式不生成总结，专家发言即结论
+	if req.SkipSummary {
+		return responses, nil
+	}
+
 	// 最终轮：小韭菜总结（带超时）
 	if progressCallback != nil {
 		progressCallback(ProgressEvent{
@@ -352,9 +602,9 @@ func (s *Service) RunSmartMeetingWithCallback(ctx context.Context, aiConfig *mod
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
 			log.Warn("summary timeout, returning partial results")
-		} else {
-			log.Error("summary error: %v", err)
+			return responses, ErrMeetingTimeout
 		}
+		log.Error("summary error: %v", err)
 		// 总结失败不影响返回已有结果
 		return responses, nil
 	}
@@ -375,17 +625,28 @@ func (s *Service) RunSmartMeetingWithCallback(ctx context.Context, aiConfig *mod
 	}
 
 	// 保存记忆（如果启用了记忆管理）
-	if s.memoryManager != nil && stockMemory != nil && summary != "" {
+	if settings.memoryManager != nil && stockMemory != nil && summary != "" {
+		// 总结已生成，先丢弃草稿：正式轮次异步写入前的追问不应把本次会议记为未完成
+		settings.memoryManager.ClearDraft(stockMemory)
 		// 异步保存记忆，不阻塞返回
 		go func() {
 			// 使用独立 context，因为会议 ctx 可能已取消
 			bgCtx := context.Background()
 			keyPoints := s.extractKeyPointsFromHistory(bgCtx, history)
-			if err := s.memoryManager.AddRound(bgCtx, stockMemory, req.Query, summary, keyPoints); err != nil {
+			if err := settings.memoryManager.AddRound(bgCtx, stockMemory, req.Query, summary, keyPoints); err != nil {
 				log.Error("save memory error: %v", err)
 			} else {
 				log.Debug("saved memory for %s", req.Stock.Symbol)
 			}
+
+			// 记录本次会议专家观点的投票结果
+			if len(history) > 0 {
+				contents := make([]string, 0, len(history))
+				for _, h := range history {
+					contents = append(contents, h.Content)
+				}
+				settings.memoryManager.RecordConsensus(stockMemory, memory.TallyStances(contents))
+			}
 		}()
 	}
 
@@ -393,36 +654,78 @@ func (s *Service) RunSmartMeetingWithCallback(ctx context.Context, aiConfig *mod
 }
 
 // runAgentsParallel 并行运行多个 Agent（带超时控制）
-func (s *Service) runAgentsParallel(ctx context.Context, llm model.LLM, req ChatRequest) ([]ChatResponse, error) {
+func (s *Service) runAgentsParallel(ctx context.Context, pool *builderPool, req ChatRequest) ([]ChatResponse, error) {
 	var (
 		wg        sync.WaitGroup
 		mu        sync.Mutex
 		responses []ChatResponse
+		timedOut  bool
 	)
+	settings := s.getSettings()
 
 	// 设置整体超时
 	parallelCtx, cancel := context.WithTimeout(ctx, MeetingTimeout)
 	defer cancel()
 
-	builder := s.createBuilder(llm)
 	log.Debug("running %d agents in parallel", len(req.Agents))
 
+	// 扇出前预建专家所需模型，避免各专家在构建器缓存锁上串行创建
+	pool.prewarm(parallelCtx, req.Agents)
+
+	// 引用内容之前附带此前的会话记录
+	replyContent := req.ReplyContent
+	if sessionContext := buildSessionContext(req.SessionHistory, settings.maxContextMsgs); sessionContext != "" {
+		replyContent = strings.TrimSpace(sessionContext + "\n" + replyContent)
+	}
+
 	for _, agentConfig := range req.Agents {
 		wg.Add(1)
 		go func(cfg models.AgentConfig) {
 			defer wg.Done()
 
+			// fail 以错误占位消息返回，避免专家被静默丢弃；用户主动取消时不再生成占位消息
+			fail := func(err error) {
+				if errors.Is(err, context.Canceled) {
+					log.Debug("agent %s cancelled", cfg.ID)
+					return
+				}
+				var errContent string
+				deadline := errors.Is(err, context.DeadlineExceeded)
+				if deadline {
+					log.Warn("agent %s timeout", cfg.ID)
+					errContent = fmt.Sprintf("%s 分析超时", cfg.Name)
+				} else {
+					log.Error("agent %s error: %v", cfg.ID, err)
+					errContent = fmt.Sprintf("%s 分析失败: %v", cfg.Name, err)
+				}
+				mu.Lock()
+				timedOut = timedOut || deadline
+				responses = append(responses, ChatResponse{
+					AgentID:   cfg.ID,
+					AgentName: cfg.Name,
+					Role:      cfg.Role,
+					Content:   errContent,
+					MsgType:   "error",
+				})
+				mu.Unlock()
+			}
+
+			// 随机错开启动，避免同时打满 API；等待期间整体超时同样输出超时占位
+			if settings.agentDelay > 0 {
+				if err := sleepContext(parallelCtx, time.Duration(rand.Int63n(int64(settings.agentDelay)))); err != nil {
+					fail(err)
+					return
+				}
+			}
+
 			// 单个 Agent 超时控制
 			agentCtx, agentCancel := context.WithTimeout(parallelCtx, AgentTimeout)
 			defer agentCancel()
 
-			content, err := s.runSingleAgentWithContext(agentCtx, builder, &cfg, &req.Stock, req.Query, req.ReplyContent, req.Position)
+			builder := pool.get(agentCtx, &cfg)
+			content, toolTrace, usage, err := s.runSingleAgentWithContext(agentCtx, builder, &cfg, &req.Stock, req.Query, replyContent, req.Position, req.OnAnalysis)
 			if err != nil {
-				if errors.Is(err, context.DeadlineExceeded) {
-					log.Warn("agent %s timeout", cfg.ID)
-				} else {
-					log.Error("agent %s error: %v", cfg.ID, err)
-				}
+				fail(err)
 				return
 			}
 
@@ -432,6 +735,8 @@ func (s *Service) runAgentsParallel(ctx context.Context, llm model.LLM, req Chat
 				AgentName: cfg.Name,
 				Role:      cfg.Role,
 				Content:   content,
+				ToolTrace: toolTrace,
+				Usage:     usage,
 			})
 			mu.Unlock()
 			log.Debug("agent %s done, content len: %d", cfg.ID, len(content))
@@ -440,14 +745,18 @@ func (s *Service) runAgentsParallel(ctx context.Context, llm model.LLM, req Chat
 
 	wg.Wait()
 	log.Info("all agents done, got %d responses", len(responses))
+	if timedOut {
+		// 部分专家超时，已返回的结果仍然有效
+		return responses, ErrMeetingTimeout
+	}
 	return responses, nil
 }
 
 // runSingleAgentWithContext 运行单个 Agent（支持引用上下文）
-func (s *Service) runSingleAgentWithContext(ctx context.Context, builder *adk.ExpertAgentBuilder, cfg *models.AgentConfig, stock *models.Stock, query string, replyContent string, position *models.StockPosition) (string, error) {
+func (s *Service) runSingleAgentWithContext(ctx context.Context, builder *adk.ExpertAgentBuilder, cfg *models.AgentConfig, stock *models.Stock, query string, replyContent string, position *models.StockPosition, onAnalysis AnalysisCallback) (string, []models.ToolTrace, *models.TokenUsage, error) {
 	agentInstance, err := builder.BuildAgentWithContext(cfg, stock, query, replyContent, position)
 	if err != nil {
-		return "", err
+		return "", nil, nil, err
 	}
 
 	sessionService := session.InMemoryService()
@@ -457,7 +766,7 @@ func (s *Service) runSingleAgentWithContext(ctx context.Context, builder *adk.Ex
 		SessionService: sessionService,
 	})
 	if err != nil {
-		return "", err
+		return "", nil, nil, err
 	}
 
 	sessionID := fmt.Sprintf("session-%s-%d", cfg.ID, time.Now().UnixNano())
@@ -467,7 +776,7 @@ func (s *Service) runSingleAgentWithContext(ctx context.Context, builder *adk.Ex
 		SessionID: sessionID,
 	})
 	if err != nil {
-		return "", fmt.Errorf("create session error: %w", err)
+		return "", nil, nil, fmt.Errorf("create session error: %w", err)
 	}
 
 	userMsg := &genai.Content{
@@ -478,16 +787,26 @@ func (s *Service) runSingleAgentWithContext(ctx context.Context, builder *adk.Ex
 	}
 
 	var content string
+	trace := newToolTraceRecorder(s.getSettings().includeToolTrace)
+	var usage usageRecorder
 	runCfg := agent.RunConfig{}
 	for event, err := range r.Run(ctx, "user", sessionID, userMsg, runCfg) {
 		if err != nil {
-			return "", err
+			return "", nil, nil, err
+		}
+		if event == nil {
+			continue
 		}
-		if event != nil && event.LLMResponse.Content != nil {
+		usage.observe(&event.LLMResponse)
+		if event.LLMResponse.Content != nil {
 			for _, part := range event.LLMResponse.Content.Parts {
 				if part.Thought {
 					continue
 				}
+				trace.observe(part)
+				if analysis, ok := extractAnalysis(part); ok && onAnalysis != nil {
+					onAnalysis(cfg.Name, analysis)
+				}
 				if part.Text != "" {
 					content += part.Text
 				}
@@ -495,7 +814,7 @@ func (s *Service) runSingleAgentWithContext(ctx context.Context, builder *adk.Ex
 		}
 	}
 
-	return content, nil
+	return content, trace.result(), usage.result(), nil
 }
 
 // filterAgentsOrdered 按指定顺序筛选专家（保持小韭菜选择的顺序）
@@ -513,6 +832,26 @@ func (s *Service) filterAgentsOrdered(all []models.AgentConfig, ids []string) []
 	return result
 }
 
+// fallbackAgentsByPriority 按优先级取前 n 位已启用的专家
+func fallbackAgentsByPriority(all []models.AgentConfig, n int) []models.AgentConfig {
+	if n <= 0 {
+		return nil
+	}
+	var enabled []models.AgentConfig
+	for _, a := range all {
+		if a.Enabled {
+			enabled = append(enabled, a)
+		}
+	}
+	sort.SliceStable(enabled, func(i, j int) bool {
+		return enabled[i].Priority < enabled[j].Priority
+	})
+	if len(enabled) > n {
+		enabled = enabled[:n]
+	}
+	return enabled
+}
+
 // buildPreviousContext 构建前面专家发言的上下文
 func (s *Service) buildPreviousContext(history []DiscussionEntry) string {
 	if len(history) == 0 {
@@ -526,10 +865,27 @@ func (s *Service) buildPreviousContext(history []DiscussionEntry) string {
 	return sb.String()
 }
 
+// checkpointMemory 开启记忆草稿时，用已完成的专家发言更新本次会议的记忆草稿
+func (s *Service) checkpointMemory(stockMemory *memory.StockMemory, query string, history []DiscussionEntry) {
+	settings := s.getSettings()
+	if !settings.memoryCheckpoint || settings.memoryManager == nil || stockMemory == nil {
+		return
+	}
+	discussions := make([]memory.DiscussionInput, 0, len(history))
+	for _, entry := range history {
+		discussions = append(discussions, memory.DiscussionInput{
+			AgentName: entry.AgentName,
+			Role:      entry.Role,
+			Content:   entry.Content,
+		})
+	}
+	settings.memoryManager.CheckpointRound(stockMemory, query, discussions)
+}
+
 // extractKeyPointsFromHistory 从讨论历史中提取关键点
 func (s *Service) extractKeyPointsFromHistory(ctx context.Context, history []DiscussionEntry) []string {
 	// 如果有记忆管理器，使用 LLM 智能提取
-	if s.memoryManager != nil {
+	if memoryManager := s.getSettings().memoryManager; memoryManager != nil {
 		discussions := make([]memory.DiscussionInput, 0, len(history))
 		for _, entry := range history {
 			discussions = append(discussions, memory.DiscussionInput{
@@ -538,7 +894,7 @@ func (s *Service) extractKeyPointsFromHistory(ctx context.Context, history []Dis
 				Content:   entry.Content,
 			})
 		}
-		keyPoints, err := s.memoryManager.ExtractKeyPoints(ctx, discussions)
+		keyPoints, err := memoryManager.ExtractKeyPoints(ctx, discussions)
 		if err != nil {
 			log.Warn("LLM extract key points error, fallback: %v", err)
 		} else {
@@ -569,11 +925,12 @@ func (s *Service) runSingleAgentWithHistory(
 	previousContext string,
 	progressCallback ProgressCallback,
 	position *models.StockPosition,
-) (string, error) {
+	onAnalysis AnalysisCallback,
+) (string, []models.ToolTrace, *models.TokenUsage, error) {
 	// 使用带上下文的方法构建 Agent
 	agentInstance, err := builder.BuildAgentWithContext(cfg, stock, query, previousContext, position)
 	if err != nil {
-		return "", err
+		return "", nil, nil, err
 	}
 
 	sessionService := session.InMemoryService()
@@ -583,7 +940,7 @@ func (s *Service) runSingleAgentWithHistory(
 		SessionService: sessionService,
 	})
 	if err != nil {
-		return "", err
+		return "", nil, nil, err
 	}
 
 	sessionID := fmt.Sprintf("session-%s-%d", cfg.ID, time.Now().UnixNano())
@@ -593,7 +950,7 @@ func (s *Service) runSingleAgentWithHistory(
 		SessionID: sessionID,
 	})
 	if err != nil {
-		return "", fmt.Errorf("create session error: %w", err)
+		return "", nil, nil, fmt.Errorf("create session error: %w", err)
 	}
 
 	userMsg := &genai.Content{
@@ -604,14 +961,20 @@ func (s *Service) runSingleAgentWithHistory(
 	}
 
 	var content string
+	trace := newToolTraceRecorder(s.getSettings().includeToolTrace)
+	var usage usageRecorder
 	runCfg := agent.RunConfig{
 		StreamingMode: agent.StreamingModeSSE,
 	}
 	for event, err := range r.Run(ctx, "user", sessionID, userMsg, runCfg) {
 		if err != nil {
-			return "", err
+			return "", nil, nil, err
 		}
-		if event == nil || event.LLMResponse.Content == nil {
+		if event == nil {
+			continue
+		}
+		usage.observe(&event.LLMResponse)
+		if event.LLMResponse.Content == nil {
 			continue
 		}
 
@@ -619,6 +982,10 @@ func (s *Service) runSingleAgentWithHistory(
 			if part.Thought {
 				continue
 			}
+			trace.observe(part)
+			if analysis, ok := extractAnalysis(part); ok && onAnalysis != nil {
+				onAnalysis(cfg.Name, analysis)
+			}
 
 			// 检测工具调用
 			if part.FunctionCall != nil && progressCallback != nil {
@@ -660,16 +1027,176 @@ func (s *Service) runSingleAgentWithHistory(
 		}
 	}
 
-	return content, nil
+	return content, trace.result(), usage.result(), nil
 }
 
 // createBuilder 创建 ExpertAgentBuilder
 func (s *Service) createBuilder(llm model.LLM) *adk.ExpertAgentBuilder {
-	if s.mcpManager != nil {
-		return adk.NewExpertAgentBuilderFull(llm, s.toolRegistry, s.mcpManager)
+	var builder *adk.ExpertAgentBuilder
+	switch {
+	case s.mcpManager != nil:
+		builder = adk.NewExpertAgentBuilderFull(llm, s.toolRegistry, s.mcpManager)
+	case s.toolRegistry != nil:
+		builder = adk.NewExpertAgentBuilderWithTools(llm, s.toolRegistry)
+	default:
+		builder = adk.NewExpertAgentBuilder(llm)
+	}
+	settings := s.getSettings()
+	builder.SetGlobalTools(settings.globalTools)
+	builder.SetUserProfile(settings.userName, settings.userPersona)
+	return builder
+}
+
+// withRequestSeed 请求指定了随机种子时返回覆盖种子后的配置副本
+func withRequestSeed(aiConfig *models.AIConfig, seed int) *models.AIConfig {
+	if aiConfig == nil || seed == 0 {
+		return aiConfig
+	}
+	c := *aiConfig
+	c.Seed = seed
+	return &c
+}
+
+// builderPool 会议内按 AI 配置 ID 缓存的专家构建器
+// 专家未指定 ProviderID 或配置不可用时使用会议默认模型
+type builderPool struct {
+	service     *Service
+	defaultID   string
+	seed        int             // 请求指定的随机种子，覆盖专家所选配置中的种子
+	toolFilter  *adk.ToolFilter // 请求指定的工具过滤，应用于会议内所有构建器
+	concurrency int             // 预建模型的并发上限
+	create      func(ctx context.Context, config *models.AIConfig) (model.LLM, error)
+	mu          sync.Mutex
+	builders    map[string]*adk.ExpertAgentBuilder
+}
+
+// newBuilderPool 创建会议内构建器缓存，默认模型对应的构建器预先放入
+func (s *Service) newBuilderPool(defaultConfig *models.AIConfig, defaultLLM model.LLM, req *ChatRequest) *builderPool {
+	p := &builderPool{
+		service:     s,
+		concurrency: ModelCreationConcurrency,
+		create:      s.modelFactory.CreateModel,
+		builders:    make(map[string]*adk.ExpertAgentBuilder),
+	}
+	if req != nil {
+		p.seed = req.Seed
+		p.toolFilter = adk.NewToolFilter(req.AllowedTools, req.DeniedTools)
+	}
+	if defaultConfig != nil {
+		p.defaultID = defaultConfig.ID
+	}
+	p.builders[p.defaultID] = p.newBuilder(defaultLLM)
+	return p
+}
+
+// newBuilder 创建构建器并应用本次会议的工具过滤
+func (p *builderPool) newBuilder(llm model.LLM) *adk.ExpertAgentBuilder {
+	b := p.service.createBuilder(llm)
+	b.SetToolFilter(p.toolFilter)
+	return b
+}
+
+// get 获取专家对应的构建器，同一配置在会议内只创建一次模型
+func (p *builderPool) get(ctx context.Context, cfg *models.AgentConfig) *adk.ExpertAgentBuilder {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	id := cfg.ProviderID
+	if id == "" {
+		return p.builders[p.defaultID]
+	}
+	if b, ok := p.builders[id]; ok {
+		return b
+	}
+
+	b := p.build(ctx, id)
+	if b == nil {
+		b = p.builders[p.defaultID]
+	}
+	p.builders[id] = b
+	return b
+}
+
+// prewarm 按 AI 配置 ID 去重后并发预建专家所需模型，并发数受 concurrency 限制
+// 每个模型单独受 ModelCreationTimeout 约束，失败时回退默认模型
+func (p *builderPool) prewarm(ctx context.Context, agents []models.AgentConfig) {
+	p.mu.Lock()
+	var ids []string
+	seen := make(map[string]bool)
+	for _, a := range agents {
+		id := a.ProviderID
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		if _, ok := p.builders[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	p.mu.Unlock()
+	if len(ids) == 0 {
+		return
 	}
-	if s.toolRegistry != nil {
-		return adk.NewExpertAgentBuilderWithTools(llm, s.toolRegistry)
+
+	limit := p.concurrency
+	if limit <= 0 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			b := p.build(ctx, id)
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			if b == nil {
+				b = p.builders[p.defaultID]
+			}
+			p.builders[id] = b
+		}(id)
+	}
+	wg.Wait()
+	log.Debug("prewarmed %d models", len(ids))
+}
+
+// build 按 AI 配置 ID 创建模型及构建器，配置不存在或创建失败时返回 nil
+func (p *builderPool) build(ctx context.Context, id string) *adk.ExpertAgentBuilder {
+	aiConfig, ok := p.service.getAIConfig(id)
+	if !ok {
+		log.Warn("provider %s not found, fallback to default", id)
+		return nil
+	}
+	if err := p.service.breaker.allow(id, time.Now()); err != nil {
+		log.Warn("provider %s unavailable, fallback to default: %v", id, err)
+		return nil
+	}
+
+	modelCtx, cancel := context.WithTimeout(ctx, ModelCreationTimeout)
+	llm, err := p.create(modelCtx, withRequestSeed(&aiConfig, p.seed))
+	cancel()
+	llm, err = p.service.withBreaker(id, llm, err)
+	if err != nil {
+		log.Warn("create model %s error, fallback to default: %v", aiConfig.ModelName, err)
+		return nil
+	}
+
+	log.Debug("provider %s using model %s", id, aiConfig.ModelName)
+	return p.newBuilder(llm)
+}
+
+// sleepContext 可被 ctx 取消的等待
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
-	return adk.NewExpertAgentBuilder(llm)
 }
@@ -0,0 +1,178 @@
+package meeting
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+
+	"google.golang.org/adk/model"
+)
+
+// TestFallbackAgentsByPriority 测试小韭菜未选专家时的兜底选择
+func TestFallbackAgentsByPriority(t *testing.T) {
+	all := []models.AgentConfig{
+		{ID: "risk", Priority: 5, Enabled: true},
+		{ID: "fundamental", Priority: 1, Enabled: true},
+		{ID: "technical", Priority: 2, Enabled: false},
+		{ID: "capital", Priority: 3, Enabled: true},
+	}
+
+	got := fallbackAgentsByPriority(all, 2)
+	if len(got) != 2 || got[0].ID != "fundamental" || got[1].ID != "capital" {
+		t.Errorf("应按优先级选出已启用专家: %+v", got)
+	}
+	if got := fallbackAgentsByPriority(all, 0); got != nil {
+		t.Errorf("数量为0时不应兜底: %+v", got)
+	}
+	if got := fallbackAgentsByPriority(all, 10); len(got) != 3 {
+		t.Errorf("数量超过可用专家时应全部返回: %d", len(got))
+	}
+}
+
+// TestExpertWeights 测试专家总结权重
+func TestExpertWeights(t *testing.T) {
+	selected := []models.AgentConfig{{ID: "technical"}, {ID: "capital"}}
+
+	// 小韭菜未给权重时按选择顺序递减
+	w := expertWeights(selected, nil)
+	if w["technical"] <= w["capital"] {
+		t.Errorf("靠前的专家权重应更高: %v", w)
+	}
+	if sum := w["technical"] + w["capital"]; sum < 0.999 || sum > 1.001 {
+		t.Errorf("权重应归一化: %v", w)
+	}
+
+	// 使用小韭菜给出的权重，缺失的按最低权重计
+	w = expertWeights(append(selected, models.AgentConfig{ID: "risk"}), map[string]float64{"technical": 0.2, "capital": 0.6})
+	if w["capital"] != 0.6 || w["risk"] != 0.2 {
+		t.Errorf("权重计算错误: %v", w)
+	}
+}
+
+// TestBuilderPoolPrewarm 测试会前预建模型按配置 ID 去重并限制并发
+func TestBuilderPoolPrewarm(t *testing.T) {
+	s := NewServiceFull(nil, nil)
+	s.SetAIConfigs([]models.AIConfig{{ID: "a", ModelName: "model-a"}, {ID: "b", ModelName: "model-b"}, {ID: "c", ModelName: "model-c"}})
+	pool := s.newBuilderPool(&models.AIConfig{ID: "default"}, nil, nil)
+	pool.concurrency = 2
+
+	var (
+		mu             sync.Mutex
+		calls          = map[string]int{}
+		inFlight, peak int
+	)
+	pool.create = func(ctx context.Context, config *models.AIConfig) (model.LLM, error) {
+		mu.Lock()
+		calls[config.ID]++
+		inFlight++
+		peak = max(peak, inFlight)
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		if config.ID == "c" {
+			return nil, errors.New("auth failed")
+		}
+		return nil, nil
+	}
+
+	agents := []models.AgentConfig{
+		{ID: "1", ProviderID: "a"}, {ID: "2", ProviderID: "a"}, {ID: "3", ProviderID: "b"},
+		{ID: "4"}, {ID: "5", ProviderID: "c"}, {ID: "6", ProviderID: "missing"}, {ID: "7", ProviderID: "b"},
+	}
+	pool.prewarm(context.Background(), agents)
+
+	if calls["a"] != 1 || calls["b"] != 1 || calls["c"] != 1 || len(calls) != 3 {
+		t.Errorf("每个配置应只创建一次模型: %v", calls)
+	}
+	if peak > 2 {
+		t.Errorf("并发创建数 = %d, 上限为 2", peak)
+	}
+
+	def := pool.builders["default"]
+	if b := pool.get(context.Background(), &agents[0]); b == nil || b == def {
+		t.Errorf("配置 a 应使用预建的构建器")
+	}
+	if pool.get(context.Background(), &agents[4]) != def || pool.get(context.Background(), &agents[5]) != def {
+		t.Errorf("创建失败或配置不存在时应回退默认构建器")
+	}
+
+	// 已预建的配置再次预建不重复创建
+	pool.prewarm(context.Background(), agents)
+	pool.get(context.Background(), &agents[6])
+	if calls["a"] != 1 || calls["b"] != 1 {
+		t.Errorf("重复预建不应再次创建模型: %v", calls)
+	}
+}
+
+// TestRunAgentsParallelDeadlineDuringDelay 测试错开等待期间整体超时仍输出超时占位，用户取消时不输出
+func TestRunAgentsParallelDeadlineDuringDelay(t *testing.T) {
+	s := NewServiceFull(nil, nil)
+	s.SetAgentDelay(time.Hour)
+	pool := s.newBuilderPool(&models.AIConfig{ID: "default"}, nil, nil)
+	req := ChatRequest{Agents: []models.AgentConfig{{ID: "a", Name: "甲"}, {ID: "b", Name: "乙"}}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	responses, err := s.runAgentsParallel(ctx, pool, req)
+	if !errors.Is(err, ErrMeetingTimeout) {
+		t.Errorf("应返回会议超时错误: %v", err)
+	}
+	if len(responses) != 2 {
+		t.Fatalf("超时的专家应以占位消息返回: %+v", responses)
+	}
+	for _, r := range responses {
+		if r.MsgType != "error" || r.Content != r.AgentName+" 分析超时" {
+			t.Errorf("占位消息错误: %+v", r)
+		}
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	responses, err = s.runAgentsParallel(ctx, pool, req)
+	if err != nil || len(responses) != 0 {
+		t.Errorf("用户取消时不应输出占位消息: %+v %v", responses, err)
+	}
+}
+
+// TestSettingsConcurrentUpdate 测试会议进行中修改设置不产生数据竞争，且读取到的是调用方数据的副本
+func TestSettingsConcurrentUpdate(t *testing.T) {
+	s := NewServiceFull(nil, nil)
+	tools := []string{"get_kline_data"}
+	aiConfig := &models.AIConfig{ID: "mem", ModelName: "model-a"}
+	s.SetGlobalTools(tools)
+	s.SetMemoryAIConfig(aiConfig)
+	tools[0] = "changed"
+	aiConfig.ModelName = "changed"
+	if got := s.getSettings(); got.globalTools[0] != "get_kline_data" || got.memoryAIConfig.ModelName != "model-a" {
+		t.Errorf("设置应保存副本: %v %s", got.globalTools, got.memoryAIConfig.ModelName)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.SetAgentDelay(time.Duration(j) * time.Millisecond)
+				s.SetGlobalTools([]string{"get_stock_realtime"})
+				s.SetUserProfile("老王", "短线")
+				s.SetAnalysisDepth(models.AnalysisDepthDeep)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				s.createBuilder(nil)
+				s.checkpointMemory(nil, "", nil)
+				_ = s.getSettings().agentDelay
+			}
+		}()
+	}
+	wg.Wait()
+}
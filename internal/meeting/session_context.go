@@ -0,0 +1,43 @@
+package meeting
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// maxSessionMessageRunes 注入上下文的单条会话消息最大字符数
+const maxSessionMessageRunes = 300
+
+// recentSessionMessages 取最近的 max 条有效会话消息（跳过错误占位消息），保持时间顺序
+func recentSessionMessages(messages []models.ChatMessage, max int) []models.ChatMessage {
+	if max <= 0 {
+		return nil
+	}
+	valid := make([]models.ChatMessage, 0, len(messages))
+	for _, msg := range messages {
+		if msg.MsgType == "error" || strings.TrimSpace(msg.Content) == "" {
+			continue
+		}
+		valid = append(valid, msg)
+	}
+	if len(valid) > max {
+		valid = valid[len(valid)-max:]
+	}
+	return valid
+}
+
+// buildSessionContext 构建此前会话记录的上下文，仅保留最近 max 条
+func buildSessionContext(messages []models.ChatMessage, max int) string {
+	recent := recentSessionMessages(messages, max)
+	if len(recent) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("【此前的会话记录】\n")
+	for _, msg := range recent {
+		sb.WriteString(fmt.Sprintf("- %s：%s\n", msg.AgentName, truncateRunes(msg.Content, maxSessionMessageRunes)))
+	}
+	return sb.String()
+}
@@ -0,0 +1,59 @@
+package meeting
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestRecentSessionMessages 测试会话历史条数上限
+func TestRecentSessionMessages(t *testing.T) {
+	messages := []models.ChatMessage{
+		{AgentName: "老韭菜", Content: "第一问"},
+		{AgentName: "老陈", Content: "回答一"},
+		{AgentName: "K线王", Content: "分析超时", MsgType: "error"},
+		{AgentName: "老韭菜", Content: "第二问"},
+		{AgentName: "钱姐", Content: "回答二"},
+	}
+
+	recent := recentSessionMessages(messages, 3)
+	if len(recent) != 3 || recent[0].Content != "回答一" || recent[2].Content != "回答二" {
+		t.Errorf("应保留最近3条有效消息: %+v", recent)
+	}
+	if recentSessionMessages(messages, 0) != nil {
+		t.Error("上限为0时不应注入会话历史")
+	}
+
+	ctx := buildSessionContext(messages, 2)
+	if strings.Contains(ctx, "回答一") || !strings.Contains(ctx, "- 钱姐：回答二") {
+		t.Errorf("会话上下文错误: %s", ctx)
+	}
+}
+
+// TestSummaryHistoryFromMessages 测试按消息ID重建讨论历史及提问定位
+func TestSummaryHistoryFromMessages(t *testing.T) {
+	messages := []models.ChatMessage{
+		{ID: "u1", AgentID: "user", Content: "旧问题"},
+		{ID: "o1", AgentID: "a", MsgType: "opinion", Content: "旧观点"},
+		{ID: "u2", AgentID: "user", Content: "估值贵不贵"},
+		{ID: "m1", AgentID: "moderator", MsgType: "opening", Content: "开场"},
+		{ID: "o2", AgentID: "a", AgentName: "老陈", MsgType: "opinion", Round: 1, Content: "PE偏高"},
+		{ID: "o3", AgentID: "b", MsgType: "opinion", Content: "被剔除"},
+		{ID: "o4", AgentID: "c", MsgType: "opinion", Content: "资金流入"},
+		{ID: "s1", AgentID: "moderator", MsgType: "summary", Content: "旧总结"},
+		{ID: "u3", AgentID: "user", Content: "之后的提问"},
+	}
+
+	query, history := SummaryHistoryFromMessages(messages, []string{"m1", "o2", "o4", "s1"})
+	if query != "估值贵不贵" {
+		t.Errorf("query = %q", query)
+	}
+	if len(history) != 2 || history[0].AgentName != "老陈" || history[0].Round != 1 || history[1].AgentID != "c" {
+		t.Errorf("history = %+v", history)
+	}
+
+	if _, history := SummaryHistoryFromMessages(messages, nil); len(history) != 0 {
+		t.Errorf("未选择消息时应为空: %+v", history)
+	}
+}
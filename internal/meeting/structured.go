@@ -0,0 +1,112 @@
+package meeting
+
+import (
+	"context"
+	"errors"
+
+	"github.com/run-bigpig/jcp/internal/memory"
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// DecisionCallback 小韭菜完成意图分析、选定专家后调用
+type DecisionCallback func(decision ModeratorDecision)
+
+// MeetingResult 智能会议的结构化结果，供外部集成直接读取，无需解析聊天消息
+type MeetingResult struct {
+	StockCode string             `json:"stockCode"`
+	StockName string             `json:"stockName"`
+	Query     string             `json:"query"`
+	Decision  *ModeratorDecision `json:"decision"`        // 小韭菜的意图分析与专家选择，意图分析失败时为空
+	Opening   string             `json:"opening"`         // 小韭菜开场白
+	Experts   []ExpertAnalysis   `json:"experts"`         // 专家发言（按发言顺序）
+	Summary   string             `json:"summary"`         // 小韭菜总结，跳过或生成失败时为空
+	Votes     VoteTally          `json:"votes"`           // 专家观点投票结果
+	Usage     models.TokenUsage  `json:"usage"`           // 专家发言 token 用量合计
+	Completed bool               `json:"completed"`       // 是否完整结束
+	Truncated bool               `json:"truncated"`       // 是否因超时只返回了部分结果
+	Error     string             `json:"error,omitempty"` // 失败原因
+}
+
+// ExpertAnalysis 单个专家的结构化发言
+type ExpertAnalysis struct {
+	AgentID   string             `json:"agentId"`
+	AgentName string             `json:"agentName"`
+	Role      string             `json:"role"`
+	Content   string             `json:"content"`
+	Stance    memory.Stance      `json:"stance"` // 按发言关键词判断的观点倾向
+	ToolTrace []models.ToolTrace `json:"toolTrace,omitempty"`
+	Usage     *models.TokenUsage `json:"usage,omitempty"`
+}
+
+// VoteTally 专家观点投票结果
+type VoteTally struct {
+	Bullish int     `json:"bullish"` // 看多票数
+	Neutral int     `json:"neutral"` // 中性票数
+	Bearish int     `json:"bearish"` // 看空票数
+	Score   float64 `json:"score"`   // 共识分 [-1, 1]，正值偏多
+}
+
+// NewMeetingResult 根据智能会议的决策和发言构建结构化结果
+func NewMeetingResult(req ChatRequest, decision *ModeratorDecision, responses []ChatResponse, err error) *MeetingResult {
+	result := &MeetingResult{
+		StockCode: req.Stock.Symbol,
+		StockName: req.Stock.Name,
+		Query:     req.Query,
+		Decision:  decision,
+		Experts:   []ExpertAnalysis{},
+		Completed: err == nil,
+		Truncated: errors.Is(err, ErrMeetingTimeout) || errors.Is(err, context.DeadlineExceeded),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	contents := make([]string, 0, len(responses))
+	for _, resp := range responses {
+		switch resp.MsgType {
+		case "opening":
+			result.Opening = resp.Content
+		case "summary":
+			result.Summary = resp.Content
+		case "opinion":
+			result.Experts = append(result.Experts, ExpertAnalysis{
+				AgentID:   resp.AgentID,
+				AgentName: resp.AgentName,
+				Role:      resp.Role,
+				Content:   resp.Content,
+				Stance:    memory.ClassifyStance(resp.Content),
+				ToolTrace: resp.ToolTrace,
+				Usage:     resp.Usage,
+			})
+			contents = append(contents, resp.Content)
+		}
+		if resp.Usage != nil {
+			result.Usage.Add(*resp.Usage)
+		}
+	}
+
+	if len(contents) > 0 {
+		point := memory.TallyStances(contents)
+		result.Votes = VoteTally{Bullish: point.Bullish, Neutral: point.Neutral, Bearish: point.Bearish, Score: point.Score}
+	}
+	return result
+}
+
+// RunSmartMeetingStructured 运行智能会议并返回结构化结果
+// 超时截断时返回已完成部分（Truncated 为 true）且不返回错误；会议未能开始（无配置、熔断、意图分析失败等）时返回错误
+func (s *Service) RunSmartMeetingStructured(ctx context.Context, aiConfig *models.AIConfig, req ChatRequest) (*MeetingResult, error) {
+	var decision *ModeratorDecision
+	onDecision := req.OnDecision
+	req.OnDecision = func(d ModeratorDecision) {
+		decision = &d
+		if onDecision != nil {
+			onDecision(d)
+		}
+	}
+
+	responses, err := s.RunSmartMeetingWithCallback(ctx, aiConfig, req, nil, nil)
+	if err != nil && !errors.Is(err, ErrMeetingTimeout) {
+		return nil, err
+	}
+	return NewMeetingResult(req, decision, responses, err), nil
+}
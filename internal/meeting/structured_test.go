@@ -0,0 +1,51 @@
+package meeting
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/memory"
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestNewMeetingResult(t *testing.T) {
+	req := ChatRequest{Stock: models.Stock{Symbol: "sh600519", Name: "贵州茅台"}, Query: "能买吗"}
+	decision := &ModeratorDecision{Intent: "买卖决策", Selected: []string{"a", "b", "c"}}
+	responses := []ChatResponse{
+		{AgentID: "moderator", MsgType: "opening", Content: "开场"},
+		{AgentID: "a", MsgType: "opinion", Content: "趋势向上，建议逢低买入", Usage: &models.TokenUsage{TotalTokens: 100}},
+		{AgentID: "b", MsgType: "opinion", Content: "估值偏高，注意下行风险，建议减仓", Usage: &models.TokenUsage{TotalTokens: 50}},
+		{AgentID: "c", MsgType: "opinion", Content: "资金面平稳"},
+		{AgentID: "moderator", MsgType: "summary", Content: "总结"},
+	}
+
+	result := NewMeetingResult(req, decision, responses, nil)
+	if !result.Completed || result.Truncated || result.Error != "" {
+		t.Errorf("正常结束状态错误: %+v", result)
+	}
+	if result.StockCode != "sh600519" || result.Decision != decision || result.Opening != "开场" || result.Summary != "总结" {
+		t.Errorf("基本字段错误: %+v", result)
+	}
+	if len(result.Experts) != 3 || result.Experts[0].AgentID != "a" || result.Experts[2].AgentID != "c" {
+		t.Fatalf("专家发言应按顺序保留: %+v", result.Experts)
+	}
+	if result.Experts[0].Stance != memory.StanceBullish || result.Experts[1].Stance != memory.StanceBearish || result.Experts[2].Stance != memory.StanceNeutral {
+		t.Errorf("观点倾向错误: %+v", result.Experts)
+	}
+	if result.Votes.Bullish != 1 || result.Votes.Bearish != 1 || result.Votes.Neutral != 1 || result.Votes.Score != 0 {
+		t.Errorf("投票结果错误: %+v", result.Votes)
+	}
+	if result.Usage.TotalTokens != 150 {
+		t.Errorf("用量合计错误: %+v", result.Usage)
+	}
+
+	partial := NewMeetingResult(req, decision, responses[:2], ErrMeetingTimeout)
+	if partial.Completed || !partial.Truncated || partial.Summary != "" || len(partial.Experts) != 1 {
+		t.Errorf("超时应标记为截断并保留已完成发言: %+v", partial)
+	}
+
+	empty := NewMeetingResult(req, nil, nil, errors.New("moderator analyze error"))
+	if empty.Experts == nil || empty.Votes != (VoteTally{}) || empty.Truncated {
+		t.Errorf("失败时专家应为空数组且无投票: %+v", empty)
+	}
+}
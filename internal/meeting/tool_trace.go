@@ -0,0 +1,86 @@
+package meeting
+
+import (
+	"encoding/json"
+
+	"github.com/run-bigpig/jcp/internal/models"
+
+	"google.golang.org/genai"
+)
+
+// maxToolTraceResultRunes 工具返回结果在记录中保留的最大字符数
+const maxToolTraceResultRunes = 500
+
+// toolTraceRecorder 收集专家发言过程中的工具调用及结果
+// nil 表示未开启记录，所有方法均可安全调用
+type toolTraceRecorder struct {
+	traces  []models.ToolTrace
+	callIDs []string // 与 traces 一一对应的调用ID
+}
+
+// newToolTraceRecorder 按开关创建记录器，未开启时返回 nil
+func newToolTraceRecorder(enabled bool) *toolTraceRecorder {
+	if !enabled {
+		return nil
+	}
+	return &toolTraceRecorder{}
+}
+
+// observe 记录事件中的工具调用与工具结果
+func (t *toolTraceRecorder) observe(part *genai.Part) {
+	if t == nil || part == nil {
+		return
+	}
+	if call := part.FunctionCall; call != nil {
+		// 流式模式下同一调用可能随多个事件重复出现
+		if call.ID != "" && t.find(call.ID, call.Name) >= 0 {
+			return
+		}
+		args, _ := json.Marshal(call.Args)
+		t.traces = append(t.traces, models.ToolTrace{Name: call.Name, Args: string(args)})
+		t.callIDs = append(t.callIDs, call.ID)
+	}
+	if resp := part.FunctionResponse; resp != nil {
+		result, _ := json.Marshal(resp.Response)
+		idx := t.find(resp.ID, resp.Name)
+		if idx < 0 {
+			t.traces = append(t.traces, models.ToolTrace{Name: resp.Name})
+			t.callIDs = append(t.callIDs, resp.ID)
+			idx = len(t.traces) - 1
+		}
+		t.traces[idx].Result = truncateRunes(string(result), maxToolTraceResultRunes)
+	}
+}
+
+// find 查找尚未填充结果的调用记录：优先按调用ID匹配，无ID时按工具名匹配
+func (t *toolTraceRecorder) find(id, name string) int {
+	for i, trace := range t.traces {
+		if trace.Result != "" {
+			continue
+		}
+		if id != "" && t.callIDs[i] == id {
+			return i
+		}
+		if id == "" && trace.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// result 返回已记录的工具调用
+func (t *toolTraceRecorder) result() []models.ToolTrace {
+	if t == nil {
+		return nil
+	}
+	return t.traces
+}
+
+// truncateRunes 按字符数截断字符串
+func truncateRunes(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max]) + "...(已截断)"
+}
@@ -0,0 +1,38 @@
+package meeting
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/genai"
+)
+
+func TestToolTraceRecorder(t *testing.T) {
+	var disabled *toolTraceRecorder
+	disabled.observe(&genai.Part{FunctionCall: &genai.FunctionCall{Name: "get_news"}})
+	if disabled.result() != nil {
+		t.Error("未开启时不应记录")
+	}
+
+	rec := newToolTraceRecorder(true)
+	call := &genai.Part{FunctionCall: &genai.FunctionCall{ID: "c1", Name: "get_kline_data", Args: map[string]any{"code": "sh600519"}}}
+	rec.observe(call)
+	rec.observe(call) // 重复事件不应重复记录
+	rec.observe(&genai.Part{FunctionCall: &genai.FunctionCall{ID: "c2", Name: "get_news"}})
+	rec.observe(&genai.Part{FunctionResponse: &genai.FunctionResponse{ID: "c2", Name: "get_news", Response: map[string]any{"data": "快讯"}}})
+	rec.observe(&genai.Part{FunctionResponse: &genai.FunctionResponse{ID: "c1", Name: "get_kline_data", Response: map[string]any{"data": strings.Repeat("数", 600)}}})
+
+	traces := rec.result()
+	if len(traces) != 2 {
+		t.Fatalf("应记录2次调用: %+v", traces)
+	}
+	if traces[0].Args != `{"code":"sh600519"}` {
+		t.Errorf("参数错误: %s", traces[0].Args)
+	}
+	if traces[1].Result != `{"data":"快讯"}` {
+		t.Errorf("结果应按调用ID匹配: %s", traces[1].Result)
+	}
+	if !strings.HasSuffix(traces[0].Result, "...(已截断)") || len([]rune(traces[0].Result)) > maxToolTraceResultRunes+10 {
+		t.Errorf("超长结果应截断: %d", len([]rune(traces[0].Result)))
+	}
+}
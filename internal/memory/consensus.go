@@ -0,0 +1,83 @@
+package memory
+
+import (
+	"strings"
+	"time"
+)
+
+// Stance 专家观点倾向
+type Stance string
+
+const (
+	StanceBullish Stance = "bullish" // 看多
+	StanceNeutral Stance = "neutral" // 中性
+	StanceBearish Stance = "bearish" // 看空
+)
+
+// maxConsensusPoints 每只股票保留的共识分数据点上限
+const maxConsensusPoints = 200
+
+// ConsensusPoint 单次会议的共识分数据点
+type ConsensusPoint struct {
+	Round     int     `json:"round"`     // 对应的讨论轮次
+	Bullish   int     `json:"bullish"`   // 看多票数
+	Neutral   int     `json:"neutral"`   // 中性票数
+	Bearish   int     `json:"bearish"`   // 看空票数
+	Score     float64 `json:"score"`     // 共识分 [-1, 1]，正值偏多
+	Timestamp int64   `json:"timestamp"` // 记录时间（毫秒）
+}
+
+// 观点倾向关键词
+var (
+	bullishKeywords = []string{"看多", "看好", "买入", "加仓", "增持", "做多", "低吸", "布局", "突破", "乐观", "上涨空间", "逢低"}
+	bearishKeywords = []string{"看空", "看淡", "卖出", "减仓", "减持", "回避", "离场", "止损", "破位", "悲观", "下行风险", "谨慎"}
+	negationPrefix  = []string{"不", "未", "暂不", "不宜", "不建议"}
+)
+
+// ClassifyStance 根据发言内容关键词判断观点倾向
+func ClassifyStance(content string) Stance {
+	bull := countKeywords(content, bullishKeywords)
+	bear := countKeywords(content, bearishKeywords)
+	switch {
+	case bull > bear:
+		return StanceBullish
+	case bear > bull:
+		return StanceBearish
+	default:
+		return StanceNeutral
+	}
+}
+
+// countKeywords 统计关键词出现次数，忽略被否定的关键词
+func countKeywords(content string, keywords []string) int {
+	count := 0
+	for _, kw := range keywords {
+		n := strings.Count(content, kw)
+		for _, neg := range negationPrefix {
+			n -= strings.Count(content, neg+kw)
+		}
+		if n > 0 {
+			count += n
+		}
+	}
+	return count
+}
+
+// TallyStances 统计多位专家发言的投票结果
+func TallyStances(contents []string) ConsensusPoint {
+	point := ConsensusPoint{Timestamp: time.Now().UnixMilli()}
+	for _, content := range contents {
+		switch ClassifyStance(content) {
+		case StanceBullish:
+			point.Bullish++
+		case StanceBearish:
+			point.Bearish++
+		default:
+			point.Neutral++
+		}
+	}
+	if total := point.Bullish + point.Neutral + point.Bearish; total > 0 {
+		point.Score = float64(point.Bullish-point.Bearish) / float64(total)
+	}
+	return point
+}
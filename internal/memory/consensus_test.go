@@ -0,0 +1,30 @@
+package memory
+
+import "testing"
+
+func TestClassifyStance(t *testing.T) {
+	tests := []struct {
+		content string
+		want    Stance
+	}{
+		{"业绩稳健，估值偏低，建议逢低布局", StanceBullish},
+		{"跌破支撑，建议减仓止损", StanceBearish},
+		{"不建议买入，短期震荡为主", StanceNeutral},
+		{"维持观察", StanceNeutral},
+	}
+	for _, tt := range tests {
+		if got := ClassifyStance(tt.content); got != tt.want {
+			t.Errorf("ClassifyStance(%q) = %s, want %s", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestTallyStances(t *testing.T) {
+	point := TallyStances([]string{"看好后市，逢低加仓", "资金持续流入，看多", "破位风险，建议减仓"})
+	if point.Bullish != 2 || point.Bearish != 1 || point.Neutral != 0 {
+		t.Fatalf("票数错误: %+v", point)
+	}
+	if point.Score <= 0 {
+		t.Errorf("共识分应为正值, got %.2f", point.Score)
+	}
+}
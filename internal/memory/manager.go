@@ -6,6 +6,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/run-bigpig/jcp/internal/pkg/store"
+
 	"google.golang.org/adk/model"
 )
 
@@ -17,20 +19,33 @@ type Manager struct {
 	relevance  *Relevance
 	summarizer Summarizer
 	dataDir    string
-	saveCh     chan *StockMemory // 异步保存通道
-	closeCh    chan struct{}     // 关闭信号
+	saveCh     chan saveRequest // 异步保存通道
+	closeCh    chan struct{}    // 关闭信号
 }
 
 // NewManager 创建记忆管理器（无 LLM，摘要功能禁用）
 func NewManager(dataDir string) *Manager {
+	m := newManager(NewFileStorage(dataDir))
+	m.dataDir = dataDir
+	return m
+}
+
+// NewManagerWithStore 使用指定存储后端创建记忆管理器
+func NewManagerWithStore(backend store.Store, config Config) *Manager {
+	m := newManager(NewStoreStorage(backend))
+	m.config = config
+	return m
+}
+
+// newManager 创建记忆管理器并启动异步保存
+func newManager(storage Storage) *Manager {
 	tokenizer := NewJiebaTokenizer()
 	m := &Manager{
 		config:    DefaultConfig(),
-		storage:   NewFileStorage(dataDir),
+		storage:   storage,
 		tokenizer: tokenizer,
 		relevance: NewRelevance(tokenizer),
-		dataDir:   dataDir,
-		saveCh:    make(chan *StockMemory, 100), // 缓冲通道
+		saveCh:    make(chan saveRequest, 100), // 缓冲通道
 		closeCh:   make(chan struct{}),
 	}
 	go m.asyncSaveLoop()
@@ -56,6 +71,8 @@ func (m *Manager) GetOrCreate(stockCode, stockName string) (*StockMemory, error)
 		// 不存在则创建新的
 		mem = NewStockMemory(stockCode, stockName)
 	}
+	// 上次会议中断遗留的草稿转为正式轮次，保留已完成的专家观点
+	promoteDraft(mem)
 	return mem, nil
 }
 
@@ -65,31 +82,49 @@ func (m *Manager) Save(mem *StockMemory) error {
 	return m.storage.Save(mem)
 }
 
+// saveRequest 异步保存请求，snapshot 非空时写入快照内容，缓存仍指向 mem
+type saveRequest struct {
+	mem      *StockMemory
+	snapshot *StockMemory
+}
+
 // SaveAsync 异步保存记忆（不阻塞）
 func (m *Manager) SaveAsync(mem *StockMemory) {
 	mem.UpdatedAt = time.Now().UnixMilli()
+	m.enqueueSave(saveRequest{mem: mem})
+}
+
+// enqueueSave 提交异步保存请求，通道满时丢弃，避免阻塞
+func (m *Manager) enqueueSave(req saveRequest) {
 	select {
-	case m.saveCh <- mem:
+	case m.saveCh <- req:
 	default:
-		// 通道满时丢弃，避免阻塞
-		fmt.Printf("memory save channel full, dropping save for %s\n", mem.StockCode)
+		fmt.Printf("memory save channel full, dropping save for %s\n", req.mem.StockCode)
+	}
+}
+
+// save 执行保存请求
+func (m *Manager) save(req saveRequest) error {
+	if req.snapshot != nil {
+		return m.storage.SaveSnapshot(req.mem, req.snapshot)
 	}
+	return m.storage.Save(req.mem)
 }
 
 // asyncSaveLoop 异步保存循环
 func (m *Manager) asyncSaveLoop() {
 	for {
 		select {
-		case mem := <-m.saveCh:
-			if err := m.storage.Save(mem); err != nil {
+		case req := <-m.saveCh:
+			if err := m.save(req); err != nil {
 				fmt.Printf("async save memory error: %v\n", err)
 			}
 		case <-m.closeCh:
 			// 退出前保存剩余的
 			for {
 				select {
-				case mem := <-m.saveCh:
-					m.storage.Save(mem)
+				case req := <-m.saveCh:
+					m.save(req)
 				default:
 					return
 				}
@@ -100,41 +135,127 @@ func (m *Manager) asyncSaveLoop() {
 
 // BuildContext 构建上下文（核心方法）
 func (m *Manager) BuildContext(mem *StockMemory, currentQuery string) string {
+	// 相关的关键事实（基于关键词匹配，按相关性降序）
+	relevantFacts := m.relevance.FindRelevant(mem.KeyFacts, currentQuery, 5)
+
+	result := renderContext(mem.Summary, relevantFacts, mem.RecentRounds)
+
+	maxLen := m.config.MaxContextLength
+	if maxLen <= 0 {
+		maxLen = DefaultConfig().MaxContextLength
+	}
+	fullLen := len([]rune(result))
+	if fullLen <= maxLen {
+		return result
+	}
+
+	truncated := m.truncateContext(mem.Summary, relevantFacts, mem.RecentRounds, maxLen)
+	fmt.Printf("memory context truncated for %s: %d -> %d chars\n",
+		mem.StockCode, fullLen, len([]rune(truncated)))
+	return truncated
+}
+
+// renderContext 渲染上下文文本
+func renderContext(summary string, facts []MemoryEntry, rounds []RoundMemory) string {
 	var sb strings.Builder
 
 	// 1. 历史摘要
-	if mem.Summary != "" {
+	if summary != "" {
 		sb.WriteString("【历史讨论摘要】\n")
-		sb.WriteString(mem.Summary)
+		sb.WriteString(summary)
 		sb.WriteString("\n\n")
 	}
 
-	// 2. 相关的关键事实（基于关键词匹配）
-	relevantFacts := m.relevance.FindRelevant(mem.KeyFacts, currentQuery, 5)
-	if len(relevantFacts) > 0 {
+	// 2. 相关的关键事实
+	if len(facts) > 0 {
 		sb.WriteString("【相关历史信息】\n")
-		for _, fact := range relevantFacts {
-			timeStr := time.UnixMilli(fact.Timestamp).Format("2006-01-02")
-			fmt.Fprintf(&sb, "- [%s] %s\n", timeStr, fact.Content)
+		for _, fact := range facts {
+			sb.WriteString(formatFactLine(fact))
 		}
 		sb.WriteString("\n")
 	}
 
 	// 3. 最近几轮讨论的要点
-	if len(mem.RecentRounds) > 0 {
+	if len(rounds) > 0 {
 		sb.WriteString("【近期讨论】\n")
-		for _, round := range mem.RecentRounds {
-			timeStr := time.UnixMilli(round.Timestamp).Format("2006-01-02 15:04")
-			fmt.Fprintf(&sb, "[%s] 问题: %s\n", timeStr, round.Query)
-			fmt.Fprintf(&sb, "结论: %s\n\n", round.Consensus)
+		for _, round := range rounds {
+			sb.WriteString(formatRoundBlock(round))
 		}
 	}
 
 	return sb.String()
 }
 
-// AddRound 添加新一轮讨论并触发压缩检查
+// formatFactLine 格式化单条事实
+func formatFactLine(fact MemoryEntry) string {
+	timeStr := time.UnixMilli(fact.Timestamp).Format("2006-01-02")
+	return fmt.Sprintf("- [%s] %s\n", timeStr, fact.Content)
+}
+
+// formatRoundBlock 格式化单轮讨论
+func formatRoundBlock(round RoundMemory) string {
+	timeStr := time.UnixMilli(round.Timestamp).Format("2006-01-02 15:04")
+	return fmt.Sprintf("[%s] 问题: %s\n结论: %s\n\n", timeStr, round.Query, round.Consensus)
+}
+
+// truncateContext 在字数上限内按优先级保留内容
+// 优先级：最近的讨论 > 高相关性事实 > 历史摘要（保留末尾较新的部分）
+func (m *Manager) truncateContext(summary string, facts []MemoryEntry, rounds []RoundMemory, maxLen int) string {
+	// 各区块标题的固定开销
+	const headerCost = 10
+	budget := maxLen
+
+	// 1. 从最新一轮往前保留
+	var keptRounds []RoundMemory
+	if len(rounds) > 0 {
+		budget -= headerCost
+		for i := len(rounds) - 1; i >= 0; i-- {
+			cost := len([]rune(formatRoundBlock(rounds[i])))
+			if cost > budget {
+				break
+			}
+			budget -= cost
+			keptRounds = append([]RoundMemory{rounds[i]}, keptRounds...)
+		}
+		if len(keptRounds) == 0 {
+			budget += headerCost
+		}
+	}
+
+	// 2. 按相关性顺序保留事实
+	var keptFacts []MemoryEntry
+	if len(facts) > 0 && budget > headerCost {
+		budget -= headerCost
+		for _, fact := range facts {
+			cost := len([]rune(formatFactLine(fact)))
+			if cost > budget {
+				continue
+			}
+			budget -= cost
+			keptFacts = append(keptFacts, fact)
+		}
+		if len(keptFacts) == 0 {
+			budget += headerCost
+		}
+	}
+
+	// 3. 摘要使用剩余预算，保留末尾（较新）的内容
+	keptSummary := ""
+	if summary != "" && budget > headerCost*2 {
+		budget -= headerCost
+		runes := []rune(summary)
+		if len(runes) > budget {
+			runes = runes[len(runes)-budget:]
+		}
+		keptSummary = string(runes)
+	}
+
+	return renderContext(keptSummary, keptFacts, keptRounds)
+}
+
+// AddRound 添加新一轮讨论并触发压缩检查，同时丢弃本次会议的记忆草稿
 func (m *Manager) AddRound(ctx context.Context, mem *StockMemory, query, consensus string, keyPoints []string) error {
+	mem.Draft = nil
 	mem.TotalRounds++
 	round := RoundMemory{
 		Round:     mem.TotalRounds,
@@ -158,6 +279,62 @@ func (m *Manager) AddRound(ctx context.Context, mem *StockMemory, query, consens
 	return nil
 }
 
+// draftConsensus 中断会议草稿转为正式轮次时的结论说明
+const draftConsensus = "（会议未完成，仅记录已发言专家的观点）"
+
+// CheckpointRound 更新进行中会议的记忆草稿并异步保存，会议被取消时已发言专家的观点不会丢失
+// 草稿要点按发言截取，不调用 LLM；写入存储的是快照，缓存仍指向 mem，之后加载得到的是会议使用的同一对象
+func (m *Manager) CheckpointRound(mem *StockMemory, query string, discussions []DiscussionInput) {
+	mem.Draft = &RoundMemory{
+		Round:     mem.TotalRounds + 1,
+		Query:     query,
+		Consensus: draftConsensus,
+		KeyPoints: m.fallbackExtractKeyPoints(discussions),
+		Timestamp: time.Now().UnixMilli(),
+	}
+	mem.UpdatedAt = time.Now().UnixMilli()
+	snapshot := *mem
+	m.enqueueSave(saveRequest{mem: mem, snapshot: &snapshot})
+}
+
+// ClearDraft 会议已生成总结时丢弃记忆草稿，避免正式轮次写入前的追问把本次会议记为未完成
+func (m *Manager) ClearDraft(mem *StockMemory) {
+	mem.Draft = nil
+}
+
+// promoteDraft 将遗留的记忆草稿转为正式轮次
+func promoteDraft(mem *StockMemory) {
+	if mem.Draft == nil {
+		return
+	}
+	draft := *mem.Draft
+	mem.Draft = nil
+	mem.TotalRounds++
+	draft.Round = mem.TotalRounds
+	mem.RecentRounds = append(mem.RecentRounds, draft)
+}
+
+// RecordConsensus 记录一次会议的共识分并异步保存
+func (m *Manager) RecordConsensus(mem *StockMemory, point ConsensusPoint) {
+	if point.Round == 0 {
+		point.Round = mem.TotalRounds
+	}
+	mem.ConsensusHistory = append(mem.ConsensusHistory, point)
+	if len(mem.ConsensusHistory) > maxConsensusPoints {
+		mem.ConsensusHistory = mem.ConsensusHistory[len(mem.ConsensusHistory)-maxConsensusPoints:]
+	}
+	m.SaveAsync(mem)
+}
+
+// GetConsensusHistory 获取指定股票的共识分时间序列
+func (m *Manager) GetConsensusHistory(stockCode string) []ConsensusPoint {
+	mem, err := m.storage.Load(stockCode)
+	if err != nil || mem.ConsensusHistory == nil {
+		return []ConsensusPoint{}
+	}
+	return mem.ConsensusHistory
+}
+
 // compress 压缩旧轮次为摘要
 func (m *Manager) compress(ctx context.Context, mem *StockMemory) error {
 	keepCount := m.config.MaxRecentRounds
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/pkg/store"
+)
+
+func TestTruncateContext(t *testing.T) {
+	m := &Manager{config: DefaultConfig()}
+	now := time.Now().UnixMilli()
+
+	rounds := []RoundMemory{
+		{Round: 1, Query: "旧问题", Consensus: strings.Repeat("旧", 200), Timestamp: now},
+		{Round: 2, Query: "新问题", Consensus: "最新结论", Timestamp: now},
+	}
+	facts := []MemoryEntry{
+		{Content: "高相关事实", Timestamp: now},
+		{Content: strings.Repeat("长", 300), Timestamp: now},
+	}
+	summary := strings.Repeat("摘", 500)
+
+	maxLen := 200
+	result := m.truncateContext(summary, facts, rounds, maxLen)
+
+	if n := len([]rune(result)); n > maxLen {
+		t.Errorf("截断后长度 %d 超过上限 %d", n, maxLen)
+	}
+	if !strings.Contains(result, "最新结论") {
+		t.Error("应保留最近一轮讨论")
+	}
+	if strings.Contains(result, "旧问题") {
+		t.Error("超出预算的旧讨论应被丢弃")
+	}
+	if !strings.Contains(result, "高相关事实") {
+		t.Error("应保留预算内的高相关事实")
+	}
+}
+
+func TestCheckpointRound(t *testing.T) {
+	m := &Manager{config: DefaultConfig(), saveCh: make(chan saveRequest, 10)}
+
+	mem := NewStockMemory("sh600519", "贵州茅台")
+	mem.TotalRounds = 2
+	m.CheckpointRound(mem, "还能买吗", []DiscussionInput{{AgentName: "老陈", Content: "估值合理"}})
+	if mem.Draft == nil || mem.Draft.Round != 3 || len(mem.Draft.KeyPoints) != 1 {
+		t.Fatalf("草稿错误: %+v", mem.Draft)
+	}
+
+	// 会议中断：下次加载时草稿转为正式轮次
+	interrupted := *mem
+	promoteDraft(&interrupted)
+	if interrupted.Draft != nil || interrupted.TotalRounds != 3 || len(interrupted.RecentRounds) != 1 {
+		t.Fatalf("草稿未转为正式轮次: %+v", interrupted)
+	}
+	if got := interrupted.RecentRounds[0]; got.Query != "还能买吗" || got.Consensus != draftConsensus {
+		t.Errorf("转换后的轮次错误: %+v", got)
+	}
+
+	// 会议正常结束：正式轮次取代草稿
+	if err := m.AddRound(t.Context(), mem, "还能买吗", "维持观望", nil); err != nil {
+		t.Fatal(err)
+	}
+	if mem.Draft != nil || mem.TotalRounds != 3 || len(mem.RecentRounds) != 1 || mem.RecentRounds[0].Consensus != "维持观望" {
+		t.Errorf("AddRound 应取代草稿: %+v", mem)
+	}
+}
+
+// TestCheckpointFollowUpBeforeAddRound 测试草稿快照保存后、正式轮次写入前追问时，加载到的是会议使用的同一对象且不记为未完成
+func TestCheckpointFollowUpBeforeAddRound(t *testing.T) {
+	backend, err := store.NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &Manager{config: DefaultConfig(), storage: NewStoreStorage(backend), saveCh: make(chan saveRequest, 10)}
+
+	mem, _ := m.GetOrCreate("sh600519", "贵州茅台")
+	m.CheckpointRound(mem, "还能买吗", []DiscussionInput{{AgentName: "老陈", Content: "估值合理"}})
+	if err := m.save(<-m.saveCh); err != nil {
+		t.Fatal(err)
+	}
+
+	// 总结已生成、正式轮次尚未写入时用户追问
+	m.ClearDraft(mem)
+	followUp, _ := m.GetOrCreate("sh600519", "贵州茅台")
+	if followUp != mem {
+		t.Fatal("追问应加载会议使用的同一记忆对象，而不是草稿快照")
+	}
+	if followUp.TotalRounds != 0 || len(followUp.RecentRounds) != 0 {
+		t.Fatalf("已完成的会议不应被记为未完成: %+v", followUp.RecentRounds)
+	}
+
+	if err := m.AddRound(t.Context(), mem, "还能买吗", "维持观望", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.save(<-m.saveCh); err != nil {
+		t.Fatal(err)
+	}
+	if mem.TotalRounds != 1 || len(mem.RecentRounds) != 1 || mem.RecentRounds[0].Consensus != "维持观望" {
+		t.Errorf("正式轮次错误: %+v", mem.RecentRounds)
+	}
+}
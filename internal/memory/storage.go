@@ -2,43 +2,45 @@ package memory
 
 import (
 	"encoding/json"
-	"os"
 	"path/filepath"
 	"sync"
+
+	"github.com/run-bigpig/jcp/internal/pkg/store"
 )
 
 // Storage 存储接口
 type Storage interface {
 	Load(stockCode string) (*StockMemory, error)
 	Save(mem *StockMemory) error
+	// SaveSnapshot 写入 snapshot 的内容，缓存指向 mem（会议进行中的对象），不被快照取代
+	SaveSnapshot(mem, snapshot *StockMemory) error
 	Delete(stockCode string) error
 	List() ([]string, error)
 }
 
-// FileStorage 文件存储（按股票隔离）
-type FileStorage struct {
-	dir   string
-	cache map[string]*StockMemory
-	mu    sync.RWMutex
+// StoreStorage 基于键值存储后端的记忆存储（按股票隔离，带内存缓存）
+type StoreStorage struct {
+	backend store.Store
+	cache   map[string]*StockMemory
+	mu      sync.RWMutex
 }
 
-// NewFileStorage 创建文件存储
-func NewFileStorage(dataDir string) *FileStorage {
-	memDir := filepath.Join(dataDir, "memories")
-	os.MkdirAll(memDir, 0755)
-	return &FileStorage{
-		dir:   memDir,
-		cache: make(map[string]*StockMemory),
+// NewStoreStorage 使用指定存储后端创建记忆存储
+func NewStoreStorage(backend store.Store) *StoreStorage {
+	return &StoreStorage{
+		backend: backend,
+		cache:   make(map[string]*StockMemory),
 	}
 }
 
-// getPath 获取存储路径
-func (s *FileStorage) getPath(stockCode string) string {
-	return filepath.Join(s.dir, stockCode+".json")
+// NewFileStorage 创建文件存储（数据目录下的 memories 目录，每只股票一个 JSON 文件）
+func NewFileStorage(dataDir string) *StoreStorage {
+	backend, _ := store.NewFileStore(filepath.Join(dataDir, "memories"))
+	return NewStoreStorage(backend)
 }
 
 // Load 加载股票记忆
-func (s *FileStorage) Load(stockCode string) (*StockMemory, error) {
+func (s *StoreStorage) Load(stockCode string) (*StockMemory, error) {
 	s.mu.RLock()
 	if mem, ok := s.cache[stockCode]; ok {
 		s.mu.RUnlock()
@@ -46,8 +48,7 @@ func (s *FileStorage) Load(stockCode string) (*StockMemory, error) {
 	}
 	s.mu.RUnlock()
 
-	// 从文件加载
-	data, err := os.ReadFile(s.getPath(stockCode))
+	data, err := s.backend.Get(stockCode)
 	if err != nil {
 		return nil, err
 	}
@@ -66,16 +67,21 @@ func (s *FileStorage) Load(stockCode string) (*StockMemory, error) {
 }
 
 // Save 保存股票记忆
-func (s *FileStorage) Save(mem *StockMemory) error {
+func (s *StoreStorage) Save(mem *StockMemory) error {
+	return s.SaveSnapshot(mem, mem)
+}
+
+// SaveSnapshot 写入快照内容，缓存指向 mem
+func (s *StoreStorage) SaveSnapshot(mem, snapshot *StockMemory) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	data, err := json.MarshalIndent(mem, "", "  ")
+	data, err := json.MarshalIndent(snapshot, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	if err := os.WriteFile(s.getPath(mem.StockCode), data, 0644); err != nil {
+	if err := s.backend.Set(mem.StockCode, data); err != nil {
 		return err
 	}
 
@@ -84,33 +90,21 @@ func (s *FileStorage) Save(mem *StockMemory) error {
 }
 
 // Delete 删除股票记忆
-func (s *FileStorage) Delete(stockCode string) error {
+func (s *StoreStorage) Delete(stockCode string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	delete(s.cache, stockCode)
-	return os.Remove(s.getPath(stockCode))
+	return s.backend.Delete(stockCode)
 }
 
 // List 列出所有股票记忆
-func (s *FileStorage) List() ([]string, error) {
-	entries, err := os.ReadDir(s.dir)
-	if err != nil {
-		return nil, err
-	}
-
-	codes := make([]string, 0, len(entries))
-	for _, e := range entries {
-		if !e.IsDir() && filepath.Ext(e.Name()) == ".json" {
-			code := e.Name()[:len(e.Name())-5]
-			codes = append(codes, code)
-		}
-	}
-	return codes, nil
+func (s *StoreStorage) List() ([]string, error) {
+	return s.backend.List()
 }
 
 // Invalidate 清除缓存
-func (s *FileStorage) Invalidate(stockCode string) {
+func (s *StoreStorage) Invalidate(stockCode string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	delete(s.cache, stockCode)
@@ -40,6 +40,10 @@ type StockMemory struct {
 	KeyFacts     []MemoryEntry `json:"key_facts"`     // 关键事实
 	RecentRounds []RoundMemory `json:"recent_rounds"` // 最近几轮讨论
 	TotalRounds  int           `json:"total_rounds"`  // 总讨论轮次
+	// 每次会议的共识分时间序列
+	ConsensusHistory []ConsensusPoint `json:"consensus_history,omitempty"`
+	// 进行中会议的记忆草稿（每位专家发言后更新），会议正常结束时由正式轮次取代
+	Draft *RoundMemory `json:"draft,omitempty"`
 	CreatedAt    int64         `json:"created_at"`
 	UpdatedAt    int64         `json:"updated_at"`
 }
@@ -63,6 +67,7 @@ type Config struct {
 	MaxKeyFacts       int // 最大关键事实数，默认 20
 	MaxSummaryLength  int // 摘要最大字数，默认 300
 	CompressThreshold int // 触发压缩的轮次数，默认 5
+	MaxContextLength  int // 构建的上下文最大字数，默认 2000
 }
 
 // DefaultConfig 默认配置
@@ -72,5 +77,6 @@ func DefaultConfig() Config {
 		MaxKeyFacts:       20,
 		MaxSummaryLength:  300,
 		CompressThreshold: 5,
+		MaxContextLength:  2000,
 	}
 }
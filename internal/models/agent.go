@@ -13,5 +13,5 @@ type AgentConfig struct {
 	Priority    int      `json:"priority"`    // 显示优先级
 	IsBuiltin   bool     `json:"isBuiltin"`   // 是否内置Agent
 	Enabled     bool     `json:"enabled"`     // 是否全局启用
-	ProviderID  string   `json:"providerId"`  // 关联的Provider ID（空则使用默认）
+	ProviderID  string   `json:"providerId"`  // 关联的 AI 配置 ID，专家使用独立模型（空则使用默认）
 }
@@ -24,6 +24,8 @@ type AIConfig struct {
 	IsDefault   bool       `json:"isDefault"`
 	// OpenAI Responses API 开关
 	UseResponses bool `json:"useResponses"`
+	// 随机种子，非 0 时每次请求携带以便复现输出（OpenAI Chat/Gemini 支持，其他提供商忽略）
+	Seed int `json:"seed"`
 	// Vertex AI 专用字段
 	Project         string `json:"project"`
 	Location        string `json:"location"`
@@ -59,8 +61,112 @@ type AppConfig struct {
 	MCPServers  []MCPServerConfig `json:"mcpServers"` // MCP服务器配置列表
 	Memory      MemoryConfig      `json:"memory"`     // 记忆管理配置
 	Proxy       ProxyConfig       `json:"proxy"`      // 代理配置
+	// 盘口推送模式: auto(未选中时自动选第一只自选股), manual(仅推送显式订阅的股票)
+	OrderBookPush OrderBookPushMode `json:"orderBookPush"`
+	// 仅交易时段拉取行情：休市期间行情/盘口/涨跌统计复用最后一次缓存
+	MarketHoursOnly bool `json:"marketHoursOnly"`
+	// 启用的快讯来源ID: cls(财联社), sina(新浪财经), eastmoney(东方财富)，为空时全部启用
+	NewsSources []string `json:"newsSources"`
+	// 小韭菜意图分析 Prompt 模板，支持占位符 {{stock}} {{query}} {{agents}} {{user}}，为空使用内置模板
+	ModeratorPrompt string `json:"moderatorPrompt"`
+	// 专家发言间隔(毫秒)：智能模式串行专家之间等待，并行模式随机错开启动，0 表示不等待
+	AgentDelayMs int `json:"agentDelayMs"`
+	// 在会议记录中附带专家的工具调用及返回结果，便于核对数据来源
+	IncludeToolTrace bool `json:"includeToolTrace"`
+	// 小韭菜总结的生成参数，与专家使用的模型参数相互独立
+	SummaryParams SummaryParams `json:"summaryParams"`
+	// 全局工具：无论专家配置如何都会提供给每位专家的内置工具
+	GlobalTools []string `json:"globalTools"`
+	// 追问时注入专家上下文的最近会话消息条数上限，0 表示不注入会话历史
+	MaxContextMessages int `json:"maxContextMessages"`
+	// 小韭菜未选中任何专家时，按优先级兜底邀请的已启用专家数量，0 表示不兜底
+	FallbackAgentCount int `json:"fallbackAgentCount"`
+	// 智能模式专家发言前统一获取实时行情、技术分析和快讯并注入上下文，减少专家重复调用工具
+	PreflightGather bool `json:"preflightGather"`
+	// 会话与记忆的存储后端: file(默认) / sqlite，修改后重启生效
+	StorageBackend string `json:"storageBackend"`
+	// 各数据源请求超时，网络或代理较慢时可适当调大
+	Timeouts TimeoutConfig `json:"timeouts"`
+	// 各数据类型的数据源顺序及重试次数，某个数据源失败时按顺序切换，为空使用默认顺序
+	SourceChains SourceChainConfig `json:"sourceChains"`
+	// 快讯推送去重窗口(分钟)，窗口期内推送过的快讯不再重复推送
+	TelegraphDedupMinutes int `json:"telegraphDedupMinutes"`
+	// 会议结束时额外推送 meeting:complete 事件，附带全部发言、token 用量及是否超时截断
+	EmitMeetingComplete bool `json:"emitMeetingComplete"`
+	// 存档每场会议喂给专家的技术分析原文（data/meeting_analysis），便于事后核对，默认关闭避免占用磁盘
+	PersistMeetingAnalysis bool `json:"persistMeetingAnalysis"`
+	// 行情和大盘指数推送合并：价格无变化时跳过推送，每 30 秒强制同步一次，减少前端无效重渲染
+	CoalesceMarketPush bool `json:"coalesceMarketPush"`
+	// 按工具名配置结果缓存时长(秒)，如 get_company_profile=86400、get_stock_realtime=2；未配置的工具不缓存
+	ToolCacheTTL map[string]int `json:"toolCacheTTL"`
+	// 分析深度预设: quick(单专家不总结) / standard(默认) / deep(全部专家+会前预取)，为空等同 standard
+	AnalysisDepth AnalysisDepth `json:"analysisDepth"`
+	// 会议中对用户的称呼，为空时使用 DefaultUserName
+	UserName string `json:"userName"`
+	// 用户投资画像（如"稳健型投资者"），注入专家和总结 Prompt 以调整建议风格
+	UserPersona string `json:"userPersona"`
+	// AI 服务熔断：同一配置连续失败达到阈值后，冷却期内新会议直接提示不可用
+	CircuitBreaker CircuitBreakerConfig `json:"circuitBreaker"`
+	// 记录智能会议的问题、小韭菜选择的专家及结果摘要（data/meeting_decisions.jsonl），用于评估和调优专家选择 Prompt
+	MeetingDecisionLog bool `json:"meetingDecisionLog"`
+	// 离线模式：工具不再请求行情/资讯数据源，改用最近一次成功调用的结果（标注为离线/缓存数据），大模型调用仍需联网
+	OfflineMode bool `json:"offlineMode"`
+	// 配置版本，用于升级时迁移旧配置
+	ConfigVersion int `json:"configVersion"`
 }
 
+// SummaryParams 小韭菜总结生成参数，未设置时沿用模型默认值
+type SummaryParams struct {
+	Temperature *float64 `json:"temperature,omitempty"` // 温度，调低可让总结更贴近专家原意
+	MaxTokens   int      `json:"maxTokens"`             // 最大输出 token 数，0 表示不限制
+	Weighted    bool     `json:"weighted"`              // 按专家权重总结，突出与问题最相关的专家观点
+}
+
+// TimeoutConfig 各数据源请求超时(秒)，0 表示使用默认值
+type TimeoutConfig struct {
+	Quote      int `json:"quote"`      // 实时行情/盘口/指数
+	KLine      int `json:"kline"`      // K线
+	LongHuBang int `json:"longHuBang"` // 龙虎榜
+	Research   int `json:"research"`   // 研报
+	HotTrend   int `json:"hotTrend"`   // 舆情热点（单平台）
+}
+
+// SourceChainConfig 各数据类型的数据源顺序，为空使用默认顺序（列表中第一个为默认数据源）
+type SourceChainConfig struct {
+	Quote        []string `json:"quote"`        // 实时行情: sina / tencent
+	KLine        []string `json:"kline"`        // K线: sina / eastmoney
+	ExtendedInfo []string `json:"extendedInfo"` // 个股扩展信息: eastmoney / tencent
+	Breadth      []string `json:"breadth"`      // 涨跌家数: sina / eastmoney
+	Retries      int      `json:"retries"`      // 单个数据源失败后的重试次数(0-3)，0 表示直接切换
+}
+
+// CircuitBreakerConfig AI 服务熔断配置
+type CircuitBreakerConfig struct {
+	Threshold   int `json:"threshold"`   // 窗口内连续失败次数达到该值时熔断，0 表示关闭
+	WindowSec   int `json:"windowSec"`   // 连续失败的统计窗口(秒)，0 使用 300
+	CooldownSec int `json:"cooldownSec"` // 熔断冷却时长(秒)，之后放行一次试探，0 使用 60
+}
+
+// OrderBookPushMode 盘口推送模式
+type OrderBookPushMode string
+
+const (
+	OrderBookPushAuto   OrderBookPushMode = "auto"   // 未选中股票时自动选择自选股第一只
+	OrderBookPushManual OrderBookPushMode = "manual" // 仅推送显式订阅的股票，未订阅时暂停
+)
+
+// AnalysisDepth 分析深度预设，智能模式下打包专家数量、总结、会前预取等会议参数
+type AnalysisDepth string
+
+const (
+	AnalysisDepthQuick    AnalysisDepth = "quick"    // 快速：仅一位专家发言，不生成总结
+	AnalysisDepthStandard AnalysisDepth = "standard" // 标准：小韭菜选择专家并总结
+	AnalysisDepthDeep     AnalysisDepth = "deep"     // 深度：全部专家发言，会前预取公共数据并总结
+)
+
+// DefaultUserName 未配置称呼时会议中对用户的默认称呼
+const DefaultUserName = "老韭菜"
+
 // ProxyMode 代理模式
 type ProxyMode string
 
@@ -84,4 +190,6 @@ type MemoryConfig struct {
 	MaxKeyFacts       int    `json:"maxKeyFacts"`       // 最大关键事实数
 	MaxSummaryLength  int    `json:"maxSummaryLength"`  // 摘要最大字数
 	CompressThreshold int    `json:"compressThreshold"` // 触发压缩的轮次数
+	MaxContextLength  int    `json:"maxContextLength"`  // 记忆上下文最大字数（0 使用默认值）
+	Checkpoint        bool   `json:"checkpoint"`        // 每位专家发言后保存记忆草稿，会议中断时保留已发言观点
 }
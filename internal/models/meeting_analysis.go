@@ -0,0 +1,16 @@
+package models
+
+// MeetingAnalysis 会议中喂给专家的技术分析原文，用于事后核对 AI 看到的指标
+type MeetingAnalysis struct {
+	MeetingID string                 `json:"meetingId"` // 股票代码_会议开始时间，如 sh600519_20260105093000
+	StockCode string                 `json:"stockCode"`
+	Query     string                 `json:"query"`     // 用户提问
+	CreatedAt int64                  `json:"createdAt"` // 会议开始时间(毫秒)
+	Entries   []MeetingAnalysisEntry `json:"entries"`
+}
+
+// MeetingAnalysisEntry 一份技术分析原文及使用它的专家（内容相同的合并为一条）
+type MeetingAnalysisEntry struct {
+	Agents  []string `json:"agents"`  // 获取该数据的专家名称，会前预取为"会前预取"
+	Content string   `json:"content"` // FormatFullAnalysis 输出原文
+}
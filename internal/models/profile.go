@@ -0,0 +1,30 @@
+package models
+
+// Profile 分析方案：一组专家配置及会议设置，便于在短线、价值等不同策略间切换
+type Profile struct {
+	Name      string          `json:"name"`
+	Agents    []AgentConfig   `json:"agents"`    // 专家配置（对应 agents.json）
+	Settings  ProfileSettings `json:"settings"`  // 随方案切换的会议设置
+	UpdatedAt int64           `json:"updatedAt"` // 保存时间(毫秒)
+}
+
+// ProfileSettings 随方案切换的会议设置
+// AI 服务商、代理、记忆等全局配置不随方案切换
+type ProfileSettings struct {
+	ModeratorPrompt    string        `json:"moderatorPrompt"`
+	AgentDelayMs       int           `json:"agentDelayMs"`
+	IncludeToolTrace   bool          `json:"includeToolTrace"`
+	SummaryParams      SummaryParams `json:"summaryParams"`
+	GlobalTools        []string      `json:"globalTools"`
+	MaxContextMessages int           `json:"maxContextMessages"`
+	FallbackAgentCount int           `json:"fallbackAgentCount"`
+	PreflightGather    bool          `json:"preflightGather"`
+	AnalysisDepth      AnalysisDepth `json:"analysisDepth"`
+}
+
+// ProfileInfo 方案概要（列表展示用）
+type ProfileInfo struct {
+	Name       string `json:"name"`
+	AgentCount int    `json:"agentCount"`
+	UpdatedAt  int64  `json:"updatedAt"`
+}
@@ -28,5 +28,28 @@ type ChatMessage struct {
 	ReplyTo   string   `json:"replyTo,omitempty"`   // 引用的消息ID
 	Mentions  []string `json:"mentions,omitempty"`  // @的成员ID列表
 	Round     int      `json:"round,omitempty"`     // 讨论轮次
-	MsgType   string   `json:"msgType,omitempty"`   // 消息类型: opening/opinion/summary
+	MsgType   string   `json:"msgType,omitempty"`   // 消息类型: opening/opinion/summary/error
+	// 工具调用记录（开启"记录工具调用"时保存）
+	ToolTrace []ToolTrace `json:"toolTrace,omitempty"`
+}
+
+// ToolTrace 专家发言过程中的一次工具调用
+type ToolTrace struct {
+	Name   string `json:"name"`   // 工具名称
+	Args   string `json:"args"`   // 调用参数(JSON)
+	Result string `json:"result"` // 返回结果（超长截断）
+}
+
+// TokenUsage 模型调用的 token 用量
+type TokenUsage struct {
+	PromptTokens     int `json:"promptTokens"`     // 输入 token
+	CompletionTokens int `json:"completionTokens"` // 输出 token
+	TotalTokens      int `json:"totalTokens"`      // 合计
+}
+
+// Add 累加另一次调用的用量
+func (u *TokenUsage) Add(other TokenUsage) {
+	u.PromptTokens += other.PromptTokens
+	u.CompletionTokens += other.CompletionTokens
+	u.TotalTokens += other.TotalTokens
 }
@@ -15,6 +15,7 @@ type Stock struct {
 	High          float64 `json:"high"`
 	Low           float64 `json:"low"`
 	PreClose      float64 `json:"preClose"`
+	Suspended     bool    `json:"suspended"` // 是否停牌
 }
 
 // KLineData K线数据
@@ -0,0 +1,105 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// sqliteDriverNames 常见 SQLite 驱动注册名（modernc.org/sqlite 为 sqlite，mattn/go-sqlite3 为 sqlite3）
+var sqliteDriverNames = []string{"sqlite", "sqlite3"}
+
+// validTableName 表名只允许字母数字下划线，避免拼接 SQL 时注入
+var validTableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// OpenSQLite 打开 SQLite 数据库文件
+// 驱动由 sqlite_driver.go 注册，未注册时返回错误
+func OpenSQLite(path string) (*sql.DB, error) {
+	drivers := sql.Drivers()
+	for _, name := range sqliteDriverNames {
+		if slices.Contains(drivers, name) {
+			db, err := sql.Open(name, path)
+			if err != nil {
+				return nil, err
+			}
+			// SQLite 写操作串行执行，单连接避免 database is locked
+			db.SetMaxOpenConns(1)
+			return db, nil
+		}
+	}
+	return nil, errors.New("store: sqlite driver not registered")
+}
+
+// SQLiteStore SQLite 存储，同一数据库中按表区分不同类型的数据
+type SQLiteStore struct {
+	db    *sql.DB
+	table string
+}
+
+// NewSQLiteStore 创建 SQLite 存储，表不存在时自动创建
+func NewSQLiteStore(db *sql.DB, table string) (*SQLiteStore, error) {
+	if !validTableName.MatchString(table) {
+		return nil, fmt.Errorf("store: invalid table name %q", table)
+	}
+	_, err := db.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	key TEXT PRIMARY KEY,
+	value BLOB NOT NULL,
+	updated_at INTEGER NOT NULL
+)`, table))
+	if err != nil {
+		return nil, fmt.Errorf("store: create table %s: %w", table, err)
+	}
+	return &SQLiteStore{db: db, table: table}, nil
+}
+
+// Get 读取键对应的数据
+func (s *SQLiteStore) Get(key string) ([]byte, error) {
+	var value []byte
+	err := s.db.QueryRow(fmt.Sprintf("SELECT value FROM %s WHERE key = ?", s.table), key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return value, err
+}
+
+// Set 写入键对应的数据，已存在时覆盖
+func (s *SQLiteStore) Set(key string, value []byte) error {
+	_, err := s.db.Exec(fmt.Sprintf(`INSERT INTO %s (key, value, updated_at) VALUES (?, ?, ?)
+ON CONFLICT(key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at`, s.table),
+		key, value, time.Now().UnixMilli())
+	return err
+}
+
+// Delete 删除键
+func (s *SQLiteStore) Delete(key string) error {
+	res, err := s.db.Exec(fmt.Sprintf("DELETE FROM %s WHERE key = ?", s.table), key)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// List 按字典序列出全部键
+func (s *SQLiteStore) List() ([]string, error) {
+	rows, err := s.db.Query(fmt.Sprintf("SELECT key FROM %s ORDER BY key", s.table))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
@@ -0,0 +1,4 @@
+package store
+
+// 注册 SQLite 驱动（modernc.org/sqlite 为纯 Go 实现，不依赖 cgo，驱动名为 sqlite）
+import _ "modernc.org/sqlite"
@@ -0,0 +1,80 @@
+// Package store 提供会话、记忆等数据的键值持久化后端
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrNotFound 键不存在
+var ErrNotFound = errors.New("store: key not found")
+
+// Store 键值存储接口，值为调用方序列化后的数据（通常为 JSON）
+type Store interface {
+	Get(key string) ([]byte, error) // 键不存在时返回 ErrNotFound
+	Set(key string, value []byte) error
+	Delete(key string) error // 键不存在时返回 ErrNotFound
+	List() ([]string, error) // 按字典序返回全部键
+}
+
+// fileExt 文件存储的扩展名
+const fileExt = ".json"
+
+// FileStore 文件存储，每个键对应目录下的一个 JSON 文件
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore 创建文件存储，目录不存在时自动创建
+// 目录创建失败时仍返回可用的存储并附带错误，后续读写会再次报错
+func NewFileStore(dir string) (*FileStore, error) {
+	err := os.MkdirAll(dir, 0755)
+	return &FileStore{dir: dir}, err
+}
+
+// path 获取键对应的文件路径
+func (s *FileStore) path(key string) string {
+	return filepath.Join(s.dir, key+fileExt)
+}
+
+// Get 读取键对应的数据
+func (s *FileStore) Get(key string) ([]byte, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// Set 写入键对应的数据
+func (s *FileStore) Set(key string, value []byte) error {
+	return os.WriteFile(s.path(key), value, 0644)
+}
+
+// Delete 删除键对应的文件
+func (s *FileStore) Delete(key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// List 列出目录下全部键
+func (s *FileStore) List() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() && filepath.Ext(e.Name()) == fileExt {
+			keys = append(keys, strings.TrimSuffix(e.Name(), fileExt))
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
@@ -0,0 +1,97 @@
+package store
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// TestFileStore 测试文件存储的读写删除
+func TestFileStore(t *testing.T) {
+	s, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Get("sh600519"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("不存在的键应返回 ErrNotFound, got %v", err)
+	}
+
+	if err := s.Set("sz000001", []byte(`{"a":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("sh600519", []byte(`{"b":2}`)); err != nil {
+		t.Fatal(err)
+	}
+	data, err := s.Get("sh600519")
+	if err != nil || string(data) != `{"b":2}` {
+		t.Errorf("读取错误: %s %v", data, err)
+	}
+
+	keys, err := s.List()
+	if err != nil || len(keys) != 2 || keys[0] != "sh600519" {
+		t.Errorf("列表错误: %v %v", keys, err)
+	}
+
+	if err := s.Delete("sh600519"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete("sh600519"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("重复删除应返回 ErrNotFound, got %v", err)
+	}
+}
+
+// TestSQLiteStore 测试 SQLite 存储的读写删除及按表隔离
+func TestSQLiteStore(t *testing.T) {
+	db, err := OpenSQLite(filepath.Join(t.TempDir(), "jcp.db"))
+	if err != nil {
+		t.Fatalf("打开 SQLite 失败: %v", err)
+	}
+	defer db.Close()
+
+	s, err := NewSQLiteStore(db, "sessions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := NewSQLiteStore(db, "memories")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSQLiteStore(db, "bad;table"); err == nil {
+		t.Error("非法表名应返回错误")
+	}
+
+	if _, err := s.Get("sh600519"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("不存在的键应返回 ErrNotFound, got %v", err)
+	}
+
+	if err := s.Set("sz000001", []byte(`{"a":1}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Set("sh600519", []byte(`{"b":2}`)); err != nil {
+		t.Fatal(err)
+	}
+	// 已存在的键覆盖写入
+	if err := s.Set("sh600519", []byte(`{"b":3}`)); err != nil {
+		t.Fatal(err)
+	}
+	data, err := s.Get("sh600519")
+	if err != nil || string(data) != `{"b":3}` {
+		t.Errorf("读取错误: %s %v", data, err)
+	}
+
+	keys, err := s.List()
+	if err != nil || len(keys) != 2 || keys[0] != "sh600519" {
+		t.Errorf("列表错误: %v %v", keys, err)
+	}
+	if keys, err := other.List(); err != nil || len(keys) != 0 {
+		t.Errorf("不同表的数据应相互隔离: %v %v", keys, err)
+	}
+
+	if err := s.Delete("sh600519"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete("sh600519"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("重复删除应返回 ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/transform"
+)
+
+// 新浪行情接口（期货、期权共用）：nf_ 为中金所股指期货，hf_ 为外盘期货（富时A50夜盘可交易）
+const sinaQuoteListURL = "http://hq.sinajs.cn/rn=%d&list=%s"
+
+// IndexFuture 股指期货代码及对应现货指数
+type IndexFuture struct {
+	Code  string // 新浪行情代码
+	Name  string // 品种名称
+	Index string // 对应现货指数
+}
+
+// DefaultIndexFutures 盘后情绪关注的股指期货（主力连续）
+var DefaultIndexFutures = []IndexFuture{
+	{Code: "nf_IF0", Name: "IF主连", Index: "沪深300"},
+	{Code: "nf_IH0", Name: "IH主连", Index: "上证50"},
+	{Code: "nf_IC0", Name: "IC主连", Index: "中证500"},
+	{Code: "nf_IM0", Name: "IM主连", Index: "中证1000"},
+}
+
+// A50FutureCode 新富时中国A50指数期货（新加坡交易所，含夜盘）
+const A50FutureCode = "hf_CHA50CFD"
+
+// FuturesQuote 期货行情
+type FuturesQuote struct {
+	Code          string  `json:"code"`          // 行情代码
+	Name          string  `json:"name"`          // 名称
+	Price         float64 `json:"price"`         // 最新价
+	PrevSettle    float64 `json:"prevSettle"`    // 昨结算/昨收
+	ChangePercent float64 `json:"changePercent"` // 相对昨结算涨跌幅(%)
+	OpenInterest  float64 `json:"openInterest"`  // 持仓量
+	UpdateTime    string  `json:"updateTime"`    // 行情时间
+}
+
+// GetFuturesQuotes 获取股指期货及A50期货行情
+func (s *StockInfoService) GetFuturesQuotes(codes []string) (map[string]*FuturesQuote, error) {
+	if len(codes) == 0 {
+		return nil, nil
+	}
+	body, err := s.fetchSinaQuotes(codes)
+	if err != nil {
+		return nil, fmt.Errorf("获取期货行情失败: %w", err)
+	}
+	return parseSinaFutures(body), nil
+}
+
+// fetchSinaQuotes 批量获取新浪行情原始文本（GBK 转 UTF-8）
+func (s *StockInfoService) fetchSinaQuotes(codes []string) (string, error) {
+	url := fmt.Sprintf(sinaQuoteListURL, time.Now().UnixNano(), strings.Join(codes, ","))
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Referer", "http://finance.sina.com.cn")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	reader := transform.NewReader(resp.Body, simplifiedchinese.GBK.NewDecoder())
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// sinaQuoteFields 解析新浪行情单行，返回代码和逗号分隔的字段
+func sinaQuoteFields(line string) (string, []string, bool) {
+	line = strings.TrimSpace(line)
+	start := strings.Index(line, "hq_str_")
+	eq := strings.Index(line, "=\"")
+	if start < 0 || eq < 0 {
+		return "", nil, false
+	}
+	code := line[start+len("hq_str_") : eq]
+	data := strings.TrimSuffix(strings.TrimSuffix(line[eq+2:], ";"), "\"")
+	if data == "" {
+		return "", nil, false
+	}
+	return code, strings.Split(data, ","), true
+}
+
+// parseSinaFutures 解析新浪期货行情响应
+func parseSinaFutures(body string) map[string]*FuturesQuote {
+	result := make(map[string]*FuturesQuote)
+	for _, line := range strings.Split(body, "\n") {
+		code, fields, ok := sinaQuoteFields(line)
+		if !ok {
+			continue
+		}
+
+		var quote *FuturesQuote
+		if strings.HasPrefix(code, "hf_") {
+			quote = parseSinaGlobalFuture(fields)
+		} else {
+			quote = parseSinaCFFEXFuture(fields)
+		}
+		if quote == nil {
+			continue
+		}
+		quote.Code = code
+		if quote.PrevSettle > 0 {
+			quote.ChangePercent = (quote.Price - quote.PrevSettle) / quote.PrevSettle * 100
+		}
+		result[code] = quote
+	}
+	return result
+}
+
+// parseSinaCFFEXFuture 解析中金所股指期货
+// 字段: 0开盘 1最高 2最低 3最新 4成交量 5成交额 6持仓量 ... 9涨停价 10跌停价 ... 36日期 37时间 ... 末位名称
+// 股指期货涨跌停价按昨结算 ±10% 计算，取两者中点还原昨结算
+func parseSinaCFFEXFuture(fields []string) *FuturesQuote {
+	if len(fields) < 38 {
+		return nil
+	}
+	num := func(i int) float64 {
+		v, _ := strconv.ParseFloat(fields[i], 64)
+		return v
+	}
+	quote := &FuturesQuote{
+		Price:        num(3),
+		PrevSettle:   (num(9) + num(10)) / 2,
+		OpenInterest: num(6),
+		UpdateTime:   strings.TrimSpace(fields[36] + " " + fields[37]),
+	}
+	for i := len(fields) - 1; i >= 38; i-- {
+		if name := strings.TrimSpace(fields[i]); name != "" {
+			quote.Name = name
+			break
+		}
+	}
+	if quote.Price <= 0 {
+		return nil
+	}
+	return quote
+}
+
+// parseSinaGlobalFuture 解析外盘期货
+// 字段: 0最新 1- 2买价 3卖价 4最高 5最低 6时间 7昨结算 8开盘 9持仓量 10买量 11卖量 12日期 13名称
+func parseSinaGlobalFuture(fields []string) *FuturesQuote {
+	if len(fields) < 14 {
+		return nil
+	}
+	num := func(i int) float64 {
+		v, _ := strconv.ParseFloat(fields[i], 64)
+		return v
+	}
+	quote := &FuturesQuote{
+		Name:         fields[13],
+		Price:        num(0),
+		PrevSettle:   num(7),
+		OpenInterest: num(9),
+		UpdateTime:   strings.TrimSpace(fields[12] + " " + fields[6]),
+	}
+	if quote.Price <= 0 {
+		return nil
+	}
+	return quote
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"math"
+	"testing"
+)
+
+// TestParseSinaFutures 测试新浪股指期货及外盘期货行情解析
+func TestParseSinaFutures(t *testing.T) {
+	body := `var hq_str_nf_IF0="3919.400,3933.000,3902.600,3921.200,74143,87148851.360,148093.000,3921.200,0.000,4301.600,3519.400,0.000,0.000,3919.400,3910.200,133683.000,3921.000,1,0.000,0,0.000,0,0.000,0,0.000,0,3921.400,8,0.000,0,0.000,0,0.000,0,0.000,0,2024-10-11,15:00:00,100,1,,,,,,,,,3920.584,沪深300指数期货2412";
+var hq_str_hf_CHA50CFD="13250.00,,13248.00,13252.00,13300.00,13180.00,02:59:58,13200.00,13210.00,0,2,3,2024-10-12,富时中国A50指数,0";
+var hq_str_nf_IH0="";`
+
+	quotes := parseSinaFutures(body)
+	if len(quotes) != 2 {
+		t.Fatalf("解析结果数量 = %d, want 2", len(quotes))
+	}
+
+	ifq := quotes["nf_IF0"]
+	if ifq == nil {
+		t.Fatal("缺少 nf_IF0")
+	}
+	if ifq.Price != 3921.2 || math.Abs(ifq.PrevSettle-3910.5) > 1e-6 {
+		t.Errorf("IF 价格/昨结算 = %v/%v, want 3921.2/3910.5", ifq.Price, ifq.PrevSettle)
+	}
+	if ifq.Name != "沪深300指数期货2412" || ifq.UpdateTime != "2024-10-11 15:00:00" {
+		t.Errorf("IF 名称/时间 = %q/%q", ifq.Name, ifq.UpdateTime)
+	}
+
+	a50 := quotes["hf_CHA50CFD"]
+	if a50 == nil {
+		t.Fatal("缺少 hf_CHA50CFD")
+	}
+	if math.Abs(a50.ChangePercent-0.3788) > 1e-3 {
+		t.Errorf("A50 涨跌幅 = %.4f, want 0.3788", a50.ChangePercent)
+	}
+	if a50.UpdateTime != "2024-10-12 02:59:58" {
+		t.Errorf("A50 时间 = %q", a50.UpdateTime)
+	}
+}
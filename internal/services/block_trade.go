@@ -0,0 +1,153 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+)
+
+const (
+	// blockTradeMaxRecords 单次最多拉取的大宗交易笔数
+	blockTradeMaxRecords = 100
+	// blockTradeDriverMinRatio 游资买入额不低于卖出额该倍数才判定为吸筹（反之为出货）
+	blockTradeDriverMinRatio = 1.5
+)
+
+// 大宗交易游资动向
+const (
+	BlockTradeHotMoneyAccumulate = "游资折价吸筹"
+	BlockTradeHotMoneyPremium    = "游资溢价承接"
+	BlockTradeHotMoneyDistribute = "游资大宗出货"
+	BlockTradeHotMoneyMixed      = "游资买卖均衡"
+	BlockTradeHotMoneyNone       = "无游资参与"
+)
+
+// BlockTrade 单笔大宗交易
+type BlockTrade struct {
+	Date         string          `json:"date"`
+	Price        float64         `json:"price"`                  // 成交价
+	Close        float64         `json:"close"`                  // 当日收盘价
+	Premium      float64         `json:"premium"`                // 成交价相对收盘价的溢价率(%)，负数为折价
+	Volume       float64         `json:"volume"`                 // 成交量(股)
+	Amount       float64         `json:"amount"`                 // 成交额(元)
+	Buyer        string          `json:"buyer"`                  // 买方营业部
+	Seller       string          `json:"seller"`                 // 卖方营业部
+	BuyerTrader  *HotMoneyTrader `json:"buyerTrader,omitempty"`  // 买方识别到的知名游资/机构
+	SellerTrader *HotMoneyTrader `json:"sellerTrader,omitempty"` // 卖方识别到的知名游资/机构
+}
+
+// BlockTradeSummary 大宗交易游资动向汇总（机构席位单独统计，不计入游资）
+type BlockTradeSummary struct {
+	HotMoneyBuy     float64  `json:"hotMoneyBuy"`     // 游资席位买入额(元)
+	HotMoneySell    float64  `json:"hotMoneySell"`    // 游资席位卖出额(元)
+	HotMoneyPremium float64  `json:"hotMoneyPremium"` // 游资买入按成交额加权的平均溢价率(%)
+	InstBuy         float64  `json:"instBuy"`         // 机构席位买入额(元)
+	InstSell        float64  `json:"instSell"`        // 机构席位卖出额(元)
+	TotalAmount     float64  `json:"totalAmount"`     // 全部大宗交易成交额(元)
+	HotMoneySeats   []string `json:"hotMoneySeats"`   // 参与的知名游资
+	Verdict         string   `json:"verdict"`
+}
+
+// blockTradeAPIItem 东方财富大宗交易明细
+type blockTradeAPIItem struct {
+	TradeDate  string  `json:"TRADE_DATE"`
+	DealPrice  float64 `json:"DEAL_PRICE"`
+	ClosePrice float64 `json:"CLOSE_PRICE"`
+	DealVolume float64 `json:"DEAL_VOLUME"`
+	DealAmt    float64 `json:"DEAL_AMT"`
+	BuyerName  string  `json:"BUYER_NAME"`
+	SellerName string  `json:"SELLER_NAME"`
+}
+
+// GetBlockTrades 获取个股最近 days 个自然日的大宗交易明细（按日期降序），并标注买卖双方的知名游资
+func (s *LongHuBangService) GetBlockTrades(code string, days int) ([]BlockTrade, error) {
+	code = stripMarketPrefix(code)
+	since := time.Now().In(cnLocation).AddDate(0, 0, -days).Format("2006-01-02")
+
+	params := url.Values{}
+	params.Set("reportName", "RPT_DATA_BLOCKTRADE")
+	params.Set("columns", "TRADE_DATE,SECURITY_CODE,DEAL_PRICE,CLOSE_PRICE,DEAL_VOLUME,DEAL_AMT,BUYER_NAME,SELLER_NAME")
+	params.Set("sortColumns", "TRADE_DATE,DEAL_AMT")
+	params.Set("sortTypes", "-1,-1")
+	params.Set("pageSize", fmt.Sprintf("%d", blockTradeMaxRecords))
+	params.Set("pageNumber", "1")
+	params.Set("filter", fmt.Sprintf(`(SECURITY_CODE="%s")(TRADE_DATE>='%s')`, code, since))
+	params.Set("source", "WEB")
+	params.Set("client", "WEB")
+
+	var items []blockTradeAPIItem
+	if _, err := fetchDatacenter(s.client.get(), eastmoneyDatacenterURL+"?"+params.Encode(), &items); err != nil {
+		return nil, fmt.Errorf("获取大宗交易明细失败: %w", err)
+	}
+
+	trades := make([]BlockTrade, 0, len(items))
+	for _, it := range items {
+		trade := BlockTrade{
+			Date:         trimDate(it.TradeDate),
+			Price:        it.DealPrice,
+			Close:        it.ClosePrice,
+			Volume:       it.DealVolume,
+			Amount:       it.DealAmt,
+			Buyer:        it.BuyerName,
+			Seller:       it.SellerName,
+			BuyerTrader:  s.IdentifyHotMoney(it.BuyerName),
+			SellerTrader: s.IdentifyHotMoney(it.SellerName),
+		}
+		if it.ClosePrice > 0 {
+			trade.Premium = (it.DealPrice - it.ClosePrice) / it.ClosePrice * 100
+		}
+		trades = append(trades, trade)
+	}
+	return trades, nil
+}
+
+// SummarizeBlockTrades 汇总大宗交易中游资与机构席位的买卖额，判断游资是否在折价吸筹
+// 游资买入不低于卖出的 1.5 倍时，按加权溢价率区分折价吸筹与溢价承接；卖出不低于买入的 1.5 倍为出货
+func SummarizeBlockTrades(trades []BlockTrade) BlockTradeSummary {
+	var summary BlockTradeSummary
+	premiumAmt := 0.0
+	addSeat := func(label string) {
+		if !slices.Contains(summary.HotMoneySeats, label) {
+			summary.HotMoneySeats = append(summary.HotMoneySeats, label)
+		}
+	}
+
+	for _, t := range trades {
+		summary.TotalAmount += t.Amount
+		switch {
+		case t.BuyerTrader == nil:
+		case t.BuyerTrader.Style == "机构":
+			summary.InstBuy += t.Amount
+		default:
+			summary.HotMoneyBuy += t.Amount
+			premiumAmt += t.Premium * t.Amount
+			addSeat(t.BuyerTrader.Label)
+		}
+		switch {
+		case t.SellerTrader == nil:
+		case t.SellerTrader.Style == "机构":
+			summary.InstSell += t.Amount
+		default:
+			summary.HotMoneySell += t.Amount
+			addSeat(t.SellerTrader.Label)
+		}
+	}
+	if summary.HotMoneyBuy > 0 {
+		summary.HotMoneyPremium = premiumAmt / summary.HotMoneyBuy
+	}
+
+	switch {
+	case summary.HotMoneyBuy == 0 && summary.HotMoneySell == 0:
+		summary.Verdict = BlockTradeHotMoneyNone
+	case summary.HotMoneyBuy >= summary.HotMoneySell*blockTradeDriverMinRatio && summary.HotMoneyPremium < 0:
+		summary.Verdict = BlockTradeHotMoneyAccumulate
+	case summary.HotMoneyBuy >= summary.HotMoneySell*blockTradeDriverMinRatio:
+		summary.Verdict = BlockTradeHotMoneyPremium
+	case summary.HotMoneySell >= summary.HotMoneyBuy*blockTradeDriverMinRatio:
+		summary.Verdict = BlockTradeHotMoneyDistribute
+	default:
+		summary.Verdict = BlockTradeHotMoneyMixed
+	}
+	return summary
+}
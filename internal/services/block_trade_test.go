@@ -0,0 +1,49 @@
+package services
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSummarizeBlockTrades(t *testing.T) {
+	s := NewLongHuBangService()
+	zhang := s.IdentifyHotMoney("国泰君安证券股份有限公司上海江苏路证券营业部")
+	inst := s.IdentifyHotMoney("机构专用")
+	if zhang == nil || inst == nil {
+		t.Fatalf("游资席位数据缺失: zhang=%v inst=%v", zhang, inst)
+	}
+
+	trades := []BlockTrade{
+		{Premium: -8, Amount: 3e7, BuyerTrader: zhang},
+		{Premium: -4, Amount: 1e7, BuyerTrader: zhang, SellerTrader: inst},
+		{Premium: 0, Amount: 5e6, Buyer: "某证券某营业部"},
+	}
+	summary := SummarizeBlockTrades(trades)
+	if summary.Verdict != BlockTradeHotMoneyAccumulate {
+		t.Errorf("应判定为折价吸筹: %+v", summary)
+	}
+	if summary.HotMoneyBuy != 4e7 || summary.InstSell != 1e7 || summary.TotalAmount != 4.5e7 {
+		t.Errorf("买卖额汇总错误: %+v", summary)
+	}
+	if math.Abs(summary.HotMoneyPremium-(-7)) > 1e-9 {
+		t.Errorf("加权溢价率 = %.4f, want -7", summary.HotMoneyPremium)
+	}
+	if len(summary.HotMoneySeats) != 1 || summary.HotMoneySeats[0] != zhang.Label {
+		t.Errorf("游资席位去重错误: %v", summary.HotMoneySeats)
+	}
+
+	summary = SummarizeBlockTrades([]BlockTrade{{Premium: -5, Amount: 2e7, SellerTrader: zhang}})
+	if summary.Verdict != BlockTradeHotMoneyDistribute {
+		t.Errorf("应判定为出货: %+v", summary)
+	}
+
+	summary = SummarizeBlockTrades([]BlockTrade{{Premium: 2, Amount: 2e7, BuyerTrader: zhang}})
+	if summary.Verdict != BlockTradeHotMoneyPremium {
+		t.Errorf("应判定为溢价承接: %+v", summary)
+	}
+
+	summary = SummarizeBlockTrades([]BlockTrade{{Premium: -5, Amount: 2e7, BuyerTrader: inst}})
+	if summary.Verdict != BlockTradeHotMoneyNone || summary.InstBuy != 2e7 {
+		t.Errorf("仅机构参与应判定为无游资: %+v", summary)
+	}
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// breadthHistoryFile 每日涨跌家数快照文件
+	breadthHistoryFile = "breadth_history.json"
+	// breadthHistoryMaxDays 保留的最大交易日数
+	breadthHistoryMaxDays = 250
+	// breadthRecordInterval 收盘快照检查间隔
+	breadthRecordInterval = 10 * time.Minute
+	// breadthCloseMinutes 收盘后记录终值的时间（15:05）
+	breadthCloseMinutes = 15*60 + 5
+)
+
+// BreadthDay 单个交易日的涨跌家数
+type BreadthDay struct {
+	Date    string `json:"date"`
+	Advance int    `json:"advance"`
+	Decline int    `json:"decline"`
+	Flat    int    `json:"flat"`
+	Final   bool   `json:"final"` // 是否为收盘后的终值，盘中快照为 false
+}
+
+// EnableHistory 开启每日涨跌家数积累，历史保存在 dataDir 下
+// statusFn 用于判断交易日，新浪接口只提供当日统计，历史只能按日积累
+func (s *MarketBreadthService) EnableHistory(dataDir string, statusFn func() MarketStatus) {
+	s.historyMu.Lock()
+	defer s.historyMu.Unlock()
+	s.historyPath = filepath.Join(dataDir, breadthHistoryFile)
+	s.statusFn = statusFn
+	if data, err := os.ReadFile(s.historyPath); err == nil {
+		if err := json.Unmarshal(data, &s.history); err != nil {
+			log.Warn("解析涨跌家数历史失败: %v", err)
+		}
+	}
+}
+
+// StartHistoryRecorder 后台定时在收盘后记录当日涨跌家数终值，ctx 取消时退出
+func (s *MarketBreadthService) StartHistoryRecorder(ctx context.Context) {
+	if s.historyPath == "" {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(breadthRecordInterval)
+		defer ticker.Stop()
+		for {
+			s.recordClose(time.Now().In(cnLocation))
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// GetBreadthHistory 返回最近 days 个交易日的涨跌家数（按日期升序）
+func (s *MarketBreadthService) GetBreadthHistory(days int) []BreadthDay {
+	s.historyMu.Lock()
+	defer s.historyMu.Unlock()
+	start := 0
+	if days > 0 && len(s.history) > days {
+		start = len(s.history) - days
+	}
+	return append([]BreadthDay(nil), s.history[start:]...)
+}
+
+// recordClose 交易日收盘后若当日尚无终值则拉取一次
+func (s *MarketBreadthService) recordClose(now time.Time) {
+	if now.Hour()*60+now.Minute() < breadthCloseMinutes || !s.isTradeDay(now) {
+		return
+	}
+	date := now.Format("2006-01-02")
+	s.historyMu.Lock()
+	n := len(s.history)
+	done := n > 0 && s.history[n-1].Date == date && s.history[n-1].Final
+	s.historyMu.Unlock()
+	if done {
+		return
+	}
+
+	data, err := s.fetchMarketBreadth()
+	if err != nil {
+		log.Warn("记录收盘涨跌家数失败: %v", err)
+		return
+	}
+	s.recordSnapshot(data, now, true)
+}
+
+// recordSnapshot 记录当日涨跌家数，同一日期覆盖，终值不被盘中快照覆盖
+func (s *MarketBreadthService) recordSnapshot(b *MarketBreadth, now time.Time, final bool) {
+	if s.historyPath == "" || b == nil || b.TotalCount == 0 {
+		return
+	}
+	// 开盘前的统计仍为上一交易日数据
+	if now.Hour()*60+now.Minute() < 9*60+30 || !s.isTradeDay(now) {
+		return
+	}
+
+	day := BreadthDay{
+		Date:    now.Format("2006-01-02"),
+		Advance: b.AdvanceCount,
+		Decline: b.DeclineCount,
+		Flat:    b.FlatCount,
+		Final:   final,
+	}
+
+	s.historyMu.Lock()
+	defer s.historyMu.Unlock()
+	var changed bool
+	s.history, changed = upsertBreadthDay(s.history, day, breadthHistoryMaxDays)
+	if !changed {
+		return
+	}
+	data, err := json.Marshal(s.history)
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(s.historyPath, data, 0644); err != nil {
+		log.Warn("保存涨跌家数历史失败: %v", err)
+	}
+}
+
+// isTradeDay 判断是否为交易日，未设置状态函数时仅排除周末
+func (s *MarketBreadthService) isTradeDay(now time.Time) bool {
+	if s.statusFn != nil {
+		return s.statusFn().IsTradeDay
+	}
+	return now.Weekday() != time.Saturday && now.Weekday() != time.Sunday
+}
+
+// upsertBreadthDay 按日期插入或覆盖当日记录并保留最近 maxDays 天，返回是否有变化
+func upsertBreadthDay(history []BreadthDay, day BreadthDay, maxDays int) ([]BreadthDay, bool) {
+	n := len(history)
+	if n > 0 && history[n-1].Date == day.Date {
+		last := history[n-1]
+		if last.Final && !day.Final || last == day {
+			return history, false
+		}
+		history[n-1] = day
+		return history, true
+	}
+	if n > 0 && history[n-1].Date > day.Date {
+		return history, false
+	}
+	history = append(history, day)
+	if maxDays > 0 && len(history) > maxDays {
+		history = history[len(history)-maxDays:]
+	}
+	return history, true
+}
+
+// AdvanceDeclineLine 计算腾落线（ADL）：逐日累加上涨家数减下跌家数
+func AdvanceDeclineLine(days []BreadthDay) []int {
+	line := make([]int, len(days))
+	sum := 0
+	for i, d := range days {
+		sum += d.Advance - d.Decline
+		line[i] = sum
+	}
+	return line
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestUpsertBreadthDay 测试同日覆盖、终值保护及保留天数
+func TestUpsertBreadthDay(t *testing.T) {
+	var h []BreadthDay
+	var changed bool
+
+	h, changed = upsertBreadthDay(h, BreadthDay{Date: "2024-10-14", Advance: 3000, Decline: 2000}, 2)
+	if !changed || len(h) != 1 {
+		t.Fatalf("首条记录应写入: %+v", h)
+	}
+	// 盘中快照同日覆盖
+	h, changed = upsertBreadthDay(h, BreadthDay{Date: "2024-10-14", Advance: 3100, Decline: 1900}, 2)
+	if !changed || h[0].Advance != 3100 {
+		t.Errorf("盘中快照应覆盖: %+v", h)
+	}
+	h, _ = upsertBreadthDay(h, BreadthDay{Date: "2024-10-14", Advance: 3200, Decline: 1800, Final: true}, 2)
+	// 终值不被盘中快照覆盖
+	h, changed = upsertBreadthDay(h, BreadthDay{Date: "2024-10-14", Advance: 100, Decline: 100}, 2)
+	if changed || h[0].Advance != 3200 {
+		t.Errorf("终值不应被盘中快照覆盖: %+v", h)
+	}
+	// 旧日期不插入
+	if _, changed = upsertBreadthDay(h, BreadthDay{Date: "2024-10-11"}, 2); changed {
+		t.Error("早于最新记录的日期不应写入")
+	}
+
+	h, _ = upsertBreadthDay(h, BreadthDay{Date: "2024-10-15"}, 2)
+	h, _ = upsertBreadthDay(h, BreadthDay{Date: "2024-10-16"}, 2)
+	if len(h) != 2 || h[0].Date != "2024-10-15" {
+		t.Errorf("应只保留最近2天: %+v", h)
+	}
+}
+
+// TestAdvanceDeclineLine 测试腾落线逐日累加
+func TestAdvanceDeclineLine(t *testing.T) {
+	days := []BreadthDay{
+		{Advance: 3000, Decline: 2000},
+		{Advance: 1500, Decline: 3500},
+		{Advance: 2600, Decline: 2400},
+	}
+	want := []int{1000, -1000, -800}
+	if got := AdvanceDeclineLine(days); !reflect.DeepEqual(got, want) {
+		t.Errorf("AdvanceDeclineLine = %v, want %v", got, want)
+	}
+}
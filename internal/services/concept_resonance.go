@@ -0,0 +1,208 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// 东方财富 F10 数据中心接口（核心题材等个股资料）
+	eastmoneyF10DatacenterURL = "https://datacenter.eastmoney.com/securities/api/data/v1/get"
+	// 东方财富 Push2 API：批量查询板块行情
+	// f3 涨跌幅, f62 主力净流入(元), f104 上涨家数, f105 下跌家数, f128 领涨股, f136 领涨股涨跌幅
+	eastmoneyBoardQuoteURL = "https://push2.eastmoney.com/api/qt/ulist.np/get?fltt=2&invt=2&fields=f3,f12,f14,f62,f104,f105,f128,f136&secids=%s"
+	// conceptMaxBoards 单只股票最多查询的板块数
+	conceptMaxBoards = 60
+)
+
+// ConceptBoard 个股所属概念/题材板块及当日表现
+type ConceptBoard struct {
+	Code          string  `json:"code"`          // 板块代码，如 BK0477
+	Name          string  `json:"name"`          // 板块名称
+	Reason        string  `json:"reason"`        // 入选理由（F10 核心题材）
+	Precise       bool    `json:"precise"`       // 是否为精准匹配的题材
+	ChangePercent float64 `json:"changePercent"` // 板块当日涨跌幅(%)
+	MainNetInflow float64 `json:"mainNetInflow"` // 板块当日主力净流入(元)
+	UpCount       int     `json:"upCount"`       // 板块上涨家数
+	DownCount     int     `json:"downCount"`     // 板块下跌家数
+	Leader        string  `json:"leader"`        // 领涨股名称
+	LeaderChange  float64 `json:"leaderChange"`  // 领涨股涨跌幅(%)
+	HasQuote      bool    `json:"hasQuote"`      // 是否取到板块行情
+}
+
+// conceptMembershipCache 个股所属板块缓存（题材归属变动较少）
+type conceptMembershipCache struct {
+	data      []ConceptBoard
+	timestamp time.Time
+}
+
+// coreThemeBoardItem 东方财富 F10 核心题材所属板块
+type coreThemeBoardItem struct {
+	BoardCode string `json:"NEW_BOARD_CODE"`
+	BoardName string `json:"BOARD_NAME"`
+	Reason    string `json:"SELECTED_BOARD_REASON"`
+	IsPrecise any    `json:"IS_PRECISE"`
+	BoardRank int    `json:"BOARD_RANK"`
+}
+
+// GetStockConcepts 获取个股所属概念/题材板块及各板块当日涨跌幅，按涨跌幅降序
+// 板块归属按代码缓存1天，板块行情每次实时获取；行情获取失败时仍返回板块列表（HasQuote 为 false）
+func (s *SectorService) GetStockConcepts(code string) ([]ConceptBoard, error) {
+	code = stripMarketPrefix(code)
+
+	s.conceptCacheMu.RLock()
+	cached, ok := s.conceptCache[code]
+	s.conceptCacheMu.RUnlock()
+
+	var boards []ConceptBoard
+	if ok && time.Since(cached.timestamp) < s.conceptCacheTTL {
+		boards = append([]ConceptBoard(nil), cached.data...)
+	} else {
+		fetched, err := s.fetchConceptBoards(code)
+		if err != nil {
+			return nil, err
+		}
+		s.conceptCacheMu.Lock()
+		s.conceptCache[code] = &conceptMembershipCache{data: fetched, timestamp: time.Now()}
+		s.conceptCacheMu.Unlock()
+		boards = append([]ConceptBoard(nil), fetched...)
+	}
+	if len(boards) == 0 {
+		return boards, nil
+	}
+
+	quotes, err := s.fetchBoardQuotes(boards)
+	if err != nil {
+		log.Warn("获取板块行情失败: %v", err)
+		return boards, nil
+	}
+	for i := range boards {
+		if q, ok := quotes[boards[i].Code]; ok {
+			q.Name, q.Reason, q.Precise = boards[i].Name, boards[i].Reason, boards[i].Precise
+			boards[i] = q
+		}
+	}
+	SortConceptBoards(boards)
+	return boards, nil
+}
+
+// fetchConceptBoards 从东方财富 F10 核心题材获取个股所属板块
+func (s *SectorService) fetchConceptBoards(code string) ([]ConceptBoard, error) {
+	params := url.Values{}
+	params.Set("reportName", "RPT_F10_CORETHEME_BOARDTYPE")
+	params.Set("columns", "SECURITY_CODE,NEW_BOARD_CODE,BOARD_NAME,SELECTED_BOARD_REASON,IS_PRECISE,BOARD_RANK")
+	params.Set("sortColumns", "BOARD_RANK")
+	params.Set("sortTypes", "1")
+	params.Set("pageSize", fmt.Sprintf("%d", conceptMaxBoards))
+	params.Set("pageNumber", "1")
+	params.Set("filter", fmt.Sprintf(`(SECURITY_CODE="%s")`, code))
+	params.Set("source", "HSF10")
+	params.Set("client", "PC")
+
+	var items []coreThemeBoardItem
+	if _, err := fetchDatacenter(s.client, eastmoneyF10DatacenterURL+"?"+params.Encode(), &items); err != nil {
+		return nil, fmt.Errorf("获取所属题材失败: %w", err)
+	}
+
+	boards := make([]ConceptBoard, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		boardCode := strings.TrimSpace(item.BoardCode)
+		if boardCode == "" || seen[boardCode] {
+			continue
+		}
+		seen[boardCode] = true
+		if !strings.HasPrefix(boardCode, "BK") {
+			boardCode = "BK" + boardCode
+		}
+		boards = append(boards, ConceptBoard{
+			Code:    boardCode,
+			Name:    item.BoardName,
+			Reason:  strings.TrimSpace(item.Reason),
+			Precise: anyToFloat(item.IsPrecise) == 1,
+		})
+	}
+	return boards, nil
+}
+
+// fetchBoardQuotes 批量获取板块当日行情，按板块代码索引
+func (s *SectorService) fetchBoardQuotes(boards []ConceptBoard) (map[string]ConceptBoard, error) {
+	secids := make([]string, 0, len(boards))
+	for _, b := range boards {
+		secids = append(secids, "90."+b.Code)
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf(eastmoneyBoardQuoteURL, strings.Join(secids, ",")), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Referer", "https://quote.eastmoney.com/")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseBoardQuotes(body)
+}
+
+// parseBoardQuotes 解析板块行情，停牌或无行情字段（"-"）按 0 处理
+func parseBoardQuotes(body []byte) (map[string]ConceptBoard, error) {
+	var result struct {
+		Data *struct {
+			Diff []struct {
+				F3   any    `json:"f3"`
+				F12  string `json:"f12"`
+				F14  string `json:"f14"`
+				F62  any    `json:"f62"`
+				F104 any    `json:"f104"`
+				F105 any    `json:"f105"`
+				F128 string `json:"f128"`
+				F136 any    `json:"f136"`
+			} `json:"diff"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("parse board quote error: %w, body: %s", err, truncateBytes(body, 200))
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("board quote empty")
+	}
+
+	quotes := make(map[string]ConceptBoard, len(result.Data.Diff))
+	for _, d := range result.Data.Diff {
+		quotes[d.F12] = ConceptBoard{
+			Code:          d.F12,
+			Name:          d.F14,
+			ChangePercent: anyToFloat(d.F3),
+			MainNetInflow: anyToFloat(d.F62),
+			UpCount:       int(anyToFloat(d.F104)),
+			DownCount:     int(anyToFloat(d.F105)),
+			Leader:        strings.TrimSpace(d.F128),
+			LeaderChange:  anyToFloat(d.F136),
+			HasQuote:      true,
+		}
+	}
+	return quotes, nil
+}
+
+// SortConceptBoards 按当日涨跌幅降序排列，无行情的板块排在最后
+func SortConceptBoards(boards []ConceptBoard) {
+	sort.SliceStable(boards, func(i, j int) bool {
+		if boards[i].HasQuote != boards[j].HasQuote {
+			return boards[i].HasQuote
+		}
+		return boards[i].ChangePercent > boards[j].ChangePercent
+	})
+}
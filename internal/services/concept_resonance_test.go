@@ -0,0 +1,37 @@
+package services
+
+import "testing"
+
+// TestParseBoardQuotes 测试板块行情解析及排序
+func TestParseBoardQuotes(t *testing.T) {
+	body := []byte(`{"data":{"total":3,"diff":[
+		{"f3":1.25,"f12":"BK0477","f14":"白酒","f62":-123456789.0,"f104":12,"f105":8,"f128":"贵州茅台","f136":3.1},
+		{"f3":4.88,"f12":"BK1036","f14":"半导体","f62":987654321.0,"f104":90,"f105":5,"f128":"中芯国际","f136":10.01},
+		{"f3":"-","f12":"BK0999","f14":"停更板块","f62":"-","f104":"-","f105":"-","f128":"-","f136":"-"}
+	]}}`)
+
+	quotes, err := parseBoardQuotes(body)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if len(quotes) != 3 {
+		t.Fatalf("板块数错误: %d", len(quotes))
+	}
+	semi := quotes["BK1036"]
+	if semi.ChangePercent != 4.88 || semi.MainNetInflow != 987654321 || semi.UpCount != 90 || semi.Leader != "中芯国际" || !semi.HasQuote {
+		t.Errorf("半导体板块解析错误: %+v", semi)
+	}
+	if stale := quotes["BK0999"]; stale.ChangePercent != 0 || stale.UpCount != 0 {
+		t.Errorf("无行情字段应按 0 处理: %+v", stale)
+	}
+
+	boards := []ConceptBoard{{Code: "BK0001", Name: "无行情"}, quotes["BK0477"], quotes["BK1036"]}
+	SortConceptBoards(boards)
+	if boards[0].Code != "BK1036" || boards[1].Code != "BK0477" || boards[2].Code != "BK0001" {
+		t.Errorf("应按涨跌幅降序且无行情排最后: %+v", boards)
+	}
+
+	if _, err := parseBoardQuotes([]byte(`{"data":null}`)); err == nil {
+		t.Error("空数据应返回错误")
+	}
+}
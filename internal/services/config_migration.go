@@ -0,0 +1,113 @@
+package services
+
+import (
+	"github.com/run-bigpig/jcp/internal/logger"
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+var configLog = logger.New("config")
+
+// CurrentConfigVersion 当前配置版本，新增需要迁移的配置项时递增并追加迁移步骤
+const CurrentConfigVersion = 5
+
+// configMigration 配置迁移步骤，将配置从 Version-1 升级到 Version
+type configMigration struct {
+	Version     int
+	Description string
+	Apply       func(config *models.AppConfig)
+}
+
+// configMigrations 按版本升序排列的迁移步骤
+var configMigrations = []configMigration{
+	{
+		Version:     1,
+		Description: "补全旧版本缺失的配置项默认值",
+		Apply: func(config *models.AppConfig) {
+			defaults := defaultAppConfig()
+			if config.Theme == "" {
+				config.Theme = defaults.Theme
+			}
+			if config.OrderBookPush == "" {
+				config.OrderBookPush = defaults.OrderBookPush
+			}
+			if config.NewsSources == nil {
+				config.NewsSources = defaults.NewsSources
+			}
+			if config.GlobalTools == nil {
+				config.GlobalTools = defaults.GlobalTools
+			}
+			// 早期版本没有记忆配置，零值会导致记忆功能被关闭
+			if config.Memory == (models.MemoryConfig{}) {
+				config.Memory = defaults.Memory
+			}
+		},
+	},
+	{
+		Version:     2,
+		Description: "新增会话历史注入条数上限",
+		Apply: func(config *models.AppConfig) {
+			if config.MaxContextMessages == 0 {
+				config.MaxContextMessages = DefaultMaxContextMessages
+			}
+		},
+	},
+	{
+		Version:     3,
+		Description: "新增小韭菜未选专家时的兜底专家数量",
+		Apply: func(config *models.AppConfig) {
+			if config.FallbackAgentCount == 0 {
+				config.FallbackAgentCount = DefaultFallbackAgentCount
+			}
+		},
+	},
+	{
+		Version:     4,
+		Description: "新增各数据源请求超时",
+		Apply: func(config *models.AppConfig) {
+			defaults := DefaultTimeouts()
+			t := &config.Timeouts
+			if t.Quote == 0 {
+				t.Quote = defaults.Quote
+			}
+			if t.KLine == 0 {
+				t.KLine = defaults.KLine
+			}
+			if t.LongHuBang == 0 {
+				t.LongHuBang = defaults.LongHuBang
+			}
+			if t.Research == 0 {
+				t.Research = defaults.Research
+			}
+			if t.HotTrend == 0 {
+				t.HotTrend = defaults.HotTrend
+			}
+		},
+	},
+	{
+		Version:     5,
+		Description: "新增快讯推送去重窗口",
+		Apply: func(config *models.AppConfig) {
+			if config.TelegraphDedupMinutes == 0 {
+				config.TelegraphDedupMinutes = DefaultTelegraphDedupMinutes
+			}
+		},
+	},
+}
+
+// migrateConfig 将配置升级到当前版本，返回是否发生了迁移
+func migrateConfig(config *models.AppConfig) bool {
+	if config.ConfigVersion >= CurrentConfigVersion {
+		return false
+	}
+	from := config.ConfigVersion
+	for _, m := range configMigrations {
+		if m.Version <= config.ConfigVersion {
+			continue
+		}
+		m.Apply(config)
+		config.ConfigVersion = m.Version
+		configLog.Info("config migrated to v%d: %s", m.Version, m.Description)
+	}
+	configLog.Info("config upgraded from v%d to v%d", from, config.ConfigVersion)
+	return true
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestMigrateConfig 测试旧版本配置迁移
+func TestMigrateConfig(t *testing.T) {
+	old := &models.AppConfig{Theme: "ocean", NewsSources: []string{NewsSourceCLS}}
+	if !migrateConfig(old) {
+		t.Fatal("旧配置应发生迁移")
+	}
+	if old.ConfigVersion != CurrentConfigVersion {
+		t.Errorf("版本未升级: %d", old.ConfigVersion)
+	}
+	if old.Theme != "ocean" || len(old.NewsSources) != 1 {
+		t.Error("迁移不应覆盖用户已有配置")
+	}
+	if !old.Memory.Enabled || old.OrderBookPush != models.OrderBookPushAuto || len(old.GlobalTools) == 0 {
+		t.Errorf("缺失项未补全默认值: %+v", old)
+	}
+	if old.MaxContextMessages != DefaultMaxContextMessages || old.FallbackAgentCount != DefaultFallbackAgentCount || old.Timeouts != DefaultTimeouts() || old.TelegraphDedupMinutes != DefaultTelegraphDedupMinutes {
+		t.Errorf("新增配置项未补全: %+v", old)
+	}
+
+	// v1 配置只执行后续迁移，用户设置的值保持不变
+	v1 := &models.AppConfig{ConfigVersion: 1, MaxContextMessages: 4}
+	if !migrateConfig(v1) || v1.MaxContextMessages != 4 || v1.Theme != "" {
+		t.Errorf("v1 配置迁移错误: %+v", v1)
+	}
+
+	// 用户清空的全局工具保持为空
+	current := &models.AppConfig{ConfigVersion: CurrentConfigVersion, GlobalTools: []string{}}
+	if migrateConfig(current) || len(current.GlobalTools) != 0 {
+		t.Error("当前版本配置不应迁移")
+	}
+}
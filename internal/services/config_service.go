@@ -60,25 +60,69 @@ func (cs *ConfigService) loadConfig() error {
 		return err
 	}
 	cs.config = &config
+
+	// 旧版本配置升级后立即保存，避免每次启动重复迁移
+	if migrateConfig(cs.config) {
+		return cs.saveConfigLocked()
+	}
 	return nil
 }
 
 // defaultConfig 默认配置
 func (cs *ConfigService) defaultConfig() *models.AppConfig {
+	return defaultAppConfig()
+}
+
+// defaultAppConfig 创建默认配置，新建配置直接使用当前版本
+func defaultAppConfig() *models.AppConfig {
 	return &models.AppConfig{
-		Theme:       "military",
-		AIConfigs:   []models.AIConfig{},
-		DefaultAIID: "",
+		ConfigVersion: CurrentConfigVersion,
+		Theme:         "military",
+		AIConfigs:     []models.AIConfig{},
+		DefaultAIID:   "",
 		Memory: models.MemoryConfig{
 			Enabled:           true,
 			MaxRecentRounds:   3,
 			MaxKeyFacts:       20,
 			MaxSummaryLength:  300,
 			CompressThreshold: 5,
+			MaxContextLength:  2000,
 		},
+		OrderBookPush:         models.OrderBookPushAuto,
+		NewsSources:           []string{NewsSourceCLS, NewsSourceSina, NewsSourceEastmoney},
+		GlobalTools:           DefaultGlobalTools(),
+		MaxContextMessages:    DefaultMaxContextMessages,
+		FallbackAgentCount:    DefaultFallbackAgentCount,
+		Timeouts:              DefaultTimeouts(),
+		TelegraphDedupMinutes: DefaultTelegraphDedupMinutes,
 	}
 }
 
+// DefaultMaxContextMessages 默认注入专家上下文的最近会话消息条数
+const DefaultMaxContextMessages = 10
+
+// DefaultFallbackAgentCount 小韭菜未选中专家时默认兜底邀请的专家数量
+const DefaultFallbackAgentCount = 2
+
+// DefaultTelegraphDedupMinutes 快讯推送默认去重窗口(分钟)
+const DefaultTelegraphDedupMinutes = 60
+
+// DefaultTimeouts 各数据源默认请求超时(秒)
+func DefaultTimeouts() models.TimeoutConfig {
+	return models.TimeoutConfig{
+		Quote:      10,
+		KLine:      10,
+		LongHuBang: 15,
+		Research:   15,
+		HotTrend:   8,
+	}
+}
+
+// DefaultGlobalTools 默认对所有专家开放的基础工具
+func DefaultGlobalTools() []string {
+	return []string{"get_stock_realtime"}
+}
+
 // saveConfigLocked 保存配置(需要已持有锁)
 func (cs *ConfigService) saveConfigLocked() error {
 	data, err := json.MarshalIndent(cs.config, "", "  ")
@@ -99,6 +143,8 @@ func (cs *ConfigService) GetConfig() *models.AppConfig {
 func (cs *ConfigService) UpdateConfig(config *models.AppConfig) error {
 	cs.mu.Lock()
 	defer cs.mu.Unlock()
+	// 前端提交的是当前版本的完整配置
+	config.ConfigVersion = CurrentConfigVersion
 	cs.config = config
 	return cs.saveConfigLocked()
 }
@@ -268,6 +314,118 @@ func (cs *ConfigService) SearchStocks(keyword string, limit int) []StockSearchRe
 	return results
 }
 
+// GetStocksByIndustry 获取指定行业的全部股票
+// industry: stock_basic.json 中的行业名称，需完全匹配
+func (cs *ConfigService) GetStocksByIndustry(industry string) []StockSearchResult {
+	if industry == "" {
+		return []StockSearchResult{}
+	}
+
+	var results []StockSearchResult
+	for _, row := range industryStockRows() {
+		if row.Industry == industry {
+			results = append(results, row)
+		}
+	}
+	return results
+}
+
+// SearchStocksByIndustry 按行业搜索股票
+// 完全匹配的行业优先，其次为包含关键词的行业（如"医"可匹配"医疗保健"、"医药商业"）
+// 缺少行业字段的数据行会被跳过；limit <= 0 时返回全部结果
+func (cs *ConfigService) SearchStocksByIndustry(industry string, limit int) []StockSearchResult {
+	industry = strings.TrimSpace(industry)
+	if industry == "" {
+		return []StockSearchResult{}
+	}
+
+	var exact, partial []StockSearchResult
+	for _, row := range industryStockRows() {
+		if row.Industry == industry {
+			exact = append(exact, row)
+		} else if strings.Contains(row.Industry, industry) {
+			partial = append(partial, row)
+		}
+	}
+
+	results := append(exact, partial...)
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	if results == nil {
+		return []StockSearchResult{}
+	}
+	return results
+}
+
+// industryStockRows 解析 stock_basic.json 中带行业字段的全部数据行
+// 缺少代码、名称或行业字段的数据行会被跳过；数据无法解析时返回 nil
+func industryStockRows() []StockSearchResult {
+	var basicData stockBasicData
+	if err := json.Unmarshal(embed.StockBasicJSON, &basicData); err != nil {
+		return nil
+	}
+
+	var symbolIdx, nameIdx, industryIdx, tsCodeIdx int = -1, -1, -1, -1
+	for i, field := range basicData.Data.Fields {
+		switch field {
+		case "symbol":
+			symbolIdx = i
+		case "name":
+			nameIdx = i
+		case "industry":
+			industryIdx = i
+		case "ts_code":
+			tsCodeIdx = i
+		}
+	}
+
+	if symbolIdx < 0 || nameIdx < 0 || industryIdx < 0 {
+		return nil
+	}
+
+	rows := make([]StockSearchResult, 0, len(basicData.Data.Items))
+	for _, item := range basicData.Data.Items {
+		if industryIdx >= len(item) || symbolIdx >= len(item) || nameIdx >= len(item) {
+			continue
+		}
+		ind, _ := item[industryIdx].(string)
+		if ind == "" {
+			continue
+		}
+
+		symbol, _ := item[symbolIdx].(string)
+		name, _ := item[nameIdx].(string)
+		var tsCode string
+		if tsCodeIdx >= 0 && tsCodeIdx < len(item) {
+			tsCode, _ = item[tsCodeIdx].(string)
+		}
+		market, fullSymbol := marketFromTsCode(tsCode, symbol)
+
+		rows = append(rows, StockSearchResult{
+			Symbol:   fullSymbol,
+			Name:     name,
+			Industry: ind,
+			Market:   market,
+		})
+	}
+	return rows
+}
+
+// marketFromTsCode 根据 ts_code 后缀返回市场名称和带前缀代码
+func marketFromTsCode(tsCode, symbol string) (market, fullSymbol string) {
+	switch {
+	case strings.HasSuffix(tsCode, ".SH"):
+		return "上海", "sh" + symbol
+	case strings.HasSuffix(tsCode, ".SZ"):
+		return "深圳", "sz" + symbol
+	case strings.HasSuffix(tsCode, ".BJ"):
+		return "北京", "bj" + symbol
+	default:
+		return "", symbol
+	}
+}
+
 // GetStockBasicInfo 根据股票代码获取基础信息
 // symbol: 纯数字代码，如 "600519"
 func (cs *ConfigService) GetStockBasicInfo(symbol string) *StockSearchResult {
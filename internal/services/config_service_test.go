@@ -0,0 +1,33 @@
+package services
+
+import "testing"
+
+// TestIndustrySearchConsistency 测试按行业精确获取与按行业搜索的结果口径一致
+func TestIndustrySearchConsistency(t *testing.T) {
+	cs := &ConfigService{}
+
+	exact := cs.GetStocksByIndustry("银行")
+	if len(exact) == 0 {
+		t.Fatal("银行行业应有股票")
+	}
+	searched := cs.SearchStocksByIndustry("银行", 0)
+	if len(searched) < len(exact) {
+		t.Fatalf("搜索结果不应少于精确匹配: %d < %d", len(searched), len(exact))
+	}
+	for i, want := range exact {
+		if searched[i] != want {
+			t.Errorf("精确匹配应排在前面且字段一致: %+v != %+v", searched[i], want)
+			break
+		}
+	}
+	for _, r := range searched {
+		if r.Symbol == "" || r.Name == "" || r.Market == "" {
+			t.Errorf("数据行字段缺失: %+v", r)
+			break
+		}
+	}
+
+	if got := cs.SearchStocksByIndustry("银", 3); len(got) != 3 {
+		t.Errorf("limit 应截断结果: %d", len(got))
+	}
+}
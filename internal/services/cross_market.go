@@ -0,0 +1,182 @@
+package services
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// OverseasTarget A股关联的境外标的（新浪行情代码：gb_ 美股，hk 港股）
+type OverseasTarget struct {
+	Code     string `json:"code"`     // 新浪行情代码
+	Name     string `json:"name"`     // 标的名称
+	Relation string `json:"relation"` // 关联关系
+}
+
+// OverseasQuote 境外标的行情
+type OverseasQuote struct {
+	Code          string  `json:"code"`          // 新浪行情代码
+	Name          string  `json:"name"`          // 名称
+	Price         float64 `json:"price"`         // 最新价/收盘价
+	ChangePercent float64 `json:"changePercent"` // 涨跌幅(%)
+	UpdateTime    string  `json:"updateTime"`    // 行情时间（当地时间）
+}
+
+// crossListings 境外同时上市的A股（A+H、美股上市），按A股代码索引
+var crossListings = map[string][]OverseasTarget{
+	"sh601318": {{Code: "hk02318", Name: "中国平安H股", Relation: "A+H两地上市"}},
+	"sh600036": {{Code: "hk03968", Name: "招商银行H股", Relation: "A+H两地上市"}},
+	"sh601398": {{Code: "hk01398", Name: "工商银行H股", Relation: "A+H两地上市"}},
+	"sh601939": {{Code: "hk00939", Name: "建设银行H股", Relation: "A+H两地上市"}},
+	"sh601288": {{Code: "hk01288", Name: "农业银行H股", Relation: "A+H两地上市"}},
+	"sh601988": {{Code: "hk03988", Name: "中国银行H股", Relation: "A+H两地上市"}},
+	"sh601628": {{Code: "hk02628", Name: "中国人寿H股", Relation: "A+H两地上市"}},
+	"sh600030": {{Code: "hk06030", Name: "中信证券H股", Relation: "A+H两地上市"}},
+	"sh601857": {{Code: "hk00857", Name: "中国石油H股", Relation: "A+H两地上市"}},
+	"sh600028": {{Code: "hk00386", Name: "中国石化H股", Relation: "A+H两地上市"}},
+	"sh600938": {{Code: "hk00883", Name: "中国海洋石油", Relation: "A+H两地上市"}},
+	"sh601088": {{Code: "hk01088", Name: "中国神华H股", Relation: "A+H两地上市"}},
+	"sh600941": {{Code: "hk00941", Name: "中国移动", Relation: "A+H两地上市"}},
+	"sh601728": {{Code: "hk00728", Name: "中国电信H股", Relation: "A+H两地上市"}},
+	"sh601899": {{Code: "hk02899", Name: "紫金矿业H股", Relation: "A+H两地上市"}},
+	"sh601600": {{Code: "hk02600", Name: "中国铝业H股", Relation: "A+H两地上市"}},
+	"sh600585": {{Code: "hk00914", Name: "海螺水泥H股", Relation: "A+H两地上市"}},
+	"sh601766": {{Code: "hk01766", Name: "中国中车H股", Relation: "A+H两地上市"}},
+	"sh601390": {{Code: "hk00390", Name: "中国中铁H股", Relation: "A+H两地上市"}},
+	"sh601186": {{Code: "hk01186", Name: "中国铁建H股", Relation: "A+H两地上市"}},
+	"sh601633": {{Code: "hk02333", Name: "长城汽车H股", Relation: "A+H两地上市"}},
+	"sh601238": {{Code: "hk02238", Name: "广汽集团H股", Relation: "A+H两地上市"}},
+	"sh601111": {{Code: "hk00753", Name: "中国国航H股", Relation: "A+H两地上市"}},
+	"sh600690": {{Code: "hk06690", Name: "海尔智家H股", Relation: "A+H两地上市"}},
+	"sh603259": {{Code: "hk02359", Name: "药明康德H股", Relation: "A+H两地上市"}},
+	"sh600276": {{Code: "hk01276", Name: "恒瑞医药H股", Relation: "A+H两地上市"}},
+	"sh688981": {{Code: "hk00981", Name: "中芯国际H股", Relation: "A+H两地上市"}},
+	"sh688235": {
+		{Code: "hk06160", Name: "百济神州H股", Relation: "A+H两地上市"},
+		{Code: "gb_onc", Name: "百济神州美股", Relation: "美股同时上市"},
+	},
+	"sz002594": {{Code: "hk01211", Name: "比亚迪股份", Relation: "A+H两地上市"}},
+	"sz300750": {{Code: "hk03750", Name: "宁德时代H股", Relation: "A+H两地上市"}},
+	"sz000333": {{Code: "hk00300", Name: "美的集团H股", Relation: "A+H两地上市"}},
+	"sz000063": {{Code: "hk00763", Name: "中兴通讯H股", Relation: "A+H两地上市"}},
+	"sz002460": {{Code: "hk01772", Name: "赣锋锂业H股", Relation: "A+H两地上市"}},
+	"sz002466": {{Code: "hk09696", Name: "天齐锂业H股", Relation: "A+H两地上市"}},
+}
+
+// industryOverseasProxies 行业对应的美股映射标的（行业名称与 stock_basic.json 一致）
+var industryOverseasProxies = map[string][]OverseasTarget{
+	"半导体":  {{Code: "gb_$sox", Name: "费城半导体指数", Relation: "行业映射"}, {Code: "gb_nvda", Name: "英伟达", Relation: "行业龙头"}},
+	"元器件":  {{Code: "gb_$sox", Name: "费城半导体指数", Relation: "行业映射"}, {Code: "gb_aapl", Name: "苹果", Relation: "消费电子链"}},
+	"通信设备": {{Code: "gb_nvda", Name: "英伟达", Relation: "算力/光模块链"}},
+	"IT设备": {{Code: "gb_nvda", Name: "英伟达", Relation: "算力链"}},
+	"软件服务": {{Code: "gb_$ixic", Name: "纳斯达克指数", Relation: "科技股风向"}, {Code: "gb_msft", Name: "微软", Relation: "行业龙头"}},
+	"互联网":  {{Code: "gb_$hxc", Name: "纳斯达克中国金龙指数", Relation: "中概股映射"}, {Code: "gb_baba", Name: "阿里巴巴", Relation: "中概龙头"}},
+	"汽车整车": {{Code: "gb_tsla", Name: "特斯拉", Relation: "新能源车映射"}},
+	"汽车配件": {{Code: "gb_tsla", Name: "特斯拉", Relation: "新能源车/机器人链"}},
+	"电气设备": {{Code: "gb_tsla", Name: "特斯拉", Relation: "新能源链"}},
+	"生物制药": {{Code: "gb_xbi", Name: "生物科技ETF(XBI)", Relation: "创新药映射"}},
+	"化学制药": {{Code: "gb_xbi", Name: "生物科技ETF(XBI)", Relation: "创新药映射"}},
+	"黄金":   {{Code: "gb_gld", Name: "黄金ETF(GLD)", Relation: "金价映射"}},
+	"铜":    {{Code: "gb_fcx", Name: "自由港麦克莫兰", Relation: "铜价映射"}},
+	"石油开采": {{Code: "gb_xom", Name: "埃克森美孚", Relation: "油价映射"}},
+	"证券":   {{Code: "gb_$hxc", Name: "纳斯达克中国金龙指数", Relation: "外资风险偏好"}},
+}
+
+// CrossMarketBenchmarks 外盘大盘参考：美股三大指数之外重点关注中概股和港股
+var CrossMarketBenchmarks = []OverseasTarget{
+	{Code: "gb_$inx", Name: "标普500", Relation: "美股大盘"},
+	{Code: "gb_$ixic", Name: "纳斯达克指数", Relation: "美股科技"},
+	{Code: "gb_$hxc", Name: "纳斯达克中国金龙指数", Relation: "中概股"},
+	{Code: "hkHSI", Name: "恒生指数", Relation: "港股大盘"},
+}
+
+// CrossMarketTargets 返回A股关联的境外标的：优先境外同时上市，其次行业映射，按代码去重
+func CrossMarketTargets(code, industry string) []OverseasTarget {
+	var targets []OverseasTarget
+	seen := make(map[string]bool)
+	add := func(list []OverseasTarget) {
+		for _, t := range list {
+			if !seen[t.Code] {
+				seen[t.Code] = true
+				targets = append(targets, t)
+			}
+		}
+	}
+	add(crossListings[strings.ToLower(code)])
+	add(industryOverseasProxies[industry])
+	return targets
+}
+
+// GetOverseasQuotes 批量获取美股/港股行情
+func (s *StockInfoService) GetOverseasQuotes(codes []string) (map[string]*OverseasQuote, error) {
+	if len(codes) == 0 {
+		return nil, nil
+	}
+	body, err := s.fetchSinaQuotes(codes)
+	if err != nil {
+		return nil, fmt.Errorf("获取境外行情失败: %w", err)
+	}
+	return parseSinaOverseas(body), nil
+}
+
+// parseSinaOverseas 解析新浪美股(gb_)及港股(hk)行情
+func parseSinaOverseas(body string) map[string]*OverseasQuote {
+	result := make(map[string]*OverseasQuote)
+	for _, line := range strings.Split(body, "\n") {
+		code, fields, ok := sinaQuoteFields(line)
+		if !ok {
+			continue
+		}
+
+		var quote *OverseasQuote
+		if strings.HasPrefix(code, "gb_") {
+			quote = parseSinaUSQuote(fields)
+		} else if strings.HasPrefix(code, "hk") {
+			quote = parseSinaHKQuote(fields)
+		}
+		if quote == nil {
+			continue
+		}
+		quote.Code = code
+		result[code] = quote
+	}
+	return result
+}
+
+// parseSinaUSQuote 解析美股行情
+// 字段: 0名称 1最新价 2涨跌幅 3时间 4涨跌额 5开盘 6最高 7最低 ...
+func parseSinaUSQuote(fields []string) *OverseasQuote {
+	if len(fields) < 4 {
+		return nil
+	}
+	price, _ := strconv.ParseFloat(fields[1], 64)
+	if price <= 0 {
+		return nil
+	}
+	change, _ := strconv.ParseFloat(fields[2], 64)
+	return &OverseasQuote{
+		Name:          fields[0],
+		Price:         price,
+		ChangePercent: change,
+		UpdateTime:    strings.TrimSpace(fields[3]),
+	}
+}
+
+// parseSinaHKQuote 解析港股行情
+// 字段: 0英文名 1中文名 2开盘 3昨收 4最高 5最低 6最新价 7涨跌额 8涨跌幅 ... 17日期 18时间
+func parseSinaHKQuote(fields []string) *OverseasQuote {
+	if len(fields) < 19 {
+		return nil
+	}
+	price, _ := strconv.ParseFloat(fields[6], 64)
+	if price <= 0 {
+		return nil
+	}
+	change, _ := strconv.ParseFloat(fields[8], 64)
+	return &OverseasQuote{
+		Name:          fields[1],
+		Price:         price,
+		ChangePercent: change,
+		UpdateTime:    strings.TrimSpace(strings.ReplaceAll(fields[17], "/", "-") + " " + fields[18]),
+	}
+}
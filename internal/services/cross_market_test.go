@@ -0,0 +1,41 @@
+package services
+
+import "testing"
+
+// TestParseSinaOverseas 测试新浪美股及港股行情解析
+func TestParseSinaOverseas(t *testing.T) {
+	body := `var hq_str_gb_baba="阿里巴巴,86.5000,2.35,2024-10-12 04:00:00,1.9900,85.1000,87.2000,84.9000,117.8200,66.6300,15234567,18000000,208000000000,3.60,24.03,0.00,0.00,0.00,0.00,2400000000,61,0.0000,0.00,0.00,,Oct 11 04:00PM EDT,84.5100,0.0000";
+var hq_str_hk02318="PING AN,中国平安,45.100,44.800,45.650,44.600,45.300,0.500,1.116,45.250,45.300,1280000000,28300000,9.830,5.120,58.350,37.500,2024/10/11,16:08";
+var hq_str_gb_$sox="";`
+
+	quotes := parseSinaOverseas(body)
+	if len(quotes) != 2 {
+		t.Fatalf("解析结果数量 = %d, want 2", len(quotes))
+	}
+
+	baba := quotes["gb_baba"]
+	if baba == nil || baba.Price != 86.5 || baba.ChangePercent != 2.35 || baba.Name != "阿里巴巴" {
+		t.Errorf("美股解析错误: %+v", baba)
+	}
+
+	hk := quotes["hk02318"]
+	if hk == nil || hk.Price != 45.3 || hk.ChangePercent != 1.116 || hk.Name != "中国平安" {
+		t.Errorf("港股解析错误: %+v", hk)
+	}
+	if hk != nil && hk.UpdateTime != "2024-10-11 16:08" {
+		t.Errorf("港股时间 = %q", hk.UpdateTime)
+	}
+}
+
+// TestCrossMarketTargets 测试关联境外标的：两地上市优先，行业映射去重
+func TestCrossMarketTargets(t *testing.T) {
+	targets := CrossMarketTargets("SH688981", "半导体")
+	if len(targets) != 3 || targets[0].Code != "hk00981" || targets[1].Code != "gb_$sox" {
+		t.Errorf("中芯国际关联标的错误: %+v", targets)
+	}
+
+	targets = CrossMarketTargets("sz000001", "银行")
+	if len(targets) != 0 {
+		t.Errorf("无关联标的时应为空: %+v", targets)
+	}
+}
@@ -0,0 +1,249 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DividendRecord 单次分红方案
+type DividendRecord struct {
+	ReportDate   string  `json:"reportDate"`   // 报告期
+	NoticeDate   string  `json:"noticeDate"`   // 方案公告日
+	ExDate       string  `json:"exDate"`       // 除权除息日，未实施时为空
+	CashPerShare float64 `json:"cashPerShare"` // 每股派现(元，税前)
+	Plan         string  `json:"plan"`         // 分红方案，如 10派10.00元(含税)
+	Progress     string  `json:"progress"`     // 方案进度：实施分配/股东大会预案/董事会预案等
+	EPS          float64 `json:"eps"`          // 报告期基本每股收益(元)
+}
+
+// DividendHistory 个股历史分红
+type DividendHistory struct {
+	Code    string           `json:"code"`    // 股票代码
+	Name    string           `json:"name"`    // 股票名称
+	Records []DividendRecord `json:"records"` // 分红方案（按报告期降序）
+}
+
+// DividendQuality 分红质量评估
+type DividendQuality struct {
+	Price            float64  `json:"price"`            // 评估所用股价
+	TTMDividend      float64  `json:"ttmDividend"`      // 近12个月每股派现(元)
+	Yield            float64  `json:"yield"`            // 股息率TTM(%)
+	ConsecutiveYears int      `json:"consecutiveYears"` // 连续现金分红年数
+	AvgPayout        float64  `json:"avgPayout"`        // 近年平均分红率(%)
+	PayoutYears      int      `json:"payoutYears"`      // 参与计算平均分红率的年数
+	Score            int      `json:"score"`            // 综合评分 0~100
+	Level            string   `json:"level"`            // 分红质量: 优/良/中/差
+	Reasons          []string `json:"reasons"`          // 评估说明
+}
+
+// dividendAPIItem 分红送配接口数据
+type dividendAPIItem struct {
+	SecurityNameAbbr string  `json:"SECURITY_NAME_ABBR"`
+	ReportDate       string  `json:"REPORT_DATE"`
+	PlanNoticeDate   string  `json:"PLAN_NOTICE_DATE"`
+	ExDividendDate   string  `json:"EX_DIVIDEND_DATE"`
+	PretaxBonusRMB   float64 `json:"PRETAX_BONUS_RMB"` // 每10股派现(元)
+	ImplPlanProfile  string  `json:"IMPL_PLAN_PROFILE"`
+	AssignProgress   string  `json:"ASSIGN_PROGRESS"`
+	BasicEPS         float64 `json:"BASIC_EPS"`
+}
+
+// dividendCache 分红数据缓存
+type dividendCache struct {
+	data      *DividendHistory
+	timestamp time.Time
+}
+
+// dividendPayoutYears 计算平均分红率的最近报告年度数
+const dividendPayoutYears = 5
+
+// GetDividendHistory 获取个股历史分红方案（按代码缓存1天）
+func (s *ShareholderService) GetDividendHistory(code string) (*DividendHistory, error) {
+	code = stripMarketPrefix(code)
+
+	s.dividendCacheMu.RLock()
+	if cached, ok := s.dividendCache[code]; ok && time.Since(cached.timestamp) < s.dividendCacheTTL {
+		s.dividendCacheMu.RUnlock()
+		return cached.data, nil
+	}
+	s.dividendCacheMu.RUnlock()
+
+	history, err := s.fetchDividendHistory(code)
+	if err != nil {
+		return nil, err
+	}
+
+	s.dividendCacheMu.Lock()
+	s.dividendCache[code] = &dividendCache{data: history, timestamp: time.Now()}
+	s.dividendCacheMu.Unlock()
+
+	return history, nil
+}
+
+// fetchDividendHistory 从东方财富获取分红送配明细
+func (s *ShareholderService) fetchDividendHistory(code string) (*DividendHistory, error) {
+	params := url.Values{}
+	params.Set("reportName", "RPT_SHAREBONUS_DET")
+	params.Set("columns", "ALL")
+	params.Set("sortColumns", "REPORT_DATE")
+	params.Set("sortTypes", "-1")
+	params.Set("pageSize", "50")
+	params.Set("pageNumber", "1")
+	params.Set("filter", fmt.Sprintf(`(SECURITY_CODE="%s")`, code))
+	params.Set("source", "WEB")
+	params.Set("client", "WEB")
+
+	var items []dividendAPIItem
+	if _, err := fetchDatacenter(s.client, eastmoneyDatacenterURL+"?"+params.Encode(), &items); err != nil {
+		return nil, fmt.Errorf("获取分红送配失败: %w", err)
+	}
+
+	history := &DividendHistory{Code: code, Records: []DividendRecord{}}
+	for _, item := range items {
+		if history.Name == "" {
+			history.Name = item.SecurityNameAbbr
+		}
+		history.Records = append(history.Records, DividendRecord{
+			ReportDate:   trimDate(item.ReportDate),
+			NoticeDate:   trimDate(item.PlanNoticeDate),
+			ExDate:       trimDate(item.ExDividendDate),
+			CashPerShare: item.PretaxBonusRMB / 10,
+			Plan:         item.ImplPlanProfile,
+			Progress:     item.AssignProgress,
+			EPS:          item.BasicEPS,
+		})
+	}
+	sort.SliceStable(history.Records, func(i, j int) bool {
+		return history.Records[i].ReportDate > history.Records[j].ReportDate
+	})
+	return history, nil
+}
+
+// paysCash 方案是否为有效的现金分红（排除不分配、停止实施的方案）
+func (r DividendRecord) paysCash() bool {
+	if r.CashPerShare <= 0 {
+		return false
+	}
+	return !strings.Contains(r.Progress, "不分配") && !strings.Contains(r.Progress, "停止") && !strings.Contains(r.Progress, "取消")
+}
+
+// AssessDividendQuality 根据分红历史和当前股价评估分红质量
+// 综合股息率TTM、连续分红年数和平均分红率打分：股息率最高40分，连续年数每年4分最高40分，分红率处于30%~70%得20分
+func AssessDividendQuality(history *DividendHistory, price float64, now time.Time) *DividendQuality {
+	q := &DividendQuality{Price: price, Reasons: []string{}}
+
+	// 按报告年度汇总每股派现，年度EPS取年报
+	yearCash := make(map[int]float64)
+	yearEPS := make(map[int]float64)
+	latestYear := 0
+	since := now.AddDate(-1, 0, 0).Format("2006-01-02")
+	today := now.Format("2006-01-02")
+	for _, r := range history.Records {
+		year, err := strconv.Atoi(strings.SplitN(r.ReportDate, "-", 2)[0])
+		if err != nil {
+			continue
+		}
+		if year > latestYear {
+			latestYear = year
+		}
+		if strings.HasSuffix(r.ReportDate, "-12-31") && r.EPS != 0 {
+			yearEPS[year] = r.EPS
+		}
+		if !r.paysCash() {
+			continue
+		}
+		yearCash[year] += r.CashPerShare
+		if r.ExDate != "" && r.ExDate > since && r.ExDate <= today {
+			q.TTMDividend += r.CashPerShare
+		}
+	}
+
+	if price > 0 {
+		q.Yield = q.TTMDividend / price * 100
+	}
+
+	// 从最近报告年度向前统计连续现金分红年数；最近年度方案未出时从上一年度起算
+	year := latestYear
+	if yearCash[year] == 0 && yearCash[year-1] > 0 {
+		year--
+	}
+	for ; yearCash[year] > 0; year-- {
+		q.ConsecutiveYears++
+	}
+
+	var payoutSum float64
+	for y := latestYear; y > latestYear-dividendPayoutYears; y-- {
+		if eps := yearEPS[y]; eps > 0 {
+			payoutSum += yearCash[y] / eps * 100
+			q.PayoutYears++
+		}
+	}
+	if q.PayoutYears > 0 {
+		q.AvgPayout = payoutSum / float64(q.PayoutYears)
+	}
+
+	q.Score, q.Level, q.Reasons = scoreDividendQuality(q)
+	return q
+}
+
+// scoreDividendQuality 分红质量打分及评级
+func scoreDividendQuality(q *DividendQuality) (int, string, []string) {
+	var reasons []string
+	score := 0
+
+	switch {
+	case q.Yield >= 5:
+		score += 40
+		reasons = append(reasons, fmt.Sprintf("股息率%.2f%%，高股息", q.Yield))
+	case q.Yield >= 3:
+		score += 30
+		reasons = append(reasons, fmt.Sprintf("股息率%.2f%%，高于一年期存款", q.Yield))
+	case q.Yield >= 2:
+		score += 20
+	case q.Yield >= 1:
+		score += 10
+	default:
+		reasons = append(reasons, fmt.Sprintf("股息率%.2f%%，现金回报有限", q.Yield))
+	}
+
+	score += min(q.ConsecutiveYears, 10) * 4
+	switch {
+	case q.ConsecutiveYears >= 10:
+		reasons = append(reasons, fmt.Sprintf("连续%d年现金分红，分红稳定", q.ConsecutiveYears))
+	case q.ConsecutiveYears == 0:
+		reasons = append(reasons, "近年无连续现金分红")
+	}
+
+	switch {
+	case q.PayoutYears == 0:
+		reasons = append(reasons, "缺少年报每股收益，无法计算分红率")
+	case q.AvgPayout > 100:
+		reasons = append(reasons, fmt.Sprintf("平均分红率%.1f%%，分红超过盈利，可持续性存疑", q.AvgPayout))
+	case q.AvgPayout >= 30 && q.AvgPayout <= 70:
+		score += 20
+	case q.AvgPayout >= 10:
+		score += 10
+		if q.AvgPayout > 70 {
+			reasons = append(reasons, fmt.Sprintf("平均分红率%.1f%%，分红比例偏高，留存收益较少", q.AvgPayout))
+		} else {
+			reasons = append(reasons, fmt.Sprintf("平均分红率%.1f%%，分红意愿偏弱", q.AvgPayout))
+		}
+	default:
+		reasons = append(reasons, fmt.Sprintf("平均分红率%.1f%%，几乎不分红", q.AvgPayout))
+	}
+
+	level := "差"
+	switch {
+	case score >= 75:
+		level = "优"
+	case score >= 55:
+		level = "良"
+	case score >= 35:
+		level = "中"
+	}
+	return score, level, reasons
+}
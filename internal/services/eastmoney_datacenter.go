@@ -0,0 +1,68 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// 东方财富数据中心通用接口
+const eastmoneyDatacenterURL = "https://datacenter-web.eastmoney.com/api/data/v1/get"
+
+// datacenterResponse 东方财富数据中心通用响应结构
+type datacenterResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Code    int    `json:"code"`
+	Result  *struct {
+		Data  json.RawMessage `json:"data"`
+		Count int             `json:"count"` // 总记录数
+		Pages int             `json:"pages"` // 总页数
+	} `json:"result"`
+}
+
+// fetchDatacenter 请求东方财富数据中心并将 result.data 解析到 out
+// 接口无数据时（result 为 null）out 保持不变，返回总数 0
+func fetchDatacenter(client *http.Client, url string, out any) (int, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Referer", "https://data.eastmoney.com/")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	var dcResp datacenterResponse
+	if err := json.Unmarshal(body, &dcResp); err != nil {
+		return 0, fmt.Errorf("解析数据中心响应失败: %w", err)
+	}
+	if dcResp.Result == nil || len(dcResp.Result.Data) == 0 {
+		if !dcResp.Success && dcResp.Code != 9201 { // 9201: 无数据
+			return 0, fmt.Errorf("数据中心返回错误: %s", dcResp.Message)
+		}
+		return 0, nil
+	}
+	if err := json.Unmarshal(dcResp.Result.Data, out); err != nil {
+		return 0, fmt.Errorf("解析数据中心数据失败: %w", err)
+	}
+	return dcResp.Result.Count, nil
+}
+
+// trimDate 截取日期部分，"2026-02-09 00:00:00" -> "2026-02-09"
+func trimDate(s string) string {
+	if len(s) > 10 {
+		return s[:10]
+	}
+	return s
+}
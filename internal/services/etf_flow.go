@@ -0,0 +1,153 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const (
+	// 上交所 ETF 规模查询：按统计日期返回全部沪市 ETF 的基金份额(万份)
+	sseETFScaleURL = "https://query.sse.com.cn/commonQuery.do?isPagination=true&pageHelp.pageSize=5000&pageHelp.pageNo=1&pageHelp.beginPage=1&pageHelp.cacheSize=1&pageHelp.endPage=1&sqlId=COMMON_SSE_ZQPZ_ETFZL_XXPL_ETFGM_SEARCH_L&STAT_DATE=%s"
+	// etfShareFetchConcurrency 并发查询的日期数
+	etfShareFetchConcurrency = 4
+)
+
+// ETFFlowDay ETF 单日份额及净申赎估算
+type ETFFlowDay struct {
+	Date        string  `json:"date"`
+	Close       float64 `json:"close"`
+	Shares      float64 `json:"shares"`      // 基金份额(万份)
+	ShareChange float64 `json:"shareChange"` // 较上一交易日份额变化(万份)
+	NetFlow     float64 `json:"netFlow"`     // 份额变化×收盘价估算的净申赎金额(万元)，正为净申购
+}
+
+// IsSHETF 判断是否为沪市ETF（上交所提供逐日份额数据）
+func IsSHETF(code string) bool {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if !strings.HasPrefix(code, "sh") && !strings.HasPrefix(code, "sz") {
+		code = "sh" + code
+	}
+	return strings.HasPrefix(code, "sh5")
+}
+
+// GetETFShares 获取沪市ETF在指定交易日的基金份额(万份)，无数据的日期不在结果中
+func (s *StockInfoService) GetETFShares(code string, dates []string) (map[string]float64, error) {
+	secCode := stripMarketPrefix(code)
+	result := make(map[string]float64, len(dates))
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		lastErr error
+	)
+	sem := make(chan struct{}, etfShareFetchConcurrency)
+	for _, date := range dates {
+		wg.Add(1)
+		go func(date string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			shares, err := s.getETFSharesByDate(date)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				lastErr = err
+				return
+			}
+			if v, ok := shares[secCode]; ok {
+				result[date] = v
+			}
+		}(date)
+	}
+	wg.Wait()
+
+	if len(result) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+	return result, nil
+}
+
+// getETFSharesByDate 获取某日全部沪市ETF份额（带缓存，空结果不缓存）
+func (s *StockInfoService) getETFSharesByDate(date string) (map[string]float64, error) {
+	s.cacheMu.RLock()
+	cached, ok := s.etfShares[date]
+	s.cacheMu.RUnlock()
+	if ok {
+		return cached, nil
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf(sseETFScaleURL, date), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Referer", "https://www.sse.com.cn/")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	shares, err := parseSSEETFScale(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(shares) > 0 {
+		s.cacheMu.Lock()
+		s.etfShares[date] = shares
+		s.cacheMu.Unlock()
+	}
+	return shares, nil
+}
+
+// parseSSEETFScale 解析上交所 ETF 规模数据，返回 代码 -> 份额(万份)
+func parseSSEETFScale(body []byte) (map[string]float64, error) {
+	var result struct {
+		Result []struct {
+			SecCode string `json:"SEC_CODE"`
+			TotVol  any    `json:"TOT_VOL"` // 基金份额(万份)
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("parse sse etf scale error: %w, body: %s", err, truncateBytes(body, 200))
+	}
+
+	shares := make(map[string]float64, len(result.Result))
+	for _, r := range result.Result {
+		if v := anyToFloat(r.TotVol); v > 0 {
+			shares[r.SecCode] = v
+		}
+	}
+	return shares, nil
+}
+
+// ComputeETFFlows 根据逐日收盘价与份额估算净申赎，缺少份额的日期跳过
+// 首个有份额的交易日仅作基准，不计份额变化
+func ComputeETFFlows(dates []string, closes []float64, shares map[string]float64) []ETFFlowDay {
+	var days []ETFFlowDay
+	prev := 0.0
+	for i, date := range dates {
+		v, ok := shares[date]
+		if !ok {
+			continue
+		}
+		day := ETFFlowDay{Date: date, Close: closes[i], Shares: v}
+		if prev > 0 {
+			day.ShareChange = v - prev
+			day.NetFlow = day.ShareChange * closes[i]
+		}
+		days = append(days, day)
+		prev = v
+	}
+	return days
+}
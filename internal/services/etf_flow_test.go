@@ -0,0 +1,48 @@
+package services
+
+import (
+	"math"
+	"testing"
+)
+
+// TestParseSSEETFScale 测试上交所ETF规模解析
+func TestParseSSEETFScale(t *testing.T) {
+	body := []byte(`{"result":[{"SEC_CODE":"512480","STAT_DATE":"2024-10-14","TOT_VOL":"2345678.12"},{"SEC_CODE":"510300","TOT_VOL":9123456.5},{"SEC_CODE":"588000","TOT_VOL":"-"}]}`)
+	shares, err := parseSSEETFScale(body)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if len(shares) != 2 || shares["512480"] != 2345678.12 || shares["510300"] != 9123456.5 {
+		t.Errorf("unexpected shares: %v", shares)
+	}
+}
+
+// TestComputeETFFlows 测试份额变化与净申赎估算，缺失日期跳过
+func TestComputeETFFlows(t *testing.T) {
+	dates := []string{"2024-10-10", "2024-10-11", "2024-10-14", "2024-10-15"}
+	closes := []float64{1.0, 1.1, 1.2, 1.3}
+	shares := map[string]float64{"2024-10-10": 1000, "2024-10-14": 1100, "2024-10-15": 1050}
+
+	flows := ComputeETFFlows(dates, closes, shares)
+	if len(flows) != 3 {
+		t.Fatalf("len = %d, want 3", len(flows))
+	}
+	if flows[0].ShareChange != 0 || flows[0].NetFlow != 0 {
+		t.Errorf("首日应只作基准: %+v", flows[0])
+	}
+	if flows[1].ShareChange != 100 || math.Abs(flows[1].NetFlow-120) > 1e-9 {
+		t.Errorf("净申购计算错误: %+v", flows[1])
+	}
+	if flows[2].ShareChange != -50 || math.Abs(flows[2].NetFlow+65) > 1e-9 {
+		t.Errorf("净赎回计算错误: %+v", flows[2])
+	}
+}
+
+// TestIsSHETF 测试沪市ETF判断
+func TestIsSHETF(t *testing.T) {
+	for code, want := range map[string]bool{"sh512480": true, "588000": true, "sz159915": false, "159915": false, "sh600519": false} {
+		if got := IsSHETF(code); got != want {
+			t.Errorf("IsSHETF(%s) = %v, want %v", code, got, want)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+)
+
+// 东方财富股吧人气榜历史接口
+const gubaRankHistoryURL = "https://emappdata.eastmoney.com/stockrank/getHisList"
+
+// 股吧人气异动判定参数
+const (
+	gubaSpikeLookback = 5   // 对比的前期天数
+	gubaSpikeRatio    = 0.5 // 排名较前期均值提升一半以上视为异动
+	gubaSpikeTopRank  = 100 // 异动后需进入的排名范围
+)
+
+// GubaRankDay 单日股吧人气排名
+type GubaRankDay struct {
+	Date string `json:"date"` // 日期
+	Rank int    `json:"rank"` // 人气排名（越小越热）
+}
+
+// DiscussionTrend 个股股吧讨论热度趋势
+type DiscussionTrend struct {
+	Code    string        `json:"code"`
+	Days    []GubaRankDay `json:"days"`    // 按日期升序
+	AvgRank float64       `json:"avgRank"` // 最新一日之前的平均排名
+	Spike   bool          `json:"spike"`   // 最新一日关注度是否突然放大
+	Trend   string        `json:"trend"`   // 升温/降温/平稳
+}
+
+// gubaRankHistoryResponse 股吧人气榜历史接口响应
+type gubaRankHistoryResponse struct {
+	Data []struct {
+		CalcTime string `json:"calcTime"`
+		Rank     int    `json:"rank"`
+	} `json:"data"`
+	Message string `json:"message"`
+	Status  int    `json:"status"`
+}
+
+// gubaCache 股吧热度缓存
+type gubaCache struct {
+	data      *DiscussionTrend
+	timestamp time.Time
+}
+
+// GubaService 股吧讨论热度服务
+type GubaService struct {
+	client   *http.Client
+	cache    map[string]*gubaCache
+	cacheMu  sync.RWMutex
+	cacheTTL time.Duration
+}
+
+// NewGubaService 创建股吧讨论热度服务
+func NewGubaService() *GubaService {
+	return &GubaService{
+		client:   proxy.GetManager().GetClientWithTimeout(10 * time.Second),
+		cache:    make(map[string]*gubaCache),
+		cacheTTL: 30 * time.Minute,
+	}
+}
+
+// GetDiscussionTrend 获取个股股吧人气排名趋势（按代码缓存30分钟）
+// code: 带市场前缀的代码，如 sh600519
+func (s *GubaService) GetDiscussionTrend(code string) (*DiscussionTrend, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+
+	s.cacheMu.RLock()
+	if cached, ok := s.cache[code]; ok && time.Since(cached.timestamp) < s.cacheTTL {
+		s.cacheMu.RUnlock()
+		return cached.data, nil
+	}
+	s.cacheMu.RUnlock()
+
+	days, err := s.fetchRankHistory(code)
+	if err != nil {
+		return nil, err
+	}
+	trend := &DiscussionTrend{Code: code, Days: days}
+	analyzeDiscussionTrend(trend)
+
+	s.cacheMu.Lock()
+	s.cache[code] = &gubaCache{data: trend, timestamp: time.Now()}
+	s.cacheMu.Unlock()
+
+	return trend, nil
+}
+
+// fetchRankHistory 从东方财富获取股吧人气排名历史
+func (s *GubaService) fetchRankHistory(code string) ([]GubaRankDay, error) {
+	payload, _ := json.Marshal(map[string]string{
+		"appId":           "appId01",
+		"globalId":        "786e4c21-70dc-435a-93bb-38",
+		"marketType":      "",
+		"srcSecurityCode": strings.ToUpper(code),
+	})
+	req, err := http.NewRequest("POST", gubaRankHistoryURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Referer", "https://guba.eastmoney.com/")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("获取股吧人气排名失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	var result gubaRankHistoryResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("解析股吧人气排名失败: %w", err)
+	}
+
+	days := make([]GubaRankDay, 0, len(result.Data))
+	for _, d := range result.Data {
+		if d.Rank <= 0 {
+			continue
+		}
+		days = append(days, GubaRankDay{Date: trimDate(d.CalcTime), Rank: d.Rank})
+	}
+	return days, nil
+}
+
+// analyzeDiscussionTrend 根据排名变化判断热度趋势和突发异动
+// Days 需按日期升序排列
+func analyzeDiscussionTrend(t *DiscussionTrend) {
+	n := len(t.Days)
+	t.Trend = "平稳"
+	if n < 2 {
+		return
+	}
+
+	latest := t.Days[n-1].Rank
+	start := n - 1 - gubaSpikeLookback
+	if start < 0 {
+		start = 0
+	}
+	var total int
+	for _, d := range t.Days[start : n-1] {
+		total += d.Rank
+	}
+	t.AvgRank = float64(total) / float64(n-1-start)
+
+	// 排名数值下降即关注度上升
+	change := (t.AvgRank - float64(latest)) / t.AvgRank
+	switch {
+	case change >= 0.2:
+		t.Trend = "升温"
+	case change <= -0.2:
+		t.Trend = "降温"
+	}
+	t.Spike = change >= gubaSpikeRatio && latest <= gubaSpikeTopRank
+}
@@ -0,0 +1,30 @@
+package services
+
+import "testing"
+
+// TestAnalyzeDiscussionTrend 测试股吧热度趋势判定
+func TestAnalyzeDiscussionTrend(t *testing.T) {
+	trend := &DiscussionTrend{Days: []GubaRankDay{
+		{Date: "2026-03-02", Rank: 900},
+		{Date: "2026-03-03", Rank: 300},
+		{Date: "2026-03-04", Rank: 320},
+		{Date: "2026-03-05", Rank: 280},
+		{Date: "2026-03-06", Rank: 300},
+		{Date: "2026-03-07", Rank: 300},
+		{Date: "2026-03-08", Rank: 60},
+	}}
+	analyzeDiscussionTrend(trend)
+
+	if trend.AvgRank != 300 {
+		t.Errorf("前期平均排名错误: %.1f", trend.AvgRank)
+	}
+	if trend.Trend != "升温" || !trend.Spike {
+		t.Errorf("应判定为升温且异动: trend=%s spike=%v", trend.Trend, trend.Spike)
+	}
+
+	cooling := &DiscussionTrend{Days: []GubaRankDay{{Rank: 100}, {Rank: 120}, {Rank: 200}}}
+	analyzeDiscussionTrend(cooling)
+	if cooling.Trend != "降温" || cooling.Spike {
+		t.Errorf("应判定为降温: trend=%s spike=%v", cooling.Trend, cooling.Spike)
+	}
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+)
+
+// 健康状态
+const (
+	HealthUp   = "up"
+	HealthDown = "down"
+)
+
+// HealthStatus 单个外部依赖的健康状态
+type HealthStatus struct {
+	Name      string `json:"name"`            // 依赖名称
+	Status    string `json:"status"`          // up/down
+	LatencyMs int64  `json:"latencyMs"`       // 检测耗时(毫秒)
+	Error     string `json:"error,omitempty"` // 失败原因
+}
+
+// HealthProbe 健康检查探针
+type HealthProbe struct {
+	ID    string                          // 唯一标识，作为结果 map 的 key
+	Name  string                          // 展示名称
+	Check func(ctx context.Context) error // 检查函数，返回 nil 表示可用
+}
+
+// RunHealthChecks 并发执行所有探针，每个探针独立超时
+func RunHealthChecks(ctx context.Context, probes []HealthProbe, timeout time.Duration) map[string]HealthStatus {
+	results := make(map[string]HealthStatus, len(probes))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, probe := range probes {
+		wg.Add(1)
+		go func(p HealthProbe) {
+			defer wg.Done()
+
+			probeCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			start := time.Now()
+			errCh := make(chan error, 1)
+			go func() { errCh <- p.Check(probeCtx) }()
+
+			var err error
+			select {
+			case err = <-errCh:
+			case <-probeCtx.Done():
+				err = fmt.Errorf("检测超时(%s)", timeout)
+			}
+
+			status := HealthStatus{
+				Name:      p.Name,
+				Status:    HealthUp,
+				LatencyMs: time.Since(start).Milliseconds(),
+			}
+			if err != nil {
+				status.Status = HealthDown
+				status.Error = err.Error()
+			}
+
+			mu.Lock()
+			results[p.ID] = status
+			mu.Unlock()
+		}(probe)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// NewHTTPProbe 创建 HTTP 探针，返回 HTTP 状态码小于 400 视为可用
+func NewHTTPProbe(id, name, url string, headers map[string]string) HealthProbe {
+	return HealthProbe{
+		ID:   id,
+		Name: name,
+		Check: func(ctx context.Context) error {
+			req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+			if err != nil {
+				return err
+			}
+			req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+			for k, v := range headers {
+				req.Header.Set(k, v)
+			}
+
+			resp, err := proxy.GetManager().GetClient().Do(req)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+			if resp.StatusCode >= 400 {
+				return fmt.Errorf("HTTP %d", resp.StatusCode)
+			}
+			return nil
+		},
+	}
+}
+
+// DataSourceProbes 内置行情/资讯数据源探针
+func DataSourceProbes() []HealthProbe {
+	return []HealthProbe{
+		NewHTTPProbe("sina_quote", "新浪行情", fmt.Sprintf(sinaStockURL, time.Now().UnixNano(), "sh000001"),
+			map[string]string{"Referer": "http://finance.sina.com.cn"}),
+		NewHTTPProbe("eastmoney_datacenter", "东方财富数据中心(龙虎榜)",
+			eastmoneyDatacenterURL+"?reportName=RPT_DAILYBILLBOARD_DETAILSNEW&columns=TRADE_DATE&pageSize=1&pageNumber=1&source=WEB&client=WEB",
+			map[string]string{"Referer": "https://data.eastmoney.com/"}),
+		NewHTTPProbe("eastmoney_report", "东方财富研报", eastmoneyReportAPI+"?pageSize=1&pageNo=1&qType=0",
+			map[string]string{"Referer": "https://data.eastmoney.com/"}),
+		NewHTTPProbe("holiday_api", "节假日接口", holidayAPIURL, nil),
+		NewHTTPProbe("cls_telegraph", "财联社快讯", "https://www.cls.cn/telegraph", nil),
+	}
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunHealthChecks(t *testing.T) {
+	probes := []HealthProbe{
+		{ID: "ok", Name: "正常", Check: func(ctx context.Context) error { return nil }},
+		{ID: "fail", Name: "失败", Check: func(ctx context.Context) error { return errors.New("connection refused") }},
+		{ID: "slow", Name: "超时", Check: func(ctx context.Context) error {
+			time.Sleep(time.Second)
+			return nil
+		}},
+	}
+
+	results := RunHealthChecks(context.Background(), probes, 100*time.Millisecond)
+	if len(results) != 3 {
+		t.Fatalf("结果数量错误: %d", len(results))
+	}
+	if results["ok"].Status != HealthUp {
+		t.Errorf("ok 应为 up: %+v", results["ok"])
+	}
+	if results["fail"].Status != HealthDown || results["fail"].Error != "connection refused" {
+		t.Errorf("fail 应为 down: %+v", results["fail"])
+	}
+	if results["slow"].Status != HealthDown || results["slow"].LatencyMs >= 1000 {
+		t.Errorf("slow 应超时返回: %+v", results["slow"])
+	}
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/run-bigpig/jcp/internal/embed"
+)
+
+// HotMoneyTrader 知名游资信息
+type HotMoneyTrader struct {
+	Label       string   `json:"label"`       // 游资标签，如"章盟主"
+	Style       string   `json:"style"`       // 操作风格: 接力/打板/机构
+	Description string   `json:"description"` // 风格描述
+	Seats       []string `json:"seats"`       // 营业部名称关键字
+}
+
+// hotMoneySeatsData hot_money_seats.json 的数据结构
+type hotMoneySeatsData struct {
+	Traders []HotMoneyTrader `json:"traders"`
+}
+
+var (
+	hotMoneyTraders []HotMoneyTrader
+	hotMoneyOnce    sync.Once
+)
+
+// loadHotMoneyTraders 加载嵌入的游资席位数据（仅加载一次）
+func loadHotMoneyTraders() []HotMoneyTrader {
+	hotMoneyOnce.Do(func() {
+		var data hotMoneySeatsData
+		if err := json.Unmarshal(embed.HotMoneySeatsJSON, &data); err != nil {
+			return
+		}
+		hotMoneyTraders = data.Traders
+	})
+	return hotMoneyTraders
+}
+
+// IdentifyHotMoney 根据营业部名称识别知名游资
+// 未匹配到时返回 nil
+func (s *LongHuBangService) IdentifyHotMoney(operName string) *HotMoneyTrader {
+	if operName == "" {
+		return nil
+	}
+	traders := loadHotMoneyTraders()
+	for i := range traders {
+		for _, seat := range traders[i].Seats {
+			if strings.Contains(operName, seat) {
+				return &traders[i]
+			}
+		}
+	}
+	return nil
+}
@@ -1,23 +1,21 @@
 package hottrend
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"time"
-
-	"github.com/run-bigpig/jcp/internal/pkg/proxy"
 )
 
 // BaiduFetcher 百度热搜获取器
 type BaiduFetcher struct {
-	client *http.Client
+	client *fetcherClient
 }
 
 // NewBaiduFetcher 创建百度热搜获取器
-func NewBaiduFetcher() *BaiduFetcher {
+func NewBaiduFetcher(client *fetcherClient) *BaiduFetcher {
 	return &BaiduFetcher{
-		client: proxy.GetManager().GetClientWithTimeout(10 * time.Second),
+		client: client,
 	}
 }
 
@@ -40,16 +38,16 @@ type baiduResponse struct {
 }
 
 // Fetch 获取百度热搜数据
-func (f *BaiduFetcher) Fetch() ([]HotItem, error) {
+func (f *BaiduFetcher) Fetch(ctx context.Context) ([]HotItem, error) {
 	url := "https://top.baidu.com/api/board?platform=wise&tab=realtime"
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (iPhone; CPU iPhone OS 14_0 like Mac OS X)")
 
-	resp, err := f.client.Do(req)
+	resp, err := f.client.get().Do(req)
 	if err != nil {
 		return nil, err
 	}
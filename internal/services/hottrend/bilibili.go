@@ -1,23 +1,21 @@
 package hottrend
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"time"
-
-	"github.com/run-bigpig/jcp/internal/pkg/proxy"
 )
 
 // BilibiliFetcher B站热搜获取器
 type BilibiliFetcher struct {
-	client *http.Client
+	client *fetcherClient
 }
 
 // NewBilibiliFetcher 创建B站热搜获取器
-func NewBilibiliFetcher() *BilibiliFetcher {
+func NewBilibiliFetcher(client *fetcherClient) *BilibiliFetcher {
 	return &BilibiliFetcher{
-		client: proxy.GetManager().GetClientWithTimeout(10 * time.Second),
+		client: client,
 	}
 }
 
@@ -37,16 +35,16 @@ type bilibiliResponse struct {
 }
 
 // Fetch 获取B站热搜数据
-func (f *BilibiliFetcher) Fetch() ([]HotItem, error) {
+func (f *BilibiliFetcher) Fetch(ctx context.Context) ([]HotItem, error) {
 	url := "https://s.search.bilibili.com/main/hotword?limit=50"
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
 
-	resp, err := f.client.Do(req)
+	resp, err := f.client.get().Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,78 @@
+package hottrend
+
+import (
+	"sync"
+	"time"
+)
+
+// 熔断默认参数
+const (
+	defaultFailureThreshold = 2               // 连续失败次数阈值
+	defaultCooldown         = 3 * time.Minute // 熔断冷却时间
+)
+
+// breakerState 单个平台的熔断状态
+type breakerState struct {
+	failures  int       // 连续失败次数
+	openUntil time.Time // 熔断截止时间
+}
+
+// CircuitBreaker 按平台维度的熔断器
+// 连续失败达到阈值后，在冷却期内直接跳过该平台
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	states    map[string]*breakerState
+	mu        sync.Mutex
+}
+
+// NewCircuitBreaker 创建熔断器
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold <= 0 {
+		threshold = defaultFailureThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = defaultCooldown
+	}
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		states:    make(map[string]*breakerState),
+	}
+}
+
+// Allow 判断平台当前是否允许请求
+func (b *CircuitBreaker) Allow(platform string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.states[platform]
+	if !ok {
+		return true
+	}
+	return time.Now().After(state.openUntil)
+}
+
+// RecordSuccess 记录成功，重置失败计数
+func (b *CircuitBreaker) RecordSuccess(platform string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.states, platform)
+}
+
+// RecordFailure 记录失败，达到阈值后打开熔断
+func (b *CircuitBreaker) RecordFailure(platform string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	state, ok := b.states[platform]
+	if !ok {
+		state = &breakerState{}
+		b.states[platform] = state
+	}
+	state.failures++
+	if state.failures >= b.threshold {
+		state.openUntil = time.Now().Add(b.cooldown)
+		state.failures = 0
+	}
+}
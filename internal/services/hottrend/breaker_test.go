@@ -0,0 +1,32 @@
+package hottrend
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	b := NewCircuitBreaker(2, time.Minute)
+
+	if !b.Allow("weibo") {
+		t.Fatal("初始状态应允许请求")
+	}
+
+	b.RecordFailure("weibo")
+	if !b.Allow("weibo") {
+		t.Fatal("未达到阈值前应允许请求")
+	}
+
+	b.RecordFailure("weibo")
+	if b.Allow("weibo") {
+		t.Fatal("达到阈值后应熔断")
+	}
+	if !b.Allow("zhihu") {
+		t.Fatal("其他平台不应受影响")
+	}
+
+	b.RecordSuccess("weibo")
+	if !b.Allow("weibo") {
+		t.Fatal("成功后应恢复")
+	}
+}
@@ -0,0 +1,41 @@
+package hottrend
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+)
+
+// fetcherClient 各抓取器共用的 HTTP Client，超时与单平台超时保持一致
+// 调整超时时整体替换 Client，避免与进行中的请求产生数据竞争
+type fetcherClient struct {
+	mu     sync.RWMutex
+	client *http.Client
+}
+
+// newFetcherClient 创建带超时的抓取器 HTTP Client
+func newFetcherClient(timeout time.Duration) *fetcherClient {
+	return &fetcherClient{client: proxy.GetManager().GetClientWithTimeout(timeout)}
+}
+
+// get 获取当前 HTTP Client
+func (c *fetcherClient) get() *http.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.client
+}
+
+// setTimeout 按新超时重建 HTTP Client，传 0 保持原值
+func (c *fetcherClient) setTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client.Timeout == timeout {
+		return
+	}
+	c.client = proxy.GetManager().GetClientWithTimeout(timeout)
+}
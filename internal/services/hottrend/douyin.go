@@ -1,23 +1,21 @@
 package hottrend
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"time"
-
-	"github.com/run-bigpig/jcp/internal/pkg/proxy"
 )
 
 // DouyinFetcher 抖音热点获取器
 type DouyinFetcher struct {
-	client *http.Client
+	client *fetcherClient
 }
 
 // NewDouyinFetcher 创建抖音热点获取器
-func NewDouyinFetcher() *DouyinFetcher {
+func NewDouyinFetcher(client *fetcherClient) *DouyinFetcher {
 	return &DouyinFetcher{
-		client: proxy.GetManager().GetClientWithTimeout(10 * time.Second),
+		client: client,
 	}
 }
 
@@ -35,17 +33,17 @@ type douyinResponse struct {
 }
 
 // Fetch 获取抖音热点数据
-func (f *DouyinFetcher) Fetch() ([]HotItem, error) {
+func (f *DouyinFetcher) Fetch(ctx context.Context) ([]HotItem, error) {
 	url := "https://www.douyin.com/aweme/v1/web/hot/search/list/"
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
 	req.Header.Set("Referer", "https://www.douyin.com/")
 
-	resp, err := f.client.Do(req)
+	resp, err := f.client.get().Do(req)
 	if err != nil {
 		return nil, err
 	}
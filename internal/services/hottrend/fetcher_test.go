@@ -1,23 +1,25 @@
 package hottrend
 
 import (
+	"context"
 	"testing"
 )
 
 // TestAllFetchers 测试所有平台的 fetcher
 func TestAllFetchers(t *testing.T) {
+	client := newFetcherClient(defaultPlatformTimeout)
 	fetchers := []Fetcher{
-		NewWeiboFetcher(),
-		NewZhihuFetcher(),
-		NewBilibiliFetcher(),
-		NewBaiduFetcher(),
-		NewDouyinFetcher(),
-		NewToutiaoFetcher(),
+		NewWeiboFetcher(client),
+		NewZhihuFetcher(client),
+		NewBilibiliFetcher(client),
+		NewBaiduFetcher(client),
+		NewDouyinFetcher(client),
+		NewToutiaoFetcher(client),
 	}
 
 	for _, f := range fetchers {
 		t.Run(f.Platform(), func(t *testing.T) {
-			items, err := f.Fetch()
+			items, err := f.Fetch(context.Background())
 			if err != nil {
 				t.Errorf("%s fetch failed: %v", f.PlatformCN(), err)
 				return
@@ -1,16 +1,30 @@
 package hottrend
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
 	"time"
 )
 
+// 超时默认参数
+const (
+	defaultPlatformTimeout = 8 * time.Second  // 单平台请求超时
+	defaultTotalBudget     = 12 * time.Second // 批量获取总耗时预算
+)
+
 // HotTrendService 舆情热点聚合服务
 type HotTrendService struct {
-	fetchers map[string]Fetcher
-	cache    *FileCache
+	fetchers        map[string]Fetcher
+	client          *fetcherClient // 抓取器共用，超时随 platformTimeout 调整
+	cache           *FileCache
+	breaker         *CircuitBreaker
+	platformTimeout time.Duration
+	totalBudget     time.Duration
+	mu              sync.RWMutex
 }
 
 // NewHotTrendService 创建舆情热点服务
@@ -28,21 +42,47 @@ func NewHotTrendService() (*HotTrendService, error) {
 	}
 
 	// 注册所有 fetcher
+	client := newFetcherClient(defaultPlatformTimeout)
 	fetchers := map[string]Fetcher{
-		"weibo":    NewWeiboFetcher(),
-		"zhihu":    NewZhihuFetcher(),
-		"bilibili": NewBilibiliFetcher(),
-		"baidu":    NewBaiduFetcher(),
-		"douyin":   NewDouyinFetcher(),
-		"toutiao":  NewToutiaoFetcher(),
+		"weibo":    NewWeiboFetcher(client),
+		"zhihu":    NewZhihuFetcher(client),
+		"bilibili": NewBilibiliFetcher(client),
+		"baidu":    NewBaiduFetcher(client),
+		"douyin":   NewDouyinFetcher(client),
+		"toutiao":  NewToutiaoFetcher(client),
 	}
 
 	return &HotTrendService{
-		fetchers: fetchers,
-		cache:    cache,
+		fetchers:        fetchers,
+		client:          client,
+		cache:           cache,
+		breaker:         NewCircuitBreaker(defaultFailureThreshold, defaultCooldown),
+		platformTimeout: defaultPlatformTimeout,
+		totalBudget:     defaultTotalBudget,
 	}, nil
 }
 
+// SetTimeouts 设置单平台超时和批量获取总预算，传 0 保持原值
+// 抓取器的 HTTP 超时同步调整为单平台超时
+func (s *HotTrendService) SetTimeouts(platformTimeout, totalBudget time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if platformTimeout > 0 {
+		s.platformTimeout = platformTimeout
+		s.client.setTimeout(platformTimeout)
+	}
+	if totalBudget > 0 {
+		s.totalBudget = totalBudget
+	}
+}
+
+// getTimeouts 获取当前超时设置
+func (s *HotTrendService) getTimeouts() (time.Duration, time.Duration) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.platformTimeout, s.totalBudget
+}
+
 // getCacheDir 获取缓存目录
 func getCacheDir() (string, error) {
 	homeDir, err := os.UserHomeDir()
@@ -78,15 +118,26 @@ func (s *HotTrendService) GetHotTrend(platform string) HotTrendResult {
 		}
 	}
 
+	// 熔断中的平台直接跳过
+	if !s.breaker.Allow(platform) {
+		return HotTrendResult{
+			Platform:   platform,
+			PlatformCN: fetcher.PlatformCN(),
+			Error:      "平台近期请求失败，暂时跳过",
+		}
+	}
+
 	// 从网络获取
-	items, err := fetcher.Fetch()
+	items, err := s.fetchWithTimeout(fetcher)
 	if err != nil {
+		s.breaker.RecordFailure(platform)
 		return HotTrendResult{
 			Platform:   platform,
 			PlatformCN: fetcher.PlatformCN(),
 			Error:      err.Error(),
 		}
 	}
+	s.breaker.RecordSuccess(platform)
 
 	// 写入缓存
 	_ = s.cache.Set(platform, items)
@@ -100,6 +151,20 @@ func (s *HotTrendService) GetHotTrend(platform string) HotTrendResult {
 	}
 }
 
+// fetchWithTimeout 带超时获取单个平台数据，超时后通过 ctx 取消进行中的请求
+func (s *HotTrendService) fetchWithTimeout(fetcher Fetcher) ([]HotItem, error) {
+	platformTimeout, _ := s.getTimeouts()
+
+	ctx, cancel := context.WithTimeout(context.Background(), platformTimeout)
+	defer cancel()
+
+	items, err := fetcher.Fetch(ctx)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("请求超时(%s)", platformTimeout)
+	}
+	return items, err
+}
+
 // GetAllHotTrends 并发获取所有平台的热点数据
 func (s *HotTrendService) GetAllHotTrends() []HotTrendResult {
 	platforms := make([]string, 0, len(s.fetchers))
@@ -110,18 +175,52 @@ func (s *HotTrendService) GetAllHotTrends() []HotTrendResult {
 }
 
 // GetHotTrends 并发获取指定平台的热点数据
+// 总耗时不超过预算，超出预算未返回的平台以错误结果占位
 func (s *HotTrendService) GetHotTrends(platforms []string) []HotTrendResult {
-	var wg sync.WaitGroup
-	results := make([]HotTrendResult, len(platforms))
+	_, totalBudget := s.getTimeouts()
+
+	type indexedResult struct {
+		idx    int
+		result HotTrendResult
+	}
+	ch := make(chan indexedResult, len(platforms))
 
 	for i, platform := range platforms {
-		wg.Add(1)
 		go func(idx int, p string) {
-			defer wg.Done()
-			results[idx] = s.GetHotTrend(p)
+			ch <- indexedResult{idx: idx, result: s.GetHotTrend(p)}
 		}(i, platform)
 	}
 
-	wg.Wait()
+	results := make([]HotTrendResult, len(platforms))
+	done := make([]bool, len(platforms))
+	timer := time.NewTimer(totalBudget)
+	defer timer.Stop()
+
+	for received := 0; received < len(platforms); received++ {
+		select {
+		case r := <-ch:
+			results[r.idx] = r.result
+			done[r.idx] = true
+		case <-timer.C:
+			for i, p := range platforms {
+				if !done[i] {
+					results[i] = HotTrendResult{
+						Platform:   p,
+						PlatformCN: s.platformCN(p),
+						Error:      "超出总耗时预算，已跳过",
+					}
+				}
+			}
+			return results
+		}
+	}
 	return results
 }
+
+// platformCN 获取平台中文名
+func (s *HotTrendService) platformCN(platform string) string {
+	if fetcher, ok := s.fetchers[platform]; ok {
+		return fetcher.PlatformCN()
+	}
+	return platform
+}
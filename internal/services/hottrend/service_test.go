@@ -0,0 +1,58 @@
+package hottrend
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// blockingFetcher 阻塞直到 ctx 结束，用于验证超时会取消请求
+type blockingFetcher struct {
+	cancelled chan struct{}
+}
+
+func (f *blockingFetcher) Platform() string   { return "blocking" }
+func (f *blockingFetcher) PlatformCN() string { return "阻塞平台" }
+
+func (f *blockingFetcher) Fetch(ctx context.Context) ([]HotItem, error) {
+	<-ctx.Done()
+	close(f.cancelled)
+	return nil, ctx.Err()
+}
+
+func TestFetchWithTimeoutCancels(t *testing.T) {
+	s := &HotTrendService{platformTimeout: 20 * time.Millisecond, totalBudget: time.Second}
+	f := &blockingFetcher{cancelled: make(chan struct{})}
+
+	_, err := s.fetchWithTimeout(f)
+	if err == nil || !strings.Contains(err.Error(), "请求超时") {
+		t.Fatalf("应返回超时错误: %v", err)
+	}
+	select {
+	case <-f.cancelled:
+	default:
+		t.Fatal("超时返回时请求应已被取消")
+	}
+}
+
+func TestSetTimeoutsUpdatesClient(t *testing.T) {
+	s := &HotTrendService{
+		client:          newFetcherClient(defaultPlatformTimeout),
+		platformTimeout: defaultPlatformTimeout,
+		totalBudget:     defaultTotalBudget,
+	}
+
+	s.SetTimeouts(45*time.Second, 0)
+	if got := s.client.get().Timeout; got != 45*time.Second {
+		t.Errorf("抓取器超时应随单平台超时调整: %s", got)
+	}
+	if _, budget := s.getTimeouts(); budget != defaultTotalBudget {
+		t.Errorf("总预算传 0 应保持原值: %s", budget)
+	}
+
+	s.SetTimeouts(0, 0)
+	if got := s.client.get().Timeout; got != 45*time.Second {
+		t.Errorf("传 0 应保持原超时: %s", got)
+	}
+}
@@ -1,23 +1,21 @@
 package hottrend
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"time"
-
-	"github.com/run-bigpig/jcp/internal/pkg/proxy"
 )
 
 // ToutiaoFetcher 头条热榜获取器
 type ToutiaoFetcher struct {
-	client *http.Client
+	client *fetcherClient
 }
 
 // NewToutiaoFetcher 创建头条热榜获取器
-func NewToutiaoFetcher() *ToutiaoFetcher {
+func NewToutiaoFetcher(client *fetcherClient) *ToutiaoFetcher {
 	return &ToutiaoFetcher{
-		client: proxy.GetManager().GetClientWithTimeout(10 * time.Second),
+		client: client,
 	}
 }
 
@@ -34,16 +32,16 @@ type toutiaoResponse struct {
 }
 
 // Fetch 获取头条热榜数据
-func (f *ToutiaoFetcher) Fetch() ([]HotItem, error) {
+func (f *ToutiaoFetcher) Fetch(ctx context.Context) ([]HotItem, error) {
 	url := "https://www.toutiao.com/hot-event/hot-board/?origin=toutiao_pc"
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
 
-	resp, err := f.client.Do(req)
+	resp, err := f.client.get().Do(req)
 	if err != nil {
 		return nil, err
 	}
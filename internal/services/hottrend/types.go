@@ -1,6 +1,9 @@
 package hottrend
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 // HotItem 热点条目
 type HotItem struct {
@@ -42,8 +45,8 @@ var SupportedPlatforms = []PlatformInfo{
 
 // Fetcher 热点数据获取接口
 type Fetcher interface {
-	// Fetch 获取热点数据
-	Fetch() ([]HotItem, error)
+	// Fetch 获取热点数据，ctx 取消或超时时中止请求
+	Fetch(ctx context.Context) ([]HotItem, error)
 	// Platform 返回平台标识
 	Platform() string
 	// PlatformCN 返回平台中文名
@@ -1,23 +1,21 @@
 package hottrend
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"time"
-
-	"github.com/run-bigpig/jcp/internal/pkg/proxy"
 )
 
 // WeiboFetcher 微博热搜获取器
 type WeiboFetcher struct {
-	client *http.Client
+	client *fetcherClient
 }
 
 // NewWeiboFetcher 创建微博热搜获取器
-func NewWeiboFetcher() *WeiboFetcher {
+func NewWeiboFetcher(client *fetcherClient) *WeiboFetcher {
 	return &WeiboFetcher{
-		client: proxy.GetManager().GetClientWithTimeout(10 * time.Second),
+		client: client,
 	}
 }
 
@@ -39,10 +37,10 @@ type weiboResponse struct {
 }
 
 // Fetch 获取微博热搜数据
-func (f *WeiboFetcher) Fetch() ([]HotItem, error) {
+func (f *WeiboFetcher) Fetch(ctx context.Context) ([]HotItem, error) {
 	url := "https://weibo.com/ajax/side/hotSearch"
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -50,7 +48,7 @@ func (f *WeiboFetcher) Fetch() ([]HotItem, error) {
 	req.Header.Set("Accept", "application/json, text/plain, */*")
 	req.Header.Set("Referer", "https://weibo.com/")
 
-	resp, err := f.client.Do(req)
+	resp, err := f.client.get().Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -1,23 +1,21 @@
 package hottrend
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"time"
-
-	"github.com/run-bigpig/jcp/internal/pkg/proxy"
 )
 
 // ZhihuFetcher 知乎热榜获取器
 type ZhihuFetcher struct {
-	client *http.Client
+	client *fetcherClient
 }
 
 // NewZhihuFetcher 创建知乎热榜获取器
-func NewZhihuFetcher() *ZhihuFetcher {
+func NewZhihuFetcher(client *fetcherClient) *ZhihuFetcher {
 	return &ZhihuFetcher{
-		client: proxy.GetManager().GetClientWithTimeout(10 * time.Second),
+		client: client,
 	}
 }
 
@@ -43,16 +41,16 @@ type zhihuResponse struct {
 }
 
 // Fetch 获取知乎热榜数据
-func (f *ZhihuFetcher) Fetch() ([]HotItem, error) {
+func (f *ZhihuFetcher) Fetch(ctx context.Context) ([]HotItem, error) {
 	url := "https://www.zhihu.com/api/v3/feed/topstory/hot-list-web?limit=50&desktop=true"
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
 
-	resp, err := f.client.Do(req)
+	resp, err := f.client.get().Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+)
+
+// timeoutClient 支持运行时调整超时的 HTTP Client
+// 调整超时时整体替换 Client，避免与进行中的请求产生数据竞争
+type timeoutClient struct {
+	mu     sync.RWMutex
+	client *http.Client
+}
+
+// newTimeoutClient 创建带超时的 HTTP Client
+func newTimeoutClient(timeout time.Duration) *timeoutClient {
+	return &timeoutClient{client: proxy.GetManager().GetClientWithTimeout(timeout)}
+}
+
+// get 获取当前 HTTP Client
+func (c *timeoutClient) get() *http.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.client
+}
+
+// setTimeout 按新超时重建 HTTP Client，传 0 保持原值
+func (c *timeoutClient) setTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client.Timeout == timeout {
+		return
+	}
+	c.client = proxy.GetManager().GetClientWithTimeout(timeout)
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+)
+
+// 蛋卷基金指数估值接口
+const danjuanIndexValuationURL = "https://danjuanfunds.com/djapi/index_eva/dj"
+
+// IndexValuation 指数估值及历史分位
+type IndexValuation struct {
+	Code          string  `json:"code"`          // 指数代码，如 SH000300
+	Name          string  `json:"name"`          // 指数名称
+	PE            float64 `json:"pe"`            // 市盈率
+	PB            float64 `json:"pb"`            // 市净率
+	PEPercentile  float64 `json:"pePercentile"`  // PE 历史百分位(%)
+	PBPercentile  float64 `json:"pbPercentile"`  // PB 历史百分位(%)
+	ROE           float64 `json:"roe"`           // 净资产收益率(%)
+	DividendYield float64 `json:"dividendYield"` // 股息率(%)
+	Level         string  `json:"level"`         // 估值水平: 低估/适中/高估
+	Date          string  `json:"date"`          // 估值日期
+}
+
+// danjuanIndexValuationResponse 蛋卷指数估值接口响应
+type danjuanIndexValuationResponse struct {
+	Data struct {
+		Items []danjuanIndexValuationItem `json:"items"`
+	} `json:"data"`
+	ResultCode int `json:"result_code"`
+}
+
+// danjuanIndexValuationItem 蛋卷指数估值条目，百分位和收益率均为小数
+type danjuanIndexValuationItem struct {
+	IndexCode    string  `json:"index_code"`
+	Name         string  `json:"name"`
+	PE           float64 `json:"pe"`
+	PB           float64 `json:"pb"`
+	PEPercentile float64 `json:"pe_percentile"`
+	PBPercentile float64 `json:"pb_percentile"`
+	ROE          float64 `json:"roe"`
+	Yield        float64 `json:"yeild"`
+	EvaType      string  `json:"eva_type"`
+	Date         string  `json:"date"`
+}
+
+// indexValuationCache 指数估值缓存
+type indexValuationCache struct {
+	data      []IndexValuation
+	timestamp time.Time
+}
+
+// IndexValuationService 指数估值服务
+type IndexValuationService struct {
+	client   *http.Client
+	cache    *indexValuationCache
+	cacheMu  sync.RWMutex
+	cacheTTL time.Duration
+}
+
+// NewIndexValuationService 创建指数估值服务
+func NewIndexValuationService() *IndexValuationService {
+	return &IndexValuationService{
+		client:   proxy.GetManager().GetClientWithTimeout(10 * time.Second),
+		cacheTTL: 24 * time.Hour, // 估值按日更新，缓存1天
+	}
+}
+
+// GetIndexValuations 获取主要指数估值及历史分位（带缓存）
+func (s *IndexValuationService) GetIndexValuations() ([]IndexValuation, error) {
+	s.cacheMu.RLock()
+	if s.cache != nil && time.Since(s.cache.timestamp) < s.cacheTTL {
+		defer s.cacheMu.RUnlock()
+		return s.cache.data, nil
+	}
+	s.cacheMu.RUnlock()
+
+	items, err := s.fetchIndexValuations()
+	if err != nil {
+		return nil, err
+	}
+
+	s.cacheMu.Lock()
+	s.cache = &indexValuationCache{data: items, timestamp: time.Now()}
+	s.cacheMu.Unlock()
+
+	return items, nil
+}
+
+// fetchIndexValuations 从蛋卷基金获取指数估值
+func (s *IndexValuationService) fetchIndexValuations() ([]IndexValuation, error) {
+	req, err := http.NewRequest("GET", danjuanIndexValuationURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Referer", "https://danjuanfunds.com/djmodule/value-center")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("获取指数估值失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	var result danjuanIndexValuationResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("解析指数估值失败: %w", err)
+	}
+	if result.ResultCode != 0 {
+		return nil, fmt.Errorf("指数估值接口返回错误码: %d", result.ResultCode)
+	}
+
+	return parseIndexValuations(result.Data.Items), nil
+}
+
+// parseIndexValuations 转换接口数据，百分位和收益率统一为百分数
+func parseIndexValuations(items []danjuanIndexValuationItem) []IndexValuation {
+	list := make([]IndexValuation, 0, len(items))
+	for _, it := range items {
+		list = append(list, IndexValuation{
+			Code:          it.IndexCode,
+			Name:          it.Name,
+			PE:            it.PE,
+			PB:            it.PB,
+			PEPercentile:  it.PEPercentile * 100,
+			PBPercentile:  it.PBPercentile * 100,
+			ROE:           it.ROE * 100,
+			DividendYield: it.Yield * 100,
+			Level:         valuationLevel(it.EvaType),
+			Date:          it.Date,
+		})
+	}
+	return list
+}
+
+// valuationLevel 估值类型转中文
+func valuationLevel(evaType string) string {
+	switch strings.ToLower(evaType) {
+	case "low":
+		return "低估"
+	case "mid":
+		return "适中"
+	case "high":
+		return "高估"
+	default:
+		return "未知"
+	}
+}
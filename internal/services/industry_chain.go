@@ -0,0 +1,45 @@
+package services
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/run-bigpig/jcp/internal/embed"
+)
+
+// IndustryChain 行业产业链位置
+type IndustryChain struct {
+	Industry             string   `json:"industry"`             // 行业名称（与股票基础数据行业字段一致）
+	Chain                string   `json:"chain"`                // 所属产业链
+	Position             string   `json:"position"`             // 产业链位置: 上游/中游/下游
+	Products             []string `json:"products"`             // 主要产品/业务
+	Upstream             []string `json:"upstream"`             // 上游环节
+	Downstream           []string `json:"downstream"`           // 下游环节
+	UpstreamIndustries   []string `json:"upstreamIndustries"`   // 上游对应的A股行业
+	DownstreamIndustries []string `json:"downstreamIndustries"` // 下游对应的A股行业
+}
+
+// industryChainsData industry_chains.json 的数据结构
+type industryChainsData struct {
+	Chains []IndustryChain `json:"chains"`
+}
+
+var (
+	industryChains     map[string]*IndustryChain
+	industryChainsOnce sync.Once
+)
+
+// GetIndustryChain 获取行业的产业链映射（尽力而为，未收录的行业返回 nil）
+func GetIndustryChain(industry string) *IndustryChain {
+	industryChainsOnce.Do(func() {
+		industryChains = make(map[string]*IndustryChain)
+		var data industryChainsData
+		if err := json.Unmarshal(embed.IndustryChainsJSON, &data); err != nil {
+			return
+		}
+		for i := range data.Chains {
+			industryChains[data.Chains[i].Industry] = &data.Chains[i]
+		}
+	})
+	return industryChains[industry]
+}
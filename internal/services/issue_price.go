@@ -0,0 +1,184 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	// issuePriceLookbackYears 仅考虑最近N年内的增发/配股，更早的发行价对当前股价已无参考意义
+	issuePriceLookbackYears = 3
+	// issuePriceNearPercent 现价高于发行价不超过该百分比视为贴近发行价
+	issuePriceNearPercent = 10
+)
+
+// 现价相对发行价的位置
+const (
+	IssueZoneBelow = "跌破发行价"
+	IssueZoneNear  = "贴近发行价"
+	IssueZoneAbove = "高于发行价"
+)
+
+// IssuePrice 最近一次增发或配股的发行价
+type IssuePrice struct {
+	Type        string  `json:"type"`        // 发行类型: 增发/配股
+	Method      string  `json:"method"`      // 发行方式，如定向增发、公开增发，配股为配股比例
+	Price       float64 `json:"price"`       // 发行价(元)
+	Shares      float64 `json:"shares"`      // 发行数量(股)
+	IssueDate   string  `json:"issueDate"`   // 发行日期（配股为股权登记日）
+	ListingDate string  `json:"listingDate"` // 新增股份上市日期
+}
+
+// issuePriceCache 增发/配股发行价缓存，无记录时 data 为 nil
+type issuePriceCache struct {
+	data      *IssuePrice
+	timestamp time.Time
+}
+
+// seoAPIItem 东方财富增发明细
+type seoAPIItem struct {
+	IssueWay         string  `json:"ISSUE_WAY"`
+	IssuePrice       float64 `json:"ISSUE_PRICE"`
+	IssueNum         float64 `json:"ISSUE_NUM"`
+	IssueDate        string  `json:"ISSUE_DATE"`
+	IssueListingDate string  `json:"ISSUE_LISTING_DATE"`
+}
+
+// allotmentAPIItem 东方财富配股明细
+type allotmentAPIItem struct {
+	PlacingRatio     any     `json:"PLACING_RATIO"` // 每10股配股数
+	IssuePrice       float64 `json:"ISSUE_PRICE"`
+	IssueNum         float64 `json:"ISSUE_NUM"`
+	EquityRecordDate string  `json:"EQUITY_RECORD_DATE"`
+	ListingDate      string  `json:"LISTING_DATE"`
+}
+
+// GetRecentIssuePrice 获取个股最近3年内最近一次增发或配股的发行价（按代码缓存1天）
+// 近3年无增发或配股时返回 nil
+func (s *ShareholderService) GetRecentIssuePrice(code string) (*IssuePrice, error) {
+	code = stripMarketPrefix(code)
+
+	s.issueCacheMu.RLock()
+	if cached, ok := s.issueCache[code]; ok && time.Since(cached.timestamp) < s.issueCacheTTL {
+		s.issueCacheMu.RUnlock()
+		return cached.data, nil
+	}
+	s.issueCacheMu.RUnlock()
+
+	seo, err := s.fetchSEOIssues(code)
+	if err != nil {
+		return nil, err
+	}
+	allotments, err := s.fetchAllotments(code)
+	if err != nil {
+		return nil, err
+	}
+	since := time.Now().AddDate(-issuePriceLookbackYears, 0, 0).Format("2006-01-02")
+	latest := latestIssuePrice(append(seo, allotments...), since)
+
+	s.issueCacheMu.Lock()
+	s.issueCache[code] = &issuePriceCache{data: latest, timestamp: time.Now()}
+	s.issueCacheMu.Unlock()
+
+	return latest, nil
+}
+
+// fetchSEOIssues 从东方财富获取增发明细
+func (s *ShareholderService) fetchSEOIssues(code string) ([]IssuePrice, error) {
+	params := url.Values{}
+	params.Set("reportName", "RPT_SEO_DETAIL")
+	params.Set("columns", "ALL")
+	params.Set("sortColumns", "ISSUE_DATE")
+	params.Set("sortTypes", "-1")
+	params.Set("pageSize", "20")
+	params.Set("pageNumber", "1")
+	params.Set("filter", fmt.Sprintf(`(SECURITY_CODE="%s")`, code))
+	params.Set("source", "WEB")
+	params.Set("client", "WEB")
+
+	var items []seoAPIItem
+	if _, err := fetchDatacenter(s.client, eastmoneyDatacenterURL+"?"+params.Encode(), &items); err != nil {
+		return nil, fmt.Errorf("获取增发明细失败: %w", err)
+	}
+
+	issues := make([]IssuePrice, 0, len(items))
+	for _, it := range items {
+		issues = append(issues, IssuePrice{
+			Type:        "增发",
+			Method:      it.IssueWay,
+			Price:       it.IssuePrice,
+			Shares:      it.IssueNum,
+			IssueDate:   trimDate(it.IssueDate),
+			ListingDate: trimDate(it.IssueListingDate),
+		})
+	}
+	return issues, nil
+}
+
+// fetchAllotments 从东方财富获取配股明细
+func (s *ShareholderService) fetchAllotments(code string) ([]IssuePrice, error) {
+	params := url.Values{}
+	params.Set("reportName", "RPT_IPO_ALLOTMENT")
+	params.Set("columns", "ALL")
+	params.Set("sortColumns", "EQUITY_RECORD_DATE")
+	params.Set("sortTypes", "-1")
+	params.Set("pageSize", "20")
+	params.Set("pageNumber", "1")
+	params.Set("filter", fmt.Sprintf(`(SECURITY_CODE="%s")`, code))
+	params.Set("source", "WEB")
+	params.Set("client", "WEB")
+
+	var items []allotmentAPIItem
+	if _, err := fetchDatacenter(s.client, eastmoneyDatacenterURL+"?"+params.Encode(), &items); err != nil {
+		return nil, fmt.Errorf("获取配股明细失败: %w", err)
+	}
+
+	issues := make([]IssuePrice, 0, len(items))
+	for _, it := range items {
+		method := "配股"
+		if ratio := anyToFloat(it.PlacingRatio); ratio > 0 {
+			method = fmt.Sprintf("10配%g", ratio)
+		}
+		issues = append(issues, IssuePrice{
+			Type:        "配股",
+			Method:      method,
+			Price:       it.IssuePrice,
+			Shares:      it.IssueNum,
+			IssueDate:   trimDate(it.EquityRecordDate),
+			ListingDate: trimDate(it.ListingDate),
+		})
+	}
+	return issues, nil
+}
+
+// latestIssuePrice 选出 since 之后发行日期最近且发行价有效的一次增发/配股，无则返回 nil
+func latestIssuePrice(issues []IssuePrice, since string) *IssuePrice {
+	var latest *IssuePrice
+	for i := range issues {
+		it := &issues[i]
+		if it.Price <= 0 || it.IssueDate == "" || it.IssueDate < since {
+			continue
+		}
+		if latest == nil || it.IssueDate > latest.IssueDate {
+			latest = it
+		}
+	}
+	return latest
+}
+
+// IssuePriceZone 计算现价相对发行价的溢价率(%)及所处位置
+func IssuePriceZone(price, issuePrice float64) (float64, string) {
+	if issuePrice <= 0 {
+		return 0, ""
+	}
+	premium := (price - issuePrice) / issuePrice * 100
+	switch {
+	case premium < 0:
+		return premium, IssueZoneBelow
+	case premium <= issuePriceNearPercent:
+		return premium, IssueZoneNear
+	default:
+		return premium, IssueZoneAbove
+	}
+}
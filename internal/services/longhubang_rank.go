@@ -0,0 +1,133 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+const (
+	// lhbRankPageSize 计算排名时每页拉取的龙虎榜条数
+	lhbRankPageSize = 200
+	// lhbRankMaxPages 计算排名时最多拉取的页数，覆盖单日全部上榜记录
+	lhbRankMaxPages = 5
+)
+
+// 龙虎榜主导力量
+const (
+	LHBDriverInstitution = "机构主导"
+	LHBDriverHotMoney    = "游资主导"
+	LHBDriverJoint       = "机构游资合力"
+	LHBDriverUnknown     = "无席位明细"
+)
+
+// LongHuBangRank 个股在当日龙虎榜中的净买入排名及买方席位构成
+type LongHuBangRank struct {
+	TradeDate     string                 `json:"tradeDate"`     // 交易日期
+	Item          *models.LongHuBangItem `json:"item"`          // 个股上榜数据，未上榜时为 nil
+	Rank          int                    `json:"rank"`          // 当日净买入排名（按股票去重，1 为净买入最多）
+	Total         int                    `json:"total"`         // 当日上榜股票数
+	InstBuy       float64                `json:"instBuy"`       // 机构席位（含沪深股通）买入额(元)
+	HotMoneyBuy   float64                `json:"hotMoneyBuy"`   // 知名游资席位买入额(元)
+	OtherBuy      float64                `json:"otherBuy"`      // 其他营业部买入额(元)
+	HotMoneySeats []string               `json:"hotMoneySeats"` // 买方识别到的知名游资
+	Driver        string                 `json:"driver"`        // 主导力量: 机构主导/游资主导/机构游资合力
+}
+
+// GetNetBuyRank 获取个股在指定交易日龙虎榜中的净买入排名及主导力量
+// tradeDate 为空时使用最近一个龙虎榜交易日
+func (s *LongHuBangService) GetNetBuyRank(code, tradeDate string) (*LongHuBangRank, error) {
+	code = stripMarketPrefix(code)
+	if tradeDate == "" {
+		latest, err := s.GetLongHuBangList(1, 1, "")
+		if err != nil {
+			return nil, err
+		}
+		if len(latest.Items) == 0 {
+			return nil, fmt.Errorf("暂无龙虎榜数据")
+		}
+		tradeDate = latest.Items[0].TradeDate
+	}
+
+	var items []models.LongHuBangItem
+	for page := 1; page <= lhbRankMaxPages; page++ {
+		result, err := s.GetLongHuBangList(lhbRankPageSize, page, tradeDate)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, result.Items...)
+		if len(result.Items) < lhbRankPageSize || len(items) >= result.Total {
+			break
+		}
+	}
+
+	rank := &LongHuBangRank{TradeDate: tradeDate}
+	rank.Item, rank.Rank, rank.Total = rankLongHuBang(items, code)
+	if rank.Item == nil {
+		return rank, nil
+	}
+
+	details, err := s.GetStockDetail(code, tradeDate)
+	if err != nil {
+		return nil, err
+	}
+	s.classifyBuySeats(rank, details)
+	return rank, nil
+}
+
+// rankLongHuBang 按净买入对当日上榜股票排名（同一股票多条上榜原因只计一次，取净买入最大的记录）
+func rankLongHuBang(items []models.LongHuBangItem, code string) (*models.LongHuBangItem, int, int) {
+	best := make(map[string]models.LongHuBangItem)
+	for _, item := range items {
+		if cur, ok := best[item.Code]; !ok || item.NetBuyAmt > cur.NetBuyAmt {
+			best[item.Code] = item
+		}
+	}
+
+	target, ok := best[code]
+	if !ok {
+		return nil, 0, len(best)
+	}
+	rank := 1
+	for c, item := range best {
+		if c != code && item.NetBuyAmt > target.NetBuyAmt {
+			rank++
+		}
+	}
+	return &target, rank, len(best)
+}
+
+// classifyBuySeats 按买方席位类型汇总买入额并判断主导力量
+// 机构买入不低于营业部买入的 1.5 倍为机构主导，营业部买入不低于机构的 1.5 倍为游资主导，否则为合力
+func (s *LongHuBangService) classifyBuySeats(rank *LongHuBangRank, details []models.LongHuBangDetail) {
+	for _, d := range details {
+		if d.Direction != "buy" {
+			continue
+		}
+		trader := s.IdentifyHotMoney(d.OperName)
+		switch {
+		case trader != nil && trader.Style == "机构":
+			rank.InstBuy += d.BuyAmt
+		case trader != nil:
+			rank.HotMoneyBuy += d.BuyAmt
+			if !slices.Contains(rank.HotMoneySeats, trader.Label) {
+				rank.HotMoneySeats = append(rank.HotMoneySeats, trader.Label)
+			}
+		default:
+			rank.OtherBuy += d.BuyAmt
+		}
+	}
+
+	seatBuy := rank.HotMoneyBuy + rank.OtherBuy
+	switch {
+	case rank.InstBuy == 0 && seatBuy == 0:
+		rank.Driver = LHBDriverUnknown
+	case rank.InstBuy >= seatBuy*1.5:
+		rank.Driver = LHBDriverInstitution
+	case seatBuy >= rank.InstBuy*1.5:
+		rank.Driver = LHBDriverHotMoney
+	default:
+		rank.Driver = LHBDriverJoint
+	}
+}
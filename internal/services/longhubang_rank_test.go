@@ -0,0 +1,48 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+func TestRankLongHuBang(t *testing.T) {
+	items := []models.LongHuBangItem{
+		{Code: "600001", NetBuyAmt: 5e8},
+		{Code: "000002", NetBuyAmt: 3e8, Reason: "日涨幅偏离值达7%"},
+		{Code: "000002", NetBuyAmt: 4e8, Reason: "连续三个交易日内涨幅偏离值累计达20%"},
+		{Code: "300003", NetBuyAmt: -1e8},
+	}
+
+	item, rank, total := rankLongHuBang(items, "000002")
+	if item == nil || rank != 2 || total != 3 || item.NetBuyAmt != 4e8 {
+		t.Errorf("排名错误: item=%+v rank=%d total=%d", item, rank, total)
+	}
+
+	item, _, total = rankLongHuBang(items, "688888")
+	if item != nil || total != 3 {
+		t.Errorf("未上榜应返回 nil: %+v", item)
+	}
+}
+
+func TestClassifyBuySeats(t *testing.T) {
+	s := NewLongHuBangService()
+	details := []models.LongHuBangDetail{
+		{OperName: "机构专用", BuyAmt: 1e8, Direction: "buy"},
+		{OperName: "深股通专用", BuyAmt: 5e7, Direction: "buy"},
+		{OperName: "某证券某营业部", BuyAmt: 3e7, Direction: "buy"},
+		{OperName: "机构专用", SellAmt: 2e8, Direction: "sell"},
+	}
+
+	rank := &LongHuBangRank{}
+	s.classifyBuySeats(rank, details)
+	if rank.InstBuy != 1.5e8 || rank.OtherBuy != 3e7 || rank.Driver != LHBDriverInstitution {
+		t.Errorf("席位分类错误: %+v", rank)
+	}
+
+	rank = &LongHuBangRank{}
+	s.classifyBuySeats(rank, nil)
+	if rank.Driver != LHBDriverUnknown {
+		t.Errorf("无明细时主导力量 = %s", rank.Driver)
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"github.com/run-bigpig/jcp/internal/models"
-	"github.com/run-bigpig/jcp/internal/pkg/proxy"
 )
 
 // 东方财富龙虎榜API
@@ -39,7 +38,7 @@ type LongHuBangListResult struct {
 
 // LongHuBangService 龙虎榜服务
 type LongHuBangService struct {
-	client   *http.Client
+	client   *timeoutClient
 	cache    *lhbCache
 	cacheMu  sync.RWMutex
 	cacheTTL time.Duration
@@ -48,11 +47,16 @@ type LongHuBangService struct {
 // NewLongHuBangService 创建龙虎榜服务
 func NewLongHuBangService() *LongHuBangService {
 	return &LongHuBangService{
-		client:   proxy.GetManager().GetClientWithTimeout(15 * time.Second),
+		client:   newTimeoutClient(15 * time.Second),
 		cacheTTL: 5 * time.Minute, // 缓存5分钟
 	}
 }
 
+// SetTimeout 设置请求超时，传 0 保持原值
+func (s *LongHuBangService) SetTimeout(timeout time.Duration) {
+	s.client.setTimeout(timeout)
+}
+
 // GetLongHuBangList 获取龙虎榜列表
 // tradeDate: 交易日期，格式 YYYY-MM-DD，为空则获取所有日期
 func (s *LongHuBangService) GetLongHuBangList(pageSize, pageNumber int, tradeDate string) (*LongHuBangListResult, error) {
@@ -116,7 +120,7 @@ func (s *LongHuBangService) fetchLongHuBangList(pageSize, pageNumber int, tradeD
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
 	req.Header.Set("Referer", "https://data.eastmoney.com/")
 
-	resp, err := s.client.Do(req)
+	resp, err := s.client.get().Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -271,7 +275,7 @@ func (s *LongHuBangService) fetchDetail(code, tradeDate, direction string) ([]mo
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
 	req.Header.Set("Referer", "https://data.eastmoney.com/")
 
-	resp, err := s.client.Do(req)
+	resp, err := s.client.get().Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+)
+
+// 融券异动判定参数
+const (
+	marginFetchDays       = 30  // 每次获取的明细天数
+	shortSpikeLookback    = 10  // 对比的历史交易日数
+	shortSpikeRatio       = 3.0 // 融券卖出量达到历史均值的倍数视为异动
+	shortSpikeMinLookback = 3   // 历史样本不足时不判定异动
+)
+
+// MarginRecord 个股单日融资融券明细
+type MarginRecord struct {
+	Date           string  `json:"date"`           // 交易日期
+	Close          float64 `json:"close"`          // 收盘价
+	ChangePercent  float64 `json:"changePercent"`  // 涨跌幅(%)
+	FinBalance     float64 `json:"finBalance"`     // 融资余额(元)
+	FinBuy         float64 `json:"finBuy"`         // 融资买入额(元)
+	ShortSellVol   float64 `json:"shortSellVol"`   // 融券卖出量(股)
+	ShortRepayVol  float64 `json:"shortRepayVol"`  // 融券偿还量(股)
+	ShortRemainVol float64 `json:"shortRemainVol"` // 融券余量(股)
+	ShortBalance   float64 `json:"shortBalance"`   // 融券余额(元)
+}
+
+// ShortSelling 个股融券做空数据
+type ShortSelling struct {
+	Code        string         `json:"code"`
+	Name        string         `json:"name"`
+	Records     []MarginRecord `json:"records"`     // 按日期降序
+	AvgSellVol  float64        `json:"avgSellVol"`  // 最新一日之前的融券卖出量均值(股)
+	SpikeRatio  float64        `json:"spikeRatio"`  // 最新一日融券卖出量 / 历史均值
+	Spike       bool           `json:"spike"`       // 是否融券卖出异动
+	RemainTrend string         `json:"remainTrend"` // 融券余量趋势: 增加/减少/持平
+}
+
+// marginDetailAPIItem 个股融资融券明细接口数据
+type marginDetailAPIItem struct {
+	Date    string  `json:"DATE"`
+	SCode   string  `json:"SCODE"`
+	SecName string  `json:"SECNAME"`
+	SPJ     float64 `json:"SPJ"`
+	ZDF     float64 `json:"ZDF"`
+	RZYE    float64 `json:"RZYE"`
+	RZMRE   float64 `json:"RZMRE"`
+	RQMCL   float64 `json:"RQMCL"`
+	RQCHL   float64 `json:"RQCHL"`
+	RQYL    float64 `json:"RQYL"`
+	RQYE    float64 `json:"RQYE"`
+}
+
+// marginCache 融资融券缓存
+type marginCache struct {
+	data      *ShortSelling
+	timestamp time.Time
+}
+
+// MarginService 融资融券数据服务
+type MarginService struct {
+	client   *http.Client
+	cache    map[string]*marginCache
+	cacheMu  sync.RWMutex
+	cacheTTL time.Duration
+}
+
+// NewMarginService 创建融资融券数据服务
+func NewMarginService() *MarginService {
+	return &MarginService{
+		client:   proxy.GetManager().GetClientWithTimeout(10 * time.Second),
+		cache:    make(map[string]*marginCache),
+		cacheTTL: time.Hour, // 明细按日更新，缓存1小时
+	}
+}
+
+// GetShortSelling 获取个股近期融券卖出数据（按代码缓存1小时）
+func (s *MarginService) GetShortSelling(code string) (*ShortSelling, error) {
+	code = stripMarketPrefix(code)
+
+	s.cacheMu.RLock()
+	if cached, ok := s.cache[code]; ok && time.Since(cached.timestamp) < s.cacheTTL {
+		s.cacheMu.RUnlock()
+		return cached.data, nil
+	}
+	s.cacheMu.RUnlock()
+
+	data, err := s.fetchShortSelling(code)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cacheMu.Lock()
+	s.cache[code] = &marginCache{data: data, timestamp: time.Now()}
+	s.cacheMu.Unlock()
+
+	return data, nil
+}
+
+// fetchShortSelling 从东方财富获取个股融资融券明细
+func (s *MarginService) fetchShortSelling(code string) (*ShortSelling, error) {
+	params := url.Values{}
+	params.Set("reportName", "RPTA_WEB_RZRQ_GGMX")
+	params.Set("columns", "ALL")
+	params.Set("sortColumns", "DATE")
+	params.Set("sortTypes", "-1")
+	params.Set("pageSize", fmt.Sprintf("%d", marginFetchDays))
+	params.Set("pageNumber", "1")
+	params.Set("filter", fmt.Sprintf(`(scode="%s")`, code))
+	params.Set("source", "WEB")
+	params.Set("client", "WEB")
+
+	var items []marginDetailAPIItem
+	if _, err := fetchDatacenter(s.client, eastmoneyDatacenterURL+"?"+params.Encode(), &items); err != nil {
+		return nil, fmt.Errorf("获取融资融券明细失败: %w", err)
+	}
+
+	result := &ShortSelling{Code: code}
+	for _, it := range items {
+		if result.Name == "" {
+			result.Name = it.SecName
+		}
+		result.Records = append(result.Records, MarginRecord{
+			Date:           trimDate(it.Date),
+			Close:          it.SPJ,
+			ChangePercent:  it.ZDF,
+			FinBalance:     it.RZYE,
+			FinBuy:         it.RZMRE,
+			ShortSellVol:   it.RQMCL,
+			ShortRepayVol:  it.RQCHL,
+			ShortRemainVol: it.RQYL,
+			ShortBalance:   it.RQYE,
+		})
+	}
+	analyzeShortSelling(result)
+	return result, nil
+}
+
+// analyzeShortSelling 计算融券卖出异动及融券余量趋势
+// Records 需按日期降序排列
+func analyzeShortSelling(data *ShortSelling) {
+	records := data.Records
+	if len(records) == 0 {
+		return
+	}
+
+	history := records[1:]
+	if len(history) > shortSpikeLookback {
+		history = history[:shortSpikeLookback]
+	}
+	if len(history) >= shortSpikeMinLookback {
+		var total float64
+		for _, r := range history {
+			total += r.ShortSellVol
+		}
+		data.AvgSellVol = total / float64(len(history))
+		if data.AvgSellVol > 0 {
+			data.SpikeRatio = records[0].ShortSellVol / data.AvgSellVol
+			data.Spike = data.SpikeRatio >= shortSpikeRatio
+		} else {
+			data.Spike = records[0].ShortSellVol > 0
+		}
+	}
+
+	// 融券余量趋势：最新一日对比回看窗口最早一日，变化不足5%视为持平
+	data.RemainTrend = "持平"
+	if len(records) > 1 {
+		base := records[len(history)].ShortRemainVol
+		latest := records[0].ShortRemainVol
+		switch {
+		case base == 0 && latest > 0:
+			data.RemainTrend = "增加"
+		case base > 0 && (latest-base)/base >= 0.05:
+			data.RemainTrend = "增加"
+		case base > 0 && (base-latest)/base >= 0.05:
+			data.RemainTrend = "减少"
+		}
+	}
+}
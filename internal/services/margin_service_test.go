@@ -0,0 +1,31 @@
+package services
+
+import "testing"
+
+// TestAnalyzeShortSelling 测试融券卖出异动判定
+func TestAnalyzeShortSelling(t *testing.T) {
+	data := &ShortSelling{Records: []MarginRecord{
+		{Date: "2026-03-06", ShortSellVol: 90000, ShortRemainVol: 300000},
+		{Date: "2026-03-05", ShortSellVol: 20000, ShortRemainVol: 220000},
+		{Date: "2026-03-04", ShortSellVol: 30000, ShortRemainVol: 210000},
+		{Date: "2026-03-03", ShortSellVol: 10000, ShortRemainVol: 200000},
+	}}
+	analyzeShortSelling(data)
+
+	if data.AvgSellVol != 20000 {
+		t.Errorf("历史均值错误: %.0f", data.AvgSellVol)
+	}
+	if !data.Spike || data.SpikeRatio != 4.5 {
+		t.Errorf("应判定为融券卖出异动: spike=%v ratio=%.2f", data.Spike, data.SpikeRatio)
+	}
+	if data.RemainTrend != "增加" {
+		t.Errorf("融券余量趋势错误: %s", data.RemainTrend)
+	}
+
+	// 历史样本不足时不判定异动
+	short := &ShortSelling{Records: data.Records[:2]}
+	analyzeShortSelling(short)
+	if short.Spike {
+		t.Error("样本不足时不应判定异动")
+	}
+}
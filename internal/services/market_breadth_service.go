@@ -13,6 +13,8 @@ import (
 
 const (
 	sinaStockCountURL = "http://vip.stock.finance.sina.com.cn/quotes_service/api/json_v2.php/Market_Center.getHQNodeStockCount?node=hs_a"
+	// 东方财富 Push2 API：上证指数、深证综指的成分股涨跌家数（f104 上涨, f105 下跌, f106 平盘）
+	eastmoneyBreadthURL = "https://push2.eastmoney.com/api/qt/ulist.np/get?fltt=2&invt=2&fields=f12,f104,f105,f106&secids=1.000001,0.399106"
 )
 
 // MarketBreadth 全市场涨跌统计
@@ -37,29 +39,56 @@ type MarketBreadthService struct {
 	cache    *breadthCache
 	cacheMu  sync.RWMutex
 	cacheTTL time.Duration
+	guard    *TradingHoursGuard
+
+	// 每日涨跌家数历史（按日积累）
+	historyMu   sync.Mutex
+	historyPath string
+	history     []BreadthDay
+	statusFn    func() MarketStatus
+
+	// 涨跌统计的数据源链
+	chain *SourceChain[BreadthProvider]
 }
 
 // NewMarketBreadthService 创建全市场涨跌统计服务
 func NewMarketBreadthService() *MarketBreadthService {
-	return &MarketBreadthService{
+	s := &MarketBreadthService{
 		client:   proxy.GetManager().GetClientWithTimeout(10 * time.Second),
 		cacheTTL: 10 * time.Second,
 	}
+	s.chain = newSourceChain[BreadthProvider](SourceTypeBreadth, sinaBreadthProvider{s}, eastmoneyBreadthProvider{s})
+	return s
+}
+
+// SetSourceChain 设置涨跌统计的数据源顺序及单个数据源重试次数，名称为空时使用默认顺序
+func (s *MarketBreadthService) SetSourceChain(names []string, retries int) {
+	s.chain.SetOrder(names, retries)
+}
+
+// SetGuard 设置休市守卫，休市期间直接复用最后一次统计结果
+func (s *MarketBreadthService) SetGuard(guard *TradingHoursGuard) {
+	s.guard = guard
 }
 
 // GetMarketBreadth 获取全市场涨跌统计（带缓存）
 func (s *MarketBreadthService) GetMarketBreadth() (*MarketBreadth, error) {
 	s.cacheMu.RLock()
-	if s.cache != nil && time.Since(s.cache.timestamp) < s.cacheTTL {
+	if s.cache != nil && (time.Since(s.cache.timestamp) < s.cacheTTL || s.guard.ShouldSkipFetch()) {
 		defer s.cacheMu.RUnlock()
 		return s.cache.data, nil
 	}
 	s.cacheMu.RUnlock()
 
-	data, err := s.fetchMarketBreadth()
+	data, err := runSourceChain(s.chain, func(p BreadthProvider) (*MarketBreadth, error) {
+		return p.FetchBreadth()
+	})
 	if err != nil {
 		return nil, err
 	}
+	if data == nil {
+		return nil, fmt.Errorf("market breadth empty")
+	}
 
 	s.cacheMu.Lock()
 	s.cache = &breadthCache{
@@ -67,6 +96,7 @@ func (s *MarketBreadthService) GetMarketBreadth() (*MarketBreadth, error) {
 		timestamp: time.Now(),
 	}
 	s.cacheMu.Unlock()
+	s.recordSnapshot(data, time.Now().In(cnLocation), false)
 
 	return data, nil
 }
@@ -118,3 +148,82 @@ func (s *MarketBreadthService) parseMarketBreadth(body []byte) (*MarketBreadth,
 		TotalCount:   int(total),
 	}, nil
 }
+
+// sinaBreadthProvider 新浪沪深A股涨跌统计
+type sinaBreadthProvider struct{ s *MarketBreadthService }
+
+func (p sinaBreadthProvider) Name() string { return SourceSina }
+
+// FetchBreadth 获取新浪涨跌统计，总数为 0 时视为无数据
+func (p sinaBreadthProvider) FetchBreadth() (*MarketBreadth, error) {
+	data, err := p.s.fetchMarketBreadth()
+	if err != nil {
+		return nil, err
+	}
+	if data.TotalCount == 0 {
+		return nil, errSourceEmpty
+	}
+	return data, nil
+}
+
+// eastmoneyBreadthProvider 东方财富指数成分股涨跌家数（上证指数 + 深证综指，不含北交所）
+type eastmoneyBreadthProvider struct{ s *MarketBreadthService }
+
+func (p eastmoneyBreadthProvider) Name() string { return SourceEastmoney }
+
+// FetchBreadth 获取东方财富涨跌家数
+func (p eastmoneyBreadthProvider) FetchBreadth() (*MarketBreadth, error) {
+	req, err := http.NewRequest("GET", eastmoneyBreadthURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Referer", "https://quote.eastmoney.com/")
+
+	resp, err := p.s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parseEastmoneyBreadth(body)
+	if err != nil {
+		return nil, err
+	}
+	if data.TotalCount == 0 {
+		return nil, errSourceEmpty
+	}
+	return data, nil
+}
+
+// parseEastmoneyBreadth 汇总各指数的成分股涨跌平家数
+func parseEastmoneyBreadth(body []byte) (*MarketBreadth, error) {
+	var result struct {
+		Data *struct {
+			Diff []struct {
+				F104 any `json:"f104"`
+				F105 any `json:"f105"`
+				F106 any `json:"f106"`
+			} `json:"diff"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("parse eastmoney breadth error: %w", err)
+	}
+
+	data := &MarketBreadth{}
+	if result.Data == nil {
+		return data, nil
+	}
+	for _, d := range result.Data.Diff {
+		data.AdvanceCount += int(anyToFloat(d.F104))
+		data.DeclineCount += int(anyToFloat(d.F105))
+		data.FlatCount += int(anyToFloat(d.F106))
+	}
+	data.TotalCount = data.AdvanceCount + data.DeclineCount + data.FlatCount
+	return data, nil
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TradingHoursGuard 休市守卫
+// 开启后在休市期间跳过行情类网络请求，优先返回最后一次缓存的数据
+type TradingHoursGuard struct {
+	enabled  atomic.Bool
+	statusFn func() MarketStatus
+
+	mu        sync.Mutex
+	closed    bool
+	checkedAt time.Time
+	checkTTL  time.Duration
+}
+
+// NewTradingHoursGuard 创建休市守卫，statusFn 用于获取当前市场状态
+func NewTradingHoursGuard(statusFn func() MarketStatus) *TradingHoursGuard {
+	return &TradingHoursGuard{
+		statusFn: statusFn,
+		checkTTL: 30 * time.Second,
+	}
+}
+
+// SetEnabled 设置是否仅在交易时段拉取行情
+func (g *TradingHoursGuard) SetEnabled(enabled bool) {
+	if g == nil {
+		return
+	}
+	g.enabled.Store(enabled)
+}
+
+// Enabled 是否已开启
+func (g *TradingHoursGuard) Enabled() bool {
+	return g != nil && g.enabled.Load()
+}
+
+// ShouldSkipFetch 开关开启且当前已休市时返回 true
+func (g *TradingHoursGuard) ShouldSkipFetch() bool {
+	if !g.Enabled() || g.statusFn == nil {
+		return false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	// 市场状态短时缓存，避免每次推送都重新判断
+	if time.Since(g.checkedAt) >= g.checkTTL {
+		g.closed = g.statusFn().Status == "closed"
+		g.checkedAt = time.Now()
+	}
+	return g.closed
+}
@@ -0,0 +1,40 @@
+package services
+
+import "testing"
+
+func TestTradingHoursGuard(t *testing.T) {
+	status := "closed"
+	calls := 0
+	guard := NewTradingHoursGuard(func() MarketStatus {
+		calls++
+		return MarketStatus{Status: status}
+	})
+
+	if guard.ShouldSkipFetch() {
+		t.Error("未开启时不应跳过请求")
+	}
+	if calls != 0 {
+		t.Errorf("未开启时不应查询市场状态, calls=%d", calls)
+	}
+
+	guard.SetEnabled(true)
+	if !guard.ShouldSkipFetch() {
+		t.Error("开启且休市时应跳过请求")
+	}
+
+	// 状态缓存期内不重复查询
+	status = "trading"
+	if !guard.ShouldSkipFetch() || calls != 1 {
+		t.Errorf("缓存期内应复用状态, calls=%d", calls)
+	}
+
+	guard.checkedAt = guard.checkedAt.Add(-guard.checkTTL)
+	if guard.ShouldSkipFetch() {
+		t.Error("交易中不应跳过请求")
+	}
+
+	var nilGuard *TradingHoursGuard
+	if nilGuard.ShouldSkipFetch() {
+		t.Error("nil 守卫不应跳过请求")
+	}
+}
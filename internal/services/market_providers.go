@@ -0,0 +1,274 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+const (
+	// 东方财富历史K线接口，fqt=0 不复权（与新浪K线保持一致），volume 单位为手
+	eastmoneyKLineURL = "https://push2his.eastmoney.com/api/qt/stock/kline/get?secid=%s&fields1=f1,f2,f3&fields2=f51,f52,f53,f54,f55,f56,f57&klt=%s&fqt=0&lmt=%d&end=20500101"
+	// sharesPerLot 每手股数，腾讯行情和东方财富K线的成交量以手为单位
+	sharesPerLot = 100
+)
+
+// sinaQuoteProvider 新浪实时行情
+type sinaQuoteProvider struct{ ms *MarketService }
+
+func (p sinaQuoteProvider) Name() string { return SourceSina }
+
+// FetchQuotes 获取新浪行情，全部代码均无数据时视为失败
+func (p sinaQuoteProvider) FetchQuotes(codes []string) ([]models.Stock, []string, error) {
+	body, failed, err := p.ms.fetchSinaQuotes(codes)
+	if err != nil {
+		return nil, nil, err
+	}
+	stocks, err := p.ms.parseSinaStockData(body, codes)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(stocks) == 0 && len(failed) == 0 {
+		return nil, nil, errSourceEmpty
+	}
+	return stocks, failed, nil
+}
+
+// tencentQuoteProvider 腾讯实时行情
+type tencentQuoteProvider struct{ ms *MarketService }
+
+func (p tencentQuoteProvider) Name() string { return SourceTencent }
+
+// FetchQuotes 获取腾讯行情，按请求代码顺序返回，成交量统一换算为股、成交额为元
+func (p tencentQuoteProvider) FetchQuotes(codes []string) ([]models.Stock, []string, error) {
+	// 新浪简化指数代码 s_sh000001 在腾讯对应简版行情，字段不同，统一按完整代码请求
+	requestCodes := make([]string, len(codes))
+	for i, code := range codes {
+		requestCodes[i] = strings.TrimPrefix(code, "s_")
+	}
+	fields, failedRequest, err := fetchTencentQuoteFields(p.ms.client.get(), requestCodes)
+	if err != nil {
+		return nil, nil, err
+	}
+	failedSet := make(map[string]bool, len(failedRequest))
+	for _, code := range failedRequest {
+		failedSet[code] = true
+	}
+
+	var stocks []models.Stock
+	var dates []string
+	var failed []string
+	for i, code := range codes {
+		if failedSet[requestCodes[i]] {
+			failed = append(failed, code)
+			continue
+		}
+		parts, ok := fields[requestCodes[i]]
+		if !ok {
+			continue
+		}
+		stocks = append(stocks, parseTencentStock(code, parts))
+		dates = append(dates, tencentQuoteDate(parts))
+	}
+	if len(stocks) == 0 && len(failed) == 0 {
+		return nil, nil, errSourceEmpty
+	}
+
+	quotes := make([]*models.Stock, len(stocks))
+	for i := range stocks {
+		quotes[i] = &stocks[i]
+	}
+	p.ms.markSuspended(quotes, dates)
+	return stocks, failed, nil
+}
+
+// parseTencentStock 解析腾讯行情字段，口径与新浪行情一致
+func parseTencentStock(code string, parts []string) models.Stock {
+	price := tencentFloat(parts, tqPrice)
+	preClose := tencentFloat(parts, tqPreClose)
+	// 盘前/无数据时当前价为0，回退到昨收价
+	if price == 0 && preClose > 0 {
+		price = preClose
+	}
+
+	change := price - preClose
+	changePercent := 0.0
+	if preClose > 0 {
+		changePercent = change / preClose * 100
+	}
+
+	return models.Stock{
+		Symbol:        code,
+		Name:          parts[tqName],
+		Price:         price,
+		Open:          tencentFloat(parts, tqOpen),
+		High:          tencentFloat(parts, tqHigh),
+		Low:           tencentFloat(parts, tqLow),
+		PreClose:      preClose,
+		Change:        change,
+		ChangePercent: changePercent,
+		Volume:        int64(tencentFloat(parts, tqVolume)) * sharesPerLot,
+		Amount:        tencentFloat(parts, tqAmount) * 10000,
+	}
+}
+
+// sinaKLineProvider 新浪K线
+type sinaKLineProvider struct{ ms *MarketService }
+
+func (p sinaKLineProvider) Name() string { return SourceSina }
+
+// FetchKLines 获取新浪K线
+func (p sinaKLineProvider) FetchKLines(code, period string, days int) ([]models.KLineData, error) {
+	url := fmt.Sprintf(sinaKLineURL, code, p.ms.periodToScale(period), days)
+
+	resp, err := p.ms.klineClient.get().Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	klines, err := p.ms.parseKLineData(string(body))
+	if err != nil {
+		return nil, err
+	}
+	if len(klines) == 0 {
+		return nil, errSourceEmpty
+	}
+	return klines, nil
+}
+
+// eastmoneyKLineProvider 东方财富K线
+type eastmoneyKLineProvider struct{ ms *MarketService }
+
+func (p eastmoneyKLineProvider) Name() string { return SourceEastmoney }
+
+// FetchKLines 获取东方财富K线，成交量换算为股并补算 MA5/10/20
+func (p eastmoneyKLineProvider) FetchKLines(code, period string, days int) ([]models.KLineData, error) {
+	url := fmt.Sprintf(eastmoneyKLineURL, toSecID(strings.TrimPrefix(code, "s_")), periodToKLT(period), days)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Referer", "https://quote.eastmoney.com/")
+
+	resp, err := p.ms.klineClient.get().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	klines, err := parseEastmoneyKLines(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(klines) == 0 {
+		return nil, errSourceEmpty
+	}
+	return klines, nil
+}
+
+// periodToKLT 周期转换为东方财富K线的 klt 参数
+func periodToKLT(period string) string {
+	switch period {
+	case "1m":
+		return "1"
+	case "5m":
+		return "5"
+	case "1w":
+		return "102"
+	case "1mo":
+		return "103"
+	default:
+		return "101"
+	}
+}
+
+// parseEastmoneyKLines 解析东方财富K线: 时间,开盘,收盘,最高,最低,成交量(手),成交额(元)
+// 分钟线时间补齐秒（2006-01-02 15:04 -> 2006-01-02 15:04:00），与新浪格式一致
+func parseEastmoneyKLines(body []byte) ([]models.KLineData, error) {
+	var result struct {
+		Data *struct {
+			KLines []string `json:"klines"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("parse eastmoney kline error: %w, body: %s", err, truncateBytes(body, 200))
+	}
+	if result.Data == nil {
+		return nil, nil
+	}
+
+	klines := make([]models.KLineData, 0, len(result.Data.KLines))
+	for _, line := range result.Data.KLines {
+		parts := strings.Split(line, ",")
+		if len(parts) < 7 {
+			continue
+		}
+		t := parts[0]
+		if len(t) == len("2006-01-02 15:04") {
+			t += ":00"
+		}
+		open, _ := strconv.ParseFloat(parts[1], 64)
+		closePrice, _ := strconv.ParseFloat(parts[2], 64)
+		high, _ := strconv.ParseFloat(parts[3], 64)
+		low, _ := strconv.ParseFloat(parts[4], 64)
+		volume, _ := strconv.ParseFloat(parts[5], 64)
+		amount, _ := strconv.ParseFloat(parts[6], 64)
+		klines = append(klines, models.KLineData{
+			Time:   t,
+			Open:   open,
+			High:   high,
+			Low:    low,
+			Close:  closePrice,
+			Volume: int64(volume) * sharesPerLot,
+			Amount: amount,
+		})
+	}
+	fillKLineMAs(klines)
+	return klines, nil
+}
+
+// fillKLineMAs 按收盘价补算 MA5/10/20，数据不足的周期保持为 0
+func fillKLineMAs(klines []models.KLineData) {
+	var sum5, sum10, sum20 float64
+	for i := range klines {
+		c := klines[i].Close
+		sum5 += c
+		sum10 += c
+		sum20 += c
+		if i >= 5 {
+			sum5 -= klines[i-5].Close
+		}
+		if i >= 10 {
+			sum10 -= klines[i-10].Close
+		}
+		if i >= 20 {
+			sum20 -= klines[i-20].Close
+		}
+		if i >= 4 {
+			klines[i].MA5 = sum5 / 5
+		}
+		if i >= 9 {
+			klines[i].MA10 = sum10 / 10
+		}
+		if i >= 19 {
+			klines[i].MA20 = sum20 / 20
+		}
+	}
+}
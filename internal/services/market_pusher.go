@@ -58,8 +58,12 @@ type MarketDataPusher struct {
 	klineSub   KLineSubscription
 	klineSubMu sync.RWMutex
 
-	// 快讯缓存（用于检测新快讯）
-	lastTelegraphContent string
+	// 已推送快讯指纹（按时间窗口去重）
+	telegraphSeen *telegraphDedup
+
+	// 行情、大盘指数推送合并（价格无变化时跳过）
+	stockCoalescer   *pushCoalescer
+	indicesCoalescer *pushCoalescer
 
 	// 控制
 	stopChan chan struct{}
@@ -69,11 +73,14 @@ type MarketDataPusher struct {
 // NewMarketDataPusher 创建市场数据推送服务
 func NewMarketDataPusher(marketService *MarketService, configService *ConfigService, newsService *NewsService) *MarketDataPusher {
 	return &MarketDataPusher{
-		marketService:   marketService,
-		configService:   configService,
-		newsService:     newsService,
-		subscribedCodes: make([]string, 0),
-		stopChan:        make(chan struct{}),
+		marketService:    marketService,
+		configService:    configService,
+		newsService:      newsService,
+		subscribedCodes:  make([]string, 0),
+		telegraphSeen:    newTelegraphDedup(),
+		stockCoalescer:   newPushCoalescer(),
+		indicesCoalescer: newPushCoalescer(),
+		stopChan:         make(chan struct{}),
 	}
 }
 
@@ -115,9 +122,7 @@ func (p *MarketDataPusher) setupEventListeners() {
 	runtime.EventsOn(p.ctx, EventOrderBookSubscribe, func(data ...any) {
 		if len(data) > 0 {
 			if code, ok := data[0].(string); ok {
-				p.mu.Lock()
-				p.currentOrderBook = code
-				p.mu.Unlock()
+				p.SetOrderBookSubscription(code)
 			}
 		}
 	})
@@ -148,13 +153,50 @@ func (p *MarketDataPusher) initSubscriptions() {
 
 	p.mu.Lock()
 	p.subscribedCodes = codes
-	// 默认订阅第一个股票的盘口
-	if len(codes) > 0 {
+	// 自动模式下默认订阅第一个股票的盘口
+	if len(codes) > 0 && p.autoSelectOrderBook() {
 		p.currentOrderBook = codes[0]
 	}
 	p.mu.Unlock()
 }
 
+// coalescePush 是否开启行情推送合并
+func (p *MarketDataPusher) coalescePush() bool {
+	config := p.configService.GetConfig()
+	return config != nil && config.CoalesceMarketPush
+}
+
+// autoSelectOrderBook 是否在未选中股票时自动选择盘口
+func (p *MarketDataPusher) autoSelectOrderBook() bool {
+	config := p.configService.GetConfig()
+	return config == nil || config.OrderBookPush != models.OrderBookPushManual
+}
+
+// SetOrderBookSubscription 设置当前推送盘口的股票，空字符串表示暂停盘口推送
+func (p *MarketDataPusher) SetOrderBookSubscription(code string) {
+	p.mu.Lock()
+	changed := p.currentOrderBook != code
+	p.currentOrderBook = code
+	p.mu.Unlock()
+
+	// 切换订阅后立即推送一次
+	if changed && code != "" && p.running {
+		go safeCall(p.pushOrderBookData)
+	}
+}
+
+// ClearOrderBookSubscription 清除盘口订阅，停止盘口推送
+func (p *MarketDataPusher) ClearOrderBookSubscription() {
+	p.SetOrderBookSubscription("")
+}
+
+// GetOrderBookSubscription 获取当前推送盘口的股票代码
+func (p *MarketDataPusher) GetOrderBookSubscription() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.currentOrderBook
+}
+
 // updateSubscriptions 更新订阅列表
 func (p *MarketDataPusher) updateSubscriptions(codes []any) {
 	p.mu.Lock()
@@ -234,10 +276,19 @@ func (p *MarketDataPusher) pushStockData() {
 		return
 	}
 
-	stocks, err := p.marketService.GetStockRealTimeData(codes...)
+	stocks, err := p.marketService.GetStockRealTimeDataPreferCache(codes...)
 	if err != nil {
 		return
 	}
+	if p.coalescePush() {
+		prices := make(map[string]float64, len(stocks))
+		for _, s := range stocks {
+			prices[s.Symbol] = s.Price
+		}
+		if !p.stockCoalescer.shouldEmit(prices, time.Now()) {
+			return
+		}
+	}
 
 	// 推送到前端
 	runtime.EventsEmit(p.ctx, EventStockUpdate, stocks)
@@ -254,7 +305,7 @@ func (p *MarketDataPusher) pushOrderBookData() {
 	}
 
 	// 获取当前选中股票的真实盘口数据
-	orderBook, err := p.marketService.GetRealOrderBook(code)
+	orderBook, err := p.marketService.GetRealOrderBookPreferCache(code)
 	if err != nil {
 		return
 	}
@@ -277,14 +328,14 @@ func (p *MarketDataPusher) pushTelegraphData() {
 	// 获取最新一条快讯
 	latest := telegraphs[0]
 
-	// 检查是否有新快讯（避免重复推送）
-	p.mu.Lock()
-	if latest.Content == p.lastTelegraphContent {
-		p.mu.Unlock()
+	// 窗口期内推送过的快讯不再推送（滚出列表后重新出现也能识别）
+	window := time.Duration(p.configService.GetConfig().TelegraphDedupMinutes) * time.Minute
+	if window <= 0 {
+		window = DefaultTelegraphDedupMinutes * time.Minute
+	}
+	if !p.telegraphSeen.markNew(latest, window, time.Now()) {
 		return
 	}
-	p.lastTelegraphContent = latest.Content
-	p.mu.Unlock()
 
 	// 推送到前端
 	runtime.EventsEmit(p.ctx, EventTelegraphUpdate, latest)
@@ -298,10 +349,19 @@ func (p *MarketDataPusher) pushMarketStatus() {
 
 // pushMarketIndices 推送大盘指数
 func (p *MarketDataPusher) pushMarketIndices() {
-	indices, err := p.marketService.GetMarketIndices()
+	indices, err := p.marketService.GetMarketIndicesPreferCache()
 	if err != nil {
 		return
 	}
+	if p.coalescePush() {
+		prices := make(map[string]float64, len(indices))
+		for _, idx := range indices {
+			prices[idx.Code] = idx.Price
+		}
+		if !p.indicesCoalescer.shouldEmit(prices, time.Now()) {
+			return
+		}
+	}
 	runtime.EventsEmit(p.ctx, EventMarketIndicesUpdate, indices)
 }
 
@@ -336,6 +396,10 @@ func (p *MarketDataPusher) pushKLineMinute() {
 	if sub.Code == "" || sub.Period != "1m" {
 		return
 	}
+	// 休市期间分时数据不再变化，跳过轮询
+	if p.marketService.Guard().ShouldSkipFetch() {
+		return
+	}
 
 	klines, err := p.marketService.GetKLineData(sub.Code, "1m", 240)
 	if err != nil {
@@ -359,6 +423,9 @@ func (p *MarketDataPusher) pushKLineDay() {
 	if sub.Code == "" || sub.Period == "1m" {
 		return
 	}
+	if p.marketService.Guard().ShouldSkipFetch() {
+		return
+	}
 
 	klines, err := p.marketService.GetKLineData(sub.Code, sub.Period, 120)
 	if err != nil {
@@ -372,6 +439,34 @@ func (p *MarketDataPusher) pushKLineDay() {
 	})
 }
 
+// Refresh 用户主动刷新：绕过休市守卫，立即拉取并推送行情、盘口、指数和K线
+func (p *MarketDataPusher) Refresh() {
+	if !p.running {
+		return
+	}
+
+	p.mu.RLock()
+	codes := make([]string, len(p.subscribedCodes))
+	copy(codes, p.subscribedCodes)
+	orderBookCode := p.currentOrderBook
+	p.mu.RUnlock()
+
+	if len(codes) > 0 {
+		if stocks, err := p.marketService.GetStockRealTimeData(codes...); err == nil {
+			runtime.EventsEmit(p.ctx, EventStockUpdate, stocks)
+		}
+	}
+	if orderBookCode != "" {
+		if orderBook, err := p.marketService.GetRealOrderBook(orderBookCode); err == nil {
+			runtime.EventsEmit(p.ctx, EventOrderBookUpdate, orderBook)
+		}
+	}
+	if indices, err := p.marketService.GetMarketIndices(); err == nil {
+		runtime.EventsEmit(p.ctx, EventMarketIndicesUpdate, indices)
+	}
+	p.pushKLineData()
+}
+
 // AddSubscription 添加订阅
 func (p *MarketDataPusher) AddSubscription(code string) {
 	p.mu.Lock()
@@ -394,7 +489,15 @@ func (p *MarketDataPusher) RemoveSubscription(code string) {
 	for i, c := range p.subscribedCodes {
 		if c == code {
 			p.subscribedCodes = append(p.subscribedCodes[:i], p.subscribedCodes[i+1:]...)
-			return
+			break
+		}
+	}
+
+	// 移除的正是当前盘口股票时，自动模式切换到第一只，否则暂停
+	if p.currentOrderBook == code {
+		p.currentOrderBook = ""
+		if len(p.subscribedCodes) > 0 && p.autoSelectOrderBook() {
+			p.currentOrderBook = p.subscribedCodes[0]
 		}
 	}
 }
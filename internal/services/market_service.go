@@ -1,11 +1,13 @@
 package services
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -13,7 +15,6 @@ import (
 
 	"github.com/run-bigpig/jcp/internal/logger"
 	"github.com/run-bigpig/jcp/internal/models"
-	"github.com/run-bigpig/jcp/internal/pkg/proxy"
 
 	"golang.org/x/text/encoding/simplifiedchinese"
 	"golang.org/x/text/transform"
@@ -27,6 +28,13 @@ const (
 	holidayAPIURL = "https://holiday.dreace.top/"
 )
 
+const (
+	// sinaQuoteBatchSize 新浪行情单次请求的最大代码数，避免自选股过多时 URL 超长
+	sinaQuoteBatchSize = 50
+	// sinaQuoteConcurrency 分批请求新浪行情的并发数
+	sinaQuoteConcurrency = 4
+)
+
 // 默认大盘指数代码
 var defaultIndexCodes = []string{
 	"s_sh000001", // 上证指数
@@ -63,7 +71,8 @@ type todayHolidayCache struct {
 
 // MarketService 市场数据服务
 type MarketService struct {
-	client *http.Client
+	client      *timeoutClient // 实时行情、指数等请求
+	klineClient *timeoutClient // K线请求
 
 	// 股票数据缓存
 	cache    map[string]*stockCache
@@ -73,15 +82,54 @@ type MarketService struct {
 	// 当天节假日缓存
 	todayCache   *todayHolidayCache
 	todayCacheMu sync.RWMutex
+
+	// 最后一次获取的行情（休市期间复用）
+	lastQuotes   map[string]models.Stock
+	lastIndices  []models.MarketIndex
+	lastQuotesMu sync.RWMutex
+
+	// 休市守卫
+	guard *TradingHoursGuard
+
+	// 最近一个有成交行情的日期，作为停牌判断的参考交易日
+	lastTradeDate   string
+	lastTradeDateMu sync.Mutex
+
+	// 实时行情与K线的数据源链
+	quoteChain *SourceChain[QuoteProvider]
+	klineChain *SourceChain[KLineProvider]
 }
 
 // NewMarketService 创建市场数据服务
 func NewMarketService() *MarketService {
-	return &MarketService{
-		client:   proxy.GetManager().GetClientWithTimeout(10 * time.Second),
-		cache:    make(map[string]*stockCache),
-		cacheTTL: 2 * time.Second, // 缓存2秒，避免频繁请求
-	}
+	ms := &MarketService{
+		client:      newTimeoutClient(10 * time.Second),
+		klineClient: newTimeoutClient(10 * time.Second),
+		cache:       make(map[string]*stockCache),
+		cacheTTL:    2 * time.Second, // 缓存2秒，避免频繁请求
+		lastQuotes:  make(map[string]models.Stock),
+	}
+	ms.guard = NewTradingHoursGuard(ms.GetMarketStatus)
+	ms.quoteChain = newSourceChain[QuoteProvider](SourceTypeQuote, sinaQuoteProvider{ms}, tencentQuoteProvider{ms})
+	ms.klineChain = newSourceChain[KLineProvider](SourceTypeKLine, sinaKLineProvider{ms}, eastmoneyKLineProvider{ms})
+	return ms
+}
+
+// SetTimeouts 设置实时行情和K线请求超时，传 0 保持原值
+func (ms *MarketService) SetTimeouts(quoteTimeout, klineTimeout time.Duration) {
+	ms.client.setTimeout(quoteTimeout)
+	ms.klineClient.setTimeout(klineTimeout)
+}
+
+// SetSourceChains 设置实时行情和K线的数据源顺序及单个数据源重试次数，名称为空时使用默认顺序
+func (ms *MarketService) SetSourceChains(quote, kline []string, retries int) {
+	ms.quoteChain.SetOrder(quote, retries)
+	ms.klineChain.SetOrder(kline, retries)
+}
+
+// Guard 获取休市守卫
+func (ms *MarketService) Guard() *TradingHoursGuard {
+	return ms.guard
 }
 
 // GetStockDataWithOrderBook 获取股票实时数据（含真实盘口），带缓存
@@ -121,33 +169,96 @@ func (ms *MarketService) GetStockDataWithOrderBook(codes ...string) ([]StockWith
 
 // fetchStockDataWithOrderBook 从API获取股票数据（含盘口）
 func (ms *MarketService) fetchStockDataWithOrderBook(codes ...string) ([]StockWithOrderBook, error) {
+	body, _, err := ms.fetchSinaQuotes(codes)
+	if err != nil {
+		return nil, err
+	}
+	return ms.parseSinaStockDataWithOrderBook(body)
+}
+
+// fetchSinaQuotes 分批并发请求新浪行情并按批次顺序拼接响应
+// 代码过多时单个 URL 可能超长导致整体失败，因此每批最多 sinaQuoteBatchSize 个代码；
+// 单批失败只丢弃该批行情并在 failed 中返回该批代码，全部批次失败时才返回错误
+func (ms *MarketService) fetchSinaQuotes(codes []string) (string, []string, error) {
+	batches := chunkCodes(codes, sinaQuoteBatchSize)
+	if len(batches) == 1 {
+		body, err := ms.fetchSinaQuoteBatch(batches[0])
+		return body, nil, err
+	}
+
+	bodies := make([]string, len(batches))
+	errs := make([]error, len(batches))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, sinaQuoteConcurrency)
+	for i, batch := range batches {
+		wg.Add(1)
+		go func(i int, batch []string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			bodies[i], errs[i] = ms.fetchSinaQuoteBatch(batch)
+		}(i, batch)
+	}
+	wg.Wait()
+
+	var sb strings.Builder
+	var lastErr error
+	var failed []string
+	for i, body := range bodies {
+		if errs[i] != nil {
+			log.Warn("新浪行情第%d/%d批请求失败: %v", i+1, len(batches), errs[i])
+			lastErr = errs[i]
+			failed = append(failed, batches[i]...)
+			continue
+		}
+		sb.WriteString(body)
+		sb.WriteString("\n")
+	}
+	if len(failed) == len(codes) {
+		return "", nil, lastErr
+	}
+	return sb.String(), failed, nil
+}
+
+// fetchSinaQuoteBatch 单次请求新浪行情（GBK 解码）
+func (ms *MarketService) fetchSinaQuoteBatch(codes []string) (string, error) {
 	codeList := strings.Join(codes, ",")
 	url := fmt.Sprintf(sinaStockURL, time.Now().UnixNano(), codeList)
 
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	req.Header.Set("Referer", "http://finance.sina.com.cn")
 
-	resp, err := ms.client.Do(req)
+	resp, err := ms.client.get().Do(req)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	defer resp.Body.Close()
 
 	reader := transform.NewReader(resp.Body, simplifiedchinese.GBK.NewDecoder())
 	body, err := io.ReadAll(reader)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
+	return string(body), nil
+}
 
-	return ms.parseSinaStockDataWithOrderBook(string(body))
+// chunkCodes 将代码按 size 分批，保持原有顺序
+func chunkCodes(codes []string, size int) [][]string {
+	var batches [][]string
+	for start := 0; start < len(codes); start += size {
+		end := min(start+size, len(codes))
+		batches = append(batches, codes[start:end])
+	}
+	return batches
 }
 
 // parseSinaStockDataWithOrderBook 解析新浪股票数据（含盘口）
 func (ms *MarketService) parseSinaStockDataWithOrderBook(data string) ([]StockWithOrderBook, error) {
 	var stocks []StockWithOrderBook
+	var dates []string
 	re := regexp.MustCompile(`var hq_str_(\w+)="([^"]*)"`)
 	matches := re.FindAllStringSubmatch(data, -1)
 
@@ -161,43 +272,156 @@ func (ms *MarketService) parseSinaStockDataWithOrderBook(data string) ([]StockWi
 		}
 		stock := ms.parseStockWithOrderBook(match[1], parts)
 		stocks = append(stocks, stock)
+		dates = append(dates, quoteDate(parts))
+	}
+
+	// 标记停牌
+	quotes := make([]*models.Stock, len(stocks))
+	for i := range stocks {
+		quotes[i] = &stocks[i].Stock
 	}
+	ms.markSuspended(quotes, dates)
 	return stocks, nil
 }
 
-// GetStockRealTimeData 获取股票实时数据
+// GetStockRealTimeData 获取股票实时数据，按行情数据源链依次尝试，代码较多时自动分批请求
 func (ms *MarketService) GetStockRealTimeData(codes ...string) ([]models.Stock, error) {
 	if len(codes) == 0 {
 		return nil, nil
 	}
 
-	codeList := strings.Join(codes, ",")
-	url := fmt.Sprintf(sinaStockURL, time.Now().UnixNano(), codeList)
-
-	req, err := http.NewRequest("GET", url, nil)
+	stocks, err := fetchQuotes(ms.quoteChain, codes)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Referer", "http://finance.sina.com.cn")
 
-	resp, err := ms.client.Do(req)
+	ms.lastQuotesMu.Lock()
+	for _, stock := range stocks {
+		ms.lastQuotes[stock.Symbol] = stock
+	}
+	ms.lastQuotesMu.Unlock()
+
+	return stocks, nil
+}
+
+// quoteResult 单个行情数据源的结果
+type quoteResult struct {
+	source string
+	stocks []models.Stock
+	failed []string // 请求失败批次中的代码
+}
+
+// fetchQuotes 按数据源链获取行情；数据源部分批次失败时，失败批次的代码由链中后续数据源补齐
+// 补齐失败只记录日志，返回已获取的部分行情；结果按请求代码顺序排列
+func fetchQuotes(chain *SourceChain[QuoteProvider], codes []string) ([]models.Stock, error) {
+	result, err := runSourceChain(chain, func(p QuoteProvider) (quoteResult, error) {
+		stocks, failed, err := p.FetchQuotes(codes)
+		return quoteResult{source: p.Name(), stocks: stocks, failed: failed}, err
+	})
+	if err != nil || len(result.failed) == 0 {
+		return result.stocks, err
+	}
+
+	fill, err := fetchQuotes(chain.after(result.source), result.failed)
 	if err != nil {
-		return nil, err
+		log.Warn("补齐 %d 个代码的行情失败: %v", len(result.failed), err)
 	}
-	defer resp.Body.Close()
+	stocks := append(result.stocks, fill...)
+	index := make(map[string]int, len(codes))
+	for i, code := range codes {
+		index[code] = i
+	}
+	slices.SortStableFunc(stocks, func(a, b models.Stock) int {
+		return cmp.Compare(index[a.Symbol], index[b.Symbol])
+	})
+	return stocks, nil
+}
 
-	reader := transform.NewReader(resp.Body, simplifiedchinese.GBK.NewDecoder())
-	body, err := io.ReadAll(reader)
+// SuspensionStatus 停牌检测结果
+type SuspensionStatus struct {
+	Code          string `json:"code"`
+	Name          string `json:"name"`
+	Suspended     bool   `json:"suspended"`     // 是否停牌
+	LastTradeDate string `json:"lastTradeDate"` // 最后交易日（停牌时为停牌前最后一个有成交的交易日）
+}
+
+// CheckSuspension 检测股票是否停牌
+// 同时请求上证指数作为参考交易日，避免单只股票查询时缺少参考
+func (ms *MarketService) CheckSuspension(code string) (*SuspensionStatus, error) {
+	stocks, err := ms.GetStockRealTimeData(code, "sh000001")
 	if err != nil {
 		return nil, err
 	}
 
-	return ms.parseSinaStockData(string(body), codes)
+	var stock *models.Stock
+	for i := range stocks {
+		if stocks[i].Symbol == code {
+			stock = &stocks[i]
+			break
+		}
+	}
+	if stock == nil {
+		return nil, fmt.Errorf("未获取到 %s 的行情", code)
+	}
+
+	status := &SuspensionStatus{
+		Code:      code,
+		Name:      stock.Name,
+		Suspended: stock.Suspended,
+	}
+
+	// 日K最后一根即最后交易日
+	if klines, err := ms.GetKLineData(code, "1d", 1); err == nil && len(klines) > 0 {
+		status.LastTradeDate = klines[len(klines)-1].Time
+	}
+	return status, nil
+}
+
+// GetStockRealTimeDataPreferCache 获取股票实时数据，休市守卫生效时优先返回最后一次行情
+// 缓存中缺失的股票仍会请求网络
+func (ms *MarketService) GetStockRealTimeDataPreferCache(codes ...string) ([]models.Stock, error) {
+	if len(codes) == 0 || !ms.guard.ShouldSkipFetch() {
+		return ms.GetStockRealTimeData(codes...)
+	}
+
+	var missing []string
+	ms.lastQuotesMu.RLock()
+	for _, code := range codes {
+		if _, ok := ms.lastQuotes[code]; !ok {
+			missing = append(missing, code)
+		}
+	}
+	ms.lastQuotesMu.RUnlock()
+
+	if len(missing) > 0 {
+		if _, err := ms.GetStockRealTimeData(missing...); err != nil {
+			return nil, err
+		}
+	}
+
+	ms.lastQuotesMu.RLock()
+	defer ms.lastQuotesMu.RUnlock()
+	stocks := make([]models.Stock, 0, len(codes))
+	for _, code := range codes {
+		if stock, ok := ms.lastQuotes[code]; ok {
+			stocks = append(stocks, stock)
+		}
+	}
+	return stocks, nil
+}
+
+// LastQuote 获取本次运行中最后一次成功获取的行情，不请求网络
+func (ms *MarketService) LastQuote(code string) (models.Stock, bool) {
+	ms.lastQuotesMu.RLock()
+	defer ms.lastQuotesMu.RUnlock()
+	stock, ok := ms.lastQuotes[code]
+	return stock, ok
 }
 
 // parseSinaStockData 解析新浪股票数据
 func (ms *MarketService) parseSinaStockData(data string, codes []string) ([]models.Stock, error) {
 	var stocks []models.Stock
+	var dates []string
 	re := regexp.MustCompile(`var hq_str_(\w+)="([^"]*)"`)
 	matches := re.FindAllStringSubmatch(data, -1)
 
@@ -212,10 +436,48 @@ func (ms *MarketService) parseSinaStockData(data string, codes []string) ([]mode
 
 		stock := ms.parseStockFields(match[1], parts)
 		stocks = append(stocks, stock)
+		dates = append(dates, quoteDate(parts))
 	}
+
+	// 标记停牌
+	quotes := make([]*models.Stock, len(stocks))
+	for i := range stocks {
+		quotes[i] = &stocks[i]
+	}
+	ms.markSuspended(quotes, dates)
 	return stocks, nil
 }
 
+// quoteDate 新浪行情中的日期字段（索引30，格式 2006-01-02）
+func quoteDate(parts []string) string {
+	if len(parts) > 30 {
+		return strings.TrimSpace(parts[30])
+	}
+	return ""
+}
+
+// isSuspendedQuote 判断行情是否处于停牌：无开盘价、无成交且行情日期早于参考交易日
+func isSuspendedQuote(open float64, volume int64, date, refDate string) bool {
+	return open == 0 && volume == 0 && date != "" && refDate != "" && date < refDate
+}
+
+// markSuspended 根据参考交易日标记停牌股票
+// 参考交易日取本批及历史行情中有成交的最新日期，dates 与 stocks 一一对应
+func (ms *MarketService) markSuspended(stocks []*models.Stock, dates []string) {
+	ms.lastTradeDateMu.Lock()
+	for i, s := range stocks {
+		if s.Volume > 0 && dates[i] > ms.lastTradeDate {
+			ms.lastTradeDate = dates[i]
+		}
+	}
+	refDate := ms.lastTradeDate
+	ms.lastTradeDateMu.Unlock()
+
+	for i, s := range stocks {
+		s.Suspended = isSuspendedQuote(s.Open, s.Volume, dates[i], refDate)
+	}
+}
+
 // parseStockFields 解析股票字段
 func (ms *MarketService) parseStockFields(code string, parts []string) models.Stock {
 	price, _ := strconv.ParseFloat(parts[3], 64)
@@ -331,23 +593,11 @@ func (ms *MarketService) calculateOrderBookTotals(items []models.OrderBookItem)
 	}
 }
 
-// GetKLineData 获取K线数据
+// GetKLineData 获取K线数据，按K线数据源链依次尝试
 func (ms *MarketService) GetKLineData(code string, period string, days int) ([]models.KLineData, error) {
-	scale := ms.periodToScale(period)
-	url := fmt.Sprintf(sinaKLineURL, code, scale, days)
-
-	resp, err := ms.client.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	klines, err := ms.parseKLineData(string(body))
+	klines, err := runSourceChain(ms.klineChain, func(p KLineProvider) ([]models.KLineData, error) {
+		return p.FetchKLines(code, period, days)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -366,6 +616,8 @@ func (ms *MarketService) periodToScale(period string) string {
 	switch period {
 	case "1m":
 		return "1" // 1分钟线（分时图）
+	case "5m":
+		return "5" // 5分钟线（多日，不做当天过滤）
 	case "1d":
 		return "240" // 日线
 	case "1w":
@@ -482,6 +734,19 @@ func (ms *MarketService) GetRealOrderBook(code string) (models.OrderBook, error)
 	return data[0].OrderBook, nil
 }
 
+// GetRealOrderBookPreferCache 获取盘口数据，休市守卫生效时优先返回最后一次缓存（忽略过期时间）
+func (ms *MarketService) GetRealOrderBookPreferCache(code string) (models.OrderBook, error) {
+	if ms.guard.ShouldSkipFetch() {
+		ms.cacheMu.RLock()
+		cached, ok := ms.cache[code]
+		ms.cacheMu.RUnlock()
+		if ok && len(cached.data) > 0 {
+			return cached.data[0].OrderBook, nil
+		}
+	}
+	return ms.GetRealOrderBook(code)
+}
+
 // GenerateOrderBook 生成盘口数据（保留兼容，建议使用 GetRealOrderBook）
 func (ms *MarketService) GenerateOrderBook(price float64) models.OrderBook {
 	var bids, asks []models.OrderBookItem
@@ -602,7 +867,7 @@ func (ms *MarketService) getTodayHolidayStatus() (bool, string) {
 
 // fetchTodayHolidayStatus 从 API 获取当天节假日状态
 func (ms *MarketService) fetchTodayHolidayStatus() (bool, string) {
-	resp, err := ms.client.Get(holidayAPIURL)
+	resp, err := ms.client.get().Get(holidayAPIURL)
 	if err != nil {
 		fmt.Println("[fetchTodayHolidayStatus] request error:", err)
 		return false, ""
@@ -642,7 +907,7 @@ func (ms *MarketService) GetMarketIndices() ([]models.MarketIndex, error) {
 	}
 	req.Header.Set("Referer", "http://finance.sina.com.cn")
 
-	resp, err := ms.client.Do(req)
+	resp, err := ms.client.get().Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -654,7 +919,29 @@ func (ms *MarketService) GetMarketIndices() ([]models.MarketIndex, error) {
 		return nil, err
 	}
 
-	return ms.parseMarketIndices(string(body))
+	indices, err := ms.parseMarketIndices(string(body))
+	if err != nil {
+		return nil, err
+	}
+
+	ms.lastQuotesMu.Lock()
+	ms.lastIndices = indices
+	ms.lastQuotesMu.Unlock()
+
+	return indices, nil
+}
+
+// GetMarketIndicesPreferCache 获取大盘指数，休市守卫生效时优先返回最后一次数据
+func (ms *MarketService) GetMarketIndicesPreferCache() ([]models.MarketIndex, error) {
+	if ms.guard.ShouldSkipFetch() {
+		ms.lastQuotesMu.RLock()
+		indices := ms.lastIndices
+		ms.lastQuotesMu.RUnlock()
+		if len(indices) > 0 {
+			return indices, nil
+		}
+	}
+	return ms.GetMarketIndices()
 }
 
 // parseMarketIndices 解析大盘指数数据
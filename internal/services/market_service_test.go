@@ -1,6 +1,7 @@
 package services
 
 import (
+	"fmt"
 	"testing"
 )
 
@@ -140,3 +141,42 @@ func TestGetKLineData(t *testing.T) {
 		}
 	})
 }
+
+func TestIsSuspendedQuote(t *testing.T) {
+	cases := []struct {
+		open    float64
+		volume  int64
+		date    string
+		refDate string
+		want    bool
+	}{
+		{0, 0, "2026-09-30", "2026-10-16", true},  // 无成交且日期陈旧
+		{0, 0, "2026-10-16", "2026-10-16", false}, // 盘前/集合竞价未成交
+		{10.5, 1200, "2026-10-16", "2026-10-16", false},
+		{0, 0, "2026-09-30", "", false}, // 缺少参考交易日
+	}
+	for _, c := range cases {
+		if got := isSuspendedQuote(c.open, c.volume, c.date, c.refDate); got != c.want {
+			t.Errorf("isSuspendedQuote(%v, %d, %s, %s) = %v, want %v", c.open, c.volume, c.date, c.refDate, got, c.want)
+		}
+	}
+}
+
+func TestChunkCodes(t *testing.T) {
+	codes := make([]string, 0, 120)
+	for i := 0; i < 120; i++ {
+		codes = append(codes, fmt.Sprintf("sh%06d", 600000+i))
+	}
+
+	batches := chunkCodes(codes, sinaQuoteBatchSize)
+	if len(batches) != 3 || len(batches[0]) != 50 || len(batches[2]) != 20 {
+		t.Fatalf("分批结果错误: %d 批", len(batches))
+	}
+	if batches[1][0] != "sh600050" || batches[2][19] != "sh600119" {
+		t.Errorf("分批未保持顺序: %s %s", batches[1][0], batches[2][19])
+	}
+
+	if batches := chunkCodes(codes[:3], sinaQuoteBatchSize); len(batches) != 1 || len(batches[0]) != 3 {
+		t.Errorf("少量代码应只有一批: %v", batches)
+	}
+}
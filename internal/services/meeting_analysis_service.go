@@ -0,0 +1,131 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/store"
+)
+
+// MeetingAnalysisService 会议技术分析存档服务，存档保存在数据目录下的 meeting_analysis 目录
+type MeetingAnalysisService struct {
+	backend store.Store
+}
+
+// NewMeetingAnalysisService 创建会议技术分析存档服务
+func NewMeetingAnalysisService(dataDir string) *MeetingAnalysisService {
+	backend, err := store.NewFileStore(filepath.Join(dataDir, "meeting_analysis"))
+	if err != nil {
+		fmt.Printf("创建meeting_analysis目录失败: %v\n", err)
+	}
+	return &MeetingAnalysisService{backend: backend}
+}
+
+// MeetingAnalysisID 生成会议存档ID：股票代码_会议开始时间
+func MeetingAnalysisID(stockCode string, t time.Time) string {
+	return stockCode + "_" + t.Format("20060102150405")
+}
+
+// validMeetingAnalysisID 校验存档ID，ID 直接作为文件名使用
+func validMeetingAnalysisID(id string) bool {
+	return id != "" && !strings.ContainsAny(id, `/\:*?"<>|`) && !strings.HasPrefix(id, ".")
+}
+
+// Begin 开始记录一场会议的技术分析
+func (s *MeetingAnalysisService) Begin(stockCode, query string) *MeetingAnalysisRecorder {
+	now := time.Now()
+	return &MeetingAnalysisRecorder{
+		service: s,
+		record: models.MeetingAnalysis{
+			MeetingID: MeetingAnalysisID(stockCode, now),
+			StockCode: stockCode,
+			Query:     query,
+			CreatedAt: now.UnixMilli(),
+		},
+	}
+}
+
+// Get 获取指定会议的技术分析存档
+func (s *MeetingAnalysisService) Get(meetingID string) (*models.MeetingAnalysis, error) {
+	if !validMeetingAnalysisID(meetingID) {
+		return nil, fmt.Errorf("会议ID无效: %s", meetingID)
+	}
+	data, err := s.backend.Get(meetingID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, fmt.Errorf("会议技术分析存档不存在: %s", meetingID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var record models.MeetingAnalysis
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("解析会议技术分析存档失败: %w", err)
+	}
+	return &record, nil
+}
+
+// List 列出指定股票的会议存档ID（按时间倒序），stockCode 为空时列出全部
+func (s *MeetingAnalysisService) List(stockCode string) ([]string, error) {
+	keys, err := s.backend.List()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if stockCode == "" || strings.HasPrefix(key, stockCode+"_") {
+			ids = append(ids, key)
+		}
+	}
+	slices.Reverse(ids)
+	return ids, nil
+}
+
+// MeetingAnalysisRecorder 单场会议的技术分析记录器，可并发调用
+type MeetingAnalysisRecorder struct {
+	service *MeetingAnalysisService
+	mu      sync.Mutex
+	record  models.MeetingAnalysis
+}
+
+// Add 记录一份技术分析原文，与已有内容相同时只追加专家名称
+func (r *MeetingAnalysisRecorder) Add(agentName, content string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.record.Entries {
+		entry := &r.record.Entries[i]
+		if entry.Content == content {
+			if !slices.Contains(entry.Agents, agentName) {
+				entry.Agents = append(entry.Agents, agentName)
+			}
+			return
+		}
+	}
+	r.record.Entries = append(r.record.Entries, models.MeetingAnalysisEntry{
+		Agents:  []string{agentName},
+		Content: content,
+	})
+}
+
+// Save 保存存档，未记录到任何技术分析时不写入，返回会议ID
+func (r *MeetingAnalysisRecorder) Save() (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.record.Entries) == 0 {
+		return "", nil
+	}
+	data, err := json.MarshalIndent(r.record, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := r.service.backend.Set(r.record.MeetingID, data); err != nil {
+		return "", err
+	}
+	return r.record.MeetingID, nil
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMeetingAnalysisRecorder(t *testing.T) {
+	svc := NewMeetingAnalysisService(t.TempDir())
+
+	empty := svc.Begin("sh600519", "怎么看")
+	if id, err := empty.Save(); err != nil || id != "" {
+		t.Errorf("无技术分析时不应保存: %q %v", id, err)
+	}
+
+	rec := svc.Begin("sh600519", "能不能买")
+	rec.Add("会前预取", "[Snapshot]\nA")
+	rec.Add("K线王", "[Snapshot]\nA")
+	rec.Add("K线王", "[Snapshot]\nA")
+	rec.Add("风控李", "[Snapshot]\nB")
+	id, err := rec.Save()
+	if err != nil || id == "" {
+		t.Fatalf("保存失败: %q %v", id, err)
+	}
+
+	got, err := svc.Get(id)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if got.StockCode != "sh600519" || got.Query != "能不能买" || len(got.Entries) != 2 {
+		t.Fatalf("存档内容错误: %+v", got)
+	}
+	if agents := got.Entries[0].Agents; len(agents) != 2 || agents[1] != "K线王" {
+		t.Errorf("相同内容应合并专家: %v", agents)
+	}
+
+	ids, err := svc.List("sh600519")
+	if err != nil || len(ids) != 1 || ids[0] != id {
+		t.Errorf("列表错误: %v %v", ids, err)
+	}
+	if ids, _ := svc.List("sz000001"); len(ids) != 0 {
+		t.Errorf("应按股票过滤: %v", ids)
+	}
+
+	if _, err := svc.Get("../config"); err == nil {
+		t.Error("非法ID应报错")
+	}
+	if MeetingAnalysisID("sh600519", time.Date(2026, 1, 5, 9, 30, 0, 0, time.Local)) != "sh600519_20260105093000" {
+		t.Error("会议ID格式错误")
+	}
+}
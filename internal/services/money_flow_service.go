@@ -0,0 +1,224 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+)
+
+// 东方财富个股日级资金流向接口（主力=超大单+大单）
+const eastmoneyFundFlowDayURL = "https://push2his.eastmoney.com/api/qt/stock/fflow/daykline/get?lmt=0&klt=101&secid=%s&fields1=f1,f2,f3,f7&fields2=f51,f52,f53,f54,f55,f56,f57,f58,f59,f60,f61,f62,f63"
+
+// MoneyFlowDay 个股单日资金流向
+type MoneyFlowDay struct {
+	Date          string  `json:"date"`          // 交易日期
+	MainNet       float64 `json:"mainNet"`       // 主力净流入(元)
+	SuperLargeNet float64 `json:"superLargeNet"` // 超大单净流入(元)
+	LargeNet      float64 `json:"largeNet"`      // 大单净流入(元)
+	MainNetRatio  float64 `json:"mainNetRatio"`  // 主力净占比(%)
+	Close         float64 `json:"close"`         // 收盘价
+	ChangePercent float64 `json:"changePercent"` // 涨跌幅(%)
+}
+
+// MoneyFlowZone 价格区间内的主力资金累计
+type MoneyFlowZone struct {
+	Low     float64 `json:"low"`     // 区间下沿
+	High    float64 `json:"high"`    // 区间上沿
+	MainNet float64 `json:"mainNet"` // 区间内主力累计净流入(元)
+	Days    int     `json:"days"`    // 落入该区间的交易日数
+}
+
+// MoneyFlowLevels 主力资金价格分布估算
+type MoneyFlowLevels struct {
+	Zones        []MoneyFlowZone `json:"zones"`        // 按价格升序
+	TopInflow    []MoneyFlowZone `json:"topInflow"`    // 净流入最多的区间
+	CostEstimate float64         `json:"costEstimate"` // 净流入日按流入额加权的均价（估算主力成本）
+	TotalMainNet float64         `json:"totalMainNet"` // 统计期主力累计净流入(元)
+}
+
+// fundFlowDayResponse 日级资金流向接口响应
+type fundFlowDayResponse struct {
+	Data *struct {
+		Code   string   `json:"code"`
+		Name   string   `json:"name"`
+		Klines []string `json:"klines"`
+	} `json:"data"`
+}
+
+// moneyFlowCache 资金流向缓存
+type moneyFlowCache struct {
+	data      []MoneyFlowDay
+	timestamp time.Time
+}
+
+// MoneyFlowService 个股资金流向服务
+type MoneyFlowService struct {
+	client   *http.Client
+	cache    map[string]*moneyFlowCache
+	cacheMu  sync.RWMutex
+	cacheTTL time.Duration
+}
+
+// NewMoneyFlowService 创建个股资金流向服务
+func NewMoneyFlowService() *MoneyFlowService {
+	return &MoneyFlowService{
+		client:   proxy.GetManager().GetClientWithTimeout(10 * time.Second),
+		cache:    make(map[string]*moneyFlowCache),
+		cacheTTL: 30 * time.Minute,
+	}
+}
+
+// GetDailyMoneyFlow 获取个股近期日级主力资金流向（按代码缓存30分钟）
+// code: 带市场前缀的代码，如 sh600519；返回按日期升序
+func (s *MoneyFlowService) GetDailyMoneyFlow(code string) ([]MoneyFlowDay, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+
+	s.cacheMu.RLock()
+	if cached, ok := s.cache[code]; ok && time.Since(cached.timestamp) < s.cacheTTL {
+		s.cacheMu.RUnlock()
+		return cached.data, nil
+	}
+	s.cacheMu.RUnlock()
+
+	days, err := s.fetchDailyMoneyFlow(code)
+	if err != nil {
+		return nil, err
+	}
+
+	s.cacheMu.Lock()
+	s.cache[code] = &moneyFlowCache{data: days, timestamp: time.Now()}
+	s.cacheMu.Unlock()
+
+	return days, nil
+}
+
+// fetchDailyMoneyFlow 从东方财富获取日级资金流向
+func (s *MoneyFlowService) fetchDailyMoneyFlow(code string) ([]MoneyFlowDay, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf(eastmoneyFundFlowDayURL, toSecID(code)), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Referer", "https://data.eastmoney.com/")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("获取资金流向失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	var result fundFlowDayResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("解析资金流向失败: %w", err)
+	}
+	if result.Data == nil {
+		return nil, nil
+	}
+
+	days := make([]MoneyFlowDay, 0, len(result.Data.Klines))
+	for _, line := range result.Data.Klines {
+		if day, ok := parseFundFlowLine(line); ok {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+// parseFundFlowLine 解析单行资金流向数据
+// 字段顺序: 日期,主力净额,小单净额,中单净额,大单净额,超大单净额,主力净占比,小单占比,中单占比,大单占比,超大单占比,收盘价,涨跌幅
+func parseFundFlowLine(line string) (MoneyFlowDay, bool) {
+	parts := strings.Split(line, ",")
+	if len(parts) < 13 {
+		return MoneyFlowDay{}, false
+	}
+	num := func(i int) float64 {
+		v, _ := strconv.ParseFloat(parts[i], 64)
+		return v
+	}
+	day := MoneyFlowDay{
+		Date:          parts[0],
+		MainNet:       num(1),
+		LargeNet:      num(4),
+		SuperLargeNet: num(5),
+		MainNetRatio:  num(6),
+		Close:         num(11),
+		ChangePercent: num(12),
+	}
+	return day, day.Close > 0
+}
+
+// ComputeMoneyFlowLevels 将日级主力净流入按收盘价分桶，估算主力资金集中的价格区间
+// 仅有日级粒度，每日资金整体归入当日收盘价所在区间，结果为估算值
+func ComputeMoneyFlowLevels(days []MoneyFlowDay, zoneCount, topN int) *MoneyFlowLevels {
+	result := &MoneyFlowLevels{}
+	if len(days) == 0 || zoneCount <= 0 {
+		return result
+	}
+
+	low, high := math.MaxFloat64, 0.0
+	for _, d := range days {
+		low = math.Min(low, d.Close)
+		high = math.Max(high, d.Close)
+	}
+	step := (high - low) / float64(zoneCount)
+	if step <= 0 {
+		zoneCount = 1
+	}
+
+	zones := make([]MoneyFlowZone, zoneCount)
+	for i := range zones {
+		zones[i].Low = low + step*float64(i)
+		zones[i].High = low + step*float64(i+1)
+	}
+	zones[zoneCount-1].High = high
+
+	var inflow, weighted float64
+	for _, d := range days {
+		idx := 0
+		if step > 0 {
+			idx = int((d.Close - low) / step)
+			if idx >= zoneCount {
+				idx = zoneCount - 1
+			}
+		}
+		zones[idx].MainNet += d.MainNet
+		zones[idx].Days++
+		result.TotalMainNet += d.MainNet
+		if d.MainNet > 0 {
+			inflow += d.MainNet
+			weighted += d.MainNet * d.Close
+		}
+	}
+	if inflow > 0 {
+		result.CostEstimate = weighted / inflow
+	}
+	result.Zones = zones
+
+	// 只挑出净流入为正的区间，按净流入额降序
+	for _, z := range zones {
+		if z.MainNet > 0 {
+			result.TopInflow = append(result.TopInflow, z)
+		}
+	}
+	sort.Slice(result.TopInflow, func(i, j int) bool {
+		return result.TopInflow[i].MainNet > result.TopInflow[j].MainNet
+	})
+	if len(result.TopInflow) > topN {
+		result.TopInflow = result.TopInflow[:topN]
+	}
+	return result
+}
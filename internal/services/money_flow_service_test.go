@@ -0,0 +1,118 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestParseFundFlowLine 测试资金流向行解析
+func TestParseFundFlowLine(t *testing.T) {
+	day, ok := parseFundFlowLine("2026-03-06,12345678.0,-2000000.0,-10345678.0,4345678.0,8000000.0,5.12,-0.83,-4.29,1.80,3.32,1688.00,1.25,0.00,0.00")
+	if !ok {
+		t.Fatal("应解析成功")
+	}
+	if day.MainNet != 12345678 || day.SuperLargeNet != 8000000 || day.LargeNet != 4345678 {
+		t.Errorf("资金字段错误: %+v", day)
+	}
+	if day.Close != 1688 || day.ChangePercent != 1.25 {
+		t.Errorf("价格字段错误: %+v", day)
+	}
+	if _, ok := parseFundFlowLine("2026-03-06,1,2"); ok {
+		t.Error("字段不足应解析失败")
+	}
+}
+
+// TestComputeMoneyFlowLevels 测试主力资金价格区间估算
+func TestComputeMoneyFlowLevels(t *testing.T) {
+	days := []MoneyFlowDay{
+		{Close: 10, MainNet: 100},
+		{Close: 10.5, MainNet: 300},
+		{Close: 12, MainNet: -200},
+		{Close: 14, MainNet: 50},
+	}
+	levels := ComputeMoneyFlowLevels(days, 4, 2)
+
+	if len(levels.Zones) != 4 {
+		t.Fatalf("区间数错误: %d", len(levels.Zones))
+	}
+	if levels.Zones[0].MainNet != 400 || levels.Zones[0].Days != 2 {
+		t.Errorf("首区间统计错误: %+v", levels.Zones[0])
+	}
+	if levels.Zones[3].MainNet != 50 {
+		t.Errorf("最高价应归入末区间: %+v", levels.Zones[3])
+	}
+	if len(levels.TopInflow) != 2 || levels.TopInflow[0].Low != 10 {
+		t.Errorf("净流入排序错误: %+v", levels.TopInflow)
+	}
+	if levels.TotalMainNet != 250 {
+		t.Errorf("累计净流入错误: %.0f", levels.TotalMainNet)
+	}
+	// (100*10 + 300*10.5 + 50*14) / 450
+	if levels.CostEstimate < 10.77 || levels.CostEstimate > 10.78 {
+		t.Errorf("成本估算错误: %.4f", levels.CostEstimate)
+	}
+
+	flat := ComputeMoneyFlowLevels([]MoneyFlowDay{{Close: 5, MainNet: 1}, {Close: 5, MainNet: 2}}, 5, 3)
+	if len(flat.Zones) != 1 || flat.Zones[0].Days != 2 {
+		t.Errorf("价格无波动时应合并为单一区间: %+v", flat.Zones)
+	}
+}
+
+// TestAnalyzeOrderFlow 测试分时内外盘统计
+func TestAnalyzeOrderFlow(t *testing.T) {
+	var ticks []orderFlowTick
+	for _, line := range []string{
+		"09:25:00,10.00,500,10,4",
+		"09:31:02,10.02,300,5,2",
+		"09:45:10,10.01,100,2,1",
+		"10:15:30,10.05,600,8,2",
+		"11:30:00,10.06,50,1,1",
+		"13:00:03,10.04,200,3,1",
+		"14:59:57,10.03,100,2,1",
+		"15:00:00,10.03,400,9,4",
+	} {
+		tick, ok := parseTickDetail(line)
+		if !ok {
+			t.Fatalf("应解析成功: %s", line)
+		}
+		ticks = append(ticks, tick)
+	}
+	if _, ok := parseTickDetail("09:30:00,10.00"); ok {
+		t.Error("字段不足应解析失败")
+	}
+
+	flow := analyzeOrderFlow(ticks)
+	if flow.OuterVolume != 900 || flow.InnerVolume != 450 || flow.AuctionVolume != 900 {
+		t.Errorf("内外盘统计错误: 外%d 内%d 竞价%d", flow.OuterVolume, flow.InnerVolume, flow.AuctionVolume)
+	}
+	if flow.Ratio != 2 || flow.Dominance != OrderFlowBuyDominant {
+		t.Errorf("主导方向错误: %.2f %s", flow.Ratio, flow.Dominance)
+	}
+	// 10.05*600*100 = 603000 为大单
+	if flow.LargeOuter != 600 || flow.LargeInner != 0 {
+		t.Errorf("大单统计错误: 外%d 内%d", flow.LargeOuter, flow.LargeInner)
+	}
+	if flow.LastTime != "15:00:00" || flow.LastPrice != 10.03 {
+		t.Errorf("最新成交错误: %s %.2f", flow.LastTime, flow.LastPrice)
+	}
+
+	periods := make([]string, len(flow.Buckets))
+	for i, b := range flow.Buckets {
+		periods[i] = b.Period
+	}
+	want := []string{"09:30-10:00", "10:00-10:30", "11:00-11:30", "13:00-13:30", "14:30-15:00"}
+	if fmt.Sprint(periods) != fmt.Sprint(want) {
+		t.Fatalf("分段错误: %v", periods)
+	}
+	last := flow.Buckets[len(flow.Buckets)-1]
+	if last.CumOuter != 900 || last.CumInner != 450 || last.CumRatio != 2 {
+		t.Errorf("累计统计错误: %+v", last)
+	}
+	if flow.Buckets[0].CumRatio != 3 {
+		t.Errorf("首段累计比错误: %.2f", flow.Buckets[0].CumRatio)
+	}
+
+	if empty := analyzeOrderFlow(nil); empty.Dominance != OrderFlowBalanced || len(empty.Buckets) != 0 {
+		t.Errorf("无成交应为均衡: %+v", empty)
+	}
+}
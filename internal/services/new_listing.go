@@ -0,0 +1,159 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// 东方财富 Push2 API：f189 为上市日期(yyyymmdd)
+const eastmoneyListingDateURL = "https://push2.eastmoney.com/api/qt/stock/get?secid=%s&fields=f57,f58,f189"
+
+// subNewDays 上市不满一年视为次新股
+const subNewDays = 365
+
+// NewListingInfo 次新股信息
+type NewListingInfo struct {
+	ListingDate    string  `json:"listingDate"`    // 上市日期
+	DaysSinceIPO   int     `json:"daysSinceIpo"`   // 上市以来自然日
+	TradingDays    int     `json:"tradingDays"`    // 上市以来交易日
+	IsSubNew       bool    `json:"isSubNew"`       // 是否次新股（上市不满一年）
+	NoLimitIPO     bool    `json:"noLimitIpo"`     // 注册制新股（上市前5日不设涨跌幅）
+	LimitUpStreak  int     `json:"limitUpStreak"`  // 上市后连续一字涨停天数（不含首日）
+	BoardOpened    bool    `json:"boardOpened"`    // 是否已开板
+	BoardOpenDate  string  `json:"boardOpenDate"`  // 开板日期
+	FirstDayClose  float64 `json:"firstDayClose"`  // 上市首日收盘价
+	HighSinceIPO   float64 `json:"highSinceIpo"`   // 上市以来最高价
+	ChangeSinceIPO float64 `json:"changeSinceIpo"` // 较首日收盘涨跌幅(%)
+	FromHigh       float64 `json:"fromHigh"`       // 距上市以来最高价回撤(%)
+}
+
+// GetListingDate 获取个股上市日期（上市日期不会变化，不设过期）
+func (s *StockInfoService) GetListingDate(code string) (time.Time, error) {
+	s.cacheMu.RLock()
+	date, ok := s.listingDates[code]
+	s.cacheMu.RUnlock()
+	if ok {
+		return date, nil
+	}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf(eastmoneyListingDateURL, toSecID(code)), nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("获取上市日期失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return time.Time{}, err
+	}
+	date, err = parseListingDate(body)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	s.cacheMu.Lock()
+	s.listingDates[code] = date
+	s.cacheMu.Unlock()
+	return date, nil
+}
+
+// parseListingDate 解析上市日期
+func parseListingDate(body []byte) (time.Time, error) {
+	var result struct {
+		Data *struct {
+			F189 any `json:"f189"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return time.Time{}, fmt.Errorf("解析上市日期失败: %w", err)
+	}
+	if result.Data == nil {
+		return time.Time{}, fmt.Errorf("未找到该股票")
+	}
+	raw := strconv.FormatFloat(anyToFloat(result.Data.F189), 'f', 0, 64)
+	date, err := time.ParseInLocation("20060102", raw, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("上市日期无效: %s", raw)
+	}
+	return date, nil
+}
+
+// isNoLimitIPO 判断新股上市初期是否不设涨跌幅（注册制）
+// 科创板、北交所自设立起，创业板自2020-08-24，主板自2023-04-10全面注册制起
+func isNoLimitIPO(code string, listingDate time.Time) bool {
+	var since string
+	switch {
+	case strings.HasPrefix(code, "sh688"), strings.HasPrefix(code, "bj"):
+		return true
+	case strings.HasPrefix(code, "sz30"):
+		since = "2020-08-24"
+	default:
+		since = "2023-04-10"
+	}
+	return listingDate.Format("2006-01-02") >= since
+}
+
+// AnalyzeNewListing 结合上市日期和上市以来日K判断次新股状态
+// klines 需按时间升序；主板新股首日后连续一字涨停视为未开板，首个非一字板交易日为开板日
+func AnalyzeNewListing(code string, listingDate time.Time, klines []models.KLineData, now time.Time) NewListingInfo {
+	info := NewListingInfo{
+		ListingDate:  listingDate.Format("2006-01-02"),
+		DaysSinceIPO: int(now.Sub(listingDate).Hours() / 24),
+		NoLimitIPO:   isNoLimitIPO(code, listingDate),
+	}
+	info.IsSubNew = info.DaysSinceIPO < subNewDays
+
+	// 仅保留上市日及之后的K线
+	start := 0
+	for start < len(klines) && klines[start].Time < info.ListingDate {
+		start++
+	}
+	klines = klines[start:]
+	// K线未覆盖上市首日（数据不足或上市已久）时不做首日相关统计
+	if len(klines) == 0 || !strings.HasPrefix(klines[0].Time, info.ListingDate) {
+		return info
+	}
+	info.TradingDays = len(klines)
+
+	info.FirstDayClose = klines[0].Close
+	for _, k := range klines {
+		if k.High > info.HighSinceIPO {
+			info.HighSinceIPO = k.High
+		}
+	}
+	last := klines[len(klines)-1].Close
+	if info.FirstDayClose > 0 {
+		info.ChangeSinceIPO = (last - info.FirstDayClose) / info.FirstDayClose * 100
+	}
+	if info.HighSinceIPO > 0 {
+		info.FromHigh = (last - info.HighSinceIPO) / info.HighSinceIPO * 100
+	}
+
+	// 注册制新股前5日无涨跌幅限制，不存在一字连板开板
+	if info.NoLimitIPO {
+		info.BoardOpened = true
+		return info
+	}
+	for i := 1; i < len(klines); i++ {
+		k, prev := klines[i], klines[i-1]
+		oneWordLimitUp := k.High == k.Low && prev.Close > 0 && (k.Close-prev.Close)/prev.Close*100 >= 9.5
+		if !oneWordLimitUp {
+			info.BoardOpened = true
+			info.BoardOpenDate = k.Time
+			break
+		}
+		info.LimitUpStreak++
+	}
+	return info
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestParseListingDate 测试上市日期解析
+func TestParseListingDate(t *testing.T) {
+	date, err := parseListingDate([]byte(`{"data":{"f57":"603100","f58":"川仪股份","f189":20140801}}`))
+	if err != nil || date.Format("2006-01-02") != "2014-08-01" {
+		t.Errorf("parseListingDate = %v, %v", date, err)
+	}
+	if _, err := parseListingDate([]byte(`{"data":null}`)); err == nil {
+		t.Error("空数据应返回错误")
+	}
+}
+
+// TestAnalyzeNewListing 测试主板新股连板及开板判断
+func TestAnalyzeNewListing(t *testing.T) {
+	// 核准制主板新股：首日后一字涨停两天，第三天开板
+	listing := time.Date(2022, 3, 1, 0, 0, 0, 0, time.Local)
+	klines := []models.KLineData{
+		{Time: "2022-02-28", Open: 9, High: 9, Low: 9, Close: 9}, // 上市前数据应忽略
+		{Time: "2022-03-01", Open: 14.4, High: 14.4, Low: 14.4, Close: 14.4},
+		{Time: "2022-03-02", Open: 15.84, High: 15.84, Low: 15.84, Close: 15.84},
+		{Time: "2022-03-03", Open: 17.42, High: 17.42, Low: 17.42, Close: 17.42},
+		{Time: "2022-03-04", Open: 18.5, High: 19.16, Low: 17.8, Close: 18.0},
+	}
+	info := AnalyzeNewListing("sh603999", listing, klines, listing.AddDate(0, 0, 30))
+
+	if !info.IsSubNew || info.NoLimitIPO || info.TradingDays != 4 {
+		t.Errorf("基础信息错误: %+v", info)
+	}
+	if info.LimitUpStreak != 2 || !info.BoardOpened || info.BoardOpenDate != "2022-03-04" {
+		t.Errorf("开板判断错误: streak=%d opened=%v date=%s", info.LimitUpStreak, info.BoardOpened, info.BoardOpenDate)
+	}
+	if info.HighSinceIPO != 19.16 || info.FirstDayClose != 14.4 {
+		t.Errorf("价格统计错误: %+v", info)
+	}
+
+	// 科创板注册制新股无连板开板概念
+	star := AnalyzeNewListing("sh688999", listing, klines, listing.AddDate(1, 1, 0))
+	if !star.NoLimitIPO || star.IsSubNew || star.LimitUpStreak != 0 {
+		t.Errorf("注册制/非次新判断错误: %+v", star)
+	}
+	// 全面注册制后的主板新股同样不设涨跌幅
+	if !isNoLimitIPO("sh603999", time.Date(2023, 5, 1, 0, 0, 0, 0, time.Local)) || isNoLimitIPO("sz300999", time.Date(2019, 5, 1, 0, 0, 0, 0, time.Local)) {
+		t.Error("注册制判断错误")
+	}
+}
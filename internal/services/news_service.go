@@ -1,13 +1,13 @@
 package services
 
 import (
-	"io"
 	"net/http"
+	"sort"
 	"strings"
 	"sync"
 	"time"
+	"unicode"
 
-	"github.com/PuerkitoBio/goquery"
 	"github.com/run-bigpig/jcp/internal/pkg/proxy"
 )
 
@@ -16,27 +16,77 @@ type Telegraph struct {
 	Time    string `json:"time"`
 	Content string `json:"content"`
 	URL     string `json:"url"`
+	Source  string `json:"source"` // 来源名称
+
+	publishedAt time.Time // 发布时间（用于多来源合并排序）
 }
 
+// 快讯缓存条数
+const (
+	telegraphListSize = 20  // 快讯列表返回条数
+	telegraphPoolSize = 100 // 主题筛选的候选池大小
+)
+
 // NewsService 资讯服务
 type NewsService struct {
 	client *http.Client
 
+	// 快讯来源
+	sources []NewsSource
+	enabled map[string]bool
+
 	// 缓存
 	telegraphs    []Telegraph
+	pool          []Telegraph // 合并去重后的全部快讯，供主题筛选使用
 	lastFetchTime time.Time
 	mu            sync.RWMutex
 }
 
-// NewNewsService 创建资讯服务
+// NewNewsService 创建资讯服务（默认启用全部内置来源）
 func NewNewsService() *NewsService {
-	return &NewsService{
+	s := &NewsService{
 		client:     proxy.GetManager().GetClientWithTimeout(10 * time.Second),
+		sources:    DefaultNewsSources(),
+		enabled:    make(map[string]bool),
 		telegraphs: make([]Telegraph, 0),
 	}
+	for _, src := range s.sources {
+		s.enabled[src.ID()] = true
+	}
+	return s
+}
+
+// SetEnabledSources 设置启用的快讯来源，为空时启用全部来源
+func (s *NewsService) SetEnabledSources(ids []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.enabled = make(map[string]bool)
+	for _, id := range ids {
+		s.enabled[id] = true
+	}
+	if len(s.enabled) == 0 {
+		for _, src := range s.sources {
+			s.enabled[src.ID()] = true
+		}
+	}
+	// 来源变更后使缓存失效
+	s.lastFetchTime = time.Time{}
+}
+
+// GetSources 获取所有快讯来源及启用状态
+func (s *NewsService) GetSources() []NewsSourceInfo {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	infos := make([]NewsSourceInfo, 0, len(s.sources))
+	for _, src := range s.sources {
+		infos = append(infos, NewsSourceInfo{ID: src.ID(), Name: src.Name(), Enabled: s.enabled[src.ID()]})
+	}
+	return infos
 }
 
-// GetTelegraphList 获取财联社快讯列表
+// GetTelegraphList 获取快讯列表（合并所有启用的来源并去重）
 func (s *NewsService) GetTelegraphList() ([]Telegraph, error) {
 	// 检查缓存，30秒内不重复请求
 	s.mu.RLock()
@@ -46,77 +96,165 @@ func (s *NewsService) GetTelegraphList() ([]Telegraph, error) {
 		s.mu.RUnlock()
 		return result, nil
 	}
+	var sources []NewsSource
+	for _, src := range s.sources {
+		if s.enabled[src.ID()] {
+			sources = append(sources, src)
+		}
+	}
 	s.mu.RUnlock()
 
-	// 请求财联社快讯页面
-	req, err := http.NewRequest("GET", "https://www.cls.cn/telegraph", nil)
-	if err != nil {
-		return nil, err
+	// 并发请求各来源，单个来源失败不影响其他来源
+	lists := make([][]Telegraph, len(sources))
+	errs := make([]error, len(sources))
+	var wg sync.WaitGroup
+	for i, src := range sources {
+		wg.Add(1)
+		go func(i int, src NewsSource) {
+			defer wg.Done()
+			lists[i], errs[i] = src.Fetch(s.client)
+			if errs[i] != nil {
+				log.Warn("快讯来源 %s 获取失败: %v", src.Name(), errs[i])
+			}
+		}(i, src)
 	}
+	wg.Wait()
 
-	// 设置请求头，模拟浏览器
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
-	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
-	req.Header.Set("Accept-Language", "zh-CN,zh;q=0.9")
+	var firstErr error
+	for _, err := range errs {
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
 
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return nil, err
+	pool := mergeTelegraphs(lists, telegraphPoolSize)
+	if len(pool) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	telegraphs := pool
+	if len(telegraphs) > telegraphListSize {
+		telegraphs = telegraphs[:telegraphListSize]
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	// 更新缓存
+	s.mu.Lock()
+	s.telegraphs = telegraphs
+	s.pool = pool
+	s.lastFetchTime = time.Now()
+	s.mu.Unlock()
+
+	return telegraphs, nil
+}
+
+// mergeTelegraphs 按发布时间倒序合并多个来源的快讯，并按内容去重
+// lists 按来源优先级排列，重复快讯保留优先级高的来源
+func mergeTelegraphs(lists [][]Telegraph, limit int) []Telegraph {
+	seen := make(map[string]bool)
+	var merged []Telegraph
+	for _, list := range lists {
+		for _, t := range list {
+			key := telegraphDedupeKey(t.Content)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, t)
+		}
 	}
 
-	// 解析 HTML
-	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
-	if err != nil {
-		return nil, err
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].publishedAt.After(merged[j].publishedAt)
+	})
+	if limit > 0 && len(merged) > limit {
+		merged = merged[:limit]
 	}
+	return merged
+}
 
-	telegraphs := make([]Telegraph, 0, 20)
+// telegraphDedupeKey 生成快讯去重键：优先使用【标题】，否则取正文前30个字符（忽略标点空白）
+func telegraphDedupeKey(content string) string {
+	if start := strings.Index(content, "【"); start >= 0 {
+		if end := strings.Index(content[start:], "】"); end > 0 {
+			if title := normalizeForDedupe(content[start+len("【") : start+end]); title != "" {
+				return title
+			}
+		}
+	}
 
-	// 解析快讯内容 - 查找包含 telegraph-content-box 的父级元素
-	// 父级元素同时包含内容和 subject-bottom-box（含详情链接）
-	doc.Find("div.telegraph-content-box").Each(func(i int, sel *goquery.Selection) {
-		if i >= 20 {
-			return
+	key := normalizeForDedupe(content)
+	if runes := []rune(key); len(runes) > 30 {
+		key = string(runes[:30])
+	}
+	return key
+}
+
+// normalizeForDedupe 去除空白和标点
+func normalizeForDedupe(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			continue
 		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// GetThemeTelegraphs 按主题关键词筛选快讯，按命中关键词数量降序、同分按时间倒序
+// theme 支持用空格、逗号或顿号分隔多个关键词，命中任一即视为相关
+func (s *NewsService) GetThemeTelegraphs(theme string, limit int) ([]Telegraph, error) {
+	if _, err := s.GetTelegraphList(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	pool := make([]Telegraph, len(s.pool))
+	copy(pool, s.pool)
+	s.mu.RUnlock()
 
-		// 获取时间
-		timeStr := sel.Find("span.telegraph-time-box").Text()
-		timeStr = strings.TrimSpace(timeStr)
+	return filterTelegraphsByTheme(pool, splitThemeKeywords(theme), limit), nil
+}
 
-		// 获取内容 - 内容在 span > div 结构中
-		content := sel.Find("span > div").Text()
-		content = strings.TrimSpace(content)
-		content = cleanContent(content)
+// splitThemeKeywords 拆分主题关键词
+func splitThemeKeywords(theme string) []string {
+	return strings.FieldsFunc(theme, func(r rune) bool {
+		return unicode.IsSpace(r) || r == ',' || r == '，' || r == '、' || r == '|'
+	})
+}
 
-		// 获取详情链接 - 链接在父级的兄弟元素 subject-bottom-box 中
-		url := ""
-		parent := sel.Parent()
-		if href, exists := parent.Find("div.subject-bottom-box a[href^='/detail/']").Attr("href"); exists {
-			url = "https://www.cls.cn" + href
+// filterTelegraphsByTheme 按关键词命中数筛选并排序快讯，telegraphs 需按时间倒序
+func filterTelegraphsByTheme(telegraphs []Telegraph, keywords []string, limit int) []Telegraph {
+	if len(keywords) == 0 {
+		return nil
+	}
+	type scored struct {
+		t     Telegraph
+		score int
+	}
+	var matched []scored
+	for _, t := range telegraphs {
+		content := strings.ToLower(t.Content)
+		score := 0
+		for _, kw := range keywords {
+			if strings.Contains(content, strings.ToLower(kw)) {
+				score++
+			}
 		}
-
-		if content != "" {
-			telegraphs = append(telegraphs, Telegraph{
-				Time:    timeStr,
-				Content: content,
-				URL:     url,
-			})
+		if score > 0 {
+			matched = append(matched, scored{t: t, score: score})
 		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].score > matched[j].score
 	})
 
-	// 更新缓存
-	s.mu.Lock()
-	s.telegraphs = telegraphs
-	s.lastFetchTime = time.Now()
-	s.mu.Unlock()
-
-	return telegraphs, nil
+	if limit > 0 && len(matched) > limit {
+		matched = matched[:limit]
+	}
+	result := make([]Telegraph, len(matched))
+	for i, m := range matched {
+		result[i] = m.t
+	}
+	return result
 }
 
 // GetLatestTelegraph 获取最新一条快讯
@@ -2,6 +2,7 @@ package services
 
 import (
 	"testing"
+	"time"
 )
 
 func TestGetTelegraphList(t *testing.T) {
@@ -61,6 +62,57 @@ func TestGetLatestTelegraph(t *testing.T) {
 	t.Logf("最新快讯内容: %s", truncate(latest.Content, 150))
 }
 
+func TestMergeTelegraphs(t *testing.T) {
+	base := time.Date(2026, 2, 9, 10, 0, 0, 0, cnLocation)
+	cls := []Telegraph{
+		{Content: "【央行开展逆回购操作】财联社2月9日电，央行今日开展...", Source: "财联社", publishedAt: base},
+		{Content: "沪指午后拉升，半导体板块走强", Source: "财联社", publishedAt: base.Add(-time.Minute)},
+	}
+	sina := []Telegraph{
+		{Content: "【央行开展逆回购操作】 新浪财经讯 央行今日...", Source: "新浪财经", publishedAt: base.Add(time.Second)},
+		{Content: "沪指午后拉升，半导体板块走强！", Source: "新浪财经", publishedAt: base.Add(-2 * time.Minute)},
+		{Content: "美元指数短线走高", Source: "新浪财经", publishedAt: base.Add(time.Minute)},
+	}
+
+	merged := mergeTelegraphs([][]Telegraph{cls, sina}, 10)
+	if len(merged) != 3 {
+		t.Fatalf("去重后应为3条, got %d", len(merged))
+	}
+	if merged[0].Content != "美元指数短线走高" {
+		t.Errorf("应按时间倒序, 第一条为: %s", merged[0].Content)
+	}
+	if merged[1].Source != "财联社" {
+		t.Errorf("重复快讯应保留优先来源, got %s", merged[1].Source)
+	}
+}
+
+func TestFilterTelegraphsByTheme(t *testing.T) {
+	telegraphs := []Telegraph{
+		{Content: "机器人概念股午后走强"},
+		{Content: "央行开展逆回购操作"},
+		{Content: "人形机器人产业链迎来政策支持，减速器厂商受益"},
+		{Content: "AI 算力需求持续增长"},
+	}
+
+	keywords := splitThemeKeywords("机器人、减速器,ai")
+	if len(keywords) != 3 {
+		t.Fatalf("关键词拆分错误: %v", keywords)
+	}
+	got := filterTelegraphsByTheme(telegraphs, keywords, 10)
+	if len(got) != 3 {
+		t.Fatalf("应命中3条, got %d", len(got))
+	}
+	if got[0].Content != telegraphs[2].Content {
+		t.Errorf("命中关键词最多的应排第一, got %s", got[0].Content)
+	}
+	if got[1].Content != telegraphs[0].Content {
+		t.Errorf("同分应保持时间顺序, got %s", got[1].Content)
+	}
+	if len(filterTelegraphsByTheme(telegraphs, nil, 10)) != 0 {
+		t.Error("无关键词时不应返回结果")
+	}
+}
+
 // truncate 截断字符串
 func truncate(s string, maxLen int) string {
 	runes := []rune(s)
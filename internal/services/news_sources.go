@@ -0,0 +1,288 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// 快讯来源ID
+const (
+	NewsSourceCLS       = "cls"
+	NewsSourceSina      = "sina"
+	NewsSourceEastmoney = "eastmoney"
+)
+
+// NewsSource 快讯来源
+type NewsSource interface {
+	ID() string
+	Name() string
+	Fetch(client *http.Client) ([]Telegraph, error)
+}
+
+// NewsSourceInfo 快讯来源信息（供前端展示）
+type NewsSourceInfo struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+}
+
+// cnLocation 固定使用 UTC+8，避免 Windows 缺少时区数据库
+var cnLocation = time.FixedZone("CST", 8*60*60)
+
+// DefaultNewsSources 内置快讯来源（按优先级排序）
+func DefaultNewsSources() []NewsSource {
+	return []NewsSource{&clsSource{}, &sinaSource{}, &eastmoneyNewsSource{}}
+}
+
+// ========== 财联社 ==========
+
+// clsSource 财联社电报
+type clsSource struct{}
+
+func (c *clsSource) ID() string   { return NewsSourceCLS }
+func (c *clsSource) Name() string { return "财联社" }
+
+// Fetch 抓取财联社电报页面
+func (c *clsSource) Fetch(client *http.Client) ([]Telegraph, error) {
+	req, err := http.NewRequest("GET", "https://www.cls.cn/telegraph", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// 设置请求头，模拟浏览器
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	req.Header.Set("Accept-Language", "zh-CN,zh;q=0.9")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析 HTML
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().In(cnLocation)
+	telegraphs := make([]Telegraph, 0, 20)
+
+	// 解析快讯内容 - 查找包含 telegraph-content-box 的父级元素
+	// 父级元素同时包含内容和 subject-bottom-box（含详情链接）
+	doc.Find("div.telegraph-content-box").Each(func(i int, sel *goquery.Selection) {
+		if i >= 20 {
+			return
+		}
+
+		// 获取时间
+		timeStr := sel.Find("span.telegraph-time-box").Text()
+		timeStr = strings.TrimSpace(timeStr)
+
+		// 获取内容 - 内容在 span > div 结构中
+		content := sel.Find("span > div").Text()
+		content = strings.TrimSpace(content)
+		content = cleanContent(content)
+
+		// 获取详情链接 - 链接在父级的兄弟元素 subject-bottom-box 中
+		url := ""
+		parent := sel.Parent()
+		if href, exists := parent.Find("div.subject-bottom-box a[href^='/detail/']").Attr("href"); exists {
+			url = "https://www.cls.cn" + href
+		}
+
+		if content != "" {
+			telegraphs = append(telegraphs, Telegraph{
+				Time:        timeStr,
+				Content:     content,
+				URL:         url,
+				Source:      c.Name(),
+				publishedAt: parseClockTime(timeStr, now),
+			})
+		}
+	})
+
+	return telegraphs, nil
+}
+
+// ========== 新浪财经 ==========
+
+// sinaSource 新浪财经7x24直播
+type sinaSource struct{}
+
+func (s *sinaSource) ID() string   { return NewsSourceSina }
+func (s *sinaSource) Name() string { return "新浪财经" }
+
+// Fetch 获取新浪财经7x24快讯
+func (s *sinaSource) Fetch(client *http.Client) ([]Telegraph, error) {
+	params := url.Values{}
+	params.Set("page", "1")
+	params.Set("page_size", "20")
+	params.Set("zhibo_id", "152") // 152: 财经频道
+	params.Set("tag_id", "0")
+	params.Set("type", "0")
+
+	req, err := http.NewRequest("GET", "https://zhibo.sina.com.cn/api/zhibo/feed?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Referer", "https://finance.sina.com.cn/7x24/")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Result struct {
+			Data struct {
+				Feed struct {
+					List []struct {
+						RichText   string `json:"rich_text"`
+						CreateTime string `json:"create_time"`
+						DocURL     string `json:"docurl"`
+					} `json:"list"`
+				} `json:"feed"`
+			} `json:"data"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("解析新浪快讯失败: %w", err)
+	}
+
+	telegraphs := make([]Telegraph, 0, len(apiResp.Result.Data.Feed.List))
+	for _, item := range apiResp.Result.Data.Feed.List {
+		content := cleanContent(item.RichText)
+		if content == "" {
+			continue
+		}
+		publishedAt, _ := time.ParseInLocation("2006-01-02 15:04:05", item.CreateTime, cnLocation)
+		telegraphs = append(telegraphs, Telegraph{
+			Time:        formatClockTime(publishedAt, item.CreateTime),
+			Content:     content,
+			URL:         item.DocURL,
+			Source:      s.Name(),
+			publishedAt: publishedAt,
+		})
+	}
+	return telegraphs, nil
+}
+
+// ========== 东方财富 ==========
+
+// eastmoneyNewsSource 东方财富7x24快讯
+type eastmoneyNewsSource struct{}
+
+func (e *eastmoneyNewsSource) ID() string   { return NewsSourceEastmoney }
+func (e *eastmoneyNewsSource) Name() string { return "东方财富" }
+
+// Fetch 获取东方财富7x24快讯
+func (e *eastmoneyNewsSource) Fetch(client *http.Client) ([]Telegraph, error) {
+	params := url.Values{}
+	params.Set("client", "web")
+	params.Set("biz", "web_724")
+	params.Set("fastColumn", "102") // 102: 7x24全部
+	params.Set("sortEnd", "")
+	params.Set("pageSize", "20")
+	params.Set("req_trace", fmt.Sprintf("%d", time.Now().UnixMilli()))
+
+	req, err := http.NewRequest("GET", "https://np-weblist.eastmoney.com/comm/web/getFastNewsList?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Referer", "https://kuaixun.eastmoney.com/")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResp struct {
+		Data struct {
+			FastNewsList []struct {
+				Code     string `json:"code"`
+				Title    string `json:"title"`
+				Summary  string `json:"summary"`
+				ShowTime string `json:"showTime"`
+			} `json:"fastNewsList"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return nil, fmt.Errorf("解析东方财富快讯失败: %w", err)
+	}
+
+	telegraphs := make([]Telegraph, 0, len(apiResp.Data.FastNewsList))
+	for _, item := range apiResp.Data.FastNewsList {
+		content := cleanContent(item.Summary)
+		if item.Title != "" && !strings.Contains(content, item.Title) {
+			content = "【" + cleanContent(item.Title) + "】" + content
+		}
+		if content == "" {
+			continue
+		}
+		link := ""
+		if item.Code != "" {
+			link = "https://finance.eastmoney.com/a/" + item.Code + ".html"
+		}
+		publishedAt, _ := time.ParseInLocation("2006-01-02 15:04:05", item.ShowTime, cnLocation)
+		telegraphs = append(telegraphs, Telegraph{
+			Time:        formatClockTime(publishedAt, item.ShowTime),
+			Content:     content,
+			URL:         link,
+			Source:      e.Name(),
+			publishedAt: publishedAt,
+		})
+	}
+	return telegraphs, nil
+}
+
+// parseClockTime 将 "15:04:05"/"15:04" 解析为今天的时间，晚于当前时间则视为昨天
+func parseClockTime(clock string, now time.Time) time.Time {
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		t, err := time.ParseInLocation(layout, clock, cnLocation)
+		if err != nil {
+			continue
+		}
+		result := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, cnLocation)
+		if result.After(now.Add(time.Minute)) {
+			result = result.AddDate(0, 0, -1)
+		}
+		return result
+	}
+	return time.Time{}
+}
+
+// formatClockTime 统一展示为 "15:04:05"，解析失败时保留原始字符串
+func formatClockTime(t time.Time, raw string) string {
+	if t.IsZero() {
+		return raw
+	}
+	return t.Format("15:04:05")
+}
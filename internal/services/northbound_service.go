@@ -0,0 +1,134 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+)
+
+// NorthboundTopItem 沪深股通十大成交股
+type NorthboundTopItem struct {
+	TradeDate     string  `json:"tradeDate"`     // 交易日期
+	Channel       string  `json:"channel"`       // 通道: 沪股通/深股通
+	Rank          int     `json:"rank"`          // 成交额排名
+	Code          string  `json:"code"`          // 股票代码
+	Name          string  `json:"name"`          // 股票名称
+	ClosePrice    float64 `json:"closePrice"`    // 收盘价
+	ChangePercent float64 `json:"changePercent"` // 涨跌幅(%)
+	NetBuyAmt     float64 `json:"netBuyAmt"`     // 净买额(元)
+	BuyAmt        float64 `json:"buyAmt"`        // 买入额(元)
+	SellAmt       float64 `json:"sellAmt"`       // 卖出额(元)
+	DealAmt       float64 `json:"dealAmt"`       // 成交额(元)
+}
+
+// northboundTopAPIItem 十大成交股接口数据
+type northboundTopAPIItem struct {
+	TradeDate    string  `json:"TRADE_DATE"`
+	MutualType   string  `json:"MUTUAL_TYPE"`
+	Rank         int     `json:"RANK"`
+	SecurityCode string  `json:"SECURITY_CODE"`
+	SecurityName string  `json:"SECURITY_NAME"`
+	ClosePrice   float64 `json:"CLOSE_PRICE"`
+	ChangeRate   float64 `json:"CHANGE_RATE"`
+	NetBuyAmt    float64 `json:"NET_BUY_AMT"`
+	BuyAmt       float64 `json:"BUY_AMT"`
+	SellAmt      float64 `json:"SELL_AMT"`
+	DealAmt      float64 `json:"DEAL_AMT"`
+}
+
+// northboundCache 北向十大成交股缓存
+type northboundCache struct {
+	data      []NorthboundTopItem
+	timestamp time.Time
+}
+
+// NorthboundService 北向资金（沪深股通）服务
+type NorthboundService struct {
+	client   *http.Client
+	cache    *northboundCache
+	cacheMu  sync.RWMutex
+	cacheTTL time.Duration
+}
+
+// NewNorthboundService 创建北向资金服务
+func NewNorthboundService() *NorthboundService {
+	return &NorthboundService{
+		client:   proxy.GetManager().GetClientWithTimeout(10 * time.Second),
+		cacheTTL: 3 * time.Minute, // 缓存3分钟
+	}
+}
+
+// GetTop10 获取最近一个交易日沪股通/深股通十大成交股（带缓存）
+func (s *NorthboundService) GetTop10() ([]NorthboundTopItem, error) {
+	s.cacheMu.RLock()
+	if s.cache != nil && time.Since(s.cache.timestamp) < s.cacheTTL {
+		defer s.cacheMu.RUnlock()
+		return s.cache.data, nil
+	}
+	s.cacheMu.RUnlock()
+
+	items, err := s.fetchTop10()
+	if err != nil {
+		return nil, err
+	}
+
+	s.cacheMu.Lock()
+	s.cache = &northboundCache{data: items, timestamp: time.Now()}
+	s.cacheMu.Unlock()
+
+	return items, nil
+}
+
+// fetchTop10 从东方财富获取沪深股通十大成交股
+func (s *NorthboundService) fetchTop10() ([]NorthboundTopItem, error) {
+	params := url.Values{}
+	params.Set("reportName", "RPT_MUTUAL_TOP10DEAL")
+	params.Set("columns", "ALL")
+	params.Set("sortColumns", "TRADE_DATE,RANK")
+	params.Set("sortTypes", "-1,1")
+	params.Set("pageSize", "20")
+	params.Set("pageNumber", "1")
+	params.Set("filter", `(MUTUAL_TYPE in ("001","003"))`)
+	params.Set("source", "WEB")
+	params.Set("client", "WEB")
+
+	var data []northboundTopAPIItem
+	if _, err := fetchDatacenter(s.client, eastmoneyDatacenterURL+"?"+params.Encode(), &data); err != nil {
+		return nil, fmt.Errorf("获取北向十大成交股失败: %w", err)
+	}
+	if len(data) == 0 {
+		return []NorthboundTopItem{}, nil
+	}
+
+	// 只保留最近一个交易日
+	latest := trimDate(data[0].TradeDate)
+	items := make([]NorthboundTopItem, 0, len(data))
+	for _, d := range data {
+		tradeDate := trimDate(d.TradeDate)
+		if tradeDate != latest {
+			continue
+		}
+		channel := "沪股通"
+		if d.MutualType == "003" {
+			channel = "深股通"
+		}
+		items = append(items, NorthboundTopItem{
+			TradeDate:     tradeDate,
+			Channel:       channel,
+			Rank:          d.Rank,
+			Code:          d.SecurityCode,
+			Name:          d.SecurityName,
+			ClosePrice:    d.ClosePrice,
+			ChangePercent: d.ChangeRate,
+			NetBuyAmt:     d.NetBuyAmt,
+			BuyAmt:        d.BuyAmt,
+			SellAmt:       d.SellAmt,
+			DealAmt:       d.DealAmt,
+		})
+	}
+	return items, nil
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// optionBatchSize 单次请求的期权合约数量
+const optionBatchSize = 50
+
+// optionUnderlyings 支持期权的ETF（带前缀代码 -> 名称）
+var optionUnderlyings = map[string]string{
+	"sh510050": "上证50ETF",
+	"sh510300": "沪深300ETF(沪)",
+	"sh510500": "中证500ETF(沪)",
+	"sh588000": "科创50ETF",
+	"sh588080": "科创板50ETF",
+	"sz159919": "沪深300ETF(深)",
+	"sz159922": "中证500ETF(深)",
+	"sz159915": "创业板ETF",
+	"sz159901": "深证100ETF",
+}
+
+// optionIndexProxies 指数代码对应的期权标的ETF
+var optionIndexProxies = map[string]string{
+	"sh000016": "sh510050",
+	"sh000300": "sh510300",
+	"sz399300": "sh510300",
+	"sh000905": "sh510500",
+	"sh000688": "sh588000",
+	"sz399006": "sz159915",
+	"sz399330": "sz159901",
+}
+
+// OptionUnderlying 返回代码对应的期权标的ETF，指数映射到跟踪该指数的期权ETF
+func OptionUnderlying(code string) (string, bool) {
+	code = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(code)), "s_")
+	if etf, ok := optionIndexProxies[code]; ok {
+		code = etf
+	}
+	_, ok := optionUnderlyings[code]
+	return code, ok
+}
+
+// OptionUnderlyingName 期权标的ETF名称
+func OptionUnderlyingName(code string) string {
+	return optionUnderlyings[code]
+}
+
+// OptionContract 期权合约行情
+type OptionContract struct {
+	Code         string  `json:"code"`         // 合约代码
+	Name         string  `json:"name"`         // 合约简称
+	IsCall       bool    `json:"isCall"`       // 认购为 true，认沽为 false
+	Strike       float64 `json:"strike"`       // 行权价
+	Price        float64 `json:"price"`        // 最新价
+	Volume       float64 `json:"volume"`       // 成交量(张)
+	OpenInterest float64 `json:"openInterest"` // 持仓量(张)
+	IV           float64 `json:"iv"`           // 隐含波动率(%)
+}
+
+// OptionChain 单个到期月份的期权链
+type OptionChain struct {
+	Underlying string           `json:"underlying"` // 标的ETF代码
+	Month      string           `json:"month"`      // 到期月份 YYMM
+	Contracts  []OptionContract `json:"contracts"`
+}
+
+// OptionSummary 期权链摘要
+type OptionSummary struct {
+	CallVolume float64 `json:"callVolume"` // 认购成交量
+	PutVolume  float64 `json:"putVolume"`  // 认沽成交量
+	CallOI     float64 `json:"callOI"`     // 认购持仓量
+	PutOI      float64 `json:"putOI"`      // 认沽持仓量
+	PCRVolume  float64 `json:"pcrVolume"`  // 成交量认沽认购比
+	PCROI      float64 `json:"pcrOI"`      // 持仓量认沽认购比
+	MaxPain    float64 `json:"maxPain"`    // 最大痛点行权价
+	ATMStrike  float64 `json:"atmStrike"`  // 平值行权价
+	ATMIV      float64 `json:"atmIV"`      // 平值隐含波动率(%)，认购认沽均值
+}
+
+// GetOptionChain 获取标的ETF指定月份的期权链，month 为空时取最近到期月份
+func (s *StockInfoService) GetOptionChain(underlying, month string) (*OptionChain, error) {
+	months := []string{month}
+	if month == "" {
+		// 当月合约到期后列表为空，依次尝试下月
+		now := time.Now()
+		months = []string{now.Format("0601"), now.AddDate(0, 1, 0).Format("0601")}
+	}
+
+	num := underlying[2:]
+	for _, m := range months {
+		body, err := s.fetchSinaQuotes([]string{"OP_UP_" + num + m, "OP_DOWN_" + num + m})
+		if err != nil {
+			return nil, fmt.Errorf("获取期权合约列表失败: %w", err)
+		}
+		calls, puts := parseOptionCodeLists(body)
+		if len(calls) == 0 && len(puts) == 0 {
+			continue
+		}
+
+		contracts, err := s.fetchOptionContracts(calls, puts)
+		if err != nil {
+			return nil, err
+		}
+		return &OptionChain{Underlying: underlying, Month: m, Contracts: contracts}, nil
+	}
+	return nil, fmt.Errorf("%s 暂无可交易的期权合约", underlying)
+}
+
+// parseOptionCodeLists 解析认购/认沽合约代码列表，返回不带 CON_OP_ 前缀的合约编号
+func parseOptionCodeLists(body string) (calls, puts []string) {
+	for _, line := range strings.Split(body, "\n") {
+		code, fields, ok := sinaQuoteFields(line)
+		if !ok {
+			continue
+		}
+		var ids []string
+		for _, f := range fields {
+			if id := strings.TrimPrefix(strings.TrimSpace(f), "CON_OP_"); id != "" {
+				ids = append(ids, id)
+			}
+		}
+		if strings.HasPrefix(code, "OP_UP_") {
+			calls = ids
+		} else if strings.HasPrefix(code, "OP_DOWN_") {
+			puts = ids
+		}
+	}
+	return calls, puts
+}
+
+// fetchOptionContracts 分批获取合约行情（CON_OP_）和希腊字母（CON_SO_）
+func (s *StockInfoService) fetchOptionContracts(calls, puts []string) ([]OptionContract, error) {
+	isCall := make(map[string]bool, len(calls))
+	ids := make([]string, 0, len(calls)+len(puts))
+	for _, id := range calls {
+		isCall[id] = true
+		ids = append(ids, id)
+	}
+	ids = append(ids, puts...)
+
+	contracts := make(map[string]*OptionContract, len(ids))
+	for start := 0; start < len(ids); start += optionBatchSize / 2 {
+		end := min(start+optionBatchSize/2, len(ids))
+		codes := make([]string, 0, (end-start)*2)
+		for _, id := range ids[start:end] {
+			codes = append(codes, "CON_OP_"+id, "CON_SO_"+id)
+		}
+		body, err := s.fetchSinaQuotes(codes)
+		if err != nil {
+			return nil, fmt.Errorf("获取期权行情失败: %w", err)
+		}
+		parseOptionQuotes(body, contracts)
+	}
+
+	result := make([]OptionContract, 0, len(contracts))
+	for id, c := range contracts {
+		if c.Strike <= 0 {
+			continue
+		}
+		c.Code = id
+		c.IsCall = isCall[id]
+		result = append(result, *c)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Strike != result[j].Strike {
+			return result[i].Strike < result[j].Strike
+		}
+		return result[i].IsCall && !result[j].IsCall
+	})
+	return result, nil
+}
+
+// parseOptionQuotes 解析期权行情及希腊字母，按合约编号合并到 contracts
+// CON_OP_ 字段: 2最新价 5持仓量 7行权价 37合约简称 41成交量
+// CON_SO_ 字段: 0合约简称 9隐含波动率 13行权价
+func parseOptionQuotes(body string, contracts map[string]*OptionContract) {
+	for _, line := range strings.Split(body, "\n") {
+		code, fields, ok := sinaQuoteFields(line)
+		if !ok {
+			continue
+		}
+		num := func(i int) float64 {
+			if i >= len(fields) {
+				return 0
+			}
+			v, _ := strconv.ParseFloat(strings.TrimSpace(fields[i]), 64)
+			return v
+		}
+
+		var id string
+		switch {
+		case strings.HasPrefix(code, "CON_OP_"):
+			id = strings.TrimPrefix(code, "CON_OP_")
+		case strings.HasPrefix(code, "CON_SO_"):
+			id = strings.TrimPrefix(code, "CON_SO_")
+		default:
+			continue
+		}
+		c, ok := contracts[id]
+		if !ok {
+			c = &OptionContract{}
+			contracts[id] = c
+		}
+
+		if strings.HasPrefix(code, "CON_OP_") {
+			if len(fields) < 42 {
+				continue
+			}
+			c.Price = num(2)
+			c.OpenInterest = num(5)
+			c.Strike = num(7)
+			c.Name = fields[37]
+			c.Volume = num(41)
+			continue
+		}
+		iv := num(9)
+		// 接口返回小数形式，统一转为百分比
+		if iv > 0 && iv < 5 {
+			iv *= 100
+		}
+		c.IV = iv
+		if c.Strike == 0 {
+			c.Strike = num(13)
+		}
+	}
+}
+
+// SummarizeOptionChain 汇总期权链：认沽认购比、最大痛点及平值隐含波动率
+// spot 为标的最新价，<=0 时不计算平值合约
+func SummarizeOptionChain(contracts []OptionContract, spot float64) OptionSummary {
+	var sum OptionSummary
+	for _, c := range contracts {
+		if c.IsCall {
+			sum.CallVolume += c.Volume
+			sum.CallOI += c.OpenInterest
+		} else {
+			sum.PutVolume += c.Volume
+			sum.PutOI += c.OpenInterest
+		}
+	}
+	if sum.CallVolume > 0 {
+		sum.PCRVolume = sum.PutVolume / sum.CallVolume
+	}
+	if sum.CallOI > 0 {
+		sum.PCROI = sum.PutOI / sum.CallOI
+	}
+	sum.MaxPain = computeMaxPain(contracts)
+
+	if spot <= 0 {
+		return sum
+	}
+	best := math.MaxFloat64
+	for _, c := range contracts {
+		if d := math.Abs(c.Strike - spot); d < best {
+			best = d
+			sum.ATMStrike = c.Strike
+		}
+	}
+	var ivSum float64
+	var ivCount int
+	for _, c := range contracts {
+		if c.Strike == sum.ATMStrike && c.IV > 0 {
+			ivSum += c.IV
+			ivCount++
+		}
+	}
+	if ivCount > 0 {
+		sum.ATMIV = ivSum / float64(ivCount)
+	}
+	return sum
+}
+
+// computeMaxPain 计算最大痛点：到期价格落在该行权价时，期权买方的内在价值总和最小
+func computeMaxPain(contracts []OptionContract) float64 {
+	strikes := make(map[float64]bool)
+	for _, c := range contracts {
+		strikes[c.Strike] = true
+	}
+
+	maxPain, minPayout := 0.0, math.MaxFloat64
+	for k := range strikes {
+		var payout float64
+		for _, c := range contracts {
+			if c.IsCall && k > c.Strike {
+				payout += (k - c.Strike) * c.OpenInterest
+			} else if !c.IsCall && k < c.Strike {
+				payout += (c.Strike - k) * c.OpenInterest
+			}
+		}
+		if payout < minPayout || (payout == minPayout && k < maxPain) {
+			minPayout = payout
+			maxPain = k
+		}
+	}
+	return maxPain
+}
@@ -0,0 +1,58 @@
+package services
+
+import (
+	"math"
+	"testing"
+)
+
+// TestOptionUnderlying 测试期权标的识别
+func TestOptionUnderlying(t *testing.T) {
+	cases := map[string]string{
+		"sh510050":   "sh510050",
+		"SH510300":   "sh510300",
+		"s_sh000016": "sh510050", // 上证50指数映射到50ETF
+		"sz399006":   "sz159915",
+	}
+	for code, want := range cases {
+		if got, ok := OptionUnderlying(code); !ok || got != want {
+			t.Errorf("OptionUnderlying(%s) = %s,%v, want %s", code, got, ok, want)
+		}
+	}
+	if _, ok := OptionUnderlying("sh600519"); ok {
+		t.Error("个股不应有期权")
+	}
+}
+
+// TestParseOptionCodeLists 测试认购/认沽合约列表解析
+func TestParseOptionCodeLists(t *testing.T) {
+	body := `var hq_str_OP_UP_5100502412=",CON_OP_10008001,CON_OP_10008002,";
+var hq_str_OP_DOWN_5100502412="CON_OP_10008011,";`
+	calls, puts := parseOptionCodeLists(body)
+	if len(calls) != 2 || calls[1] != "10008002" || len(puts) != 1 || puts[0] != "10008011" {
+		t.Errorf("解析结果错误: calls=%v puts=%v", calls, puts)
+	}
+}
+
+// TestSummarizeOptionChain 测试认沽认购比、最大痛点及平值隐含波动率
+func TestSummarizeOptionChain(t *testing.T) {
+	contracts := []OptionContract{
+		{IsCall: true, Strike: 2.5, Volume: 100, OpenInterest: 1000, IV: 20},
+		{IsCall: false, Strike: 2.5, Volume: 50, OpenInterest: 200, IV: 22},
+		{IsCall: true, Strike: 2.6, Volume: 300, OpenInterest: 3000, IV: 18},
+		{IsCall: false, Strike: 2.6, Volume: 150, OpenInterest: 500, IV: 19},
+		{IsCall: true, Strike: 2.7, Volume: 100, OpenInterest: 500, IV: 17},
+		{IsCall: false, Strike: 2.7, Volume: 300, OpenInterest: 3000, IV: 16},
+	}
+	sum := SummarizeOptionChain(contracts, 2.62)
+
+	if math.Abs(sum.PCRVolume-1.0) > 1e-9 || math.Abs(sum.PCROI-3700.0/4500.0) > 1e-9 {
+		t.Errorf("认沽认购比错误: vol=%.4f oi=%.4f", sum.PCRVolume, sum.PCROI)
+	}
+	// 2.5: 认沽 (0.1*500+0.2*3000)=650；2.6: 认购 0.1*1000 + 认沽 0.1*3000 = 400；2.7: 认购 0.2*1000+0.1*3000=500
+	if sum.MaxPain != 2.6 {
+		t.Errorf("最大痛点 = %v, want 2.6", sum.MaxPain)
+	}
+	if sum.ATMStrike != 2.6 || math.Abs(sum.ATMIV-18.5) > 1e-9 {
+		t.Errorf("平值合约错误: strike=%v iv=%v", sum.ATMStrike, sum.ATMIV)
+	}
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// 东方财富分时成交明细接口，pos=-N 取当日最近 N 笔
+// 明细字段: 时间,成交价,成交量(手),成交笔数,方向(1=主动卖出/内盘,2=主动买入/外盘,4=中性/集合竞价)
+const eastmoneyTickDetailsURL = "https://push2.eastmoney.com/api/qt/stock/details/get?secid=%s&fields1=f1,f2,f3,f4&fields2=f51,f52,f53,f54,f55&pos=-%d"
+
+const (
+	// orderFlowMaxTicks 单次获取的最大成交明细笔数，覆盖全天交易
+	orderFlowMaxTicks = 20000
+	// orderFlowLargeAmount 单笔成交额不低于该值(元)视为大单
+	orderFlowLargeAmount = 500000
+	// orderFlowBuyRatio 外盘/内盘不低于该值为买方主导
+	orderFlowBuyRatio = 1.2
+	// orderFlowSellRatio 外盘/内盘不高于该值为卖方主导
+	orderFlowSellRatio = 1 / 1.2
+)
+
+// 成交方向
+const (
+	tickDirectionSell    = 1
+	tickDirectionBuy     = 2
+	tickDirectionNeutral = 4
+)
+
+// 内外盘主导方向
+const (
+	OrderFlowBuyDominant  = "买方主导"
+	OrderFlowSellDominant = "卖方主导"
+	OrderFlowBalanced     = "多空均衡"
+)
+
+// orderFlowBucketLabels 半小时分段的标签，上午4段、下午4段
+var orderFlowBucketLabels = []string{
+	"09:30-10:00", "10:00-10:30", "10:30-11:00", "11:00-11:30",
+	"13:00-13:30", "13:30-14:00", "14:00-14:30", "14:30-15:00",
+}
+
+// OrderFlowBucket 半小时分段的内外盘统计，成交量单位为手
+type OrderFlowBucket struct {
+	Period      string  `json:"period"`      // 时段，如 09:30-10:00
+	OuterVolume int64   `json:"outerVolume"` // 本时段外盘（主动买入）
+	InnerVolume int64   `json:"innerVolume"` // 本时段内盘（主动卖出）
+	CumOuter    int64   `json:"cumOuter"`    // 开盘至本时段累计外盘
+	CumInner    int64   `json:"cumInner"`    // 开盘至本时段累计内盘
+	CumRatio    float64 `json:"cumRatio"`    // 累计外盘/内盘
+	LastPrice   float64 `json:"lastPrice"`   // 本时段最后成交价
+}
+
+// OrderFlow 个股当日分时内外盘统计，成交量单位为手
+type OrderFlow struct {
+	Code          string            `json:"code"`
+	PrePrice      float64           `json:"prePrice"`      // 昨收价
+	LastPrice     float64           `json:"lastPrice"`     // 最新成交价
+	LastTime      string            `json:"lastTime"`      // 最新成交时间
+	Ticks         int               `json:"ticks"`         // 统计的成交明细条数
+	OuterVolume   int64             `json:"outerVolume"`   // 外盘（主动买入）
+	InnerVolume   int64             `json:"innerVolume"`   // 内盘（主动卖出）
+	AuctionVolume int64             `json:"auctionVolume"` // 集合竞价及中性成交
+	Ratio         float64           `json:"ratio"`         // 外盘/内盘
+	LargeOuter    int64             `json:"largeOuter"`    // 大单外盘
+	LargeInner    int64             `json:"largeInner"`    // 大单内盘
+	Dominance     string            `json:"dominance"`     // 买方主导/卖方主导/多空均衡
+	Buckets       []OrderFlowBucket `json:"buckets"`       // 有成交的半小时分段，按时间升序
+}
+
+// orderFlowTick 单笔成交明细
+type orderFlowTick struct {
+	Time      string
+	Price     float64
+	Volume    int64
+	Direction int
+}
+
+// tickDetailsResponse 分时成交明细接口响应
+type tickDetailsResponse struct {
+	Data *struct {
+		PrePrice float64  `json:"prePrice"`
+		Details  []string `json:"details"`
+	} `json:"data"`
+}
+
+// GetOrderFlow 获取个股当日分时成交明细并统计内外盘（盘中数据实时变化，不缓存）
+// code: 带市场前缀的代码，如 sh600519；非交易日返回最近一个交易日的数据
+func (s *MoneyFlowService) GetOrderFlow(code string) (*OrderFlow, error) {
+	code = strings.ToLower(strings.TrimSpace(code))
+
+	req, err := http.NewRequest("GET", fmt.Sprintf(eastmoneyTickDetailsURL, toSecID(code), orderFlowMaxTicks), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Referer", "https://quote.eastmoney.com/")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("获取成交明细失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+
+	var result tickDetailsResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("解析成交明细失败: %w", err)
+	}
+	if result.Data == nil {
+		return &OrderFlow{Code: code}, nil
+	}
+
+	ticks := make([]orderFlowTick, 0, len(result.Data.Details))
+	for _, line := range result.Data.Details {
+		if tick, ok := parseTickDetail(line); ok {
+			ticks = append(ticks, tick)
+		}
+	}
+	flow := analyzeOrderFlow(ticks)
+	flow.Code = code
+	flow.PrePrice = result.Data.PrePrice
+	return flow, nil
+}
+
+// parseTickDetail 解析单条成交明细: 时间,成交价,成交量(手),成交笔数,方向
+func parseTickDetail(line string) (orderFlowTick, bool) {
+	parts := strings.Split(line, ",")
+	if len(parts) < 5 {
+		return orderFlowTick{}, false
+	}
+	price, _ := strconv.ParseFloat(parts[1], 64)
+	volume, _ := strconv.ParseInt(parts[2], 10, 64)
+	direction, _ := strconv.Atoi(parts[4])
+	if price <= 0 || volume <= 0 {
+		return orderFlowTick{}, false
+	}
+	return orderFlowTick{Time: parts[0], Price: price, Volume: volume, Direction: direction}, true
+}
+
+// orderFlowBucketIndex 返回成交时间所属的半小时分段序号，集合竞价（09:30前）返回 -1
+// 11:30 收盘撮合归入上午最后一段，15:00 收盘集合竞价归入下午最后一段
+func orderFlowBucketIndex(hhmmss string) int {
+	if len(hhmmss) < 5 {
+		return -1
+	}
+	h, err1 := strconv.Atoi(hhmmss[:2])
+	m, err2 := strconv.Atoi(hhmmss[3:5])
+	if err1 != nil || err2 != nil {
+		return -1
+	}
+	minutes := h*60 + m
+	switch {
+	case minutes < 9*60+30:
+		return -1
+	case minutes < 13*60:
+		return min((minutes-(9*60+30))/30, 3)
+	default:
+		return min(4+(minutes-13*60)/30, 7)
+	}
+}
+
+// analyzeOrderFlow 按成交方向统计内外盘，并按半小时分段累计，ticks 需按时间升序
+// 集合竞价和中性成交不计入内外盘；外盘/内盘 ≥1.2 为买方主导，≤1/1.2 为卖方主导
+func analyzeOrderFlow(ticks []orderFlowTick) *OrderFlow {
+	flow := &OrderFlow{Ticks: len(ticks), Dominance: OrderFlowBalanced}
+	buckets := make([]OrderFlowBucket, len(orderFlowBucketLabels))
+	used := make([]bool, len(orderFlowBucketLabels))
+
+	for _, t := range ticks {
+		flow.LastPrice, flow.LastTime = t.Price, t.Time
+		idx := orderFlowBucketIndex(t.Time)
+		if idx < 0 || t.Direction == tickDirectionNeutral {
+			flow.AuctionVolume += t.Volume
+			continue
+		}
+		used[idx] = true
+		buckets[idx].LastPrice = t.Price
+
+		large := t.Price*float64(t.Volume)*100 >= orderFlowLargeAmount
+		switch t.Direction {
+		case tickDirectionBuy:
+			flow.OuterVolume += t.Volume
+			buckets[idx].OuterVolume += t.Volume
+			if large {
+				flow.LargeOuter += t.Volume
+			}
+		case tickDirectionSell:
+			flow.InnerVolume += t.Volume
+			buckets[idx].InnerVolume += t.Volume
+			if large {
+				flow.LargeInner += t.Volume
+			}
+		}
+	}
+
+	var cumOuter, cumInner int64
+	for i := range buckets {
+		if !used[i] {
+			continue
+		}
+		cumOuter += buckets[i].OuterVolume
+		cumInner += buckets[i].InnerVolume
+		b := buckets[i]
+		b.Period = orderFlowBucketLabels[i]
+		b.CumOuter, b.CumInner = cumOuter, cumInner
+		b.CumRatio = volumeRatio(cumOuter, cumInner)
+		flow.Buckets = append(flow.Buckets, b)
+	}
+
+	flow.Ratio = volumeRatio(flow.OuterVolume, flow.InnerVolume)
+	switch {
+	case flow.InnerVolume == 0 && flow.OuterVolume == 0:
+	case flow.InnerVolume == 0 || flow.Ratio >= orderFlowBuyRatio:
+		flow.Dominance = OrderFlowBuyDominant
+	case flow.Ratio <= orderFlowSellRatio:
+		flow.Dominance = OrderFlowSellDominant
+	}
+	return flow
+}
+
+// volumeRatio 外盘/内盘，内盘为 0 时返回 0
+func volumeRatio(outer, inner int64) float64 {
+	if inner == 0 {
+		return 0
+	}
+	return float64(outer) / float64(inner)
+}
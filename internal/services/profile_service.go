@@ -0,0 +1,134 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/store"
+)
+
+// maxProfileNameLen 方案名称最大长度（字符）
+const maxProfileNameLen = 32
+
+// ProfileService 分析方案服务，方案保存在数据目录下的 profiles 目录
+type ProfileService struct {
+	backend store.Store
+}
+
+// NewProfileService 创建分析方案服务
+func NewProfileService(dataDir string) *ProfileService {
+	backend, err := store.NewFileStore(filepath.Join(dataDir, "profiles"))
+	if err != nil {
+		fmt.Printf("创建profiles目录失败: %v\n", err)
+	}
+	return &ProfileService{backend: backend}
+}
+
+// normalizeProfileName 校验并规范化方案名称，名称直接作为文件名使用
+func normalizeProfileName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("方案名称不能为空")
+	}
+	if utf8.RuneCountInString(name) > maxProfileNameLen {
+		return "", fmt.Errorf("方案名称不能超过%d个字符", maxProfileNameLen)
+	}
+	if strings.ContainsAny(name, `/\:*?"<>|`) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("方案名称包含非法字符: %s", name)
+	}
+	return name, nil
+}
+
+// Save 保存方案，同名方案覆盖
+func (ps *ProfileService) Save(profile models.Profile) error {
+	name, err := normalizeProfileName(profile.Name)
+	if err != nil {
+		return err
+	}
+	if len(profile.Agents) == 0 {
+		return fmt.Errorf("方案至少需要包含一位专家")
+	}
+	profile.Name = name
+	profile.UpdatedAt = time.Now().UnixMilli()
+
+	data, err := json.MarshalIndent(profile, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ps.backend.Set(name, data)
+}
+
+// Load 加载指定方案
+func (ps *ProfileService) Load(name string) (*models.Profile, error) {
+	name, err := normalizeProfileName(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ps.backend.Get(name)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, fmt.Errorf("方案不存在: %s", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var profile models.Profile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("解析方案失败: %w", err)
+	}
+	return &profile, nil
+}
+
+// List 列出全部方案概要，按名称排序
+func (ps *ProfileService) List() []models.ProfileInfo {
+	names, err := ps.backend.List()
+	if err != nil {
+		return []models.ProfileInfo{}
+	}
+	result := make([]models.ProfileInfo, 0, len(names))
+	for _, name := range names {
+		profile, err := ps.Load(name)
+		if err != nil {
+			continue
+		}
+		result = append(result, models.ProfileInfo{
+			Name:       profile.Name,
+			AgentCount: len(profile.Agents),
+			UpdatedAt:  profile.UpdatedAt,
+		})
+	}
+	return result
+}
+
+// ProfileSettingsFromConfig 从应用配置中提取随方案切换的会议设置
+func ProfileSettingsFromConfig(config *models.AppConfig) models.ProfileSettings {
+	return models.ProfileSettings{
+		ModeratorPrompt:    config.ModeratorPrompt,
+		AgentDelayMs:       config.AgentDelayMs,
+		IncludeToolTrace:   config.IncludeToolTrace,
+		SummaryParams:      config.SummaryParams,
+		GlobalTools:        append([]string{}, config.GlobalTools...),
+		MaxContextMessages: config.MaxContextMessages,
+		FallbackAgentCount: config.FallbackAgentCount,
+		PreflightGather:    config.PreflightGather,
+		AnalysisDepth:      config.AnalysisDepth,
+	}
+}
+
+// ApplyProfileSettings 将方案的会议设置写入应用配置，其余配置保持不变
+func ApplyProfileSettings(config *models.AppConfig, settings models.ProfileSettings) {
+	config.ModeratorPrompt = settings.ModeratorPrompt
+	config.AgentDelayMs = settings.AgentDelayMs
+	config.IncludeToolTrace = settings.IncludeToolTrace
+	config.SummaryParams = settings.SummaryParams
+	config.GlobalTools = append([]string{}, settings.GlobalTools...)
+	config.MaxContextMessages = settings.MaxContextMessages
+	config.FallbackAgentCount = settings.FallbackAgentCount
+	config.PreflightGather = settings.PreflightGather
+	config.AnalysisDepth = settings.AnalysisDepth
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestProfileService 测试分析方案的保存、加载与列表
+func TestProfileService(t *testing.T) {
+	ps := NewProfileService(t.TempDir())
+
+	config := &models.AppConfig{AgentDelayMs: 500, GlobalTools: []string{"get_news"}, FallbackAgentCount: 3, PreflightGather: true, AnalysisDepth: models.AnalysisDepthDeep}
+	profile := models.Profile{
+		Name:     " 短线 ",
+		Agents:   []models.AgentConfig{{ID: "a1", Name: "K线王"}, {ID: "a2", Name: "风控李"}},
+		Settings: ProfileSettingsFromConfig(config),
+	}
+	if err := ps.Save(profile); err != nil {
+		t.Fatalf("保存方案失败: %v", err)
+	}
+
+	loaded, err := ps.Load("短线")
+	if err != nil {
+		t.Fatalf("加载方案失败: %v", err)
+	}
+	if loaded.Name != "短线" || len(loaded.Agents) != 2 || loaded.UpdatedAt == 0 {
+		t.Errorf("加载结果错误: %+v", loaded)
+	}
+
+	applied := &models.AppConfig{Theme: "ocean"}
+	ApplyProfileSettings(applied, loaded.Settings)
+	if applied.AgentDelayMs != 500 || applied.FallbackAgentCount != 3 || !applied.PreflightGather || applied.AnalysisDepth != models.AnalysisDepthDeep || len(applied.GlobalTools) != 1 || applied.Theme != "ocean" {
+		t.Errorf("应用方案设置错误: %+v", applied)
+	}
+
+	list := ps.List()
+	if len(list) != 1 || list[0].Name != "短线" || list[0].AgentCount != 2 {
+		t.Errorf("方案列表错误: %+v", list)
+	}
+
+	for _, name := range []string{"", "../x", "a/b", ".hidden"} {
+		if err := ps.Save(models.Profile{Name: name, Agents: profile.Agents}); err == nil {
+			t.Errorf("非法名称 %q 应保存失败", name)
+		}
+	}
+	if _, err := ps.Load("不存在"); err == nil {
+		t.Error("加载不存在的方案应返回错误")
+	}
+}
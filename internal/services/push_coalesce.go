@@ -0,0 +1,55 @@
+package services
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// pushCoalesceEpsilon 价格变动绝对值不超过该值视为未变化
+	pushCoalesceEpsilon = 0.001
+	// pushForceInterval 行情无变化时的强制推送间隔，保证前端定期同步
+	pushForceInterval = 30 * time.Second
+)
+
+// pushCoalescer 行情推送合并器
+// 记录上次推送的各代码价格，价格均未变化且未到强制推送间隔时跳过推送，减少前端无效重渲染
+type pushCoalescer struct {
+	mu       sync.Mutex
+	last     map[string]float64
+	lastEmit time.Time
+}
+
+// newPushCoalescer 创建行情推送合并器
+func newPushCoalescer() *pushCoalescer {
+	return &pushCoalescer{last: make(map[string]float64)}
+}
+
+// shouldEmit 判断本次行情是否需要推送，需要推送时记录本次价格
+// 代码集合变化（订阅增减）、任一价格变动超过阈值或距上次推送超过强制间隔时返回 true
+func (c *pushCoalescer) shouldEmit(prices map[string]float64, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.changed(prices) && now.Sub(c.lastEmit) < pushForceInterval {
+		return false
+	}
+	c.last = prices
+	c.lastEmit = now
+	return true
+}
+
+// changed 与上次推送的价格比较是否有变化
+func (c *pushCoalescer) changed(prices map[string]float64) bool {
+	if len(prices) != len(c.last) {
+		return true
+	}
+	for code, price := range prices {
+		last, ok := c.last[code]
+		if !ok || math.Abs(price-last) > pushCoalesceEpsilon {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPushCoalescer 测试行情推送合并：无变化跳过，变化、订阅变更及强制间隔时推送
+func TestPushCoalescer(t *testing.T) {
+	c := newPushCoalescer()
+	now := time.Now()
+
+	if !c.shouldEmit(map[string]float64{"sh000001": 3200.12, "sz399001": 10500.5}, now) {
+		t.Fatal("首次推送应发出")
+	}
+	if c.shouldEmit(map[string]float64{"sh000001": 3200.12, "sz399001": 10500.5}, now.Add(3*time.Second)) {
+		t.Error("价格未变化不应推送")
+	}
+	if !c.shouldEmit(map[string]float64{"sh000001": 3200.15, "sz399001": 10500.5}, now.Add(6*time.Second)) {
+		t.Error("价格变化应推送")
+	}
+	if !c.shouldEmit(map[string]float64{"sh000001": 3200.15}, now.Add(9*time.Second)) {
+		t.Error("代码集合变化应推送")
+	}
+	if c.shouldEmit(map[string]float64{"sh000001": 3200.15}, now.Add(20*time.Second)) {
+		t.Error("未到强制间隔不应推送")
+	}
+	if !c.shouldEmit(map[string]float64{"sh000001": 3200.15}, now.Add(40*time.Second)) {
+		t.Error("超过强制间隔应推送")
+	}
+}
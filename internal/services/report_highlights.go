@@ -0,0 +1,243 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	eastmoneyAnnounceListAPI    = "https://np-anotice-stock.eastmoney.com/api/security/ann"
+	eastmoneyAnnounceContentAPI = "https://np-cnotice-stock.eastmoney.com/api/content/ann"
+
+	// reportHighlightsMaxPages 定期报告正文最多读取的页数（每页约数万字）
+	reportHighlightsMaxPages = 5
+)
+
+// 经营情况讨论与分析章节标题，新旧格式报告写法不同
+var mdaHeadings = []string{"经营情况讨论与分析", "管理层讨论与分析"}
+
+// reportSectionPattern 报告章节标题，如 "第三节 管理层讨论与分析"
+var reportSectionPattern = regexp.MustCompile(`(?m)^\s*第[一二三四五六七八九十]+节`)
+
+// sectionPrefixPattern 章节标题行中标题前的部分：空白或章节序号
+var sectionPrefixPattern = regexp.MustCompile(`^\s*(第[一二三四五六七八九十]+节)?\s*$`)
+
+// ReportHighlights 定期报告经营情况摘录
+type ReportHighlights struct {
+	Title      string `json:"title"`      // 公告标题
+	NoticeDate string `json:"noticeDate"` // 公告日期
+	Section    string `json:"section"`    // 摘录的章节，未定位到时为空
+	Content    string `json:"content"`    // 摘录正文
+	Truncated  bool   `json:"truncated"`  // 是否截断
+	PDFUrl     string `json:"pdfUrl"`     // 报告原文链接
+}
+
+// announceItem 公告列表项
+type announceItem struct {
+	ArtCode    string `json:"art_code"`
+	Title      string `json:"title"`
+	NoticeDate string `json:"notice_date"`
+}
+
+// announceListResponse 公告列表响应
+type announceListResponse struct {
+	Data struct {
+		List []announceItem `json:"list"`
+	} `json:"data"`
+}
+
+// announceContent 公告正文（按页返回）
+type announceContent struct {
+	NoticeContent string `json:"notice_content"`
+	AttachURL     string `json:"attach_url"`
+	PageSize      int    `json:"page_size"` // 总页数
+}
+
+// announceContentResponse 公告正文响应
+type announceContentResponse struct {
+	Data announceContent `json:"data"`
+}
+
+// GetReportHighlights 获取最新年报/半年报中经营情况讨论与分析的摘录
+// maxRunes 为返回正文的字符上限，超出时截断并标记
+func (s *ResearchReportService) GetReportHighlights(stockCode string, maxRunes int) (*ReportHighlights, error) {
+	code := stripMarketPrefix(stockCode)
+	items, err := s.fetchPeriodicReports(code)
+	if err != nil {
+		return nil, err
+	}
+	item := pickLatestPeriodicReport(items)
+	if item == nil {
+		return nil, fmt.Errorf("未找到 %s 的年报或半年报", code)
+	}
+
+	// 逐页读取，定位到章节结束即停止
+	var (
+		sb      strings.Builder
+		pdfURL  string
+		section string
+		content string
+	)
+	for page := 1; page <= reportHighlightsMaxPages; page++ {
+		data, err := s.fetchAnnounceContent(item.ArtCode, page)
+		if err != nil {
+			if page == 1 {
+				return nil, err
+			}
+			break
+		}
+		if pdfURL == "" {
+			pdfURL = data.AttachURL
+		}
+		sb.WriteString(s.cleanHTML(data.NoticeContent))
+
+		var complete bool
+		section, content, complete = extractMDA(sb.String())
+		if complete || page >= data.PageSize {
+			break
+		}
+	}
+	// 未定位到章节时退回报告开头（摘要通常为重要提示及主要财务数据）
+	if section == "" {
+		content = strings.TrimSpace(sb.String())
+	}
+
+	content, truncated := truncateText(content, maxRunes)
+	return &ReportHighlights{
+		Title:      item.Title,
+		NoticeDate: trimDate(item.NoticeDate),
+		Section:    section,
+		Content:    content,
+		Truncated:  truncated,
+		PDFUrl:     pdfURL,
+	}, nil
+}
+
+// fetchPeriodicReports 获取定期报告公告列表
+func (s *ResearchReportService) fetchPeriodicReports(code string) ([]announceItem, error) {
+	params := url.Values{}
+	params.Set("sr", "-1")
+	params.Set("page_size", "30")
+	params.Set("page_index", "1")
+	params.Set("ann_type", "A")
+	params.Set("client_source", "web")
+	params.Set("stock_list", code)
+	params.Set("f_node", "1") // 定期报告
+	params.Set("s_node", "0")
+
+	var resp announceListResponse
+	if err := s.getAnnounceJSON(eastmoneyAnnounceListAPI+"?"+params.Encode(), &resp); err != nil {
+		return nil, fmt.Errorf("获取定期报告列表失败: %w", err)
+	}
+	return resp.Data.List, nil
+}
+
+// fetchAnnounceContent 获取公告正文的指定页
+func (s *ResearchReportService) fetchAnnounceContent(artCode string, page int) (*announceContent, error) {
+	params := url.Values{}
+	params.Set("art_code", artCode)
+	params.Set("client_source", "web")
+	params.Set("page_index", fmt.Sprint(page))
+
+	var resp announceContentResponse
+	if err := s.getAnnounceJSON(eastmoneyAnnounceContentAPI+"?"+params.Encode(), &resp); err != nil {
+		return nil, fmt.Errorf("获取报告正文失败: %w", err)
+	}
+	return &resp.Data, nil
+}
+
+// getAnnounceJSON 请求公告接口并解析 JSON
+func (s *ResearchReportService) getAnnounceJSON(apiURL string, out any) error {
+	req, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
+	req.Header.Set("Referer", "https://data.eastmoney.com/")
+
+	resp, err := s.client.get().Do(req)
+	if err != nil {
+		return fmt.Errorf("请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("读取响应失败: %w", err)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("解析响应失败: %w", err)
+	}
+	return nil
+}
+
+// pickLatestPeriodicReport 从定期报告中选出最新的年报或半年报正文
+// 排除摘要、英文版、季报及更正/取消公告；列表已按公告日期倒序
+func pickLatestPeriodicReport(items []announceItem) *announceItem {
+	for i := range items {
+		title := items[i].Title
+		if !strings.Contains(title, "年度报告") || strings.Contains(title, "季度") {
+			continue
+		}
+		if strings.Contains(title, "摘要") || strings.Contains(title, "英文") ||
+			strings.Contains(title, "更正") || strings.Contains(title, "取消") {
+			continue
+		}
+		return &items[i]
+	}
+	return nil
+}
+
+// extractMDA 从报告正文中截取经营情况讨论与分析章节
+// 返回章节标题、正文以及是否已读到下一章节（章节完整）；跳过目录中的同名条目
+func extractMDA(text string) (string, string, bool) {
+	for _, heading := range mdaHeadings {
+		start := -1
+		for offset := 0; ; {
+			idx := strings.Index(text[offset:], heading)
+			if idx == -1 {
+				break
+			}
+			idx += offset
+			offset = idx + len(heading)
+			// 须独占一行（可带章节序号），排除正文中"详见管理层讨论与分析"等引用
+			lineStart := strings.LastIndex(text[:idx], "\n") + 1
+			if !sectionPrefixPattern.MatchString(text[lineStart:idx]) {
+				continue
+			}
+			// 目录条目后紧跟下一章节标题，正文标题后为较长正文
+			next := reportSectionPattern.FindStringIndex(text[offset:])
+			if next == nil || next[0] > 200 {
+				start = offset
+				break
+			}
+		}
+		if start == -1 {
+			continue
+		}
+
+		body := text[start:]
+		if next := reportSectionPattern.FindStringIndex(body); next != nil {
+			return heading, strings.TrimSpace(body[:next[0]]), true
+		}
+		return heading, strings.TrimSpace(body), false
+	}
+	return "", "", false
+}
+
+// truncateText 按字符数截断文本，返回是否截断
+func truncateText(text string, maxRunes int) (string, bool) {
+	if maxRunes <= 0 {
+		return text, false
+	}
+	runes := []rune(text)
+	if len(runes) <= maxRunes {
+		return text, false
+	}
+	return string(runes[:maxRunes]) + "\n...(内容过长已截断)", true
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestPickLatestPeriodicReport 测试选取最新年报/半年报正文
+func TestPickLatestPeriodicReport(t *testing.T) {
+	items := []announceItem{
+		{ArtCode: "1", Title: "贵州茅台:2024年第三季度报告"},
+		{ArtCode: "2", Title: "贵州茅台:2024年半年度报告摘要"},
+		{ArtCode: "3", Title: "贵州茅台:2024年半年度报告(英文版)"},
+		{ArtCode: "4", Title: "贵州茅台:2024年半年度报告"},
+		{ArtCode: "5", Title: "贵州茅台:2023年年度报告"},
+	}
+	if got := pickLatestPeriodicReport(items); got == nil || got.ArtCode != "4" {
+		t.Errorf("应选中最新半年报正文: %+v", got)
+	}
+	if got := pickLatestPeriodicReport(items[:3]); got != nil {
+		t.Errorf("只有季报和摘要时应返回 nil: %+v", got)
+	}
+}
+
+// TestExtractMDA 测试截取经营情况讨论与分析章节，跳过目录及正文引用
+func TestExtractMDA(t *testing.T) {
+	text := "目录\n第一节 释义\n第二节 公司简介\n第三节 管理层讨论与分析\n第四节 公司治理\n" +
+		"第一节 释义\n本报告中，除非文义另有所指。详见管理层讨论与分析" + strings.Repeat("。", 100) + "\n" +
+		"第三节 管理层讨论与分析\n一、报告期内公司从事的主要业务\n公司主营茅台酒及系列酒的生产与销售。" + strings.Repeat("营收稳步增长。", 20) + "\n" +
+		"第四节 公司治理\n治理情况。"
+
+	section, content, complete := extractMDA(text)
+	if section != "管理层讨论与分析" || !complete {
+		t.Fatalf("section=%q complete=%v", section, complete)
+	}
+	if !strings.HasPrefix(content, "一、报告期内公司从事的主要业务") || strings.Contains(content, "公司治理") {
+		t.Errorf("章节正文错误: %q", content)
+	}
+
+	// 章节未读完时标记不完整
+	_, content, complete = extractMDA(text[:strings.LastIndex(text, "第四节")])
+	if complete || !strings.HasSuffix(content, "营收稳步增长。") {
+		t.Errorf("未读到下一章节时应返回不完整: complete=%v", complete)
+	}
+
+	if section, _, _ := extractMDA("第一节 重要提示\n内容"); section != "" {
+		t.Errorf("无该章节时应返回空: %q", section)
+	}
+}
+
+// TestTruncateText 测试按字符截断
+func TestTruncateText(t *testing.T) {
+	if s, cut := truncateText("经营情况", 10); cut || s != "经营情况" {
+		t.Errorf("未超长不应截断: %q", s)
+	}
+	if s, cut := truncateText("经营情况讨论", 4); !cut || !strings.HasPrefix(s, "经营情况\n") {
+		t.Errorf("超长应截断并标记: %q", s)
+	}
+}
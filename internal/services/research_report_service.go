@@ -5,10 +5,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
-
-	"github.com/run-bigpig/jcp/internal/pkg/proxy"
 )
 
 const (
@@ -48,16 +48,21 @@ type ResearchReportResponse struct {
 
 // ResearchReportService 研报服务
 type ResearchReportService struct {
-	client *http.Client
+	client *timeoutClient
 }
 
 // NewResearchReportService 创建研报服务
 func NewResearchReportService() *ResearchReportService {
 	return &ResearchReportService{
-		client: proxy.GetManager().GetClientWithTimeout(15 * time.Second),
+		client: newTimeoutClient(15 * time.Second),
 	}
 }
 
+// SetTimeout 设置请求超时，传 0 保持原值
+func (s *ResearchReportService) SetTimeout(timeout time.Duration) {
+	s.client.setTimeout(timeout)
+}
+
 // GetResearchReports 获取个股研报
 // stockCode: 股票代码 (如 "000001"，支持带前缀如 "sz000001")
 // pageSize: 每页数量
@@ -80,7 +85,7 @@ func (s *ResearchReportService) GetResearchReports(stockCode string, pageSize, p
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
 	req.Header.Set("Referer", "https://data.eastmoney.com/")
 
-	resp, err := s.client.Do(req)
+	resp, err := s.client.get().Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("请求失败: %w", err)
 	}
@@ -118,6 +123,78 @@ func (s *ResearchReportService) FormatReportsToText(reports []ResearchReport) st
 	return sb.String()
 }
 
+// ReportTarget 单篇研报隐含目标价
+type ReportTarget struct {
+	OrgSName    string  `json:"orgSName"`    // 券商简称
+	PublishDate string  `json:"publishDate"` // 发布日期
+	Rating      string  `json:"rating"`      // 评级
+	EPS         float64 `json:"eps"`         // 今年预测EPS
+	PE          float64 `json:"pe"`          // 今年预测PE
+	Target      float64 `json:"target"`      // 隐含目标价 = EPS × PE
+}
+
+// ReportTargetConsensus 研报一致目标价
+type ReportTargetConsensus struct {
+	Targets      []ReportTarget `json:"targets"`      // 有效研报目标价
+	Median       float64        `json:"median"`       // 目标价中位数（一致目标价）
+	Mean         float64        `json:"mean"`         // 目标价均值
+	Min          float64        `json:"min"`          // 最低目标价
+	Max          float64        `json:"max"`          // 最高目标价
+	CurrentPrice float64        `json:"currentPrice"` // 当前价
+	UpsidePct    float64        `json:"upsidePct"`    // 一致目标价相对当前价空间(%)，负数表示下行
+}
+
+// ComputeReportTargets 根据研报预测EPS与PE计算隐含目标价并汇总一致预期
+// currentPrice 为0时不计算上行/下行空间；无有效研报时返回 nil
+func ComputeReportTargets(reports []ResearchReport, currentPrice float64) *ReportTargetConsensus {
+	targets := make([]ReportTarget, 0, len(reports))
+	for _, r := range reports {
+		eps, err1 := strconv.ParseFloat(strings.TrimSpace(r.PredictThisYearEps), 64)
+		pe, err2 := strconv.ParseFloat(strings.TrimSpace(r.PredictThisYearPe), 64)
+		if err1 != nil || err2 != nil || eps <= 0 || pe <= 0 {
+			continue
+		}
+		targets = append(targets, ReportTarget{
+			OrgSName:    r.OrgSName,
+			PublishDate: trimDate(r.PublishDate),
+			Rating:      r.EmRatingName,
+			EPS:         eps,
+			PE:          pe,
+			Target:      eps * pe,
+		})
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	values := make([]float64, len(targets))
+	var sum float64
+	for i, t := range targets {
+		values[i] = t.Target
+		sum += t.Target
+	}
+	sort.Float64s(values)
+
+	n := len(values)
+	median := values[n/2]
+	if n%2 == 0 {
+		median = (values[n/2-1] + values[n/2]) / 2
+	}
+
+	consensus := &ReportTargetConsensus{
+		Targets:      targets,
+		Median:       median,
+		Mean:         sum / float64(n),
+		Min:          values[0],
+		Max:          values[n-1],
+		CurrentPrice: currentPrice,
+	}
+	if currentPrice > 0 {
+		consensus.UpsidePct = (median - currentPrice) / currentPrice * 100
+	}
+	return consensus
+}
+
 // GetReportPDFUrl 根据 infoCode 生成研报 PDF 下载链接
 func (s *ResearchReportService) GetReportPDFUrl(infoCode string) string {
 	if infoCode == "" {
@@ -143,7 +220,7 @@ func (s *ResearchReportService) GetReportContent(infoCode string) (*ReportConten
 
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36")
 
-	resp, err := s.client.Do(req)
+	resp, err := s.client.get().Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("请求失败: %w", err)
 	}
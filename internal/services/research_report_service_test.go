@@ -60,3 +60,31 @@ func TestGetReportContent(t *testing.T) {
 	fmt.Printf("PDF链接: %s\n\n", content.PDFUrl)
 	fmt.Printf("研报正文:\n%s\n", content.Content)
 }
+
+func TestComputeReportTargets(t *testing.T) {
+	reports := []ResearchReport{
+		{OrgSName: "A证券", PredictThisYearEps: "2.00", PredictThisYearPe: "20", PublishDate: "2026-03-01 00:00:00.000"},
+		{OrgSName: "B证券", PredictThisYearEps: "2.50", PredictThisYearPe: "20"},
+		{OrgSName: "C证券", PredictThisYearEps: "3.00", PredictThisYearPe: "20"},
+		{OrgSName: "D证券", PredictThisYearEps: "", PredictThisYearPe: "18"},
+		{OrgSName: "E证券", PredictThisYearEps: "-0.5", PredictThisYearPe: "30"},
+	}
+
+	c := ComputeReportTargets(reports, 40)
+	if c == nil || len(c.Targets) != 3 {
+		t.Fatalf("有效研报数量错误: %+v", c)
+	}
+	if c.Median != 50 || c.Mean != 50 || c.Min != 40 || c.Max != 60 {
+		t.Errorf("汇总错误: %+v", c)
+	}
+	if c.UpsidePct != 25 {
+		t.Errorf("上行空间错误: %.2f", c.UpsidePct)
+	}
+	if c.Targets[0].PublishDate != "2026-03-01" {
+		t.Errorf("日期未截断: %s", c.Targets[0].PublishDate)
+	}
+
+	if ComputeReportTargets(reports[3:], 40) != nil {
+		t.Error("无有效研报时应返回 nil")
+	}
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/embed"
+)
+
+const (
+	// 东方财富 Push2 API：批量查询ETF行情及IOPV（f441）
+	eastmoneyETFQuoteURL = "https://push2.eastmoney.com/api/qt/ulist.np/get?fltt=2&invt=2&fields=f2,f3,f6,f12,f13,f14,f441&secids=%s"
+)
+
+// SectorETF 行业/主题ETF
+type SectorETF struct {
+	Code  string `json:"code"`  // 带前缀代码，如 sh512480
+	Name  string `json:"name"`  // ETF名称
+	Theme string `json:"theme"` // 对应行业/主题
+}
+
+// ETFQuote ETF实时行情
+type ETFQuote struct {
+	Code          string  `json:"code"`          // 带前缀代码
+	Name          string  `json:"name"`          // 名称
+	Price         float64 `json:"price"`         // 最新价
+	ChangePercent float64 `json:"changePercent"` // 涨跌幅(%)
+	Amount        float64 `json:"amount"`        // 成交额(元)
+	IOPV          float64 `json:"iopv"`          // 实时参考净值
+	Premium       float64 `json:"premium"`       // 溢价率(%)，正为溢价负为折价
+}
+
+// etfQuoteCache ETF行情缓存
+type etfQuoteCache struct {
+	data      *ETFQuote
+	timestamp time.Time
+}
+
+// sectorETFsData sector_etfs.json 的数据结构
+type sectorETFsData struct {
+	ETFs []SectorETF `json:"etfs"`
+}
+
+var (
+	sectorETFs     []SectorETF
+	sectorETFsOnce sync.Once
+)
+
+// GetSectorETFs 获取嵌入的行业/主题ETF列表
+// theme: 主题关键词，为空则返回全部
+func GetSectorETFs(theme string) []SectorETF {
+	sectorETFsOnce.Do(func() {
+		var data sectorETFsData
+		if err := json.Unmarshal(embed.SectorETFsJSON, &data); err != nil {
+			return
+		}
+		sectorETFs = data.ETFs
+	})
+
+	if theme == "" {
+		return sectorETFs
+	}
+	var result []SectorETF
+	for _, etf := range sectorETFs {
+		if strings.Contains(etf.Theme, theme) || strings.Contains(theme, etf.Theme) || strings.Contains(etf.Name, theme) {
+			result = append(result, etf)
+		}
+	}
+	return result
+}
+
+// GetETFQuotes 批量获取ETF实时行情及溢价率（带缓存）
+func (s *StockInfoService) GetETFQuotes(codes []string) (map[string]*ETFQuote, error) {
+	result := make(map[string]*ETFQuote, len(codes))
+
+	var missing []string
+	s.cacheMu.RLock()
+	for _, code := range codes {
+		if cached, ok := s.etfCache[code]; ok && time.Since(cached.timestamp) < s.cacheTTL {
+			result[code] = cached.data
+			continue
+		}
+		missing = append(missing, code)
+	}
+	s.cacheMu.RUnlock()
+
+	if len(missing) == 0 {
+		return result, nil
+	}
+
+	quotes, err := s.fetchETFQuotes(missing)
+	if err != nil {
+		if len(result) > 0 {
+			return result, nil
+		}
+		return nil, err
+	}
+
+	s.cacheMu.Lock()
+	for code, quote := range quotes {
+		result[code] = quote
+		s.etfCache[code] = &etfQuoteCache{data: quote, timestamp: time.Now()}
+	}
+	s.cacheMu.Unlock()
+
+	return result, nil
+}
+
+// fetchETFQuotes 从东方财富获取ETF行情
+func (s *StockInfoService) fetchETFQuotes(codes []string) (map[string]*ETFQuote, error) {
+	secids := make([]string, 0, len(codes))
+	for _, code := range codes {
+		secids = append(secids, toSecID(code))
+	}
+	url := fmt.Sprintf(eastmoneyETFQuoteURL, strings.Join(secids, ","))
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.parseETFQuotes(body)
+}
+
+// parseETFQuotes 解析ETF行情数据
+func (s *StockInfoService) parseETFQuotes(body []byte) (map[string]*ETFQuote, error) {
+	var result struct {
+		Data *struct {
+			Diff []struct {
+				F2   any    `json:"f2"`   // 最新价
+				F3   any    `json:"f3"`   // 涨跌幅(%)
+				F6   any    `json:"f6"`   // 成交额(元)
+				F12  string `json:"f12"`  // 代码
+				F13  int    `json:"f13"`  // 市场
+				F14  string `json:"f14"`  // 名称
+				F441 any    `json:"f441"` // IOPV
+			} `json:"diff"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("parse etf quote error: %w, body: %s", err, truncateBytes(body, 200))
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("etf quote empty")
+	}
+
+	quotes := make(map[string]*ETFQuote, len(result.Data.Diff))
+	for _, d := range result.Data.Diff {
+		code := fromSecID(d.F13, d.F12)
+		quote := &ETFQuote{
+			Code:          code,
+			Name:          d.F14,
+			Price:         anyToFloat(d.F2),
+			ChangePercent: anyToFloat(d.F3),
+			Amount:        anyToFloat(d.F6),
+			IOPV:          anyToFloat(d.F441),
+		}
+		if quote.IOPV > 0 && quote.Price > 0 {
+			quote.Premium = (quote.Price - quote.IOPV) / quote.IOPV * 100
+		}
+		quotes[code] = quote
+	}
+	return quotes, nil
+}
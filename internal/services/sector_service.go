@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/run-bigpig/jcp/internal/models"
 	"github.com/run-bigpig/jcp/internal/pkg/proxy"
 )
 
@@ -39,6 +41,10 @@ type SectorService struct {
 	cache    map[string]*sectorCache
 	cacheMu  sync.RWMutex
 	cacheTTL time.Duration
+
+	conceptCache    map[string]*conceptMembershipCache
+	conceptCacheMu  sync.RWMutex
+	conceptCacheTTL time.Duration
 }
 
 // NewSectorService 创建板块/概念服务
@@ -47,6 +53,9 @@ func NewSectorService() *SectorService {
 		client:   proxy.GetManager().GetClientWithTimeout(10 * time.Second),
 		cache:    make(map[string]*sectorCache),
 		cacheTTL: 30 * time.Second,
+
+		conceptCache:    make(map[string]*conceptMembershipCache),
+		conceptCacheTTL: 24 * time.Hour,
 	}
 }
 
@@ -146,3 +155,66 @@ func (s *SectorService) parseIndustryData(industry string, body []byte) (*StockS
 		},
 	}, nil
 }
+
+// IndustryRank 个股在所属行业内的当日涨跌幅排名
+type IndustryRank struct {
+	Industry  string         `json:"industry"`
+	Rank      int            `json:"rank"`      // 目标股票排名，1 为涨幅第一，0 表示无有效行情或停牌
+	Total     int            `json:"total"`     // 参与排名的同行数（剔除停牌）
+	Target    *models.Stock  `json:"target"`    // 目标股票行情
+	AvgChange float64        `json:"avgChange"` // 行业平均涨跌幅(%)
+	UpCount   int            `json:"upCount"`   // 上涨家数
+	DownCount int            `json:"downCount"` // 下跌家数
+	Leaders   []models.Stock `json:"leaders"`   // 涨幅居前
+	Laggards  []models.Stock `json:"laggards"`  // 跌幅居前
+}
+
+// RankIndustryPeers 按当日涨跌幅对行业成员排名
+// quotes 为行业全部成员的实时行情，停牌或无价格的股票不参与排名
+func RankIndustryPeers(industry, target string, quotes []models.Stock, topN int) *IndustryRank {
+	result := &IndustryRank{Industry: industry}
+
+	active := make([]models.Stock, 0, len(quotes))
+	for _, q := range quotes {
+		if q.Symbol == target {
+			stock := q
+			result.Target = &stock
+		}
+		if q.Suspended || q.Price <= 0 {
+			continue
+		}
+		active = append(active, q)
+	}
+	if len(active) == 0 {
+		return result
+	}
+
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].ChangePercent > active[j].ChangePercent
+	})
+
+	var total float64
+	for i, q := range active {
+		total += q.ChangePercent
+		switch {
+		case q.ChangePercent > 0:
+			result.UpCount++
+		case q.ChangePercent < 0:
+			result.DownCount++
+		}
+		if q.Symbol == target {
+			result.Rank = i + 1
+		}
+	}
+	result.Total = len(active)
+	result.AvgChange = total / float64(len(active))
+
+	if topN > len(active) {
+		topN = len(active)
+	}
+	result.Leaders = append([]models.Stock(nil), active[:topN]...)
+	for i := len(active) - 1; i >= len(active)-topN; i-- {
+		result.Laggards = append(result.Laggards, active[i])
+	}
+	return result
+}
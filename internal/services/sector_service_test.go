@@ -0,0 +1,38 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// TestRankIndustryPeers 测试行业内涨跌幅排名
+func TestRankIndustryPeers(t *testing.T) {
+	quotes := []models.Stock{
+		{Symbol: "sh600001", Price: 10, ChangePercent: 1.5},
+		{Symbol: "sh600002", Price: 10, ChangePercent: 9.98},
+		{Symbol: "sh600003", Price: 10, ChangePercent: -2},
+		{Symbol: "sh600004", Price: 10, Suspended: true},
+		{Symbol: "sh600005", Price: 10, ChangePercent: 0.5},
+	}
+
+	rank := RankIndustryPeers("白酒", "sh600001", quotes, 2)
+	if rank.Rank != 2 || rank.Total != 4 {
+		t.Fatalf("排名错误: %d/%d", rank.Rank, rank.Total)
+	}
+	if rank.UpCount != 3 || rank.DownCount != 1 {
+		t.Errorf("涨跌家数错误: %d/%d", rank.UpCount, rank.DownCount)
+	}
+	if rank.Leaders[0].Symbol != "sh600002" || rank.Laggards[0].Symbol != "sh600003" {
+		t.Errorf("领涨/领跌错误: %+v %+v", rank.Leaders, rank.Laggards)
+	}
+	if rank.AvgChange < 2.49 || rank.AvgChange > 2.50 {
+		t.Errorf("平均涨跌幅错误: %.2f", rank.AvgChange)
+	}
+
+	// 停牌股不参与排名，但保留行情
+	suspended := RankIndustryPeers("白酒", "sh600004", quotes, 2)
+	if suspended.Rank != 0 || suspended.Target == nil {
+		t.Errorf("停牌股应不参与排名: %+v", suspended)
+	}
+}
@@ -3,43 +3,38 @@ package services
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
 	"sync"
 	"time"
 
 	"github.com/run-bigpig/jcp/internal/models"
+	"github.com/run-bigpig/jcp/internal/pkg/store"
 
 	"github.com/google/uuid"
 )
 
 // SessionService Session服务
 type SessionService struct {
-	sessionsDir string
-	sessions    map[string]*models.StockSession
-	mu          sync.RWMutex
+	backend  store.Store
+	sessions map[string]*models.StockSession
+	mu       sync.RWMutex
 }
 
-// NewSessionService 创建Session服务
+// NewSessionService 创建Session服务（数据目录下的 sessions 目录，每只股票一个 JSON 文件）
 func NewSessionService(dataDir string) *SessionService {
-	ss := &SessionService{
-		sessionsDir: filepath.Join(dataDir, "sessions"),
-		sessions:    make(map[string]*models.StockSession),
-	}
-	ss.ensureDir()
-	return ss
-}
-
-// ensureDir 确保目录存在
-func (ss *SessionService) ensureDir() {
-	if err := os.MkdirAll(ss.sessionsDir, 0755); err != nil {
+	backend, err := store.NewFileStore(filepath.Join(dataDir, "sessions"))
+	if err != nil {
 		fmt.Printf("创建sessions目录失败: %v\n", err)
 	}
+	return NewSessionServiceWithStore(backend)
 }
 
-// getSessionPath 获取Session文件路径
-func (ss *SessionService) getSessionPath(stockCode string) string {
-	return filepath.Join(ss.sessionsDir, stockCode+".json")
+// NewSessionServiceWithStore 使用指定存储后端创建Session服务
+func NewSessionServiceWithStore(backend store.Store) *SessionService {
+	return &SessionService{
+		backend:  backend,
+		sessions: make(map[string]*models.StockSession),
+	}
 }
 
 // GetOrCreateSession 获取或创建Session
@@ -74,10 +69,9 @@ func (ss *SessionService) GetOrCreateSession(stockCode, stockName string) (*mode
 	return session, ss.saveSession(session)
 }
 
-// loadSession 从文件加载Session
+// loadSession 从存储加载Session
 func (ss *SessionService) loadSession(stockCode string) (*models.StockSession, error) {
-	path := ss.getSessionPath(stockCode)
-	data, err := os.ReadFile(path)
+	data, err := ss.backend.Get(stockCode)
 	if err != nil {
 		return nil, err
 	}
@@ -89,14 +83,13 @@ func (ss *SessionService) loadSession(stockCode string) (*models.StockSession, e
 	return &session, nil
 }
 
-// saveSession 保存Session到文件
+// saveSession 保存Session到存储
 func (ss *SessionService) saveSession(session *models.StockSession) error {
-	path := ss.getSessionPath(session.StockCode)
 	data, err := json.MarshalIndent(session, "", "  ")
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(path, data, 0644)
+	return ss.backend.Set(session.StockCode, data)
 }
 
 // GetSession 获取Session
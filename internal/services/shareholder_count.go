@@ -0,0 +1,149 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	// holderCountPeriods 获取的股东户数报告期数
+	holderCountPeriods = 8
+	// holderCountTrendPeriods 判断筹码集中/分散趋势所用的最近报告期数
+	holderCountTrendPeriods = 3
+	// holderCountFlatPercent 户数累计变化不超过该百分比视为基本持平
+	holderCountFlatPercent = 3
+)
+
+// 股东户数趋势
+const (
+	HolderTrendConcentrating = "筹码集中"
+	HolderTrendDispersing    = "筹码分散"
+	HolderTrendFlat          = "基本持平"
+	HolderTrendUnknown       = "数据不足"
+)
+
+// HolderCountPeriod 单个报告期的股东户数
+type HolderCountPeriod struct {
+	EndDate       string  `json:"endDate"`       // 统计截止日
+	NoticeDate    string  `json:"noticeDate"`    // 公告日
+	HolderNum     float64 `json:"holderNum"`     // 股东户数
+	ChangePercent float64 `json:"changePercent"` // 较上期户数变化(%)
+	PriceChange   float64 `json:"priceChange"`   // 较上期区间股价涨跌幅(%)
+	AvgHoldShares float64 `json:"avgHoldShares"` // 户均持股数(股)
+	AvgMarketCap  float64 `json:"avgMarketCap"`  // 户均持股市值(元)
+}
+
+// HolderCountTrend 个股股东户数变化趋势
+type HolderCountTrend struct {
+	Code    string              `json:"code"`
+	Name    string              `json:"name"`
+	Periods []HolderCountPeriod `json:"periods"` // 按截止日降序
+	Trend   string              `json:"trend"`   // 最近几期趋势: 筹码集中/筹码分散/基本持平
+	// 最近几期户数累计变化(%)及同期股价累计涨跌幅(%)
+	CumHolderChange float64 `json:"cumHolderChange"`
+	CumPriceChange  float64 `json:"cumPriceChange"`
+}
+
+// holderCountAPIItem 东方财富股东户数明细
+type holderCountAPIItem struct {
+	SecurityNameAbbr string  `json:"SECURITY_NAME_ABBR"`
+	EndDate          string  `json:"END_DATE"`
+	NoticeDate       string  `json:"HOLD_NOTICE_DATE"`
+	HolderNum        float64 `json:"HOLDER_NUM"`
+	HolderNumRatio   float64 `json:"HOLDER_NUM_RATIO"` // 较上期变化(%)
+	IntervalChrate   float64 `json:"INTERVAL_CHRATE"`  // 区间涨跌幅(%)
+	AvgHoldNum       float64 `json:"AVG_HOLD_NUM"`
+	AvgMarketCap     float64 `json:"AVG_MARKET_CAP"`
+}
+
+// holderCountCache 股东户数缓存
+type holderCountCache struct {
+	data      *HolderCountTrend
+	timestamp time.Time
+}
+
+// GetHolderCountTrend 获取个股最近几个报告期的股东户数及变化趋势（按代码缓存1天）
+func (s *ShareholderService) GetHolderCountTrend(code string) (*HolderCountTrend, error) {
+	code = stripMarketPrefix(code)
+
+	s.holderCountCacheMu.RLock()
+	if cached, ok := s.holderCountCache[code]; ok && time.Since(cached.timestamp) < s.holderCountCacheTTL {
+		s.holderCountCacheMu.RUnlock()
+		return cached.data, nil
+	}
+	s.holderCountCacheMu.RUnlock()
+
+	trend, err := s.fetchHolderCountTrend(code)
+	if err != nil {
+		return nil, err
+	}
+
+	s.holderCountCacheMu.Lock()
+	s.holderCountCache[code] = &holderCountCache{data: trend, timestamp: time.Now()}
+	s.holderCountCacheMu.Unlock()
+
+	return trend, nil
+}
+
+// fetchHolderCountTrend 从东方财富获取股东户数明细
+func (s *ShareholderService) fetchHolderCountTrend(code string) (*HolderCountTrend, error) {
+	params := url.Values{}
+	params.Set("reportName", "RPT_HOLDERNUM_DET")
+	params.Set("columns", "ALL")
+	params.Set("sortColumns", "END_DATE")
+	params.Set("sortTypes", "-1")
+	params.Set("pageSize", fmt.Sprintf("%d", holderCountPeriods))
+	params.Set("pageNumber", "1")
+	params.Set("filter", fmt.Sprintf(`(SECURITY_CODE="%s")`, code))
+	params.Set("source", "WEB")
+	params.Set("client", "WEB")
+
+	var items []holderCountAPIItem
+	if _, err := fetchDatacenter(s.client, eastmoneyDatacenterURL+"?"+params.Encode(), &items); err != nil {
+		return nil, fmt.Errorf("获取股东户数失败: %w", err)
+	}
+
+	trend := &HolderCountTrend{Code: code, Periods: []HolderCountPeriod{}}
+	for _, it := range items {
+		if trend.Name == "" {
+			trend.Name = it.SecurityNameAbbr
+		}
+		trend.Periods = append(trend.Periods, HolderCountPeriod{
+			EndDate:       trimDate(it.EndDate),
+			NoticeDate:    trimDate(it.NoticeDate),
+			HolderNum:     it.HolderNum,
+			ChangePercent: it.HolderNumRatio,
+			PriceChange:   it.IntervalChrate,
+			AvgHoldShares: it.AvgHoldNum,
+			AvgMarketCap:  it.AvgMarketCap,
+		})
+	}
+	trend.Trend, trend.CumHolderChange, trend.CumPriceChange = assessHolderCountTrend(trend.Periods)
+	return trend, nil
+}
+
+// assessHolderCountTrend 根据最近几期户数累计变化判断筹码趋势，periods 按截止日降序
+// 户数累计减少超过 3% 为筹码集中，增加超过 3% 为筹码分散，否则为基本持平
+func assessHolderCountTrend(periods []HolderCountPeriod) (string, float64, float64) {
+	n := min(holderCountTrendPeriods, len(periods)-1)
+	if n <= 0 || periods[n].HolderNum <= 0 {
+		return HolderTrendUnknown, 0, 0
+	}
+
+	holderChange := (periods[0].HolderNum/periods[n].HolderNum - 1) * 100
+	priceGrowth := 1.0
+	for _, p := range periods[:n] {
+		priceGrowth *= 1 + p.PriceChange/100
+	}
+	priceChange := (priceGrowth - 1) * 100
+
+	switch {
+	case holderChange <= -holderCountFlatPercent:
+		return HolderTrendConcentrating, holderChange, priceChange
+	case holderChange >= holderCountFlatPercent:
+		return HolderTrendDispersing, holderChange, priceChange
+	default:
+		return HolderTrendFlat, holderChange, priceChange
+	}
+}
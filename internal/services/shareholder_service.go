@@ -0,0 +1,568 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/run-bigpig/jcp/internal/pkg/proxy"
+)
+
+// PledgeHolder 股东质押汇总（仅统计未解押部分）
+type PledgeHolder struct {
+	HolderName     string  `json:"holderName"`     // 股东名称
+	PledgeShares   float64 `json:"pledgeShares"`   // 质押股数(股)
+	HoldRatio      float64 `json:"holdRatio"`      // 质押占其所持股份比例(%)
+	TotalRatio     float64 `json:"totalRatio"`     // 质押占总股本比例(%)
+	RecordCount    int     `json:"recordCount"`    // 未解押笔数
+	LatestNotice   string  `json:"latestNotice"`   // 最近公告日期
+	ClosePriceLine float64 `json:"closePriceLine"` // 最高预估平仓线
+}
+
+// PledgeRisk 个股股权质押风险
+type PledgeRisk struct {
+	Code          string         `json:"code"`          // 股票代码
+	Name          string         `json:"name"`          // 股票名称
+	TradeDate     string         `json:"tradeDate"`     // 统计日期
+	PledgeRatio   float64        `json:"pledgeRatio"`   // 整体质押比例(%)
+	PledgeDealNum int            `json:"pledgeDealNum"` // 质押笔数
+	PledgeMktCap  float64        `json:"pledgeMktCap"`  // 质押市值(元)
+	Holders       []PledgeHolder `json:"holders"`       // 重要股东质押（按质押占总股本比例降序）
+	RiskLevel     string         `json:"riskLevel"`     // 风险等级: 低/中/较高/高
+	RiskReasons   []string       `json:"riskReasons"`   // 风险说明
+}
+
+// pledgeRatioAPIItem 股权质押总比例接口数据
+type pledgeRatioAPIItem struct {
+	TradeDate         string  `json:"TRADE_DATE"`
+	SecurityCode      string  `json:"SECURITY_CODE"`
+	SecurityNameAbbr  string  `json:"SECURITY_NAME_ABBR"`
+	PledgeRatio       float64 `json:"PLEDGE_RATIO"`
+	PledgeDealNum     int     `json:"PLEDGE_DEAL_NUM"`
+	PledgeMarketCap   float64 `json:"PLEDGE_MARKET_CAP"`
+	RepurchaseBalance float64 `json:"REPURCHASE_BALANCE"`
+}
+
+// pledgeDetailAPIItem 重要股东股权质押明细接口数据
+type pledgeDetailAPIItem struct {
+	HolderName       string  `json:"HOLDER_NAME"`
+	PfNum            float64 `json:"PF_NUM"`
+	PfHoldRatio      float64 `json:"PF_HOLD_RATIO"`
+	PfTsr            float64 `json:"PF_TSR"`
+	NoticeDate       string  `json:"NOTICE_DATE"`
+	UnfreezeState    string  `json:"UNFREEZE_STATE"`
+	CloseForcedPrice float64 `json:"CLOSE_FORCED_PRICE"`
+}
+
+// InsiderTrade 大股东/董监高增减持记录
+type InsiderTrade struct {
+	Category  string  `json:"category"`  // 类别: 大股东/董监高
+	Person    string  `json:"person"`    // 股东或高管姓名
+	Position  string  `json:"position"`  // 职务（仅董监高）
+	Direction string  `json:"direction"` // 增持/减持
+	Shares    float64 `json:"shares"`    // 变动股数(股)，始终为正
+	AvgPrice  float64 `json:"avgPrice"`  // 成交均价
+	Ratio     float64 `json:"ratio"`     // 变动占总股本比例(%)，董监高无此数据
+	HoldAfter float64 `json:"holdAfter"` // 变动后持股比例(%)
+	Date      string  `json:"date"`      // 变动日期（大股东为变动截止日期）
+}
+
+// holderChangeAPIItem 股东增减持接口数据
+type holderChangeAPIItem struct {
+	HolderName        string  `json:"HOLDER_NAME"`
+	Direction         string  `json:"DIRECTION"`
+	ChangeNum         float64 `json:"CHANGE_NUM"` // 万股
+	ChangeRate        float64 `json:"CHANGE_RATE"`
+	HoldRatio         float64 `json:"HOLD_RATIO"`
+	TradeAveragePrice float64 `json:"TRADE_AVERAGE_PRICE"`
+	EndDate           string  `json:"END_DATE"`
+	NoticeDate        string  `json:"NOTICE_DATE"`
+}
+
+// executiveChangeAPIItem 董监高持股变动接口数据
+type executiveChangeAPIItem struct {
+	PersonName   string  `json:"PERSON_NAME"`
+	PositionName string  `json:"POSITION_NAME"`
+	ChangeShares float64 `json:"CHANGE_SHARES"` // 股，减持为负
+	AveragePrice float64 `json:"AVERAGE_PRICE"`
+	ChangeDate   string  `json:"CHANGE_DATE"`
+}
+
+// UnlockEvent 限售股解禁批次
+type UnlockEvent struct {
+	Date       string  `json:"date"`       // 解禁日期
+	ShareType  string  `json:"shareType"`  // 限售股类型，如首发原股东限售股份、定向增发机构配售股份
+	Shares     float64 `json:"shares"`     // 实际解禁数量(股)
+	MarketCap  float64 `json:"marketCap"`  // 实际解禁市值(元)
+	FloatRatio float64 `json:"floatRatio"` // 占解禁前流通股比例(%)
+	Upcoming   bool    `json:"upcoming"`   // 是否尚未解禁
+}
+
+// UnlockSchedule 个股限售股解禁安排
+type UnlockSchedule struct {
+	Code        string        `json:"code"`        // 股票代码
+	Name        string        `json:"name"`        // 股票名称
+	Events      []UnlockEvent `json:"events"`      // 近期已解禁及待解禁批次（按日期升序）
+	RiskLevel   string        `json:"riskLevel"`   // 风险等级: 低/中/较高/高
+	RiskReasons []string      `json:"riskReasons"` // 风险说明
+}
+
+// unlockAPIItem 限售解禁接口数据
+type unlockAPIItem struct {
+	SecurityNameAbbr string  `json:"SECURITY_NAME_ABBR"`
+	FreeDate         string  `json:"FREE_DATE"`
+	FreeSharesType   string  `json:"FREE_SHARES_TYPE"`
+	AbleFreeShares   float64 `json:"ABLE_FREE_SHARES"` // 万股
+	LiftMarketCap    float64 `json:"LIFT_MARKET_CAP"`
+	FreeRatio        float64 `json:"FREE_RATIO"` // 小数形式
+}
+
+// unlockLookbackDays 解禁安排中保留的已解禁批次天数，刚解禁的筹码仍可能带来抛压
+const unlockLookbackDays = 30
+
+// unlockCache 解禁数据缓存
+type unlockCache struct {
+	data      *UnlockSchedule
+	timestamp time.Time
+}
+
+// insiderCache 增减持数据缓存
+type insiderCache struct {
+	data      []InsiderTrade
+	timestamp time.Time
+}
+
+// pledgeCache 质押数据缓存
+type pledgeCache struct {
+	data      *PledgeRisk
+	timestamp time.Time
+}
+
+// ShareholderService 股东相关数据服务（股权质押、增减持、解禁、分红等）
+type ShareholderService struct {
+	client *http.Client
+
+	pledgeCache    map[string]*pledgeCache
+	pledgeCacheMu  sync.RWMutex
+	pledgeCacheTTL time.Duration
+
+	insiderCache    map[string]*insiderCache
+	insiderCacheMu  sync.RWMutex
+	insiderCacheTTL time.Duration
+
+	unlockCache    map[string]*unlockCache
+	unlockCacheMu  sync.RWMutex
+	unlockCacheTTL time.Duration
+
+	dividendCache    map[string]*dividendCache
+	dividendCacheMu  sync.RWMutex
+	dividendCacheTTL time.Duration
+
+	issueCache    map[string]*issuePriceCache
+	issueCacheMu  sync.RWMutex
+	issueCacheTTL time.Duration
+
+	holderCountCache    map[string]*holderCountCache
+	holderCountCacheMu  sync.RWMutex
+	holderCountCacheTTL time.Duration
+}
+
+// NewShareholderService 创建股东数据服务
+func NewShareholderService() *ShareholderService {
+	return &ShareholderService{
+		client:         proxy.GetManager().GetClientWithTimeout(10 * time.Second),
+		pledgeCache:    make(map[string]*pledgeCache),
+		pledgeCacheTTL: 24 * time.Hour, // 质押数据按周更新，缓存1天
+
+		insiderCache:    make(map[string]*insiderCache),
+		insiderCacheTTL: 24 * time.Hour,
+
+		unlockCache:    make(map[string]*unlockCache),
+		unlockCacheTTL: 24 * time.Hour, // 解禁安排提前公告，缓存1天
+
+		dividendCache:    make(map[string]*dividendCache),
+		dividendCacheTTL: 24 * time.Hour,
+
+		issueCache:    make(map[string]*issuePriceCache),
+		issueCacheTTL: 24 * time.Hour,
+
+		holderCountCache:    make(map[string]*holderCountCache),
+		holderCountCacheTTL: 24 * time.Hour, // 股东户数随定期报告或互动平台不定期披露，缓存1天
+	}
+}
+
+// GetPledgeRisk 获取个股股权质押风险（按代码缓存1天）
+func (s *ShareholderService) GetPledgeRisk(code string) (*PledgeRisk, error) {
+	code = stripMarketPrefix(code)
+
+	s.pledgeCacheMu.RLock()
+	if cached, ok := s.pledgeCache[code]; ok && time.Since(cached.timestamp) < s.pledgeCacheTTL {
+		s.pledgeCacheMu.RUnlock()
+		return cached.data, nil
+	}
+	s.pledgeCacheMu.RUnlock()
+
+	risk, err := s.fetchPledgeRisk(code)
+	if err != nil {
+		return nil, err
+	}
+
+	s.pledgeCacheMu.Lock()
+	s.pledgeCache[code] = &pledgeCache{data: risk, timestamp: time.Now()}
+	s.pledgeCacheMu.Unlock()
+
+	return risk, nil
+}
+
+// fetchPledgeRisk 从东方财富获取质押总比例和重要股东质押明细
+func (s *ShareholderService) fetchPledgeRisk(code string) (*PledgeRisk, error) {
+	risk := &PledgeRisk{Code: code}
+
+	params := url.Values{}
+	params.Set("reportName", "RPT_CSDC_LIST")
+	params.Set("columns", "ALL")
+	params.Set("sortColumns", "TRADE_DATE")
+	params.Set("sortTypes", "-1")
+	params.Set("pageSize", "1")
+	params.Set("pageNumber", "1")
+	params.Set("filter", fmt.Sprintf(`(SECURITY_CODE="%s")`, code))
+	params.Set("source", "WEB")
+	params.Set("client", "WEB")
+
+	var ratios []pledgeRatioAPIItem
+	if _, err := fetchDatacenter(s.client, eastmoneyDatacenterURL+"?"+params.Encode(), &ratios); err != nil {
+		return nil, fmt.Errorf("获取质押比例失败: %w", err)
+	}
+	if len(ratios) > 0 {
+		r := ratios[0]
+		risk.Name = r.SecurityNameAbbr
+		risk.TradeDate = trimDate(r.TradeDate)
+		risk.PledgeRatio = r.PledgeRatio
+		risk.PledgeDealNum = r.PledgeDealNum
+		risk.PledgeMktCap = r.PledgeMarketCap
+	}
+
+	params = url.Values{}
+	params.Set("reportName", "RPTA_APP_ACCUMDETAILS")
+	params.Set("columns", "ALL")
+	params.Set("sortColumns", "NOTICE_DATE")
+	params.Set("sortTypes", "-1")
+	params.Set("pageSize", "100")
+	params.Set("pageNumber", "1")
+	params.Set("filter", fmt.Sprintf(`(SECURITY_CODE="%s")`, code))
+	params.Set("source", "WEB")
+	params.Set("client", "WEB")
+
+	var details []pledgeDetailAPIItem
+	if _, err := fetchDatacenter(s.client, eastmoneyDatacenterURL+"?"+params.Encode(), &details); err != nil {
+		return nil, fmt.Errorf("获取股东质押明细失败: %w", err)
+	}
+	risk.Holders = aggregatePledgeHolders(details)
+	risk.RiskLevel, risk.RiskReasons = assessPledgeRisk(risk)
+
+	return risk, nil
+}
+
+// aggregatePledgeHolders 按股东汇总未解押的质押记录，按质押占总股本比例降序
+func aggregatePledgeHolders(details []pledgeDetailAPIItem) []PledgeHolder {
+	byHolder := make(map[string]*PledgeHolder)
+	var order []string
+	for _, d := range details {
+		if d.HolderName == "" || strings.Contains(d.UnfreezeState, "已解押") {
+			continue
+		}
+		h, ok := byHolder[d.HolderName]
+		if !ok {
+			h = &PledgeHolder{HolderName: d.HolderName}
+			byHolder[d.HolderName] = h
+			order = append(order, d.HolderName)
+		}
+		h.PledgeShares += d.PfNum
+		h.HoldRatio += d.PfHoldRatio
+		h.TotalRatio += d.PfTsr
+		h.RecordCount++
+		if notice := trimDate(d.NoticeDate); notice > h.LatestNotice {
+			h.LatestNotice = notice
+		}
+		if d.CloseForcedPrice > h.ClosePriceLine {
+			h.ClosePriceLine = d.CloseForcedPrice
+		}
+	}
+
+	holders := make([]PledgeHolder, 0, len(order))
+	for _, name := range order {
+		h := byHolder[name]
+		// 多笔累加可能因补充质押等口径超过100%
+		if h.HoldRatio > 100 {
+			h.HoldRatio = 100
+		}
+		holders = append(holders, *h)
+	}
+	sort.SliceStable(holders, func(i, j int) bool {
+		return holders[i].TotalRatio > holders[j].TotalRatio
+	})
+	return holders
+}
+
+// assessPledgeRisk 根据整体质押比例和大股东质押比例评估风险等级
+func assessPledgeRisk(risk *PledgeRisk) (string, []string) {
+	level := 0
+	var reasons []string
+	raise := func(l int, reason string) {
+		if l > level {
+			level = l
+		}
+		reasons = append(reasons, reason)
+	}
+
+	switch {
+	case risk.PledgeRatio >= 50:
+		raise(3, fmt.Sprintf("整体质押比例%.2f%%，超过50%%", risk.PledgeRatio))
+	case risk.PledgeRatio >= 30:
+		raise(2, fmt.Sprintf("整体质押比例%.2f%%，超过30%%", risk.PledgeRatio))
+	case risk.PledgeRatio >= 10:
+		raise(1, fmt.Sprintf("整体质押比例%.2f%%", risk.PledgeRatio))
+	}
+
+	// 第一大质押股东通常为控股股东/实控人
+	if len(risk.Holders) > 0 {
+		top := risk.Holders[0]
+		switch {
+		case top.HoldRatio >= 80:
+			raise(3, fmt.Sprintf("%s质押占其持股%.2f%%，接近全部质押，存在爆仓/控制权变更风险", top.HolderName, top.HoldRatio))
+		case top.HoldRatio >= 50:
+			raise(2, fmt.Sprintf("%s质押占其持股%.2f%%，质押比例偏高", top.HolderName, top.HoldRatio))
+		case top.HoldRatio >= 30:
+			raise(1, fmt.Sprintf("%s质押占其持股%.2f%%", top.HolderName, top.HoldRatio))
+		}
+	}
+
+	levels := []string{"低", "中", "较高", "高"}
+	return levels[level], reasons
+}
+
+// GetInsiderTrades 获取个股近期大股东及董监高增减持记录（按代码缓存1天）
+func (s *ShareholderService) GetInsiderTrades(code string) ([]InsiderTrade, error) {
+	code = stripMarketPrefix(code)
+
+	s.insiderCacheMu.RLock()
+	if cached, ok := s.insiderCache[code]; ok && time.Since(cached.timestamp) < s.insiderCacheTTL {
+		s.insiderCacheMu.RUnlock()
+		return cached.data, nil
+	}
+	s.insiderCacheMu.RUnlock()
+
+	trades, err := s.fetchInsiderTrades(code)
+	if err != nil {
+		return nil, err
+	}
+
+	s.insiderCacheMu.Lock()
+	s.insiderCache[code] = &insiderCache{data: trades, timestamp: time.Now()}
+	s.insiderCacheMu.Unlock()
+
+	return trades, nil
+}
+
+// fetchInsiderTrades 从东方财富获取股东增减持和董监高持股变动
+func (s *ShareholderService) fetchInsiderTrades(code string) ([]InsiderTrade, error) {
+	params := url.Values{}
+	params.Set("reportName", "RPT_SHARE_HOLDER_INCREASE")
+	params.Set("columns", "ALL")
+	params.Set("sortColumns", "END_DATE")
+	params.Set("sortTypes", "-1")
+	params.Set("pageSize", "30")
+	params.Set("pageNumber", "1")
+	params.Set("filter", fmt.Sprintf(`(SECURITY_CODE="%s")`, code))
+	params.Set("source", "WEB")
+	params.Set("client", "WEB")
+
+	var holderChanges []holderChangeAPIItem
+	if _, err := fetchDatacenter(s.client, eastmoneyDatacenterURL+"?"+params.Encode(), &holderChanges); err != nil {
+		return nil, fmt.Errorf("获取股东增减持失败: %w", err)
+	}
+
+	params = url.Values{}
+	params.Set("reportName", "RPT_EXECUTIVE_HOLD_DETAILS")
+	params.Set("columns", "ALL")
+	params.Set("sortColumns", "CHANGE_DATE")
+	params.Set("sortTypes", "-1")
+	params.Set("pageSize", "30")
+	params.Set("pageNumber", "1")
+	params.Set("filter", fmt.Sprintf(`(SECURITY_CODE="%s")`, code))
+	params.Set("source", "WEB")
+	params.Set("client", "WEB")
+
+	var execChanges []executiveChangeAPIItem
+	if _, err := fetchDatacenter(s.client, eastmoneyDatacenterURL+"?"+params.Encode(), &execChanges); err != nil {
+		return nil, fmt.Errorf("获取董监高持股变动失败: %w", err)
+	}
+
+	return mergeInsiderTrades(holderChanges, execChanges), nil
+}
+
+// mergeInsiderTrades 合并大股东和董监高变动记录，按日期倒序
+func mergeInsiderTrades(holderChanges []holderChangeAPIItem, execChanges []executiveChangeAPIItem) []InsiderTrade {
+	trades := make([]InsiderTrade, 0, len(holderChanges)+len(execChanges))
+	for _, h := range holderChanges {
+		date := trimDate(h.EndDate)
+		if date == "" {
+			date = trimDate(h.NoticeDate)
+		}
+		trades = append(trades, InsiderTrade{
+			Category:  "大股东",
+			Person:    h.HolderName,
+			Direction: h.Direction,
+			Shares:    math.Abs(h.ChangeNum) * 10000,
+			AvgPrice:  h.TradeAveragePrice,
+			Ratio:     h.ChangeRate,
+			HoldAfter: h.HoldRatio,
+			Date:      date,
+		})
+	}
+	for _, e := range execChanges {
+		if e.ChangeShares == 0 {
+			continue
+		}
+		direction := "增持"
+		if e.ChangeShares < 0 {
+			direction = "减持"
+		}
+		trades = append(trades, InsiderTrade{
+			Category:  "董监高",
+			Person:    e.PersonName,
+			Position:  e.PositionName,
+			Direction: direction,
+			Shares:    math.Abs(e.ChangeShares),
+			AvgPrice:  e.AveragePrice,
+			Date:      trimDate(e.ChangeDate),
+		})
+	}
+
+	sort.SliceStable(trades, func(i, j int) bool {
+		return trades[i].Date > trades[j].Date
+	})
+	return trades
+}
+
+// GetUnlockSchedule 获取个股近期已解禁及待解禁的限售股（按代码缓存1天）
+func (s *ShareholderService) GetUnlockSchedule(code string) (*UnlockSchedule, error) {
+	code = stripMarketPrefix(code)
+
+	s.unlockCacheMu.RLock()
+	if cached, ok := s.unlockCache[code]; ok && time.Since(cached.timestamp) < s.unlockCacheTTL {
+		s.unlockCacheMu.RUnlock()
+		return cached.data, nil
+	}
+	s.unlockCacheMu.RUnlock()
+
+	schedule, err := s.fetchUnlockSchedule(code, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	s.unlockCacheMu.Lock()
+	s.unlockCache[code] = &unlockCache{data: schedule, timestamp: time.Now()}
+	s.unlockCacheMu.Unlock()
+
+	return schedule, nil
+}
+
+// fetchUnlockSchedule 从东方财富获取限售股解禁批次
+func (s *ShareholderService) fetchUnlockSchedule(code string, now time.Time) (*UnlockSchedule, error) {
+	since := now.AddDate(0, 0, -unlockLookbackDays).Format("2006-01-02")
+
+	params := url.Values{}
+	params.Set("reportName", "RPT_LIFT_STAGE")
+	params.Set("columns", "ALL")
+	params.Set("sortColumns", "FREE_DATE")
+	params.Set("sortTypes", "1")
+	params.Set("pageSize", "20")
+	params.Set("pageNumber", "1")
+	params.Set("filter", fmt.Sprintf(`(SECURITY_CODE="%s")(FREE_DATE>='%s')`, code, since))
+	params.Set("source", "WEB")
+	params.Set("client", "WEB")
+
+	var items []unlockAPIItem
+	if _, err := fetchDatacenter(s.client, eastmoneyDatacenterURL+"?"+params.Encode(), &items); err != nil {
+		return nil, fmt.Errorf("获取限售解禁失败: %w", err)
+	}
+	return buildUnlockSchedule(code, items, now), nil
+}
+
+// buildUnlockSchedule 转换解禁批次并评估抛压风险
+func buildUnlockSchedule(code string, items []unlockAPIItem, now time.Time) *UnlockSchedule {
+	schedule := &UnlockSchedule{Code: code, Events: []UnlockEvent{}}
+	today := now.Format("2006-01-02")
+	for _, item := range items {
+		if schedule.Name == "" {
+			schedule.Name = item.SecurityNameAbbr
+		}
+		date := trimDate(item.FreeDate)
+		schedule.Events = append(schedule.Events, UnlockEvent{
+			Date:       date,
+			ShareType:  item.FreeSharesType,
+			Shares:     item.AbleFreeShares * 10000,
+			MarketCap:  item.LiftMarketCap,
+			FloatRatio: item.FreeRatio * 100,
+			Upcoming:   date >= today,
+		})
+	}
+	sort.SliceStable(schedule.Events, func(i, j int) bool {
+		return schedule.Events[i].Date < schedule.Events[j].Date
+	})
+	schedule.RiskLevel, schedule.RiskReasons = assessUnlockRisk(schedule.Events, now)
+	return schedule
+}
+
+// assessUnlockRisk 按待解禁规模和距解禁日的时间评估抛压风险
+// 30日内解禁占流通股比例越高风险越大，90日内的大额解禁给予提示
+func assessUnlockRisk(events []UnlockEvent, now time.Time) (string, []string) {
+	level := 0
+	var reasons []string
+	raise := func(l int, reason string) {
+		if l > level {
+			level = l
+		}
+		reasons = append(reasons, reason)
+	}
+
+	today := now.Format("2006-01-02")
+	near := now.AddDate(0, 0, 30).Format("2006-01-02")
+	far := now.AddDate(0, 0, 90).Format("2006-01-02")
+	for _, e := range events {
+		if !e.Upcoming || e.Date < today {
+			continue
+		}
+		desc := fmt.Sprintf("%s 解禁%.2f万股(%s)，占流通股%.2f%%", e.Date, e.Shares/10000, e.ShareType, e.FloatRatio)
+		switch {
+		case e.Date <= near && e.FloatRatio >= 20:
+			raise(3, desc+"，30日内大额解禁")
+		case e.Date <= near && e.FloatRatio >= 5:
+			raise(2, desc+"，30日内解禁")
+		case e.Date <= near && e.FloatRatio >= 1:
+			raise(1, desc)
+		case e.Date <= far && e.FloatRatio >= 10:
+			raise(1, desc+"，90日内较大规模解禁")
+		}
+	}
+
+	levels := []string{"低", "中", "较高", "高"}
+	return levels[level], reasons
+}
+
+// stripMarketPrefix 去除 sh/sz/bj 市场前缀
+func stripMarketPrefix(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	for _, prefix := range []string{"sh", "sz", "bj"} {
+		if strings.HasPrefix(code, prefix) {
+			return code[len(prefix):]
+		}
+	}
+	return code
+}
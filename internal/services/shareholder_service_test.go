@@ -0,0 +1,184 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestAssessPledgeRisk(t *testing.T) {
+	details := []pledgeDetailAPIItem{
+		{HolderName: "控股集团", PfNum: 1e8, PfHoldRatio: 60, PfTsr: 20, NoticeDate: "2026-01-05 00:00:00", UnfreezeState: "未解押"},
+		{HolderName: "控股集团", PfNum: 5e7, PfHoldRatio: 30, PfTsr: 10, NoticeDate: "2026-02-01 00:00:00", UnfreezeState: "未解押"},
+		{HolderName: "控股集团", PfNum: 5e7, PfHoldRatio: 30, PfTsr: 10, UnfreezeState: "已解押"},
+		{HolderName: "自然人甲", PfNum: 1e6, PfHoldRatio: 10, PfTsr: 0.5, UnfreezeState: "未解押"},
+	}
+
+	holders := aggregatePledgeHolders(details)
+	if len(holders) != 2 {
+		t.Fatalf("股东数量错误: %d", len(holders))
+	}
+	top := holders[0]
+	if top.HolderName != "控股集团" || top.RecordCount != 2 || top.HoldRatio != 90 || top.LatestNotice != "2026-02-01" {
+		t.Errorf("汇总结果错误: %+v", top)
+	}
+
+	risk := &PledgeRisk{PledgeRatio: 35, Holders: holders}
+	level, reasons := assessPledgeRisk(risk)
+	if level != "高" || len(reasons) != 2 {
+		t.Errorf("风险等级错误: %s %v", level, reasons)
+	}
+
+	level, reasons = assessPledgeRisk(&PledgeRisk{PledgeRatio: 2})
+	if level != "低" || len(reasons) != 0 {
+		t.Errorf("低质押应为低风险: %s %v", level, reasons)
+	}
+}
+
+func TestMergeInsiderTrades(t *testing.T) {
+	holders := []holderChangeAPIItem{
+		{HolderName: "控股集团", Direction: "减持", ChangeNum: 120.5, ChangeRate: 0.8, EndDate: "2026-01-20 00:00:00"},
+	}
+	execs := []executiveChangeAPIItem{
+		{PersonName: "张三", PositionName: "董事长", ChangeShares: 5000, ChangeDate: "2026-02-03 00:00:00"},
+		{PersonName: "李四", PositionName: "监事", ChangeShares: -2000, ChangeDate: "2026-01-10 00:00:00"},
+		{PersonName: "王五", ChangeShares: 0, ChangeDate: "2026-02-05 00:00:00"},
+	}
+
+	trades := mergeInsiderTrades(holders, execs)
+	if len(trades) != 3 {
+		t.Fatalf("记录数错误: %d", len(trades))
+	}
+	if trades[0].Person != "张三" || trades[0].Direction != "增持" {
+		t.Errorf("应按日期倒序: %+v", trades[0])
+	}
+	if trades[1].Shares != 1205000 || trades[1].Category != "大股东" {
+		t.Errorf("大股东变动股数应换算为股: %+v", trades[1])
+	}
+	if trades[2].Direction != "减持" || trades[2].Shares != 2000 {
+		t.Errorf("董监高减持应取绝对值: %+v", trades[2])
+	}
+}
+
+func TestBuildUnlockSchedule(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 0, 0, 0, time.Local)
+	items := []unlockAPIItem{
+		{SecurityNameAbbr: "测试股份", FreeDate: "2026-05-20 00:00:00", FreeSharesType: "定向增发机构配售股份", AbleFreeShares: 800, FreeRatio: 0.12},
+		{SecurityNameAbbr: "测试股份", FreeDate: "2026-03-16 00:00:00", FreeSharesType: "首发原股东限售股份", AbleFreeShares: 12000, LiftMarketCap: 1.5e9, FreeRatio: 0.35},
+		{SecurityNameAbbr: "测试股份", FreeDate: "2026-02-10 00:00:00", FreeSharesType: "首发战略配售股份", AbleFreeShares: 300, FreeRatio: 0.02},
+	}
+
+	schedule := buildUnlockSchedule("688001", items, now)
+	if schedule.Name != "测试股份" || len(schedule.Events) != 3 {
+		t.Fatalf("解禁批次错误: %+v", schedule)
+	}
+	first := schedule.Events[0]
+	if first.Date != "2026-02-10" || first.Upcoming {
+		t.Errorf("应按日期升序且已解禁批次标记为非待解禁: %+v", first)
+	}
+	next := schedule.Events[1]
+	if !next.Upcoming || next.Shares != 1.2e8 || math.Abs(next.FloatRatio-35) > 1e-9 {
+		t.Errorf("解禁数量应换算为股、比例换算为百分比: %+v", next)
+	}
+	if schedule.RiskLevel != "高" || len(schedule.RiskReasons) != 2 {
+		t.Errorf("30日内大额解禁应为高风险: %s %v", schedule.RiskLevel, schedule.RiskReasons)
+	}
+
+	level, reasons := assessUnlockRisk(schedule.Events[:1], now)
+	if level != "低" || len(reasons) != 0 {
+		t.Errorf("仅有已解禁批次应为低风险: %s %v", level, reasons)
+	}
+}
+
+func TestAssessDividendQuality(t *testing.T) {
+	now := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	history := &DividendHistory{Code: "600036", Records: []DividendRecord{
+		{ReportDate: "2025-12-31", CashPerShare: 1.2, Progress: "董事会预案", EPS: 3.0},
+		{ReportDate: "2025-06-30", ExDate: "2026-01-15", CashPerShare: 1.0, Progress: "实施分配", EPS: 2.0},
+		{ReportDate: "2024-12-31", ExDate: "2025-07-11", CashPerShare: 2.0, Progress: "实施分配", EPS: 5.0},
+		{ReportDate: "2023-12-31", ExDate: "2024-07-10", CashPerShare: 1.9, Progress: "实施分配", EPS: 4.8},
+		{ReportDate: "2022-12-31", Progress: "不分配", EPS: 4.5},
+		{ReportDate: "2021-12-31", ExDate: "2022-07-08", CashPerShare: 1.5, Progress: "实施分配", EPS: 4.6},
+	}}
+
+	q := AssessDividendQuality(history, 40, now)
+	if math.Abs(q.TTMDividend-3.0) > 1e-9 || math.Abs(q.Yield-7.5) > 1e-9 {
+		t.Errorf("股息率计算错误: ttm=%v yield=%v", q.TTMDividend, q.Yield)
+	}
+	if q.ConsecutiveYears != 3 {
+		t.Errorf("连续分红年数 = %d, want 3", q.ConsecutiveYears)
+	}
+	// 2025: 2.2/3.0, 2024: 2.0/5.0, 2023: 1.9/4.8, 2022: 0/4.5, 2021: 1.5/4.6
+	wantPayout := (2.2/3.0 + 2.0/5.0 + 1.9/4.8 + 0 + 1.5/4.6) / 5 * 100
+	if q.PayoutYears != 5 || math.Abs(q.AvgPayout-wantPayout) > 1e-9 {
+		t.Errorf("平均分红率错误: years=%d payout=%v want %v", q.PayoutYears, q.AvgPayout, wantPayout)
+	}
+	if q.Score != 72 || q.Level != "良" {
+		t.Errorf("评级错误: score=%d level=%s reasons=%v", q.Score, q.Level, q.Reasons)
+	}
+
+	q = AssessDividendQuality(&DividendHistory{Code: "300001"}, 20, now)
+	if q.Level != "差" || q.ConsecutiveYears != 0 || q.Yield != 0 {
+		t.Errorf("无分红应评为差: %+v", q)
+	}
+}
+
+func TestLatestIssuePrice(t *testing.T) {
+	issues := []IssuePrice{
+		{Type: "增发", Price: 12.5, IssueDate: "2024-03-15"},
+		{Type: "配股", Price: 8.2, IssueDate: "2025-06-20"},
+		{Type: "增发", Price: 0, IssueDate: "2025-09-01"},
+		{Type: "增发", Price: 20, IssueDate: "2020-01-10"},
+	}
+
+	latest := latestIssuePrice(issues, "2023-10-16")
+	if latest == nil || latest.Type != "配股" || latest.Price != 8.2 {
+		t.Errorf("应选出最近一次有效发行: %+v", latest)
+	}
+	if latest := latestIssuePrice(issues[3:], "2023-10-16"); latest != nil {
+		t.Errorf("超出回溯期的发行应忽略: %+v", latest)
+	}
+
+	cases := []struct {
+		price float64
+		zone  string
+	}{
+		{9.5, IssueZoneBelow},
+		{10, IssueZoneNear},
+		{10.8, IssueZoneNear},
+		{12, IssueZoneAbove},
+	}
+	for _, c := range cases {
+		if _, zone := IssuePriceZone(c.price, 10); zone != c.zone {
+			t.Errorf("price=%.2f zone=%s, want %s", c.price, zone, c.zone)
+		}
+	}
+}
+
+func TestAssessHolderCountTrend(t *testing.T) {
+	periods := []HolderCountPeriod{
+		{EndDate: "2026-09-30", HolderNum: 45000, PriceChange: 5},
+		{EndDate: "2026-06-30", HolderNum: 48000, PriceChange: -2},
+		{EndDate: "2026-03-31", HolderNum: 49000, PriceChange: 1},
+		{EndDate: "2025-12-31", HolderNum: 50000},
+		{EndDate: "2025-09-30", HolderNum: 60000},
+	}
+
+	trend, holderChange, priceChange := assessHolderCountTrend(periods)
+	if trend != HolderTrendConcentrating || math.Abs(holderChange-(-10)) > 1e-9 {
+		t.Errorf("trend=%s holderChange=%.2f, want 筹码集中 -10", trend, holderChange)
+	}
+	if want := (1.05*0.98*1.01 - 1) * 100; math.Abs(priceChange-want) > 1e-9 {
+		t.Errorf("priceChange=%.4f, want %.4f", priceChange, want)
+	}
+
+	if trend, _, _ := assessHolderCountTrend(periods[:2]); trend != HolderTrendConcentrating {
+		t.Errorf("两期数据应按一期变化判断: %s", trend)
+	}
+	if trend, _, _ := assessHolderCountTrend([]HolderCountPeriod{{HolderNum: 45000}, {HolderNum: 44000}}); trend != HolderTrendFlat {
+		t.Errorf("小幅变化应为基本持平: %s", trend)
+	}
+	if trend, _, _ := assessHolderCountTrend(periods[:1]); trend != HolderTrendUnknown {
+		t.Errorf("单期数据应为数据不足: %s", trend)
+	}
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// 数据类型
+const (
+	SourceTypeQuote        = "quote"        // 实时行情
+	SourceTypeKLine        = "kline"        // K线
+	SourceTypeExtendedInfo = "extendedInfo" // 个股扩展信息（市值/换手率/PE/PB）
+	SourceTypeBreadth      = "breadth"      // 全市场涨跌家数
+)
+
+// 数据源名称
+const (
+	SourceSina      = "sina"
+	SourceTencent   = "tencent"
+	SourceEastmoney = "eastmoney"
+)
+
+// maxSourceRetries 单个数据源失败后的最大重试次数
+const maxSourceRetries = 3
+
+// errSourceEmpty 数据源请求成功但没有返回任何数据，同样切换到下一个数据源
+var errSourceEmpty = errors.New("数据源返回空数据")
+
+// SourceProvider 数据源，同一数据类型的各数据源实现相同的获取接口
+type SourceProvider interface {
+	Name() string
+}
+
+// QuoteProvider 实时行情数据源
+// 分批请求时部分批次失败仍返回已获取的行情，failed 为失败批次中的代码，由链中后续数据源补齐
+type QuoteProvider interface {
+	SourceProvider
+	FetchQuotes(codes []string) (stocks []models.Stock, failed []string, err error)
+}
+
+// KLineProvider K线数据源，period 取值同 MarketService.GetKLineData
+type KLineProvider interface {
+	SourceProvider
+	FetchKLines(code, period string, days int) ([]models.KLineData, error)
+}
+
+// ExtendedInfoProvider 个股扩展信息数据源
+type ExtendedInfoProvider interface {
+	SourceProvider
+	FetchExtendedInfo(code string) (*StockExtendedInfo, error)
+}
+
+// BreadthProvider 全市场涨跌统计数据源
+type BreadthProvider interface {
+	SourceProvider
+	FetchBreadth() (*MarketBreadth, error)
+}
+
+// SourceChain 同一数据类型的数据源链：按顺序尝试，请求失败时重试后切换到下一个，返回空数据时直接切换
+type SourceChain[P SourceProvider] struct {
+	dataType  string
+	mu        sync.RWMutex
+	available []P // 全部可用数据源，注册顺序即默认顺序
+	order     []P
+	retries   int
+}
+
+// newSourceChain 创建数据源链，providers 的顺序为默认顺序
+func newSourceChain[P SourceProvider](dataType string, providers ...P) *SourceChain[P] {
+	return &SourceChain[P]{
+		dataType:  dataType,
+		available: providers,
+		order:     append([]P(nil), providers...),
+	}
+}
+
+// SetOrder 按名称设置数据源顺序及单个数据源的重试次数
+// 未知名称忽略，重复名称只保留第一次；names 为空或全部无效时恢复默认顺序
+func (c *SourceChain[P]) SetOrder(names []string, retries int) {
+	var order []P
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if seen[name] {
+			continue
+		}
+		for _, p := range c.available {
+			if p.Name() == name {
+				order = append(order, p)
+				seen[name] = true
+				break
+			}
+		}
+	}
+	if len(order) == 0 {
+		order = append([]P(nil), c.available...)
+	}
+
+	c.mu.Lock()
+	c.order = order
+	c.retries = max(0, min(retries, maxSourceRetries))
+	c.mu.Unlock()
+}
+
+// Names 当前生效的数据源顺序
+func (c *SourceChain[P]) Names() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	names := make([]string, len(c.order))
+	for i, p := range c.order {
+		names[i] = p.Name()
+	}
+	return names
+}
+
+// after 返回当前顺序中位于 name 之后的数据源组成的链（重试次数相同），用于补齐前一数据源缺失的数据
+func (c *SourceChain[P]) after(name string) *SourceChain[P] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	next := &SourceChain[P]{dataType: c.dataType, available: c.available, retries: c.retries}
+	for i, p := range c.order {
+		if p.Name() == name {
+			next.order = append([]P(nil), c.order[i+1:]...)
+			break
+		}
+	}
+	return next
+}
+
+// AvailableSources 各数据类型可选的数据源（按默认顺序），供配置参考
+func AvailableSources() map[string][]string {
+	return map[string][]string{
+		SourceTypeQuote:        {SourceSina, SourceTencent},
+		SourceTypeKLine:        {SourceSina, SourceEastmoney},
+		SourceTypeExtendedInfo: {SourceEastmoney, SourceTencent},
+		SourceTypeBreadth:      {SourceSina, SourceEastmoney},
+	}
+}
+
+// runSourceChain 依次调用数据源链中的数据源，返回第一个成功的结果
+// 空数据不重试直接切换；全部数据源均为空数据时返回零值且不报错（如代码不存在），
+// 否则返回汇总了各数据源错误的 error
+func runSourceChain[P SourceProvider, T any](c *SourceChain[P], call func(P) (T, error)) (T, error) {
+	c.mu.RLock()
+	order, retries := c.order, c.retries
+	c.mu.RUnlock()
+
+	var zero T
+	var errs []string
+	allEmpty := true
+	for i, p := range order {
+		for attempt := 0; attempt <= retries; attempt++ {
+			result, err := call(p)
+			if err == nil {
+				if i > 0 {
+					log.Info("%s 数据源切换: 使用 %s", c.dataType, p.Name())
+				}
+				return result, nil
+			}
+			if errors.Is(err, errSourceEmpty) {
+				errs = append(errs, fmt.Sprintf("%s: %v", p.Name(), err))
+				break
+			}
+			allEmpty = false
+			log.Warn("%s 数据源 %s 第%d次请求失败: %v", c.dataType, p.Name(), attempt+1, err)
+			if attempt == retries {
+				errs = append(errs, fmt.Sprintf("%s: %v", p.Name(), err))
+			}
+		}
+	}
+	if allEmpty {
+		return zero, nil
+	}
+	return zero, fmt.Errorf("%s 全部数据源失败: %s", c.dataType, strings.Join(errs, "; "))
+}
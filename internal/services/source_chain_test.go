@@ -0,0 +1,258 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/run-bigpig/jcp/internal/models"
+)
+
+// stubProvider 测试用数据源，按 results 顺序依次返回结果
+type stubProvider struct {
+	name    string
+	results []error
+	calls   int
+}
+
+func (p *stubProvider) Name() string { return p.name }
+
+func (p *stubProvider) fetch() (string, error) {
+	i := min(p.calls, len(p.results)-1)
+	p.calls++
+	if err := p.results[i]; err != nil {
+		return "", err
+	}
+	return p.name, nil
+}
+
+func runStubChain(c *SourceChain[*stubProvider]) (string, error) {
+	return runSourceChain(c, func(p *stubProvider) (string, error) { return p.fetch() })
+}
+
+// TestRunSourceChain 测试数据源链的重试、切换及空数据处理
+func TestRunSourceChain(t *testing.T) {
+	netErr := errors.New("timeout")
+
+	t.Run("重试后成功", func(t *testing.T) {
+		a := &stubProvider{name: "a", results: []error{netErr, nil}}
+		b := &stubProvider{name: "b", results: []error{nil}}
+		c := newSourceChain("test", a, b)
+		c.SetOrder(nil, 1)
+		got, err := runStubChain(c)
+		if err != nil || got != "a" || a.calls != 2 || b.calls != 0 {
+			t.Errorf("应在重试后使用 a: got=%q err=%v calls=%d/%d", got, err, a.calls, b.calls)
+		}
+	})
+
+	t.Run("失败后切换", func(t *testing.T) {
+		a := &stubProvider{name: "a", results: []error{netErr}}
+		b := &stubProvider{name: "b", results: []error{nil}}
+		c := newSourceChain("test", a, b)
+		c.SetOrder(nil, 2)
+		got, err := runStubChain(c)
+		if err != nil || got != "b" || a.calls != 3 {
+			t.Errorf("a 重试 2 次后应切换到 b: got=%q err=%v calls=%d", got, err, a.calls)
+		}
+	})
+
+	t.Run("空数据不重试", func(t *testing.T) {
+		a := &stubProvider{name: "a", results: []error{errSourceEmpty}}
+		b := &stubProvider{name: "b", results: []error{nil}}
+		c := newSourceChain("test", a, b)
+		c.SetOrder(nil, 3)
+		got, err := runStubChain(c)
+		if err != nil || got != "b" || a.calls != 1 {
+			t.Errorf("空数据应直接切换: got=%q err=%v calls=%d", got, err, a.calls)
+		}
+	})
+
+	t.Run("全部为空", func(t *testing.T) {
+		a := &stubProvider{name: "a", results: []error{errSourceEmpty}}
+		b := &stubProvider{name: "b", results: []error{errSourceEmpty}}
+		got, err := runStubChain(newSourceChain("test", a, b))
+		if err != nil || got != "" {
+			t.Errorf("全部为空应返回零值且不报错: got=%q err=%v", got, err)
+		}
+	})
+
+	t.Run("全部失败", func(t *testing.T) {
+		a := &stubProvider{name: "a", results: []error{netErr}}
+		b := &stubProvider{name: "b", results: []error{errSourceEmpty}}
+		_, err := runStubChain(newSourceChain("test", a, b))
+		if err == nil || !strings.Contains(err.Error(), "a: timeout") || !strings.Contains(err.Error(), "b: ") {
+			t.Errorf("应汇总各数据源错误: %v", err)
+		}
+	})
+}
+
+// TestSourceChainSetOrder 测试数据源顺序配置
+func TestSourceChainSetOrder(t *testing.T) {
+	c := newSourceChain("test", &stubProvider{name: "sina"}, &stubProvider{name: "tencent"})
+
+	c.SetOrder([]string{" Tencent ", "unknown", "tencent", "sina"}, 10)
+	if got := strings.Join(c.Names(), ","); got != "tencent,sina" {
+		t.Errorf("顺序错误: %s", got)
+	}
+	if c.retries != maxSourceRetries {
+		t.Errorf("重试次数应限制为 %d: %d", maxSourceRetries, c.retries)
+	}
+
+	c.SetOrder([]string{"tencent"}, -1)
+	if got := strings.Join(c.Names(), ","); got != "tencent" || c.retries != 0 {
+		t.Errorf("应只保留配置的数据源: %s retries=%d", got, c.retries)
+	}
+
+	c.SetOrder([]string{"unknown"}, 0)
+	if got := strings.Join(c.Names(), ","); got != "sina,tencent" {
+		t.Errorf("全部无效时应恢复默认顺序: %s", got)
+	}
+}
+
+// TestParseTencentStock 测试腾讯行情解析
+func TestParseTencentStock(t *testing.T) {
+	parts := make([]string, tencentQuoteMinFields)
+	parts[tqName] = "贵州茅台"
+	parts[tqPrice] = "1650.00"
+	parts[tqPreClose] = "1500.00"
+	parts[tqOpen] = "1510.00"
+	parts[tqVolume] = "12345"
+	parts[tqDateTime] = "20240105150003"
+	parts[tqHigh] = "1660.00"
+	parts[tqLow] = "1505.00"
+	parts[tqAmount] = "200000"
+	data := `v_sh600519="1~` + strings.Join(parts[1:], "~") + `";` + "\n" + `v_pv_none_match="1";`
+
+	fields := parseTencentQuoteFields(data)
+	if len(fields) != 1 {
+		t.Fatalf("应只解析出完整条目: %d", len(fields))
+	}
+	got := fields["sh600519"]
+	stock := parseTencentStock("sh600519", got)
+	if stock.Name != "贵州茅台" || stock.Price != 1650 || stock.ChangePercent != 10 || stock.Volume != 1234500 || stock.Amount != 2e9 {
+		t.Errorf("行情解析错误: %+v", stock)
+	}
+	if d := tencentQuoteDate(got); d != "2024-01-05" {
+		t.Errorf("日期解析错误: %s", d)
+	}
+}
+
+// TestParseEastmoneyKLines 测试东方财富K线解析
+func TestParseEastmoneyKLines(t *testing.T) {
+	body := []byte(`{"data":{"klines":[
+		"2024-01-05 09:31,10.00,10.10,10.20,9.90,120,121200.00",
+		"2024-01-05 09:32,10.10,10.30,10.30,10.05,80,82000.00",
+		"bad"
+	]}}`)
+	klines, err := parseEastmoneyKLines(body)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if len(klines) != 2 {
+		t.Fatalf("K线数错误: %d", len(klines))
+	}
+	k := klines[1]
+	if k.Time != "2024-01-05 09:32:00" || k.Open != 10.1 || k.Close != 10.3 || k.High != 10.3 || k.Low != 10.05 || k.Volume != 8000 {
+		t.Errorf("K线解析错误: %+v", k)
+	}
+
+	if klines, err := parseEastmoneyKLines([]byte(`{"data":null}`)); err != nil || len(klines) != 0 {
+		t.Errorf("无数据应返回空: %v %v", klines, err)
+	}
+}
+
+// TestFillKLineMAs 测试均线补算
+func TestFillKLineMAs(t *testing.T) {
+	closes := make([]float64, 20)
+	for i := range closes {
+		closes[i] = float64(i + 1)
+	}
+	klines := make([]models.KLineData, len(closes))
+	for i, c := range closes {
+		klines[i].Close = c
+	}
+	fillKLineMAs(klines)
+
+	if klines[3].MA5 != 0 || klines[4].MA5 != 3 || klines[19].MA5 != 18 {
+		t.Errorf("MA5 错误: %v %v %v", klines[3].MA5, klines[4].MA5, klines[19].MA5)
+	}
+	if klines[8].MA10 != 0 || klines[9].MA10 != 5.5 {
+		t.Errorf("MA10 错误: %v %v", klines[8].MA10, klines[9].MA10)
+	}
+	if klines[18].MA20 != 0 || klines[19].MA20 != 10.5 {
+		t.Errorf("MA20 错误: %v %v", klines[18].MA20, klines[19].MA20)
+	}
+}
+
+// TestParseEastmoneyBreadth 测试东方财富涨跌家数汇总
+func TestParseEastmoneyBreadth(t *testing.T) {
+	body := []byte(`{"data":{"diff":[{"f104":1200,"f105":900,"f106":100},{"f104":1500,"f105":1000,"f106":"-"}]}}`)
+	data, err := parseEastmoneyBreadth(body)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if data.AdvanceCount != 2700 || data.DeclineCount != 1900 || data.FlatCount != 100 || data.TotalCount != 4700 {
+		t.Errorf("涨跌家数汇总错误: %+v", data)
+	}
+}
+
+// stubQuoteProvider 测试用行情数据源，返回 stocks 中存在的代码，failed 中的代码视为请求失败
+type stubQuoteProvider struct {
+	name      string
+	stocks    map[string]bool
+	failed    map[string]bool
+	requested [][]string
+}
+
+func (p *stubQuoteProvider) Name() string { return p.name }
+
+func (p *stubQuoteProvider) FetchQuotes(codes []string) ([]models.Stock, []string, error) {
+	p.requested = append(p.requested, codes)
+	var stocks []models.Stock
+	var failed []string
+	for _, code := range codes {
+		switch {
+		case p.failed[code]:
+			failed = append(failed, code)
+		case p.stocks[code]:
+			stocks = append(stocks, models.Stock{Symbol: code, Name: p.name})
+		}
+	}
+	if len(stocks) == 0 && len(failed) == 0 {
+		return nil, nil, errSourceEmpty
+	}
+	return stocks, failed, nil
+}
+
+// TestFetchQuotesFillFailed 测试部分批次失败时由后续数据源补齐，结果保持请求顺序
+func TestFetchQuotesFillFailed(t *testing.T) {
+	a := &stubQuoteProvider{name: "a",
+		stocks: map[string]bool{"sh1": true, "sh3": true},
+		failed: map[string]bool{"sh2": true, "sh4": true}}
+	b := &stubQuoteProvider{name: "b", stocks: map[string]bool{"sh2": true, "sh4": true, "sh5": true}}
+	c := newSourceChain[QuoteProvider]("test", a, b)
+
+	stocks, err := fetchQuotes(c, []string{"sh1", "sh2", "sh3", "sh4", "sh5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, s := range stocks {
+		got = append(got, s.Symbol+":"+s.Name)
+	}
+	if strings.Join(got, ",") != "sh1:a,sh2:b,sh3:a,sh4:b" {
+		t.Errorf("补齐结果错误: %v", got)
+	}
+	// 仅失败批次的代码交给后续数据源，a 未返回的 sh5 不补齐
+	if len(b.requested) != 1 || strings.Join(b.requested[0], ",") != "sh2,sh4" {
+		t.Errorf("应只向 b 请求失败的代码: %v", b.requested)
+	}
+
+	// 最后一个数据源无法补齐时返回已获取的部分行情
+	c.SetOrder([]string{"b", "a"}, 0)
+	b.failed = map[string]bool{"sh6": true}
+	stocks, err = fetchQuotes(c, []string{"sh5", "sh6"})
+	if err != nil || len(stocks) != 1 || stocks[0].Symbol != "sh5" {
+		t.Errorf("补齐失败应返回部分行情: %v %v", stocks, err)
+	}
+}
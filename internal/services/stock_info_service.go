@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -14,15 +15,21 @@ import (
 
 const (
 	// 东方财富 Push2 API：单股查询市值/换手率/PE
-	eastmoneyStockURL = "https://push2.eastmoney.com/api/qt/stock/get?secid=%s&fields=f57,f58,f116,f117,f162,f168"
+	eastmoneyStockURL = "https://push2.eastmoney.com/api/qt/stock/get?secid=%s&fields=f57,f58,f116,f117,f162,f167,f168"
+	// 东方财富 Push2 API：批量查询市值/换手率/PE/PB（fltt=2 返回真实小数）
+	eastmoneyBatchStockURL = "https://push2.eastmoney.com/api/qt/ulist.np/get?fltt=2&invt=2&fields=f8,f9,f12,f13,f14,f20,f21,f23&secids=%s"
 )
 
+// batchQuerySize 批量查询单次最大股票数
+const batchQuerySize = 80
+
 // StockExtendedInfo 个股扩展信息
 type StockExtendedInfo struct {
 	FloatMarketCap float64 `json:"floatMarketCap"` // 流通市值（元）
 	TotalMarketCap float64 `json:"totalMarketCap"` // 总市值（元）
 	TurnoverRate   float64 `json:"turnoverRate"`   // 换手率(%)
 	PE             float64 `json:"pe"`             // 市盈率
+	PB             float64 `json:"pb"`             // 市净率
 }
 
 // stockInfoCache 缓存条目
@@ -35,17 +42,35 @@ type stockInfoCache struct {
 type StockInfoService struct {
 	client   *http.Client
 	cache    map[string]*stockInfoCache
-	cacheMu  sync.RWMutex
-	cacheTTL time.Duration
+	etfCache map[string]*etfQuoteCache
+	// 上市日期缓存
+	listingDates map[string]time.Time
+	// 沪市ETF逐日份额缓存：日期 -> 代码 -> 份额(万份)，历史份额不再变化
+	etfShares map[string]map[string]float64
+	cacheMu   sync.RWMutex
+	cacheTTL  time.Duration
+
+	// 单股扩展信息的数据源链
+	chain *SourceChain[ExtendedInfoProvider]
 }
 
 // NewStockInfoService 创建个股扩展信息服务
 func NewStockInfoService() *StockInfoService {
-	return &StockInfoService{
-		client:   proxy.GetManager().GetClientWithTimeout(10 * time.Second),
-		cache:    make(map[string]*stockInfoCache),
-		cacheTTL: 30 * time.Second,
+	s := &StockInfoService{
+		client:       proxy.GetManager().GetClientWithTimeout(10 * time.Second),
+		cache:        make(map[string]*stockInfoCache),
+		etfCache:     make(map[string]*etfQuoteCache),
+		listingDates: make(map[string]time.Time),
+		etfShares:    make(map[string]map[string]float64),
+		cacheTTL:     30 * time.Second,
 	}
+	s.chain = newSourceChain[ExtendedInfoProvider](SourceTypeExtendedInfo, eastmoneyExtendedInfoProvider{s}, tencentExtendedInfoProvider{s})
+	return s
+}
+
+// SetSourceChain 设置单股扩展信息的数据源顺序及单个数据源重试次数，名称为空时使用默认顺序
+func (s *StockInfoService) SetSourceChain(names []string, retries int) {
+	s.chain.SetOrder(names, retries)
 }
 
 // IsETF 判断是否为场内ETF
@@ -58,6 +83,15 @@ func IsETF(code string) bool {
 		strings.HasPrefix(code, "bj88")
 }
 
+// IsIndex 判断是否为指数代码（新浪简化指数 s_ 前缀或沪深北指数代码段）
+// 注意 sz000001 为平安银行，上证指数段只匹配 sh000
+func IsIndex(code string) bool {
+	code = strings.TrimPrefix(strings.ToLower(code), "s_")
+	return strings.HasPrefix(code, "sh000") ||
+		strings.HasPrefix(code, "sz399") ||
+		strings.HasPrefix(code, "bj899")
+}
+
 // GetExtendedInfo 获取个股扩展信息（带缓存）
 func (s *StockInfoService) GetExtendedInfo(code string) (*StockExtendedInfo, error) {
 	// 检查缓存
@@ -70,11 +104,16 @@ func (s *StockInfoService) GetExtendedInfo(code string) (*StockExtendedInfo, err
 	}
 	s.cacheMu.RUnlock()
 
-	// 从 API 获取
-	info, err := s.fetchExtendedInfo(code)
+	// 按数据源链从 API 获取，全部数据源均无数据（如指数）时返回零值
+	info, err := runSourceChain(s.chain, func(p ExtendedInfoProvider) (*StockExtendedInfo, error) {
+		return p.FetchExtendedInfo(code)
+	})
 	if err != nil {
 		return nil, err
 	}
+	if info == nil {
+		info = &StockExtendedInfo{}
+	}
 
 	// 更新缓存
 	s.cacheMu.Lock()
@@ -133,6 +172,7 @@ func (s *StockInfoService) parseExtendedInfo(body []byte) (*StockExtendedInfo, e
 			F116 float64 `json:"f116"` // 总市值（元）
 			F117 float64 `json:"f117"` // 流通市值（元）
 			F162 float64 `json:"f162"` // 市盈率 * 100
+			F167 float64 `json:"f167"` // 市净率 * 100
 			F168 float64 `json:"f168"` // 换手率 * 100
 		} `json:"data"`
 	}
@@ -146,9 +186,194 @@ func (s *StockInfoService) parseExtendedInfo(body []byte) (*StockExtendedInfo, e
 		TotalMarketCap: result.Data.F116,
 		TurnoverRate:   result.Data.F168 / 100,
 		PE:             result.Data.F162 / 100,
+		PB:             result.Data.F167 / 100,
 	}, nil
 }
 
+// eastmoneyExtendedInfoProvider 东方财富单股扩展信息
+type eastmoneyExtendedInfoProvider struct{ s *StockInfoService }
+
+func (p eastmoneyExtendedInfoProvider) Name() string { return SourceEastmoney }
+
+// FetchExtendedInfo 获取东方财富扩展信息，市值均为 0 时视为无数据
+func (p eastmoneyExtendedInfoProvider) FetchExtendedInfo(code string) (*StockExtendedInfo, error) {
+	info, err := p.s.fetchExtendedInfo(code)
+	if err != nil {
+		return nil, err
+	}
+	if info.TotalMarketCap == 0 && info.FloatMarketCap == 0 {
+		return nil, errSourceEmpty
+	}
+	return info, nil
+}
+
+// tencentExtendedInfoProvider 腾讯行情中的市值/换手率/PE/PB
+type tencentExtendedInfoProvider struct{ s *StockInfoService }
+
+func (p tencentExtendedInfoProvider) Name() string { return SourceTencent }
+
+// FetchExtendedInfo 获取腾讯行情并换算市值单位（亿元 -> 元）
+func (p tencentExtendedInfoProvider) FetchExtendedInfo(code string) (*StockExtendedInfo, error) {
+	fields, _, err := fetchTencentQuoteFields(p.s.client, []string{code})
+	if err != nil {
+		return nil, err
+	}
+	parts, ok := fields[code]
+	if !ok {
+		return nil, errSourceEmpty
+	}
+	info := parseTencentExtendedInfo(parts)
+	if info.TotalMarketCap == 0 && info.FloatMarketCap == 0 {
+		return nil, errSourceEmpty
+	}
+	return info, nil
+}
+
+// parseTencentExtendedInfo 解析腾讯行情中的扩展信息字段
+func parseTencentExtendedInfo(parts []string) *StockExtendedInfo {
+	return &StockExtendedInfo{
+		FloatMarketCap: tencentFloat(parts, tqFloatCap) * 1e8,
+		TotalMarketCap: tencentFloat(parts, tqTotalCap) * 1e8,
+		TurnoverRate:   tencentFloat(parts, tqTurnover),
+		PE:             tencentFloat(parts, tqPE),
+		PB:             tencentFloat(parts, tqPB),
+	}
+}
+
+// GetBatchExtendedInfo 批量获取个股扩展信息（带缓存）
+// codes: 带市场前缀的代码列表，如 sh600519；返回以代码为键的映射
+func (s *StockInfoService) GetBatchExtendedInfo(codes []string) (map[string]*StockExtendedInfo, error) {
+	result := make(map[string]*StockExtendedInfo, len(codes))
+
+	// 先从缓存取
+	var missing []string
+	s.cacheMu.RLock()
+	for _, code := range codes {
+		if cached, ok := s.cache[code]; ok && time.Since(cached.timestamp) < s.cacheTTL {
+			result[code] = cached.data
+			continue
+		}
+		missing = append(missing, code)
+	}
+	s.cacheMu.RUnlock()
+
+	// 分批请求未命中的代码，单批失败只丢弃该批，全部批次失败时才返回错误
+	batches := chunkCodes(missing, batchQuerySize)
+	var lastErr error
+	failed := 0
+	for i, batch := range batches {
+		infos, err := s.fetchBatchExtendedInfo(batch)
+		if err != nil {
+			log.Warn("批量扩展信息第%d/%d批请求失败: %v", i+1, len(batches), err)
+			lastErr = err
+			failed++
+			continue
+		}
+
+		s.cacheMu.Lock()
+		for code, info := range infos {
+			result[code] = info
+			s.cache[code] = &stockInfoCache{data: info, timestamp: time.Now()}
+		}
+		s.cacheMu.Unlock()
+	}
+	if len(batches) > 0 && failed == len(batches) {
+		return nil, lastErr
+	}
+
+	return result, nil
+}
+
+// fetchBatchExtendedInfo 从东方财富批量接口获取扩展信息
+func (s *StockInfoService) fetchBatchExtendedInfo(codes []string) (map[string]*StockExtendedInfo, error) {
+	secids := make([]string, 0, len(codes))
+	for _, code := range codes {
+		secids = append(secids, toSecID(code))
+	}
+	url := fmt.Sprintf(eastmoneyBatchStockURL, strings.Join(secids, ","))
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.parseBatchExtendedInfo(body)
+}
+
+// parseBatchExtendedInfo 解析批量接口返回数据
+func (s *StockInfoService) parseBatchExtendedInfo(body []byte) (map[string]*StockExtendedInfo, error) {
+	var result struct {
+		Data *struct {
+			Diff []struct {
+				F8  any    `json:"f8"`  // 换手率(%)
+				F9  any    `json:"f9"`  // 市盈率(动)
+				F12 string `json:"f12"` // 代码
+				F13 int    `json:"f13"` // 市场: 1沪 0深/北
+				F20 any    `json:"f20"` // 总市值（元）
+				F21 any    `json:"f21"` // 流通市值（元）
+				F23 any    `json:"f23"` // 市净率
+			} `json:"diff"`
+		} `json:"data"`
+	}
+
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("parse batch stock info error: %w, body: %s", err, truncateBytes(body, 200))
+	}
+	if result.Data == nil {
+		return nil, fmt.Errorf("batch stock info empty")
+	}
+
+	infos := make(map[string]*StockExtendedInfo, len(result.Data.Diff))
+	for _, d := range result.Data.Diff {
+		infos[fromSecID(d.F13, d.F12)] = &StockExtendedInfo{
+			FloatMarketCap: anyToFloat(d.F21),
+			TotalMarketCap: anyToFloat(d.F20),
+			TurnoverRate:   anyToFloat(d.F8),
+			PE:             anyToFloat(d.F9),
+			PB:             anyToFloat(d.F23),
+		}
+	}
+	return infos, nil
+}
+
+// fromSecID 将东方财富市场标识和代码还原为带前缀的代码
+func fromSecID(market int, code string) string {
+	if market == 1 {
+		return "sh" + code
+	}
+	if strings.HasPrefix(code, "4") || strings.HasPrefix(code, "8") || strings.HasPrefix(code, "92") {
+		return "bj" + code
+	}
+	return "sz" + code
+}
+
+// anyToFloat 将接口返回的数值字段转为 float64，"-" 等无效值返回 0
+func anyToFloat(v any) float64 {
+	switch val := v.(type) {
+	case float64:
+		return val
+	case string:
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return 0
+		}
+		return f
+	default:
+		return 0
+	}
+}
+
 // truncateBytes 截断字节切片用于日志
 func truncateBytes(b []byte, maxLen int) string {
 	if len(b) <= maxLen {
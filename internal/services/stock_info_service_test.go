@@ -0,0 +1,73 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestIsIndex 测试指数代码识别
+func TestIsIndex(t *testing.T) {
+	cases := map[string]bool{
+		"sh000001":   true,
+		"s_sh000001": true,
+		"sz399006":   true,
+		"bj899050":   true,
+		"sz000001":   false, // 平安银行
+		"sh600519":   false,
+		"sh510300":   false,
+	}
+	for code, want := range cases {
+		if got := IsIndex(code); got != want {
+			t.Errorf("IsIndex(%s) = %v, want %v", code, got, want)
+		}
+	}
+}
+
+// roundTripFunc 测试用 HTTP Transport
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// TestGetBatchExtendedInfoPartialFailure 测试单批失败时继续请求后续批次，全部失败才返回错误
+func TestGetBatchExtendedInfoPartialFailure(t *testing.T) {
+	codes := make([]string, batchQuerySize+1)
+	for i := range codes {
+		codes[i] = fmt.Sprintf("sh6%05d", i)
+	}
+
+	newService := func(failFirst, failSecond bool) *StockInfoService {
+		s := NewStockInfoService()
+		calls := 0
+		s.client = &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			calls++
+			if (calls == 1 && failFirst) || (calls == 2 && failSecond) {
+				return nil, errors.New("connection reset")
+			}
+			// 按请求的 secids 原样返回每只股票的市值
+			var diff []string
+			for _, secid := range strings.Split(req.URL.Query().Get("secids"), ",") {
+				code := strings.TrimPrefix(secid, "1.")
+				diff = append(diff, fmt.Sprintf(`{"f12":"%s","f13":1,"f20":1e10,"f21":5e9}`, code))
+			}
+			body := `{"data":{"diff":[` + strings.Join(diff, ",") + `]}}`
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body)), Header: make(http.Header)}, nil
+		})}
+		return s
+	}
+
+	infos, err := newService(true, false).GetBatchExtendedInfo(codes)
+	if err != nil {
+		t.Fatalf("仅首批失败不应返回错误: %v", err)
+	}
+	if len(infos) != 1 || infos[codes[batchQuerySize]] == nil {
+		t.Errorf("应返回第二批结果: %d", len(infos))
+	}
+
+	if _, err := newService(true, true).GetBatchExtendedInfo(codes); err == nil {
+		t.Error("全部批次失败应返回错误")
+	}
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// telegraphDedup 带时间窗口的快讯推送去重集合
+// 记录窗口期内已推送快讯的指纹，过期条目在每次检查时清理，内存占用受窗口长度约束
+type telegraphDedup struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// newTelegraphDedup 创建快讯去重集合
+func newTelegraphDedup() *telegraphDedup {
+	return &telegraphDedup{seen: make(map[string]time.Time)}
+}
+
+// telegraphFingerprint 计算快讯指纹（内容 + 发布时间）
+func telegraphFingerprint(t Telegraph) string {
+	sum := sha1.Sum([]byte(t.Time + "\x00" + t.Content))
+	return hex.EncodeToString(sum[:])
+}
+
+// markNew 若快讯在窗口期内未推送过则记录并返回 true，否则返回 false
+func (d *telegraphDedup) markNew(t Telegraph, window time.Duration, now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for key, at := range d.seen {
+		if now.Sub(at) > window {
+			delete(d.seen, key)
+		}
+	}
+
+	key := telegraphFingerprint(t)
+	if _, ok := d.seen[key]; ok {
+		return false
+	}
+	d.seen[key] = now
+	return true
+}
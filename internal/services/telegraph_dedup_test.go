@@ -0,0 +1,29 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+// TestTelegraphDedup 测试快讯去重窗口
+func TestTelegraphDedup(t *testing.T) {
+	d := newTelegraphDedup()
+	now := time.Now()
+	a := Telegraph{Time: "10:00", Content: "央行开展逆回购操作"}
+	b := Telegraph{Time: "10:01", Content: "沪指午后拉升"}
+
+	if !d.markNew(a, time.Hour, now) || !d.markNew(b, time.Hour, now) {
+		t.Fatal("首次出现的快讯应推送")
+	}
+	// 滚出后重新出现在首位，窗口期内不再推送
+	if d.markNew(a, time.Hour, now.Add(30*time.Minute)) {
+		t.Error("窗口期内重复快讯不应推送")
+	}
+	// 超出窗口后被清理，可再次推送
+	if !d.markNew(a, time.Hour, now.Add(2*time.Hour)) {
+		t.Error("超出窗口的快讯应重新推送")
+	}
+	if len(d.seen) != 1 {
+		t.Errorf("过期指纹未清理: %d", len(d.seen))
+	}
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/transform"
+)
+
+const (
+	// 腾讯行情接口，多个代码以逗号分隔，返回 GBK 编码的 v_sh600519="1~名称~代码~..." 文本
+	tencentQuoteURL = "http://qt.gtimg.cn/q=%s"
+	// tencentQuoteBatchSize 腾讯行情单次请求的最大代码数
+	tencentQuoteBatchSize = 50
+	// tencentQuoteMinFields 完整行情至少包含的字段数（需覆盖市值、市净率）
+	tencentQuoteMinFields = 47
+)
+
+// 腾讯行情字段索引（~ 分隔）
+const (
+	tqName     = 1
+	tqPrice    = 3
+	tqPreClose = 4
+	tqOpen     = 5
+	tqVolume   = 6 // 成交量(手)
+	tqDateTime = 30
+	tqHigh     = 33
+	tqLow      = 34
+	tqAmount   = 37 // 成交额(万元)
+	tqTurnover = 38 // 换手率(%)
+	tqPE       = 39
+	tqFloatCap = 44 // 流通市值(亿元)
+	tqTotalCap = 45 // 总市值(亿元)
+	tqPB       = 46
+)
+
+var tencentQuoteRe = regexp.MustCompile(`v_(\w+)="([^"]*)"`)
+
+// fetchTencentQuoteFields 分批请求腾讯行情，返回 代码 -> 字段列表
+// 单批失败时跳过该批，failed 为失败批次中的代码；全部批次失败时返回错误
+func fetchTencentQuoteFields(client *http.Client, codes []string) (map[string][]string, []string, error) {
+	result := make(map[string][]string, len(codes))
+	batches := chunkCodes(codes, tencentQuoteBatchSize)
+	var failed []string
+	var lastErr error
+	for i, batch := range batches {
+		data, err := fetchTencentQuoteBatch(client, batch)
+		if err != nil {
+			log.Warn("腾讯行情第%d/%d批请求失败: %v", i+1, len(batches), err)
+			failed = append(failed, batch...)
+			lastErr = err
+			continue
+		}
+		for code, parts := range parseTencentQuoteFields(data) {
+			result[code] = parts
+		}
+	}
+	if len(batches) > 0 && len(failed) == len(codes) {
+		return nil, nil, lastErr
+	}
+	return result, failed, nil
+}
+
+// fetchTencentQuoteBatch 单次请求腾讯行情（GBK 解码）
+func fetchTencentQuoteBatch(client *http.Client, codes []string) (string, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf(tencentQuoteURL, strings.Join(codes, ",")), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Referer", "https://gu.qq.com/")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(transform.NewReader(resp.Body, simplifiedchinese.GBK.NewDecoder()))
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+// parseTencentQuoteFields 解析腾讯行情文本，字段不完整（如代码不存在返回 v_pv_none_match）的条目跳过
+func parseTencentQuoteFields(data string) map[string][]string {
+	result := make(map[string][]string)
+	for _, match := range tencentQuoteRe.FindAllStringSubmatch(data, -1) {
+		parts := strings.Split(match[2], "~")
+		if len(parts) < tencentQuoteMinFields {
+			continue
+		}
+		result[match[1]] = parts
+	}
+	return result
+}
+
+// tencentFloat 读取腾讯行情的数值字段，越界或无法解析时返回 0
+func tencentFloat(parts []string, i int) float64 {
+	if i >= len(parts) {
+		return 0
+	}
+	f, _ := strconv.ParseFloat(strings.TrimSpace(parts[i]), 64)
+	return f
+}
+
+// tencentQuoteDate 腾讯行情时间字段（20060102150405）中的日期，格式 2006-01-02
+func tencentQuoteDate(parts []string) string {
+	if len(parts) <= tqDateTime || len(parts[tqDateTime]) < 8 {
+		return ""
+	}
+	d := parts[tqDateTime]
+	return d[:4] + "-" + d[4:6] + "-" + d[6:8]
+}